@@ -6,6 +6,7 @@ Supports both streaming and non-streaming responses.
 Includes OpenTelemetry instrumentation for tracing, metrics, and log correlation.
 """
 
+import asyncio
 import os
 import time
 import uuid
@@ -16,6 +17,7 @@ from contextlib import asynccontextmanager
 
 from fastapi import FastAPI, HTTPException, Request
 from fastapi.responses import JSONResponse, StreamingResponse
+from starlette.background import BackgroundTask
 from pydantic import BaseModel, model_validator
 from pydantic_settings import BaseSettings
 import uvicorn
@@ -142,9 +144,18 @@ class AgentServerSettings(BaseSettings):
     memory_max_sessions: int = 1000  # Maximum sessions to keep
     memory_max_session_events: int = 500  # Maximum events per session
 
+    # Concurrency: chat completions processed at once, and what happens to the rest:
+    # "queue" waits for a slot (up to agent_queue_depth waiting), "reject" answers 503
+    agent_max_concurrent_requests: int = 16
+    agent_overflow_policy: str = "queue"
+    agent_queue_depth: Optional[int] = None
+
     # Logging settings
     agent_access_log: bool = False  # Mute uvicorn access logs by default
 
+    # Debug endpoints: /memory/* return raw session contents without authentication
+    agent_debug_memory_endpoints: bool = True
+
     class Config:
         env_file = ".env"
         case_sensitive = False
@@ -160,6 +171,60 @@ class ChatCompletionRequest(BaseModel):
     max_tokens: Optional[int] = None
 
 
+class RequestLimiter:
+    """Bounds the chat completions processed at once by an agent server.
+
+    Requests over the limit wait for a free slot under the "queue" overflow policy,
+    up to queue_depth of them, and are rejected with HTTP 503 otherwise.
+    """
+
+    def __init__(
+        self,
+        max_concurrent: int = 16,
+        overflow_policy: str = "queue",
+        queue_depth: Optional[int] = None,
+    ):
+        """Initialize RequestLimiter.
+
+        Args:
+            max_concurrent: Maximum requests processed at once (must be >= 1)
+            overflow_policy: "queue" or "reject" for requests over the limit
+            queue_depth: Maximum requests waiting under "queue" (None: unbounded)
+        """
+        if max_concurrent < 1:
+            raise ValueError(f"max_concurrent must be at least 1, got {max_concurrent}")
+        if overflow_policy not in ("queue", "reject"):
+            raise ValueError(f"overflow_policy must be queue or reject, got {overflow_policy}")
+        if queue_depth is not None and queue_depth < 0:
+            raise ValueError(f"queue_depth must not be negative, got {queue_depth}")
+        self.max_concurrent = max_concurrent
+        self.overflow_policy = overflow_policy
+        self.queue_depth = queue_depth
+        self._slots = asyncio.Semaphore(max_concurrent)
+        self._waiting = 0
+
+    async def acquire(self):
+        """Take a slot, waiting for one when the overflow policy allows it.
+
+        Raises:
+            HTTPException: 503 when the request cannot be queued
+        """
+        if self._slots.locked():
+            if self.overflow_policy == "reject":
+                raise HTTPException(status_code=503, detail="agent is at capacity")
+            if self.queue_depth is not None and self._waiting >= self.queue_depth:
+                raise HTTPException(status_code=503, detail="agent request queue is full")
+        self._waiting += 1
+        try:
+            await self._slots.acquire()
+        finally:
+            self._waiting -= 1
+
+    def release(self):
+        """Free a slot taken by acquire."""
+        self._slots.release()
+
+
 class AgentServer:
     """AgentServer exposing OpenAI-compatible chat completions API."""
 
@@ -168,6 +233,10 @@ class AgentServer:
         agent: Agent,
         port: int = 8000,
         access_log: bool = False,
+        debug_memory_endpoints: bool = True,
+        max_concurrent_requests: int = 16,
+        overflow_policy: str = "queue",
+        queue_depth: Optional[int] = None,
     ):
         """Initialize AgentServer with an agent.
 
@@ -175,10 +244,16 @@ class AgentServer:
             agent: Agent instance to serve
             port: Port to serve on
             access_log: Whether to enable uvicorn access logs (default: False)
+            debug_memory_endpoints: Whether to expose the /memory/* debug endpoints
+            max_concurrent_requests: Chat completions processed at once (default: 16)
+            overflow_policy: "queue" or "reject" for requests over the limit
+            queue_depth: Maximum requests waiting for a slot under "queue"
         """
         self.agent = agent
         self.port = port
         self.access_log = access_log
+        self.debug_memory_endpoints = debug_memory_endpoints
+        self.limiter = RequestLimiter(max_concurrent_requests, overflow_policy, queue_depth)
 
         # Create FastAPI app
         self.app = FastAPI(
@@ -288,6 +363,11 @@ class AgentServer:
             )
 
         logger.info(f"Access Log: {self.access_log}")
+        logger.info(f"Debug Memory Endpoints: {self.debug_memory_endpoints}")
+        logger.info(f"Max Concurrent Requests: {self.limiter.max_concurrent}")
+        logger.info(f"Overflow Policy: {self.limiter.overflow_policy}")
+        if self.limiter.queue_depth is not None:
+            logger.info(f"Queue Depth: {self.limiter.queue_depth}")
         logger.info("=" * 60)
 
     def _setup_routes(self):
@@ -322,51 +402,53 @@ class AgentServer:
             card = await self.agent.get_agent_card(base_url)
             return JSONResponse(card.to_dict())
 
-        # Memory endpoints (always enabled - used by UI and debugging)
-        @self.app.get("/memory/events")
-        async def get_memory_events(
-            limit: int = 100,
-            session_id: Optional[str] = None,
-        ):
-            """Get memory events with optional filtering.
-
-            Args:
-                limit: Maximum number of events to return (default: 100, max: 1000)
-                session_id: Filter to specific session (optional)
-            """
-            limit = min(limit, 1000)  # Cap at 1000
-
-            if session_id:
-                events = await self.agent.memory.get_session_events(session_id)
-            else:
-                sessions = await self.agent.memory.list_sessions()
-                events = []
-                for sid in sessions:
-                    sid_events = await self.agent.memory.get_session_events(sid)
-                    events.extend(sid_events)
+        # Memory debug endpoints (used by the UI and debugging), off with
+        # AGENT_DEBUG_MEMORY_ENDPOINTS=false since they expose session contents
+        if self.debug_memory_endpoints:
+            @self.app.get("/memory/events")
+            async def get_memory_events(
+                limit: int = 100,
+                session_id: Optional[str] = None,
+            ):
+                """Get memory events with optional filtering.
+
+                Args:
+                    limit: Maximum number of events to return (default: 100, max: 1000)
+                    session_id: Filter to specific session (optional)
+                """
+                limit = min(limit, 1000)  # Cap at 1000
+
+                if session_id:
+                    events = await self.agent.memory.get_session_events(session_id)
+                else:
+                    sessions = await self.agent.memory.list_sessions()
+                    events = []
+                    for sid in sessions:
+                        sid_events = await self.agent.memory.get_session_events(sid)
+                        events.extend(sid_events)
 
-            # Get most recent events up to limit
-            events = events[-limit:] if len(events) > limit else events
+                # Get most recent events up to limit
+                events = events[-limit:] if len(events) > limit else events
 
-            return JSONResponse(
-                {
-                    "agent": self.agent.name,
-                    "events": [e.to_dict() for e in events],
-                    "total": len(events),
-                }
-            )
+                return JSONResponse(
+                    {
+                        "agent": self.agent.name,
+                        "events": [e.to_dict() for e in events],
+                        "total": len(events),
+                    }
+                )
 
-        @self.app.get("/memory/sessions")
-        async def get_memory_sessions():
-            """Get list of memory sessions."""
-            sessions = await self.agent.memory.list_sessions()
-            return JSONResponse(
-                {
-                    "agent": self.agent.name,
-                    "sessions": sessions,
-                    "total": len(sessions),
-                }
-            )
+            @self.app.get("/memory/sessions")
+            async def get_memory_sessions():
+                """Get list of memory sessions."""
+                sessions = await self.agent.memory.list_sessions()
+                return JSONResponse(
+                    {
+                        "agent": self.agent.name,
+                        "sessions": sessions,
+                        "total": len(sessions),
+                    }
+                )
 
         @self.app.post("/v1/chat/completions")
         async def chat_completions(request: Request):
@@ -399,12 +481,26 @@ class AgentServer:
                         detail="No user or task-delegation message found",
                     )
 
+                # Wait for a free slot, so bursts do not overload the model and tools;
+                # rejected with 503 when the overflow policy does not allow waiting
+                await self.limiter.acquire()
+
                 # Pass full messages array to agent for processing
                 # Agent handles tool calls and delegations based on model response
-                if stream_requested:
-                    return await self._stream_chat_completion(messages, model_name)
-                else:
-                    return await self._complete_chat_completion(messages, model_name)
+                if not stream_requested:
+                    try:
+                        return await self._complete_chat_completion(messages, model_name)
+                    finally:
+                        self.limiter.release()
+
+                # A stream holds its slot until the response has been sent
+                try:
+                    response = await self._stream_chat_completion(messages, model_name)
+                except BaseException:
+                    self.limiter.release()
+                    raise
+                response.background = BackgroundTask(self.limiter.release)
+                return response
 
             except HTTPException:
                 raise
@@ -635,6 +731,10 @@ def create_agent_server(
         agent,
         port=settings.agent_port,
         access_log=settings.agent_access_log,
+        debug_memory_endpoints=settings.agent_debug_memory_endpoints,
+        max_concurrent_requests=settings.agent_max_concurrent_requests,
+        overflow_policy=settings.agent_overflow_policy,
+        queue_depth=settings.agent_queue_depth,
     )
 
     return server
@@ -5,14 +5,16 @@ Tests Agent, RemoteAgent, AgentCard, LocalMemory, NullMemory, and ModelAPI funct
 Focuses on meaningful integration between components.
 """
 
+import asyncio
 import pytest
 import logging
 from unittest.mock import Mock, AsyncMock
 from typing import List, Dict, Optional
 
+from fastapi import HTTPException
 from agent.client import Agent, RemoteAgent, AgentCard
 from agent.memory import LocalMemory, NullMemory
-from agent.server import AgentServer
+from agent.server import AgentServer, RequestLimiter
 from modelapi.client import ModelAPI, LiteLLM
 
 logger = logging.getLogger(__name__)
@@ -476,3 +478,56 @@ class TestAgentServer:
         assert server.app is not None
 
         logger.info("✓ AgentServer creation works correctly")
+
+    def test_agent_server_debug_memory_endpoints(self):
+        """Test the /memory/* routes are only registered when enabled."""
+        agent = Agent(name="server-agent", model_api=MockModelAPI("server-agent"))
+
+        def memory_routes(server: AgentServer) -> List[str]:
+            return [r.path for r in server.app.routes if r.path.startswith("/memory")]
+
+        assert memory_routes(AgentServer(agent)) == ["/memory/events", "/memory/sessions"]
+        assert memory_routes(AgentServer(agent, debug_memory_endpoints=False)) == []
+
+        logger.info("✓ AgentServer debug memory endpoints toggle works correctly")
+
+    @pytest.mark.asyncio
+    async def test_request_limiter_bounds_concurrency(self):
+        """Test requests beyond the limit wait until a slot is released."""
+        limiter = RequestLimiter(max_concurrent=2)
+        await limiter.acquire()
+        await limiter.acquire()
+
+        waiting = asyncio.create_task(limiter.acquire())
+        await asyncio.sleep(0.01)
+        assert not waiting.done()
+
+        limiter.release()
+        await asyncio.wait_for(waiting, timeout=1.0)
+
+        with pytest.raises(ValueError):
+            RequestLimiter(max_concurrent=0)
+
+        logger.info("✓ RequestLimiter bounds concurrency correctly")
+
+    @pytest.mark.asyncio
+    async def test_request_limiter_overflow_policy(self):
+        """Test the overflow policy and queue depth reject requests over the limit."""
+        rejecting = RequestLimiter(max_concurrent=1, overflow_policy="reject")
+        await rejecting.acquire()
+        with pytest.raises(HTTPException) as exc:
+            await rejecting.acquire()
+        assert exc.value.status_code == 503
+
+        queueing = RequestLimiter(max_concurrent=1, overflow_policy="queue", queue_depth=1)
+        await queueing.acquire()
+        waiting = asyncio.create_task(queueing.acquire())
+        await asyncio.sleep(0.01)
+        with pytest.raises(HTTPException) as exc:
+            await queueing.acquire()
+        assert exc.value.status_code == 503
+
+        queueing.release()
+        await asyncio.wait_for(waiting, timeout=1.0)
+
+        logger.info("✓ RequestLimiter overflow policy works correctly")
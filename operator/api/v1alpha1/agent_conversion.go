@@ -0,0 +1,5 @@
+package v1alpha1
+
+// Hub marks v1alpha1 as the conversion hub for Agent. All other versions
+// convert to and from this version, which is also the storage version.
+func (*Agent) Hub() {}
@@ -5,6 +5,49 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// AgentMode defines how the agent workload is run
+type AgentMode string
+
+const (
+	// AgentModeService runs the agent as a long-running Deployment with a Service
+	AgentModeService AgentMode = "service"
+	// AgentModeJob runs the agent once as a Job that exits on completion
+	AgentModeJob AgentMode = "job"
+)
+
+// ModelValidation defines how the agent's model is checked against its ModelAPIs
+type ModelValidation string
+
+const (
+	// ModelValidationStatic matches the model against the ModelAPI's models list, which
+	// may contain wildcards
+	ModelValidationStatic ModelValidation = "static"
+	// ModelValidationLive checks the model against the proxy's /v1/models once the
+	// ModelAPI is ready, falling back to the static check when the query fails
+	ModelValidationLive ModelValidation = "live"
+)
+
+// MemoryBackend defines where agent session memory is stored
+type MemoryBackend string
+
+const (
+	// MemoryBackendInMemory keeps sessions in the agent process (not shared between replicas)
+	MemoryBackendInMemory MemoryBackend = "inmemory"
+	// MemoryBackendRedis stores sessions in Redis so they are shared between replicas
+	MemoryBackendRedis MemoryBackend = "redis"
+)
+
+// OverflowPolicy defines what an agent does with requests beyond its concurrency limit
+type OverflowPolicy string
+
+const (
+	// OverflowPolicyReject rejects requests over the limit immediately (HTTP 503)
+	OverflowPolicyReject OverflowPolicy = "reject"
+	// OverflowPolicyQueue holds requests over the limit until a slot frees up, rejecting
+	// them only once queueDepth requests are waiting
+	OverflowPolicyQueue OverflowPolicy = "queue"
+)
+
 // +kubebuilder:object:generate=true
 
 // ContainerOverride provides shorthand container configuration.
@@ -48,7 +91,21 @@ type AgentNetworkConfig struct {
 
 // +kubebuilder:object:generate=true
 
+// RedisMemoryConfig defines the connection to a Redis memory backend
+type RedisMemoryConfig struct {
+	// URL is the Redis connection URL (e.g. "redis://redis.default.svc.cluster.local:6379/0")
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// PasswordSecretRef references a Secret key containing the Redis password
+	// +kubebuilder:validation:Optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
 // MemoryConfig defines memory settings for the agent
+// +kubebuilder:validation:XValidation:rule="!has(self.backend) || self.backend != 'redis' || has(self.redis)",message="memory.redis is required when backend is redis"
 type MemoryConfig struct {
 	// Enabled controls whether memory is enabled (default: true)
 	// When disabled, NullMemory is used (no-op implementation)
@@ -78,6 +135,41 @@ type MemoryConfig struct {
 	// +kubebuilder:validation:Maximum=10000
 	// +kubebuilder:default=500
 	MaxSessionEvents *int32 `json:"maxSessionEvents,omitempty"`
+
+	// Backend selects where session memory is stored (default: "inmemory").
+	// The inmemory backend is per pod, so agents with replicas > 1 need a shared
+	// backend such as redis to keep sessions consistent across pods.
+	// +kubebuilder:default=inmemory
+	// +kubebuilder:validation:Enum=inmemory;redis
+	Backend MemoryBackend `json:"backend,omitempty"`
+
+	// Redis configures the connection when backend is "redis"
+	// +kubebuilder:validation:Optional
+	Redis *RedisMemoryConfig `json:"redis,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// GenerationConfig defines default generation parameters for model calls.
+// The runtime applies them when a request does not set its own values.
+// Decimal values are strings to avoid floating point fields in the API.
+type GenerationConfig struct {
+	// Temperature is the default sampling temperature, between 0 and 2 (e.g. "0.7")
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +kubebuilder:validation:XValidation:rule="double(self) >= 0.0 && double(self) <= 2.0",message="temperature must be between 0 and 2"
+	Temperature string `json:"temperature,omitempty"`
+
+	// TopP is the default nucleus sampling probability, between 0 and 1 (e.g. "0.9")
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +kubebuilder:validation:XValidation:rule="double(self) >= 0.0 && double(self) <= 1.0",message="topP must be between 0 and 1"
+	TopP string `json:"topP,omitempty"`
+
+	// MaxTokens is the default maximum number of tokens to generate
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxTokens *int32 `json:"maxTokens,omitempty"`
 }
 
 // +kubebuilder:object:generate=true
@@ -100,6 +192,7 @@ type TelemetryConfig struct {
 // +kubebuilder:object:generate=true
 
 // AgentConfig defines agent-specific configuration
+// +kubebuilder:validation:XValidation:rule="!has(self.instructions) || !has(self.instructionsFrom)",message="only one of instructions or instructionsFrom may be set"
 type AgentConfig struct {
 	// Description is a human-readable description of the agent
 	// +kubebuilder:validation:Optional
@@ -109,16 +202,84 @@ type AgentConfig struct {
 	// +kubebuilder:validation:Optional
 	Instructions string `json:"instructions,omitempty"`
 
+	// InstructionsFrom loads the system instructions from a ConfigMap or Secret key.
+	// The value is mounted as a file instead of being passed as an env var, which
+	// avoids env size limits for large prompts.
+	// +kubebuilder:validation:Optional
+	InstructionsFrom *InstructionsSource `json:"instructionsFrom,omitempty"`
+
 	// ReasoningLoopMaxSteps is the maximum number of reasoning steps before stopping
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=20
 	// +kubebuilder:default=5
 	ReasoningLoopMaxSteps *int32 `json:"reasoningLoopMaxSteps,omitempty"`
 
+	// SessionTTLSeconds expires sessions that have been idle for this many seconds.
+	// 0 disables expiry. Maximum is 30 days.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=2592000
+	// +kubebuilder:validation:Optional
+	SessionTTLSeconds *int32 `json:"sessionTTLSeconds,omitempty"`
+
+	// MaxHistoryMessages caps the number of messages kept per session history.
+	// 0 means no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10000
+	// +kubebuilder:validation:Optional
+	MaxHistoryMessages *int32 `json:"maxHistoryMessages,omitempty"`
+
+	// MaxConcurrentRequests bounds the requests each agent pod processes at once, to
+	// protect the ModelAPI and tools from bursts. Further requests wait for a free slot.
+	// Defaults to 16.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10000
+	// +kubebuilder:validation:Optional
+	MaxConcurrentRequests *int32 `json:"maxConcurrentRequests,omitempty"`
+
+	// OverflowPolicy is what the agent does with requests beyond maxConcurrentRequests:
+	// "queue" them until a slot frees up or "reject" them with HTTP 503 so clients can
+	// retry elsewhere. When unset the runtime default applies.
+	// +kubebuilder:validation:Enum=reject;queue
+	// +kubebuilder:validation:Optional
+	OverflowPolicy OverflowPolicy `json:"overflowPolicy,omitempty"`
+
+	// QueueDepth caps the requests waiting for a slot under the "queue" overflow policy;
+	// requests beyond it are rejected with HTTP 503. 0 means no request waits.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100000
+	// +kubebuilder:validation:Optional
+	QueueDepth *int32 `json:"queueDepth,omitempty"`
+
+	// ModelHeaders are extra HTTP headers attached to every ModelAPI request
+	// (e.g. tenant IDs). Header names may contain letters, digits, '-' and '_'.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k.matches('^[A-Za-z0-9_-]+$'))",message="modelHeaders keys must be valid HTTP header names"
+	ModelHeaders map[string]string `json:"modelHeaders,omitempty"`
+
+	// ModelRoutes maps capability tags (e.g. "simple", "reasoning") to the model the
+	// runtime uses for steps with that tag, so cheap steps can use a cheaper model.
+	// Every model must be supported by the agent's ModelAPIs.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxProperties=16
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k.matches('^[A-Za-z0-9_-]+$'))",message="modelRoutes keys must contain only letters, digits, '-' and '_'"
+	// +kubebuilder:validation:XValidation:rule="self.all(k, size(self[k]) > 0)",message="modelRoutes models must not be empty"
+	ModelRoutes map[string]string `json:"modelRoutes,omitempty"`
+
+	// Generation sets default generation parameters for model calls
+	// +kubebuilder:validation:Optional
+	Generation *GenerationConfig `json:"generation,omitempty"`
+
 	// Memory configures the agent's memory system
 	// +kubebuilder:validation:Optional
 	Memory *MemoryConfig `json:"memory,omitempty"`
 
+	// DebugMemoryEndpoints exposes the agent's /memory/* debug endpoints, which return
+	// raw session contents without authentication. Intended for development only.
+	// Defaults to the operator's DEFAULT_AGENT_DEBUG_MEMORY_ENDPOINTS when configured,
+	// else true.
+	// +kubebuilder:validation:Optional
+	DebugMemoryEndpoints *bool `json:"debugMemoryEndpoints,omitempty"`
+
 	// Telemetry configures OpenTelemetry instrumentation
 	// +kubebuilder:validation:Optional
 	Telemetry *TelemetryConfig `json:"telemetry,omitempty"`
@@ -126,19 +287,207 @@ type AgentConfig struct {
 
 // +kubebuilder:object:generate=true
 
+// InstructionsSource defines where agent instructions are loaded from
+// +kubebuilder:validation:XValidation:rule="has(self.configMapKeyRef) != has(self.secretKeyRef)",message="exactly one of configMapKeyRef or secretKeyRef must be set"
+type InstructionsSource struct {
+	// ConfigMapKeyRef is a reference to a configmap key
+	// +kubebuilder:validation:Optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+
+	// SecretKeyRef is a reference to a secret key
+	// +kubebuilder:validation:Optional
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// AgentMCPServerRef references an MCPServer with agent-specific options
+// +kubebuilder:validation:XValidation:rule="!has(self.allowTools) || !has(self.denyTools)",message="only one of allowTools or denyTools may be set"
+type AgentMCPServerRef struct {
+	// Name is the MCPServer name
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// AllowTools restricts the agent to these tools from the server
+	// +kubebuilder:validation:Optional
+	AllowTools []string `json:"allowTools,omitempty"`
+
+	// DenyTools hides these tools from the agent
+	// +kubebuilder:validation:Optional
+	DenyTools []string `json:"denyTools,omitempty"`
+
+	// Prefix namespaces this server's tool names (e.g. "docs" exposes "search" as
+	// "docs_search") to avoid collisions between servers. Default: no prefix.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z][a-zA-Z0-9_]*$`
+	Prefix string `json:"prefix,omitempty"`
+
+	// Timeout bounds each tool call to this server, so a slow server fails the call
+	// instead of the whole request. Duration string (e.g. "30s", "2m"). Default: the
+	// runtime's tool call timeout.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(h|m|s|ms)){1,4}$`
+	// +kubebuilder:validation:XValidation:rule="duration(self) > duration('0s')",message="timeout must be greater than 0"
+	Timeout string `json:"timeout,omitempty"`
+
+	// MaxConcurrent bounds the tool calls each agent pod makes to this server at once.
+	// Default: no limit.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	// +kubebuilder:validation:Optional
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// AgentJobConfig configures the Job created for agents in job mode
+type AgentJobConfig struct {
+	// BackoffLimit is the number of retries before the Job is marked as failed
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+
+	// RestartPolicy for the Job pods (OnFailure or Never, default: Never)
+	// +kubebuilder:validation:Enum=OnFailure;Never
+	// +kubebuilder:default=Never
+	RestartPolicy corev1.RestartPolicy `json:"restartPolicy,omitempty"`
+
+	// ActiveDeadlineSeconds limits how long the Job may run before it is terminated
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+
+	// Schedule runs the agent on a cron schedule (e.g. "0 * * * *") via a CronJob
+	// instead of a single Job. Uses standard 5-field cron syntax.
+	// +kubebuilder:validation:Optional
+	Schedule string `json:"schedule,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// AgentTrafficSplit sends a weighted share of the agent's gateway traffic to other
+// agents, e.g. to canary a new prompt. The agent itself receives the remaining weight.
+// +kubebuilder:validation:XValidation:rule="self.backends.map(b, b.weight).sum() <= 100",message="trafficSplit backend weights must not add up to more than 100"
+type AgentTrafficSplit struct {
+	// Backends are the agents receiving a share of the traffic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=4
+	// +listType=map
+	// +listMapKey=agent
+	Backends []AgentTrafficSplitBackend `json:"backends"`
+}
+
+// +kubebuilder:object:generate=true
+
+// AgentTrafficSplitBackend is an agent receiving a percentage of the traffic
+type AgentTrafficSplitBackend struct {
+	// Agent is the name of an exposed Agent in the same namespace
+	// +kubebuilder:validation:MinLength=1
+	Agent string `json:"agent"`
+
+	// Weight is the percentage of traffic (0-100) sent to this agent
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Weight int32 `json:"weight"`
+}
+
+// AgentWarmUpHandler selects how the warm-up postStart hook reaches the ModelAPI
+type AgentWarmUpHandler string
+
+const (
+	// AgentWarmUpHandlerHTTPGet sends an HTTP GET to the ModelAPI from the kubelet. A
+	// failed request fails the hook, which kills and restarts the agent container.
+	AgentWarmUpHandlerHTTPGet AgentWarmUpHandler = "httpGet"
+	// AgentWarmUpHandlerExec requests the ModelAPI from inside the agent container and
+	// ignores any failure, so an unreachable ModelAPI never restarts the container
+	AgentWarmUpHandlerExec AgentWarmUpHandler = "exec"
+)
+
+// +kubebuilder:object:generate=true
+
+// AgentWarmUp configures a postStart hook that pings the primary ModelAPI when an agent
+// container starts, so the first request does not pay for establishing the connection
+type AgentWarmUp struct {
+	// Handler is how the hook reaches the ModelAPI: "exec" (default) tolerates a failed
+	// request, while "httpGet" kills and restarts the container when the request fails
+	// +kubebuilder:validation:Enum=httpGet;exec
+	// +kubebuilder:default=exec
+	Handler AgentWarmUpHandler `json:"handler,omitempty"`
+
+	// Path is requested on the ModelAPI endpoint (default: the ModelAPI health path)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
 // AgentSpec defines the desired state of Agent
+// +kubebuilder:validation:XValidation:rule="has(self.modelAPI) || (has(self.modelAPIs) && size(self.modelAPIs) > 0)",message="one of modelAPI or modelAPIs must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.mode) || self.mode != 'job' || !has(self.agentNetwork) || !has(self.agentNetwork.expose) || !self.agentNetwork.expose",message="agentNetwork.expose must be false in job mode"
+// +kubebuilder:validation:XValidation:rule="!has(self.job) || !has(self.job.schedule) || (has(self.mode) && self.mode == 'job')",message="job.schedule requires job mode"
+// +kubebuilder:validation:XValidation:rule="(has(self.progressDeadlineSeconds) ? self.progressDeadlineSeconds : 600) > (has(self.minReadySeconds) ? self.minReadySeconds : 0)",message="progressDeadlineSeconds must be greater than minReadySeconds"
 type AgentSpec struct {
-	// ModelAPI is the name of the ModelAPI resource this agent uses
-	ModelAPI string `json:"modelAPI"`
+	// Mode selects how the agent runs: "service" (long-running Deployment and Service)
+	// or "job" (run-once Job without Service or probes). Immutable once set.
+	// +kubebuilder:validation:Enum=service;job
+	// +kubebuilder:default=service
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="mode is immutable"
+	Mode AgentMode `json:"mode,omitempty"`
+
+	// Replicas is the desired number of agent pods in service mode (default: 1).
+	// Setting it to 0 suspends the agent: the deployment is scaled to zero and the
+	// agent reports the Suspended phase until replicas is increased again.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Workers is the number of server worker processes in each agent pod, passed to the
+	// runtime as WEB_CONCURRENCY (default: the runtime's single worker). Each worker keeps
+	// its own inmemory sessions and uses its own memory.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Workers *int32 `json:"workers,omitempty"`
+
+	// Job configures the Job created in job mode (ignored in service mode)
+	// +kubebuilder:validation:Optional
+	Job *AgentJobConfig `json:"job,omitempty"`
+
+	// ModelAPI is the name of the ModelAPI resource this agent uses.
+	// Ignored when ModelAPIs is set.
+	// +kubebuilder:validation:Optional
+	ModelAPI string `json:"modelAPI,omitempty"`
+
+	// ModelAPIs is an ordered list of ModelAPI resource names. The first entry is the
+	// primary ModelAPI and the remaining entries are fallbacks used by the runtime on failure.
+	// Each referenced ModelAPI must support the agent's model.
+	// +kubebuilder:validation:Optional
+	ModelAPIs []string `json:"modelAPIs,omitempty"`
 
 	// Model is the model identifier this agent uses (e.g., "openai/gpt-4", "ollama/smollm2:135m")
-	// Must be supported by the referenced ModelAPI
+	// Must be supported by every referenced ModelAPI
 	Model string `json:"model"`
 
+	// ModelValidation selects how the model (and modelRoutes targets) are validated:
+	// "static" (default) matches them against each ModelAPI's models list, "live" checks
+	// them against the models a ready Proxy ModelAPI serves at /v1/models, falling back to
+	// the static check when that query fails
+	// +kubebuilder:validation:Enum=static;live
+	// +kubebuilder:default=static
+	ModelValidation ModelValidation `json:"modelValidation,omitempty"`
+
 	// MCPServers is a list of MCPServer names this agent can use
 	// +kubebuilder:validation:Optional
 	MCPServers []string `json:"mcpServers,omitempty"`
 
+	// MCPServerRefs references MCPServers with per-server options such as tool filters.
+	// Servers listed here are referenced in addition to mcpServers; when a name appears
+	// in both, the options from mcpServerRefs apply.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	MCPServerRefs []AgentMCPServerRef `json:"mcpServerRefs,omitempty"`
+
 	// AgentNetwork defines A2A communication settings
 	// +kubebuilder:validation:Optional
 	AgentNetwork *AgentNetworkConfig `json:"agentNetwork,omitempty"`
@@ -152,10 +501,89 @@ type AgentSpec struct {
 	// +kubebuilder:default=true
 	WaitForDependencies *bool `json:"waitForDependencies,omitempty"`
 
+	// WaitForDependencyEndpoints adds an init container that polls the ModelAPI and
+	// MCPServer endpoints until they respond, so the agent container only starts once
+	// its dependencies are reachable. Default is false.
+	// +kubebuilder:default=false
+	WaitForDependencyEndpoints *bool `json:"waitForDependencyEndpoints,omitempty"`
+
+	// WarmUp adds a postStart hook to the agent container that pings the primary
+	// ModelAPI before the pod serves traffic. Disabled when unset.
+	// +kubebuilder:validation:Optional
+	WarmUp *AgentWarmUp `json:"warmUp,omitempty"`
+
+	// ScaleDownOnMissingModelAPI scales the agent deployment to zero replicas while the
+	// referenced ModelAPI does not exist. The deployment is scaled back up once the
+	// ModelAPI is recreated. Default is false (deployment is left running).
+	// +kubebuilder:default=false
+	ScaleDownOnMissingModelAPI *bool `json:"scaleDownOnMissingModelAPI,omitempty"`
+
+	// RequestTimeout is the timeout for a single agent request, applied consistently to
+	// the HTTPRoute (unless gatewayRoute.timeout is set) and to the runtime via the
+	// AGENT_REQUEST_TIMEOUT env var, so the gateway does not cut off requests the agent
+	// is still processing. Gateway API Duration string (e.g., "30s", "2m").
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(h|m|s|ms)){1,4}$`
+	RequestTimeout string `json:"requestTimeout,omitempty"`
+
 	// GatewayRoute configures Gateway API routing (timeout, etc.)
 	// +kubebuilder:validation:Optional
 	GatewayRoute *GatewayRoute `json:"gatewayRoute,omitempty"`
 
+	// TrafficSplit routes a weighted share of this agent's HTTPRoute traffic to other
+	// agents. Requires Gateway API integration and agentNetwork.expose.
+	// +kubebuilder:validation:Optional
+	TrafficSplit *AgentTrafficSplit `json:"trafficSplit,omitempty"`
+
+	// DeploymentStrategy configures how the Deployment rolls out changes (Recreate or
+	// RollingUpdate with maxSurge/maxUnavailable)
+	// +kubebuilder:validation:Optional
+	DeploymentStrategy *DeploymentStrategy `json:"deploymentStrategy,omitempty"`
+
+	// MinReadySeconds is how long a new pod must be ready without crashing before it
+	// counts as available during a rollout (default: 0)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+
+	// ProgressDeadlineSeconds is how long a rollout may make no progress before the
+	// Deployment reports ProgressDeadlineExceeded and the resource is marked Failed
+	// (default: 600)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// ReadyTimeout is how long a rollout may wait for its first ready replica before the
+	// resource is marked Failed with the Deployment's latest failing condition, e.g. when
+	// pods never schedule. The timer restarts with every rollout. Duration string (e.g.
+	// "5m", "1h"); unset waits indefinitely.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(h|m|s|ms)){1,4}$`
+	ReadyTimeout string `json:"readyTimeout,omitempty"`
+
+	// CABundle is a PEM bundle of CA certificates the agent container trusts for outbound
+	// TLS, e.g. calls to ModelAPIs, MCPServers and peer agents behind a private CA. It replaces the image's
+	// default trust store, so include public CAs if they are still needed.
+	// +kubebuilder:validation:Optional
+	CABundle *CABundleSource `json:"caBundle,omitempty"`
+
+	// EgressProxy routes the agent container's outbound HTTP(S) calls through a proxy,
+	// overriding the operator-wide defaults. In-cluster calls bypass the proxy.
+	// +kubebuilder:validation:Optional
+	EgressProxy *EgressProxyConfig `json:"egressProxy,omitempty"`
+
+	// ServicePort sets the name and appProtocol of the generated Service port for
+	// service-mesh protocol detection
+	// +kubebuilder:validation:Optional
+	ServicePort *ServicePortConfig `json:"servicePort,omitempty"`
+
+	// Protocol is the protocol the agent runtime speaks: "http" (default) or "grpc". gRPC sets
+	// the Service port appProtocol to grpc, uses gRPC health probes and routes Gateway
+	// traffic over h2c.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=http
+	Protocol ServiceProtocol `json:"protocol,omitempty"`
+
 	// Container provides shorthand container overrides (image, env, resources)
 	// +kubebuilder:validation:Optional
 	Container *ContainerOverride `json:"container,omitempty"`
@@ -169,8 +597,9 @@ type AgentSpec struct {
 
 // AgentStatus defines the observed state of Agent
 type AgentStatus struct {
-	// Phase of the deployment
-	// +kubebuilder:validation:Enum=Pending;Ready;Failed;Waiting
+	// Phase of the deployment (Running and Succeeded are used in job mode,
+	// Suspended when scaled to zero replicas, Progressing while a rollout is running)
+	// +kubebuilder:validation:Enum=Pending;Ready;Progressing;Failed;Waiting;Running;Succeeded;Suspended
 	Phase string `json:"phase,omitempty"`
 
 	// Ready indicates if the agent is ready
@@ -180,20 +609,79 @@ type AgentStatus struct {
 	// +kubebuilder:validation:Optional
 	Endpoint string `json:"endpoint,omitempty"`
 
+	// Endpoints lists the agent's base URL in each available format: "internal"
+	// (service.namespace), "fqdn" (same as endpoint) and "gateway" (external URL via the
+	// Gateway, when routing is enabled and the gateway host is configured). With
+	// gatewayRoute.gateways, each gateway with a host is also listed as "gateway-<name>".
+	// +kubebuilder:validation:Optional
+	Endpoints map[string]string `json:"endpoints,omitempty"`
+
 	// LinkedResources tracks references to ModelAPI and MCPServer resources
 	// +kubebuilder:validation:Optional
 	LinkedResources map[string]string `json:"linkedResources,omitempty"`
 
+	// Card summarizes the agent card served at the endpoint (service mode), so clients can
+	// list the agent's capabilities without calling the pod
+	// +kubebuilder:validation:Optional
+	Card *AgentCardSummary `json:"card,omitempty"`
+
 	// Message provides additional status information
 	Message string `json:"message,omitempty"`
 
+	// ObservedReplicas is the number of agent pods the Deployment is scaled to (service mode)
+	// +kubebuilder:validation:Optional
+	ObservedReplicas int32 `json:"observedReplicas,omitempty"`
+
+	// ReadyReplicas is the number of agent pods that are ready (service mode)
+	// +kubebuilder:validation:Optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
 	// Deployment contains status information from the underlying Deployment
 	// +kubebuilder:validation:Optional
 	Deployment *DeploymentStatus `json:"deployment,omitempty"`
+
+	// Conditions represent the latest observations of the resource's state.
+	// The Ready condition mirrors the ready flag with a reason and message; in service
+	// mode the Degraded condition is True while only some replicas are ready.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Job contains status information from the underlying Job (job mode only)
+	// +kubebuilder:validation:Optional
+	Job *JobStatus `json:"job,omitempty"`
+
+	// CronJob contains schedule information from the underlying CronJob (scheduled job mode only)
+	// +kubebuilder:validation:Optional
+	CronJob *CronJobStatus `json:"cronJob,omitempty"`
+}
+
+// AgentCardSummary is a bounded summary of an agent card. Skills are the tools the
+// agent's MCPServers report in status.availableTools, after the mcpServerRefs tool
+// filters and prefixes are applied.
+type AgentCardSummary struct {
+	// Description is the rendered config.description, truncated to 512 characters
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+
+	// Skills lists up to 64 skill names
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=64
+	Skills []string `json:"skills,omitempty"`
+
+	// TruncatedSkills is the number of skills left out of skills
+	// +kubebuilder:validation:Optional
+	TruncatedSkills int32 `json:"truncatedSkills,omitempty"`
+
+	// Capabilities lists the agent's capabilities (e.g. tool_execution, task_delegation)
+	// +kubebuilder:validation:Optional
+	Capabilities []string `json:"capabilities,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:shortName=agent;agents
 // +kubebuilder:printcolumn:name="ModelAPI",type=string,JSONPath=`.spec.modelAPI`
 // +kubebuilder:printcolumn:name="Model",type=string,JSONPath=`.spec.model`
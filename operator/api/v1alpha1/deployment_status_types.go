@@ -30,4 +30,14 @@ type DeploymentStatus struct {
 	// Typical conditions include Available, Progressing, and ReplicaFailure.
 	// +kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the generation of the Deployment this status was read from
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// PendingSince is when the current rollout (Deployment generation) started waiting
+	// for its first ready replica. It is cleared once a replica is ready and only set
+	// again by the next rollout. Used by readyTimeout.
+	// +kubebuilder:validation:Optional
+	PendingSince *metav1.Time `json:"pendingSince,omitempty"`
 }
@@ -0,0 +1,31 @@
+package v1alpha1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// +kubebuilder:object:generate=true
+
+// DeploymentStrategy configures how the underlying Deployment replaces pods on update
+// +kubebuilder:validation:XValidation:rule="!has(self.type) || self.type != 'Recreate' || (!has(self.maxSurge) && !has(self.maxUnavailable))",message="maxSurge and maxUnavailable are only valid for the RollingUpdate type"
+type DeploymentStrategy struct {
+	// Type is Recreate (all old pods are stopped before new ones start) or RollingUpdate.
+	// Defaults to RollingUpdate, except for a Hosted ModelAPI with a ReadWriteOnce
+	// model cache, which defaults to Recreate since two pods cannot mount the volume.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Recreate;RollingUpdate
+	Type appsv1.DeploymentStrategyType `json:"type,omitempty"`
+
+	// MaxSurge is the number or percentage of pods created above the desired replicas
+	// during a rolling update (default: 25%)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XIntOrString
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods that can be unavailable during
+	// a rolling update (default: 25%)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XIntOrString
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
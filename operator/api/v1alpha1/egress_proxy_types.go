@@ -0,0 +1,20 @@
+package v1alpha1
+
+// +kubebuilder:object:generate=true
+
+// EgressProxyConfig sets the HTTP proxy the generated containers use for outbound calls.
+// Fields left empty inherit the operator-wide defaults.
+type EgressProxyConfig struct {
+	// HTTPProxy is the proxy URL for plain HTTP requests (HTTP_PROXY)
+	// +kubebuilder:validation:Optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the proxy URL for HTTPS requests (HTTPS_PROXY)
+	// +kubebuilder:validation:Optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy lists extra hosts, domains, IPs or CIDRs that bypass the proxy (NO_PROXY).
+	// Added to the operator-wide list; cluster-internal names are always included.
+	// +kubebuilder:validation:Optional
+	NoProxy []string `json:"noProxy,omitempty"`
+}
@@ -4,7 +4,14 @@ package v1alpha1
 
 // GatewayRoute defines Gateway API routing configuration for a resource.
 // This is a shared type used by Agent, ModelAPI, and MCPServer.
+// +kubebuilder:validation:XValidation:rule="!has(self.gateways) || !has(self.sectionName)",message="sectionName cannot be combined with gateways; set sectionName on each gateway instead"
 type GatewayRoute struct {
+	// Enabled controls whether an HTTPRoute is created for the resource when Gateway API
+	// integration is enabled. Setting it to false deletes a previously created HTTPRoute.
+	// +kubebuilder:default=true
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+
 	// Timeout specifies the request timeout for the HTTPRoute.
 	// This is a Gateway API Duration string (e.g., "30s", "1m", "5m").
 	// If not specified, defaults to "60s" for ModelAPI (to accommodate LLM inference)
@@ -13,4 +20,93 @@ type GatewayRoute struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Pattern=`^([0-9]+(h|m|s|ms)){1,4}$`
 	Timeout string `json:"timeout,omitempty"`
+
+	// PathPrefix overrides the computed /{namespace}/{type}/{name} path of the HTTPRoute
+	// with a custom prefix such as "/chat". The prefix is stripped before requests reach
+	// the resource. Reconciliation of the route fails if another HTTPRoute on the same
+	// Gateway already matches the prefix.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=1024
+	// +kubebuilder:validation:Pattern=`^(/[A-Za-z0-9._~%-]+)+/?$`
+	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	// SectionName binds the HTTPRoute to a single listener of the Gateway (e.g. "https"),
+	// overriding the operator-wide GATEWAY_SECTION_NAME. When neither is set, the route
+	// binds to all compatible listeners.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	SectionName string `json:"sectionName,omitempty"`
+
+	// Gateways attaches the HTTPRoute to each of these Gateways (e.g. an internal and an
+	// external one) instead of the operator-wide GATEWAY_NAME. The route gets one
+	// parentRef per gateway, and each gateway with a host is reported in status.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:XValidation:rule="self.all(g, self.exists_one(h, h.name == g.name))",message="gateway names must be unique"
+	Gateways []GatewayTarget `json:"gateways,omitempty"`
+
+	// CORS adds CORS response headers to the HTTPRoute so browser clients on other origins
+	// can call the resource through the Gateway. Disabled when not set.
+	// +kubebuilder:validation:Optional
+	CORS *GatewayRouteCORS `json:"cors,omitempty"`
+
+	// RequestHeaders are set on every request the Gateway forwards to the resource,
+	// replacing any value sent by the client, e.g. {"x-tenant-id": "acme"}. Header names
+	// may contain letters, digits, '-' and '_'.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxProperties=16
+	// +kubebuilder:validation:XValidation:rule="self.all(k, k.matches('^[A-Za-z0-9_-]+$'))",message="requestHeaders keys must be valid HTTP header names"
+	RequestHeaders map[string]string `json:"requestHeaders,omitempty"`
+}
+
+// GatewayTarget is a Gateway an HTTPRoute attaches to
+type GatewayTarget struct {
+	// Name of the Gateway
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	Name string `json:"name"`
+
+	// Namespace of the Gateway. Defaults to the operator-wide GATEWAY_NAMESPACE.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Namespace string `json:"namespace,omitempty"`
+
+	// SectionName binds the route to a single listener of this Gateway. When not set,
+	// the route binds to all compatible listeners.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	SectionName string `json:"sectionName,omitempty"`
+
+	// Host clients use to reach this Gateway (e.g. "kaos.internal.example.com"), used to
+	// report the resource's URL through it in status. Defaults to GATEWAY_HOST for the
+	// operator-wide Gateway; no URL is reported for other Gateways without a host.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9]([-A-Za-z0-9.]*[A-Za-z0-9])?(:[0-9]+)?$`
+	Host string `json:"host,omitempty"`
+}
+
+// GatewayRouteCORS configures the CORS response headers added by the Gateway
+type GatewayRouteCORS struct {
+	// AllowOrigins lists the origins allowed to call the resource, such as
+	// "https://app.example.com", or a single "*" to allow any origin.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=8
+	// +kubebuilder:validation:items:Pattern=`^(\*|https?://[^/\s]+)$`
+	// +kubebuilder:validation:XValidation:rule="!self.exists(o, o == '*') || self.size() == 1",message="'*' cannot be combined with other origins"
+	AllowOrigins []string `json:"allowOrigins"`
+
+	// AllowMethods lists the HTTP methods allowed for cross-origin requests.
+	// Defaults to GET, POST and OPTIONS.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Enum=GET;HEAD;POST;PUT;PATCH;DELETE;OPTIONS
+	AllowMethods []string `json:"allowMethods,omitempty"`
+
+	// AllowHeaders lists the request headers allowed for cross-origin requests.
+	// Defaults to Content-Type and Authorization.
+	// +kubebuilder:validation:Optional
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
 }
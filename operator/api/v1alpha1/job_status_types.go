@@ -0,0 +1,62 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:generate=true
+
+// JobStatus mirrors key status fields from the underlying Job.
+// This provides visibility into run-once agent progress and completion.
+type JobStatus struct {
+	// Active is the number of pending and running pods.
+	// +kubebuilder:validation:Optional
+	Active int32 `json:"active,omitempty"`
+
+	// Succeeded is the number of pods which reached phase Succeeded.
+	// +kubebuilder:validation:Optional
+	Succeeded int32 `json:"succeeded,omitempty"`
+
+	// Failed is the number of pods which reached phase Failed.
+	// +kubebuilder:validation:Optional
+	Failed int32 `json:"failed,omitempty"`
+
+	// StartTime is when the Job controller started processing the Job.
+	// +kubebuilder:validation:Optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the Job completed successfully.
+	// +kubebuilder:validation:Optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Conditions represent the latest available observations of the Job's state.
+	// Typical conditions include Complete and Failed.
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:generate=true
+
+// CronJobStatus mirrors key status fields from the underlying CronJob.
+// This provides visibility into the last and next scheduled runs.
+type CronJobStatus struct {
+	// Schedule is the cron schedule the CronJob runs on.
+	// +kubebuilder:validation:Optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Active is the number of currently running Jobs.
+	// +kubebuilder:validation:Optional
+	Active int32 `json:"active,omitempty"`
+
+	// LastScheduleTime is when the Job was last scheduled.
+	// +kubebuilder:validation:Optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// LastSuccessfulTime is when the last Job successfully completed.
+	// +kubebuilder:validation:Optional
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+
+	// NextScheduleTime is when the next Job is expected to be scheduled.
+	// +kubebuilder:validation:Optional
+	NextScheduleTime *metav1.Time `json:"nextScheduleTime,omitempty"`
+}
@@ -8,6 +8,7 @@ import (
 // +kubebuilder:object:generate=true
 
 // MCPServerSpec defines the desired state of MCPServer
+// +kubebuilder:validation:XValidation:rule="(has(self.progressDeadlineSeconds) ? self.progressDeadlineSeconds : 600) > (has(self.minReadySeconds) ? self.minReadySeconds : 0)",message="progressDeadlineSeconds must be greater than minReadySeconds"
 type MCPServerSpec struct {
 	// Runtime identifier from ConfigMap registry or "custom"
 	// Examples: "python-string", "kubernetes", "slack", "custom"
@@ -19,6 +20,12 @@ type MCPServerSpec struct {
 	// +kubebuilder:validation:Optional
 	Params string `json:"params,omitempty"`
 
+	// SecretRef names a Secret in the MCPServer's namespace that provides the runtime's
+	// secretEnv vars, one key per env var name (e.g. SLACK_BOT_TOKEN and SLACK_TEAM_ID for
+	// slack). Each is injected as valueFrom.secretKeyRef; container.env still overrides.
+	// +kubebuilder:validation:Optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
 	// ServiceAccountName for RBAC (e.g., for kubernetes runtime)
 	// Created via `kaos system create-rbac`
 	// +kubebuilder:validation:Optional
@@ -28,10 +35,53 @@ type MCPServerSpec struct {
 	// +kubebuilder:validation:Optional
 	Telemetry *TelemetryConfig `json:"telemetry,omitempty"`
 
+	// EgressProxy routes the mcp-server container's outbound HTTP(S) calls through a proxy,
+	// overriding the operator-wide defaults. In-cluster calls bypass the proxy.
+	// +kubebuilder:validation:Optional
+	EgressProxy *EgressProxyConfig `json:"egressProxy,omitempty"`
+
+	// ServicePort sets the name and appProtocol of the generated Service port for
+	// service-mesh protocol detection
+	// +kubebuilder:validation:Optional
+	ServicePort *ServicePortConfig `json:"servicePort,omitempty"`
+
+	// Protocol is the protocol the MCP server runtime speaks: "http" (default) or "grpc". gRPC sets
+	// the Service port appProtocol to grpc, uses gRPC health probes and routes Gateway
+	// traffic over h2c.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=http
+	Protocol ServiceProtocol `json:"protocol,omitempty"`
+
 	// GatewayRoute configures Gateway API routing (timeout, etc.)
 	// +kubebuilder:validation:Optional
 	GatewayRoute *GatewayRoute `json:"gatewayRoute,omitempty"`
 
+	// DeploymentStrategy configures how the Deployment rolls out changes (Recreate or
+	// RollingUpdate with maxSurge/maxUnavailable)
+	// +kubebuilder:validation:Optional
+	DeploymentStrategy *DeploymentStrategy `json:"deploymentStrategy,omitempty"`
+
+	// MinReadySeconds is how long a new pod must be ready without crashing before it
+	// counts as available during a rollout (default: 0)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+
+	// ProgressDeadlineSeconds is how long a rollout may make no progress before the
+	// Deployment reports ProgressDeadlineExceeded and the resource is marked Failed
+	// (default: 600)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// ReadyTimeout is how long a rollout may wait for its first ready replica before the
+	// resource is marked Failed with the Deployment's latest failing condition, e.g. when
+	// pods never schedule. The timer restarts with every rollout. Duration string (e.g.
+	// "5m", "1h"); unset waits indefinitely.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(h|m|s|ms)){1,4}$`
+	ReadyTimeout string `json:"readyTimeout,omitempty"`
+
 	// Container provides shorthand container overrides (image, env, resources)
 	// For "custom" runtime, container.image is required
 	// +kubebuilder:validation:Optional
@@ -56,6 +106,11 @@ type MCPServerStatus struct {
 	// Endpoint is the service endpoint for the MCP server
 	Endpoint string `json:"endpoint,omitempty"`
 
+	// GatewayEndpoints maps each Gateway the resource is routed through to its external
+	// URL there, for Gateways with a known host
+	// +kubebuilder:validation:Optional
+	GatewayEndpoints map[string]string `json:"gatewayEndpoints,omitempty"`
+
 	// AvailableTools lists tools exposed by this server
 	// +kubebuilder:validation:Optional
 	AvailableTools []string `json:"availableTools,omitempty"`
@@ -66,6 +121,18 @@ type MCPServerStatus struct {
 	// Deployment contains status information from the underlying Deployment
 	// +kubebuilder:validation:Optional
 	Deployment *DeploymentStatus `json:"deployment,omitempty"`
+
+	// Dependents lists the Agents in the namespace that reference this MCPServer, i.e. what
+	// breaks if it is deleted
+	// +kubebuilder:validation:Optional
+	Dependents []string `json:"dependents,omitempty"`
+
+	// Conditions represent the latest observations of the resource's state.
+	// The Ready condition mirrors the ready flag with a reason and message.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // +kubebuilder:object:root=true
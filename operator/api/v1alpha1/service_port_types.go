@@ -0,0 +1,31 @@
+package v1alpha1
+
+// ServiceProtocol is the protocol an agent or MCP server runtime speaks on port 8000
+// +kubebuilder:validation:Enum=http;grpc
+type ServiceProtocol string
+
+const (
+	// ServiceProtocolHTTP serves HTTP/1.1 (the default)
+	ServiceProtocolHTTP ServiceProtocol = "http"
+	// ServiceProtocolGRPC serves gRPC over cleartext HTTP/2 (h2c)
+	ServiceProtocolGRPC ServiceProtocol = "grpc"
+)
+
+// +kubebuilder:object:generate=true
+
+// ServicePortConfig names the generated Service port so service meshes such as Istio and
+// Linkerd classify its traffic correctly
+type ServicePortConfig struct {
+	// Name of the Service port. Meshes read the protocol from its prefix, e.g. http-web,
+	// http2 or grpc-api (default: http)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name,omitempty"`
+
+	// AppProtocol sets the port's appProtocol, which takes precedence over the name
+	// prefix in most meshes, e.g. http, http2, grpc or kubernetes.io/h2c
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=253
+	AppProtocol string `json:"appProtocol,omitempty"`
+}
@@ -8,6 +8,7 @@ import (
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -37,19 +38,93 @@ func (in *Agent) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentCardSummary) DeepCopyInto(out *AgentCardSummary) {
+	*out = *in
+	if in.Skills != nil {
+		in, out := &in.Skills, &out.Skills
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentCardSummary.
+func (in *AgentCardSummary) DeepCopy() *AgentCardSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentCardSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentConfig) DeepCopyInto(out *AgentConfig) {
 	*out = *in
+	if in.InstructionsFrom != nil {
+		in, out := &in.InstructionsFrom, &out.InstructionsFrom
+		*out = new(InstructionsSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ReasoningLoopMaxSteps != nil {
 		in, out := &in.ReasoningLoopMaxSteps, &out.ReasoningLoopMaxSteps
 		*out = new(int32)
 		**out = **in
 	}
+	if in.SessionTTLSeconds != nil {
+		in, out := &in.SessionTTLSeconds, &out.SessionTTLSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxHistoryMessages != nil {
+		in, out := &in.MaxHistoryMessages, &out.MaxHistoryMessages
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrentRequests != nil {
+		in, out := &in.MaxConcurrentRequests, &out.MaxConcurrentRequests
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QueueDepth != nil {
+		in, out := &in.QueueDepth, &out.QueueDepth
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ModelHeaders != nil {
+		in, out := &in.ModelHeaders, &out.ModelHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ModelRoutes != nil {
+		in, out := &in.ModelRoutes, &out.ModelRoutes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Generation != nil {
+		in, out := &in.Generation, &out.Generation
+		*out = new(GenerationConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Memory != nil {
 		in, out := &in.Memory, &out.Memory
 		*out = new(MemoryConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DebugMemoryEndpoints != nil {
+		in, out := &in.DebugMemoryEndpoints, &out.DebugMemoryEndpoints
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Telemetry != nil {
 		in, out := &in.Telemetry, &out.Telemetry
 		*out = new(TelemetryConfig)
@@ -67,6 +142,31 @@ func (in *AgentConfig) DeepCopy() *AgentConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentJobConfig) DeepCopyInto(out *AgentJobConfig) {
+	*out = *in
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentJobConfig.
+func (in *AgentJobConfig) DeepCopy() *AgentJobConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentJobConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentList) DeepCopyInto(out *AgentList) {
 	*out = *in
@@ -99,6 +199,36 @@ func (in *AgentList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentMCPServerRef) DeepCopyInto(out *AgentMCPServerRef) {
+	*out = *in
+	if in.AllowTools != nil {
+		in, out := &in.AllowTools, &out.AllowTools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DenyTools != nil {
+		in, out := &in.DenyTools, &out.DenyTools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentMCPServerRef.
+func (in *AgentMCPServerRef) DeepCopy() *AgentMCPServerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentMCPServerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentNetworkConfig) DeepCopyInto(out *AgentNetworkConfig) {
 	*out = *in
@@ -127,11 +257,38 @@ func (in *AgentNetworkConfig) DeepCopy() *AgentNetworkConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
 	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Workers != nil {
+		in, out := &in.Workers, &out.Workers
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(AgentJobConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModelAPIs != nil {
+		in, out := &in.ModelAPIs, &out.ModelAPIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.MCPServers != nil {
 		in, out := &in.MCPServers, &out.MCPServers
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.MCPServerRefs != nil {
+		in, out := &in.MCPServerRefs, &out.MCPServerRefs
+		*out = make([]AgentMCPServerRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.AgentNetwork != nil {
 		in, out := &in.AgentNetwork, &out.AgentNetwork
 		*out = new(AgentNetworkConfig)
@@ -147,9 +304,59 @@ func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.WaitForDependencyEndpoints != nil {
+		in, out := &in.WaitForDependencyEndpoints, &out.WaitForDependencyEndpoints
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WarmUp != nil {
+		in, out := &in.WarmUp, &out.WarmUp
+		*out = new(AgentWarmUp)
+		**out = **in
+	}
+	if in.ScaleDownOnMissingModelAPI != nil {
+		in, out := &in.ScaleDownOnMissingModelAPI, &out.ScaleDownOnMissingModelAPI
+		*out = new(bool)
+		**out = **in
+	}
 	if in.GatewayRoute != nil {
 		in, out := &in.GatewayRoute, &out.GatewayRoute
 		*out = new(GatewayRoute)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrafficSplit != nil {
+		in, out := &in.TrafficSplit, &out.TrafficSplit
+		*out = new(AgentTrafficSplit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(DeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinReadySeconds != nil {
+		in, out := &in.MinReadySeconds, &out.MinReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(CABundleSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EgressProxy != nil {
+		in, out := &in.EgressProxy, &out.EgressProxy
+		*out = new(EgressProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServicePort != nil {
+		in, out := &in.ServicePort, &out.ServicePort
+		*out = new(ServicePortConfig)
 		**out = **in
 	}
 	if in.Container != nil {
@@ -177,6 +384,13 @@ func (in *AgentSpec) DeepCopy() *AgentSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentStatus) DeepCopyInto(out *AgentStatus) {
 	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.LinkedResources != nil {
 		in, out := &in.LinkedResources, &out.LinkedResources
 		*out = make(map[string]string, len(*in))
@@ -184,11 +398,33 @@ func (in *AgentStatus) DeepCopyInto(out *AgentStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Card != nil {
+		in, out := &in.Card, &out.Card
+		*out = new(AgentCardSummary)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Deployment != nil {
 		in, out := &in.Deployment, &out.Deployment
 		*out = new(DeploymentStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Job != nil {
+		in, out := &in.Job, &out.Job
+		*out = new(JobStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CronJob != nil {
+		in, out := &in.CronJob, &out.CronJob
+		*out = new(CronJobStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentStatus.
@@ -201,6 +437,56 @@ func (in *AgentStatus) DeepCopy() *AgentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentTrafficSplit) DeepCopyInto(out *AgentTrafficSplit) {
+	*out = *in
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]AgentTrafficSplitBackend, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTrafficSplit.
+func (in *AgentTrafficSplit) DeepCopy() *AgentTrafficSplit {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentTrafficSplit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentTrafficSplitBackend) DeepCopyInto(out *AgentTrafficSplitBackend) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTrafficSplitBackend.
+func (in *AgentTrafficSplitBackend) DeepCopy() *AgentTrafficSplitBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentTrafficSplitBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentWarmUp) DeepCopyInto(out *AgentWarmUp) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentWarmUp.
+func (in *AgentWarmUp) DeepCopy() *AgentWarmUp {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentWarmUp)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApiKeySource) DeepCopyInto(out *ApiKeySource) {
 	*out = *in
@@ -246,6 +532,31 @@ func (in *ApiKeyValueFrom) DeepCopy() *ApiKeyValueFrom {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleSource) DeepCopyInto(out *CABundleSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleSource.
+func (in *CABundleSource) DeepCopy() *CABundleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigYamlSource) DeepCopyInto(out *ConfigYamlSource) {
 	*out = *in
@@ -279,78 +590,363 @@ func (in *ContainerOverride) DeepCopyInto(out *ContainerOverride) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerOverride.
+func (in *ContainerOverride) DeepCopy() *ContainerOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CronJobStatus) DeepCopyInto(out *CronJobStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduleTime != nil {
+		in, out := &in.NextScheduleTime, &out.NextScheduleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronJobStatus.
+func (in *CronJobStatus) DeepCopy() *CronJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CronJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStatus) DeepCopyInto(out *DeploymentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingSince != nil {
+		in, out := &in.PendingSince, &out.PendingSince
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatus.
+func (in *DeploymentStatus) DeepCopy() *DeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentStrategy) DeepCopyInto(out *DeploymentStrategy) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStrategy.
+func (in *DeploymentStrategy) DeepCopy() *DeploymentStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressProxyConfig) DeepCopyInto(out *EgressProxyConfig) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressProxyConfig.
+func (in *EgressProxyConfig) DeepCopy() *EgressProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalConfig) DeepCopyInto(out *ExternalConfig) {
+	*out = *in
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalConfig.
+func (in *ExternalConfig) DeepCopy() *ExternalConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayRoute) DeepCopyInto(out *GatewayRoute) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Gateways != nil {
+		in, out := &in.Gateways, &out.Gateways
+		*out = make([]GatewayTarget, len(*in))
+		copy(*out, *in)
+	}
+	if in.CORS != nil {
+		in, out := &in.CORS, &out.CORS
+		*out = new(GatewayRouteCORS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayRoute.
+func (in *GatewayRoute) DeepCopy() *GatewayRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayRouteCORS) DeepCopyInto(out *GatewayRouteCORS) {
+	*out = *in
+	if in.AllowOrigins != nil {
+		in, out := &in.AllowOrigins, &out.AllowOrigins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowMethods != nil {
+		in, out := &in.AllowMethods, &out.AllowMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowHeaders != nil {
+		in, out := &in.AllowHeaders, &out.AllowHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayRouteCORS.
+func (in *GatewayRouteCORS) DeepCopy() *GatewayRouteCORS {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayRouteCORS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayTarget) DeepCopyInto(out *GatewayTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayTarget.
+func (in *GatewayTarget) DeepCopy() *GatewayTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GenerationConfig) DeepCopyInto(out *GenerationConfig) {
+	*out = *in
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GenerationConfig.
+func (in *GenerationConfig) DeepCopy() *GenerationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GenerationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Guardrail) DeepCopyInto(out *Guardrail) {
+	*out = *in
+	if in.DefaultOn != nil {
+		in, out := &in.DefaultOn, &out.DefaultOn
+		*out = new(bool)
+		**out = **in
+	}
+	if in.APIKey != nil {
+		in, out := &in.APIKey, &out.APIKey
+		*out = new(ApiKeySource)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Env != nil {
-		in, out := &in.Env, &out.Env
-		*out = make([]v1.EnvVar, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerOverride.
-func (in *ContainerOverride) DeepCopy() *ContainerOverride {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Guardrail.
+func (in *Guardrail) DeepCopy() *Guardrail {
 	if in == nil {
 		return nil
 	}
-	out := new(ContainerOverride)
+	out := new(Guardrail)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentStatus) DeepCopyInto(out *DeploymentStatus) {
+func (in *HostedConfig) DeepCopyInto(out *HostedConfig) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Models != nil {
+		in, out := &in.Models, &out.Models
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PersistModels != nil {
+		in, out := &in.PersistModels, &out.PersistModels
+		*out = new(PersistModelsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WarmStandby != nil {
+		in, out := &in.WarmStandby, &out.WarmStandby
+		*out = new(bool)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentStatus.
-func (in *DeploymentStatus) DeepCopy() *DeploymentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedConfig.
+func (in *HostedConfig) DeepCopy() *HostedConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentStatus)
+	out := new(HostedConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GatewayRoute) DeepCopyInto(out *GatewayRoute) {
+func (in *InstructionsSource) DeepCopyInto(out *InstructionsSource) {
 	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayRoute.
-func (in *GatewayRoute) DeepCopy() *GatewayRoute {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstructionsSource.
+func (in *InstructionsSource) DeepCopy() *InstructionsSource {
 	if in == nil {
 		return nil
 	}
-	out := new(GatewayRoute)
+	out := new(InstructionsSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HostedConfig) DeepCopyInto(out *HostedConfig) {
+func (in *JobStatus) DeepCopyInto(out *JobStatus) {
 	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostedConfig.
-func (in *HostedConfig) DeepCopy() *HostedConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobStatus.
+func (in *JobStatus) DeepCopy() *JobStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(HostedConfig)
+	out := new(JobStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -417,14 +1013,44 @@ func (in *MCPServerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPServerSpec) DeepCopyInto(out *MCPServerSpec) {
 	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
 	if in.Telemetry != nil {
 		in, out := &in.Telemetry, &out.Telemetry
 		*out = new(TelemetryConfig)
 		**out = **in
 	}
+	if in.EgressProxy != nil {
+		in, out := &in.EgressProxy, &out.EgressProxy
+		*out = new(EgressProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServicePort != nil {
+		in, out := &in.ServicePort, &out.ServicePort
+		*out = new(ServicePortConfig)
+		**out = **in
+	}
 	if in.GatewayRoute != nil {
 		in, out := &in.GatewayRoute, &out.GatewayRoute
 		*out = new(GatewayRoute)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(DeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinReadySeconds != nil {
+		in, out := &in.MinReadySeconds, &out.MinReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
 		**out = **in
 	}
 	if in.Container != nil {
@@ -452,6 +1078,13 @@ func (in *MCPServerSpec) DeepCopy() *MCPServerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPServerStatus) DeepCopyInto(out *MCPServerStatus) {
 	*out = *in
+	if in.GatewayEndpoints != nil {
+		in, out := &in.GatewayEndpoints, &out.GatewayEndpoints
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.AvailableTools != nil {
 		in, out := &in.AvailableTools, &out.AvailableTools
 		*out = make([]string, len(*in))
@@ -462,6 +1095,18 @@ func (in *MCPServerStatus) DeepCopyInto(out *MCPServerStatus) {
 		*out = new(DeploymentStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Dependents != nil {
+		in, out := &in.Dependents, &out.Dependents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerStatus.
@@ -497,6 +1142,11 @@ func (in *MemoryConfig) DeepCopyInto(out *MemoryConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(RedisMemoryConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryConfig.
@@ -579,18 +1229,65 @@ func (in *ModelAPISpec) DeepCopyInto(out *ModelAPISpec) {
 	if in.HostedConfig != nil {
 		in, out := &in.HostedConfig, &out.HostedConfig
 		*out = new(HostedConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalConfig != nil {
+		in, out := &in.ExternalConfig, &out.ExternalConfig
+		*out = new(ExternalConfig)
+		(*in).DeepCopyInto(*out)
 	}
 	if in.GatewayRoute != nil {
 		in, out := &in.GatewayRoute, &out.GatewayRoute
 		*out = new(GatewayRoute)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Telemetry != nil {
 		in, out := &in.Telemetry, &out.Telemetry
 		*out = new(TelemetryConfig)
 		**out = **in
 	}
+	if in.DeploymentStrategy != nil {
+		in, out := &in.DeploymentStrategy, &out.DeploymentStrategy
+		*out = new(DeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinReadySeconds != nil {
+		in, out := &in.MinReadySeconds, &out.MinReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(v1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]v1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(CABundleSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EgressProxy != nil {
+		in, out := &in.EgressProxy, &out.EgressProxy
+		*out = new(EgressProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServicePort != nil {
+		in, out := &in.ServicePort, &out.ServicePort
+		*out = new(ServicePortConfig)
+		**out = **in
+	}
 	if in.Container != nil {
 		in, out := &in.Container, &out.Container
 		*out = new(ContainerOverride)
@@ -613,14 +1310,62 @@ func (in *ModelAPISpec) DeepCopy() *ModelAPISpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelAPISpendStatus) DeepCopyInto(out *ModelAPISpendStatus) {
+	*out = *in
+	if in.LastUpdated != nil {
+		in, out := &in.LastUpdated, &out.LastUpdated
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelAPISpendStatus.
+func (in *ModelAPISpendStatus) DeepCopy() *ModelAPISpendStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelAPISpendStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelAPIStatus) DeepCopyInto(out *ModelAPIStatus) {
 	*out = *in
+	if in.GatewayEndpoints != nil {
+		in, out := &in.GatewayEndpoints, &out.GatewayEndpoints
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SupportedModels != nil {
+		in, out := &in.SupportedModels, &out.SupportedModels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Deployment != nil {
 		in, out := &in.Deployment, &out.Deployment
 		*out = new(DeploymentStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Spend != nil {
+		in, out := &in.Spend, &out.Spend
+		*out = new(ModelAPISpendStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Dependents != nil {
+		in, out := &in.Dependents, &out.Dependents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelAPIStatus.
@@ -633,6 +1378,31 @@ func (in *ModelAPIStatus) DeepCopy() *ModelAPIStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersistModelsConfig) DeepCopyInto(out *PersistModelsConfig) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistModelsConfig.
+func (in *PersistModelsConfig) DeepCopy() *PersistModelsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistModelsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
 	*out = *in
@@ -641,16 +1411,75 @@ func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
 	if in.APIKey != nil {
 		in, out := &in.APIKey, &out.APIKey
 		*out = new(ApiKeySource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExtraParams != nil {
+		in, out := &in.ExtraParams, &out.ExtraParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ConfigYaml != nil {
 		in, out := &in.ConfigYaml, &out.ConfigYaml
 		*out = new(ConfigYamlSource)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Callbacks != nil {
+		in, out := &in.Callbacks, &out.Callbacks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CallbackEnv != nil {
+		in, out := &in.CallbackEnv, &out.CallbackEnv
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Guardrails != nil {
+		in, out := &in.Guardrails, &out.Guardrails
+		*out = make([]Guardrail, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(ProxyDatabaseConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SpendTracking != nil {
+		in, out := &in.SpendTracking, &out.SpendTracking
+		*out = new(SpendTrackingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
@@ -663,6 +1492,83 @@ func (in *ProxyConfig) DeepCopy() *ProxyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyDatabaseConfig) DeepCopyInto(out *ProxyDatabaseConfig) {
+	*out = *in
+	in.URLSecretRef.DeepCopyInto(&out.URLSecretRef)
+	if in.MasterKey != nil {
+		in, out := &in.MasterKey, &out.MasterKey
+		*out = new(ApiKeySource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyDatabaseConfig.
+func (in *ProxyDatabaseConfig) DeepCopy() *ProxyDatabaseConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyDatabaseConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisMemoryConfig) DeepCopyInto(out *RedisMemoryConfig) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisMemoryConfig.
+func (in *RedisMemoryConfig) DeepCopy() *RedisMemoryConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisMemoryConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePortConfig) DeepCopyInto(out *ServicePortConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePortConfig.
+func (in *ServicePortConfig) DeepCopy() *ServicePortConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePortConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpendTrackingConfig) DeepCopyInto(out *SpendTrackingConfig) {
+	*out = *in
+	in.MasterKey.DeepCopyInto(&out.MasterKey)
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpendTrackingConfig.
+func (in *SpendTrackingConfig) DeepCopy() *SpendTrackingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SpendTrackingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TelemetryConfig) DeepCopyInto(out *TelemetryConfig) {
 	*out = *in
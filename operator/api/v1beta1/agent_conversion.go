@@ -0,0 +1,25 @@
+package v1beta1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// ConvertTo converts this Agent to the hub version (v1alpha1).
+func (src *Agent) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Agent)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = *src.Spec.DeepCopy()
+	dst.Status = *src.Status.DeepCopy()
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version.
+func (dst *Agent) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Agent)
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = *src.Spec.DeepCopy()
+	dst.Status = *src.Status.DeepCopy()
+	return nil
+}
@@ -0,0 +1,94 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
+
+	"github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+func testHubAgent() *v1alpha1.Agent {
+	replicas := int32(2)
+	return &v1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "researcher",
+			Namespace:   "default",
+			Labels:      map[string]string{"team": "a"},
+			Annotations: map[string]string{"note": "x"},
+		},
+		Spec: v1alpha1.AgentSpec{
+			ModelAPI:   "llm",
+			Model:      "gpt-4o",
+			MCPServers: []string{"echo"},
+			Replicas:   &replicas,
+			Config: &v1alpha1.AgentConfig{
+				Instructions: "You are helpful.",
+			},
+		},
+		Status: v1alpha1.AgentStatus{
+			Phase:    "Ready",
+			Ready:    true,
+			Endpoint: "http://researcher.default:8000",
+		},
+	}
+}
+
+func TestAgentRoundTripFromHub(t *testing.T) {
+	hub := testHubAgent()
+
+	spoke := &Agent{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom failed: %v", err)
+	}
+	restored := &v1alpha1.Agent{}
+	if err := spoke.ConvertTo(restored); err != nil {
+		t.Fatalf("ConvertTo failed: %v", err)
+	}
+
+	if !equality.Semantic.DeepEqual(hub.ObjectMeta, restored.ObjectMeta) {
+		t.Errorf("metadata changed in round trip: %+v", restored.ObjectMeta)
+	}
+	if !equality.Semantic.DeepEqual(hub.Spec, restored.Spec) {
+		t.Errorf("spec changed in round trip: %+v", restored.Spec)
+	}
+	if !equality.Semantic.DeepEqual(hub.Status, restored.Status) {
+		t.Errorf("status changed in round trip: %+v", restored.Status)
+	}
+}
+
+func TestAgentConversionDoesNotAlias(t *testing.T) {
+	hub := testHubAgent()
+
+	spoke := &Agent{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom failed: %v", err)
+	}
+	spoke.Spec.MCPServers[0] = "changed"
+	spoke.Labels["team"] = "b"
+
+	if hub.Spec.MCPServers[0] != "echo" || hub.Labels["team"] != "a" {
+		t.Error("expected conversion to deep copy, hub was modified through spoke")
+	}
+}
+
+func TestAgentIsConvertible(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add v1alpha1: %v", err)
+	}
+	if err := AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add v1beta1: %v", err)
+	}
+
+	ok, err := conversion.IsConvertible(scheme, &v1alpha1.Agent{})
+	if err != nil {
+		t.Fatalf("IsConvertible failed: %v", err)
+	}
+	if !ok {
+		t.Error("expected Agent to be convertible between v1alpha1 and v1beta1")
+	}
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+// getExisting reads the object named key into obj from the cache and, if the cache does
+// not hold it, from the API server through reader. With CACHE_LABEL_SELECTOR set, the
+// cache hides a pre-existing object that lacks the operator's labels; reading it directly
+// lets it be adopted instead of failing to create it. A nil reader only reads the cache.
+func getExisting(ctx context.Context, c client.Reader, reader client.Reader, key types.NamespacedName, obj client.Object) error {
+	err := c.Get(ctx, key, obj)
+	if !apierrors.IsNotFound(err) || reader == nil {
+		return err
+	}
+	return reader.Get(ctx, key, obj)
+}
+
+// adoptResource ensures owner controls obj, an existing resource found under the name the
+// operator would create for owner. A resource without a controller (e.g. left behind by an
+// install that did not set owner references) is adopted by writing the owner reference and
+// the owner's app and name labels it lacks, so it is watched, matches a cache label
+// selector and is garbage collected with owner; its spec is then reconciled as usual.
+//
+// It returns false when reconciliation must stop: obj is controlled by another owner, which
+// is recorded on owner as a terminal failure, or the adoption could not be written.
+func adoptResource(ctx context.Context, c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, owner, obj client.Object) (bool, error) {
+	kind := kindOf(obj, scheme)
+
+	if controller := metav1.GetControllerOf(obj); controller != nil {
+		if controller.UID == owner.GetUID() {
+			return true, nil
+		}
+		return false, reconcileError(ctx, c, recorder, owner, "ResourceConflict", nil,
+			fmt.Sprintf("%s %s already exists and is controlled by %s %s", kind, obj.GetName(), controller.Kind, controller.Name))
+	}
+
+	if err := util.SetControllerReference(owner, obj, scheme); err != nil {
+		return false, err
+	}
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	ownerKind := strings.ToLower(kindOf(owner, scheme))
+	for key, value := range map[string]string{"app": ownerKind, ownerKind: owner.GetName()} {
+		if _, ok := labels[key]; !ok {
+			labels[key] = value
+		}
+	}
+	obj.SetLabels(labels)
+	log.FromContext(ctx).Info("Adopting existing resource", "kind", kind, "name", obj.GetName())
+	if err := c.Update(ctx, obj); err != nil {
+		return false, reconcileError(ctx, c, recorder, owner, "AdoptFailed", err, fmt.Sprintf("Failed to adopt %s %s", kind, obj.GetName()))
+	}
+	if recorder != nil {
+		recorder.Eventf(owner, corev1.EventTypeNormal, "Adopted", "Adopted existing %s %s", kind, obj.GetName())
+	}
+	return true, nil
+}
+
+// kindOf returns the kind of obj, or "resource" if the scheme does not know it
+func kindOf(obj client.Object, scheme *runtime.Scheme) string {
+	if gvk, err := apiutil.GVKForObject(obj, scheme); err == nil {
+		return gvk.Kind
+	}
+	return "resource"
+}
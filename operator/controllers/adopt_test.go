@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+var _ = ginkgo.Describe("adopting resources hidden from the cache", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		agent  *kaosv1alpha1.Agent
+		req    ctrl.Request
+	)
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		setDefaultAgentImage()
+
+		scheme = runtime.NewScheme()
+		gomega.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+		gomega.Expect(kaosv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default", UID: "agent-uid"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "mock-model"},
+		}
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: "agent", Namespace: "default"}}
+	})
+
+	orphanDeployment := func() *appsv1.Deployment {
+		labels := map[string]string{"app": "agent"}
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent-agent", Namespace: "default"},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "agent", Image: "old:image"}}},
+				},
+			},
+		}
+	}
+
+	ginkgo.It("adopts an unlabelled Deployment that the cache label selector hides", func() {
+		setEnv("CACHE_LABEL_SELECTOR", "app in (agent,modelapi,mcpserver)")
+		selector, err := util.GetCacheLabelSelector()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		modelapi := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:        kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"mock-model"}},
+			},
+			Status: kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}
+		deployment := orphanDeployment()
+		deployment.Labels = nil
+		apiServer := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(modelapi, agent, deployment).
+			WithStatusSubresource(&kaosv1alpha1.ModelAPI{}, &kaosv1alpha1.Agent{}).
+			Build()
+		cached := labelFilteredClient{Client: apiServer, selector: selector}
+
+		// The cache alone does not see the Deployment, so creating it fails
+		r := &AgentReconciler{Client: cached, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+		_, err = r.Reconcile(ctx, req)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("already exists")))
+
+		r.APIReader = apiServer
+		_, err = r.Reconcile(ctx, req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		// Adopted and labelled, so the cache sees it from now on
+		key := types.NamespacedName{Name: "agent-agent", Namespace: "default"}
+		gomega.Expect(cached.Get(ctx, key, deployment)).To(gomega.Succeed())
+		gomega.Expect(metav1.IsControlledBy(deployment, agent)).To(gomega.BeTrue())
+		gomega.Expect(deployment.Labels).To(gomega.Equal(map[string]string{"app": "agent", "agent": "agent"}))
+		gomega.Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(gomega.Equal(testAgentImage))
+	})
+
+})
+
+// labelFilteredClient hides Deployments and Services that do not match selector from Get,
+// like the manager's cache does when CACHE_LABEL_SELECTOR is set
+type labelFilteredClient struct {
+	client.Client
+	selector labels.Selector
+}
+
+func (c labelFilteredClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := c.Client.Get(ctx, key, obj, opts...); err != nil {
+		return err
+	}
+	switch obj.(type) {
+	case *appsv1.Deployment, *corev1.Service:
+		if !c.selector.Matches(labels.Set(obj.GetLabels())) {
+			return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"slices"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/deps"
+)
+
+const (
+	// maxCardSkills bounds the skills listed in status.card
+	maxCardSkills = 64
+	// maxCardDescriptionLength bounds the description in status.card, in characters
+	maxCardDescriptionLength = 512
+)
+
+// agentCardSummary builds status.card the way the agent runtime builds its card: skills
+// are the tools of the agent's MCPServers and capabilities follow its MCP servers and
+// peers. Tools come from each MCPServer's status.availableTools, filtered and prefixed
+// by the matching mcpServerRefs entry.
+func agentCardSummary(agent *kaosv1alpha1.Agent, mcpServers []deps.MCPServer, peerAgents map[string]string) *kaosv1alpha1.AgentCardSummary {
+	summary := &kaosv1alpha1.AgentCardSummary{
+		Capabilities: []string{"message_processing", "task_execution"},
+	}
+	if len(mcpServers) > 0 {
+		summary.Capabilities = append(summary.Capabilities, "tool_execution")
+	}
+	if len(peerAgents) > 0 {
+		summary.Capabilities = append(summary.Capabilities, "task_delegation")
+	}
+
+	description, _, _ := renderAgentConfigText(agent)
+	if runes := []rune(description); len(runes) > maxCardDescriptionLength {
+		description = string(runes[:maxCardDescriptionLength])
+	}
+	summary.Description = description
+
+	var skills []string
+	for _, mcp := range mcpServers {
+		if mcp.Object == nil {
+			continue
+		}
+		var ref kaosv1alpha1.AgentMCPServerRef
+		if i := slices.IndexFunc(agent.Spec.MCPServerRefs, func(r kaosv1alpha1.AgentMCPServerRef) bool { return r.Name == mcp.Name }); i >= 0 {
+			ref = agent.Spec.MCPServerRefs[i]
+		}
+		for _, tool := range mcp.Object.Status.AvailableTools {
+			if len(ref.AllowTools) > 0 && !slices.Contains(ref.AllowTools, tool) {
+				continue
+			}
+			if slices.Contains(ref.DenyTools, tool) {
+				continue
+			}
+			if ref.Prefix != "" {
+				tool = ref.Prefix + "_" + tool
+			}
+			if !slices.Contains(skills, tool) {
+				skills = append(skills, tool)
+			}
+		}
+	}
+	if len(skills) > maxCardSkills {
+		summary.TruncatedSkills = int32(len(skills) - maxCardSkills)
+		skills = skills[:maxCardSkills]
+	}
+	summary.Skills = skills
+	return summary
+}
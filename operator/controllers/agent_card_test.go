@@ -0,0 +1,35 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/deps"
+)
+
+var _ = ginkgo.Describe("agent card summary", func() {
+	ginkgo.It("bounds the skills and description", func() {
+		tools := make([]string, maxCardSkills+6)
+		for i := range tools {
+			tools[i] = fmt.Sprintf("tool%d", i)
+		}
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec: kaosv1alpha1.AgentSpec{
+				Config: &kaosv1alpha1.AgentConfig{Description: strings.Repeat("x", maxCardDescriptionLength+10)},
+			},
+		}
+		mcp := &kaosv1alpha1.MCPServer{Status: kaosv1alpha1.MCPServerStatus{AvailableTools: tools}}
+
+		summary := agentCardSummary(agent, []deps.MCPServer{{Name: "tools", Object: mcp}}, map[string]string{"peer": "http://agent-peer:8000"})
+		gomega.Expect(summary.Skills).To(gomega.HaveLen(maxCardSkills))
+		gomega.Expect(summary.TruncatedSkills).To(gomega.Equal(int32(6)))
+		gomega.Expect(summary.Description).To(gomega.HaveLen(maxCardDescriptionLength))
+		gomega.Expect(summary.Capabilities).To(gomega.ContainElement("task_delegation"))
+	})
+})
@@ -2,20 +2,32 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -23,17 +35,44 @@ import (
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/deps"
+	"github.com/axsaucedo/kaos/operator/pkg/features"
 	"github.com/axsaucedo/kaos/operator/pkg/gateway"
+	"github.com/axsaucedo/kaos/operator/pkg/metrics"
+	"github.com/axsaucedo/kaos/operator/pkg/tracing"
 	"github.com/axsaucedo/kaos/operator/pkg/util"
+	"github.com/axsaucedo/kaos/operator/pkg/validation"
 )
 
 const agentFinalizerName = "kaos.tools/agent-finalizer"
 
+const (
+	// instructionsVolumeName is the pod volume holding instructions from config.instructionsFrom
+	instructionsVolumeName = "agent-instructions"
+	// instructionsMountPath is where the instructions volume is mounted in the agent container
+	instructionsMountPath = "/etc/kaos/instructions"
+	// instructionsFileName is the file name of the mounted instructions
+	instructionsFileName = "instructions"
+)
+
 // AgentReconciler reconciles an Agent object
 type AgentReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Clientset reads the pods of a Deployment that is missing replicas, to
+	// explain the failure in status.message (optional)
+	Clientset kubernetes.Interface
+	// APIReader reads Deployments and Services the cache does not hold, such as unlabelled
+	// pre-existing ones hidden by CACHE_LABEL_SELECTOR, so they are adopted (optional)
+	APIReader client.Reader
+	// HTTPClient is used for live model validation (defaults to a client with a 5s timeout)
+	HTTPClient *http.Client
+
+	// backoff delays requeues while a dependency is missing (see dependencyBackoff)
+	backoff     workqueue.TypedRateLimiter[types.NamespacedName]
+	backoffOnce sync.Once
 }
 
 //+kubebuilder:rbac:groups=kaos.tools,resources=agents,verbs=get;list;watch;create;update;patch;delete
@@ -42,11 +81,26 @@ type AgentReconciler struct {
 //+kubebuilder:rbac:groups=kaos.tools,resources=modelapis,verbs=get;list;watch
 //+kubebuilder:rbac:groups=kaos.tools,resources=mcpservers,verbs=get;list;watch
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *AgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcile(ctx, "Agent", req.NamespacedName)
+	result, err := metrics.ObserveReconcile("Agent", func() (ctrl.Result, error) {
+		return r.reconcile(ctx, req)
+	})
+	tracing.End(span, err)
+	return result, err
+}
+
+// reconcile performs a single reconciliation of the Agent
+func (r *AgentReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 
 	agent := &kaosv1alpha1.Agent{}
@@ -57,6 +111,7 @@ func (r *AgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 
 	// Handle deletion with finalizer
 	if agent.ObjectMeta.DeletionTimestamp != nil {
+		r.resetDependencyBackoff(req.NamespacedName)
 		if controllerutil.ContainsFinalizer(agent, agentFinalizerName) {
 			log.Info("Deleting Agent", "name", agent.Name)
 			controllerutil.RemoveFinalizer(agent, agentFinalizerName)
@@ -77,15 +132,12 @@ func (r *AgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		}
 	}
 
-	// Set initial status
+	// Set initial status; it is persisted by the single status update at the end of
+	// the reconcile (or by the status update of an early return)
 	if agent.Status.Phase == "" {
 		agent.Status.Phase = "Pending"
 		agent.Status.Ready = false
 		agent.Status.LinkedResources = make(map[string]string)
-		if err := r.Status().Update(ctx, agent); err != nil {
-			log.Error(err, "failed to update status")
-			return ctrl.Result{}, err
-		}
 	}
 
 	// Validate telemetry config
@@ -98,131 +150,201 @@ func (r *AgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		log.Info("WARNING: telemetry.enabled=true but endpoint is empty; telemetry will not function", "agent", agent.Name)
 	}
 
-	// Resolve ModelAPI reference
-	modelapi := &kaosv1alpha1.ModelAPI{}
-	err := r.Get(ctx, types.NamespacedName{Name: agent.Spec.ModelAPI, Namespace: agent.Namespace}, modelapi)
-	if err != nil {
-		log.Error(err, "unable to fetch ModelAPI", "modelAPI", agent.Spec.ModelAPI)
-		agent.Status.Phase = "Failed"
-		agent.Status.Message = fmt.Sprintf("Failed to resolve ModelAPI: %v", err)
-		r.Status().Update(ctx, agent)
-		return ctrl.Result{}, err
+	// Reject inline text that would exceed env var size limits and fail pod creation
+	if err := validateInlineConfigSize(agent, util.GetMaxInlineTextBytes()); err != nil {
+		return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "InlineConfigTooLarge", nil, err.Error())
 	}
 
-	// Check if we should wait for dependencies (default true)
-	waitForDeps := agent.Spec.WaitForDependencies == nil || *agent.Spec.WaitForDependencies
+	// Reject self-referencing peers (also rejected by the validating webhook when installed)
+	if err := validation.ValidateAgentNetworkAccess(agent); err != nil {
+		return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "InvalidAgentNetwork", nil, err.Error())
+	}
 
-	if !modelapi.Status.Ready && waitForDeps {
-		log.Info("ModelAPI not ready, waiting", "modelAPI", agent.Spec.ModelAPI)
-		agent.Status.Phase = "Waiting"
-		agent.Status.Message = "ModelAPI is not ready"
-		r.Status().Update(ctx, agent)
-		return ctrl.Result{}, nil
+	// Reject invalid overflow settings (also rejected by the validating webhook when installed)
+	if err := validation.ValidateAgentOverflow(agent); err != nil {
+		return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "InvalidOverflowPolicy", nil, err.Error())
 	}
 
-	// Validate that agent's model is supported by the ModelAPI
-	if err := r.validateAgentModel(agent, modelapi); err != nil {
-		log.Error(err, "model validation failed")
-		agent.Status.Phase = "Failed"
-		agent.Status.Message = err.Error()
-		r.Status().Update(ctx, agent)
-		return ctrl.Result{}, nil
+	// Reject entrypoint overrides that podSpec would silently replace
+	if err := validateContainerCommand(agent); err != nil {
+		return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "InvalidContainerOverride", nil, err.Error())
 	}
 
-	// Resolve MCPServer references
-	mcpServers := make(map[string]string)
-	for _, mcpName := range agent.Spec.MCPServers {
-		mcp := &kaosv1alpha1.MCPServer{}
-		err := r.Get(ctx, types.NamespacedName{Name: mcpName, Namespace: agent.Namespace}, mcp)
+	// Reject instructions and descriptions whose templates do not render
+	if _, _, err := renderAgentConfigText(agent); err != nil {
+		return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "InvalidInstructionsTemplate", nil, err.Error())
+	}
+
+	setWarningCondition(r.Recorder, agent, &agent.Status.Conditions, ConditionTypeMemoryNotShared, memoryNotSharedMessage(agent))
+
+	// Check if we should wait for dependencies (default true)
+	waitForDeps := agent.Spec.WaitForDependencies == nil || *agent.Spec.WaitForDependencies
+
+	// Resolve ModelAPI references (primary first, then fallbacks)
+	modelAPINames := agentModelAPINames(agent)
+	modelapis := make([]*kaosv1alpha1.ModelAPI, 0, len(modelAPINames))
+	for _, modelAPIName := range modelAPINames {
+		resolved, err := deps.ResolveModelAPI(ctx, r.Client, agent.Namespace, modelAPIName)
 		if err != nil {
-			log.Error(err, "unable to fetch MCPServer", "mcpserver", mcpName)
-			agent.Status.Phase = "Failed"
-			agent.Status.Message = fmt.Sprintf("Failed to resolve MCPServer %s: %v", mcpName, err)
-			r.Status().Update(ctx, agent)
-			return ctrl.Result{}, err
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "ModelAPIResolveFailed", err, "Failed to resolve ModelAPI")
+		}
+		if !resolved.Found() {
+			// ModelAPI was deleted (or not created yet) - the ModelAPI watch re-triggers
+			// reconciliation once it exists again; the backed-off requeue is a fallback
+			if err := r.handleMissingModelAPI(ctx, agent, modelAPIName); err != nil {
+				return ctrl.Result{}, err
+			}
+			return r.requeueMissingDependency(req.NamespacedName), nil
 		}
 
-		if !mcp.Status.Ready && waitForDeps {
-			log.Info("MCPServer not ready, waiting", "mcpserver", mcpName)
+		if !resolved.Ready && waitForDeps {
+			log.Info("ModelAPI not ready, waiting", "modelAPI", modelAPIName)
 			agent.Status.Phase = "Waiting"
-			agent.Status.Message = fmt.Sprintf("MCPServer %s is not ready", mcpName)
-			r.Status().Update(ctx, agent)
+			agent.Status.Message = fmt.Sprintf("ModelAPI %s is not ready", modelAPIName)
+			if err := patchStatus(ctx, r.Client, agent); err != nil {
+				log.Error(err, "failed to update status")
+				return ctrl.Result{}, err
+			}
 			return ctrl.Result{}, nil
 		}
 
-		mcpServers[mcpName] = mcp.Status.Endpoint
+		// Validate that agent's model is supported by the ModelAPI
+		if err := r.validateAgentModel(ctx, agent, resolved.Object); err != nil {
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "ModelNotSupported", nil, err.Error())
+		}
+
+		modelapis = append(modelapis, resolved.Object)
+	}
+
+	// Resolve MCPServer references
+	resolvedMCPServers, err := deps.ResolveMCPServers(ctx, r.Client, agent.Namespace, agentMCPServerNames(agent))
+	if apierrors.IsNotFound(err) {
+		// Back off rather than failing fast: the MCPServer watch re-triggers reconciliation
+		// once it is created
+		if err := reconcileError(ctx, r.Client, r.Recorder, agent, "MCPServerNotFound", nil, err.Error()); err != nil {
+			return ctrl.Result{}, err
+		}
+		return r.requeueMissingDependency(req.NamespacedName), nil
+	} else if err != nil {
+		return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "MCPServerResolveFailed", err, "Failed to resolve MCPServers")
 	}
+	for _, mcp := range resolvedMCPServers {
+		if !mcp.Ready && waitForDeps {
+			log.Info("MCPServer not ready, waiting", "mcpserver", mcp.Name)
+			agent.Status.Phase = "Waiting"
+			agent.Status.Message = fmt.Sprintf("MCPServer %s is not ready", mcp.Name)
+			if err := patchStatus(ctx, r.Client, agent); err != nil {
+				log.Error(err, "failed to update status")
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+	}
+	mcpServers := deps.MCPServerEndpoints(resolvedMCPServers)
+	r.resetDependencyBackoff(req.NamespacedName)
 
 	// Resolve peer agent endpoints
-	peerAgents := make(map[string]string)
+	var peerNames []string
 	if agent.Spec.AgentNetwork != nil {
-		for _, peerName := range agent.Spec.AgentNetwork.Access {
-			peerAgent := &kaosv1alpha1.Agent{}
-			err := r.Get(ctx, types.NamespacedName{Name: peerName, Namespace: agent.Namespace}, peerAgent)
-			if err != nil {
-				log.Info("peer agent not found yet", "peer", peerName)
-				continue
-			}
+		peerNames = agent.Spec.AgentNetwork.Access
+	}
+	peers, err := deps.ResolvePeers(ctx, r.Client, agent.Namespace, peerNames)
+	if err != nil {
+		return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "PeerResolveFailed", err, "Failed to resolve peer agents")
+	}
+	for _, peer := range peers {
+		if !peer.Found {
+			log.Info("peer agent not found yet", "peer", peer.Name)
+		} else if peer.Endpoint != "" {
+			log.Info("found peer agent endpoint", "peer", peer.Name, "endpoint", peer.Endpoint)
+		}
+	}
+	peerAgents := deps.PeerEndpoints(peers)
 
-			if peerAgent.Status.Endpoint != "" {
-				peerAgents[peerName] = peerAgent.Status.Endpoint
-				log.Info("found peer agent endpoint", "peer", peerName, "endpoint", peerAgent.Status.Endpoint)
-			}
+	// Edits to the instructionsFrom source roll the pods through the referenced content
+	// hash; here the key only has to exist
+	if err := r.checkInstructionsSource(ctx, agent); err != nil {
+		return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "InstructionsResolveFailed", err, "Failed to resolve config.instructionsFrom")
+	}
+
+	// Job mode runs the agent once as a Job (or on a schedule as a CronJob)
+	// instead of a Deployment and Service
+	if agent.Spec.Mode == kaosv1alpha1.AgentModeJob {
+		if agent.Spec.Job != nil && agent.Spec.Job.Schedule != "" {
+			return r.reconcileCronJob(ctx, agent, modelAPINames, modelapis, mcpServers, peerAgents)
 		}
+		return r.reconcileJob(ctx, agent, modelAPINames, modelapis, mcpServers, peerAgents)
 	}
 
 	// Create or update Deployment
 	deployment := &appsv1.Deployment{}
 	deploymentName := fmt.Sprintf("agent-%s", agent.Name)
-	err = r.Get(ctx, types.NamespacedName{Name: deploymentName, Namespace: agent.Namespace}, deployment)
+	err = getExisting(ctx, r.Client, r.APIReader, types.NamespacedName{Name: deploymentName, Namespace: agent.Namespace}, deployment)
 
 	if err != nil && apierrors.IsNotFound(err) {
 		// Create new Deployment
-		deployment, err = r.constructDeployment(agent, modelapi, mcpServers, peerAgents)
+		var overridden []string
+		deployment, overridden, err = r.constructDeployment(agent, modelapis, mcpServers, peerAgents)
 		if err != nil {
-			log.Error(err, "failed to construct Deployment")
-			agent.Status.Phase = "Failed"
-			agent.Status.Message = fmt.Sprintf("Failed to construct Deployment: %v", err)
-			r.Status().Update(ctx, agent)
+			return ctrl.Result{}, constructError(ctx, r.Client, r.Recorder, agent, err)
+		}
+		recordEnvOverrides(r.Recorder, agent, &agent.Status.Conditions, overridden)
+		if err := applyReferencedContentHash(ctx, contentReader(r.Client, r.APIReader), agent, &deployment.Spec.Template); err != nil {
+			log.Error(err, "failed to hash referenced ConfigMaps and Secrets")
 			return ctrl.Result{}, err
 		}
-		if err := controllerutil.SetControllerReference(agent, deployment, r.Scheme); err != nil {
+		applyAutoRollout(agent, deployment)
+		if err := util.SetControllerReference(agent, deployment, r.Scheme); err != nil {
 			log.Error(err, "failed to set controller reference")
 			return ctrl.Result{}, err
 		}
 
 		log.Info("Creating Deployment", "name", deployment.Name)
 		if err := r.Create(ctx, deployment); err != nil {
-			log.Error(err, "failed to create Deployment")
-			agent.Status.Phase = "Failed"
-			agent.Status.Message = fmt.Sprintf("Failed to create Deployment: %v", err)
-			r.Status().Update(ctx, agent)
-			return ctrl.Result{}, err
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "DeploymentCreateFailed", err, "Failed to create Deployment")
 		}
 	} else if err != nil {
 		log.Error(err, "failed to get Deployment")
 		return ctrl.Result{}, err
 	} else {
-		// Deployment exists - check if spec has changed using hash annotation
-		desiredDeployment, err := r.constructDeployment(agent, modelapi, mcpServers, peerAgents)
-		if err != nil {
-			log.Error(err, "failed to construct Deployment for comparison")
+		if ok, err := adoptResource(ctx, r.Client, r.Scheme, r.Recorder, agent, deployment); !ok {
 			return ctrl.Result{}, err
 		}
-		currentHash := ""
-		if deployment.Spec.Template.Annotations != nil {
-			currentHash = deployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+
+		// Deployment exists - check if spec has changed using hash annotation
+		desiredDeployment, overridden, err := r.constructDeployment(agent, modelapis, mcpServers, peerAgents)
+		if err != nil {
+			return ctrl.Result{}, constructError(ctx, r.Client, r.Recorder, agent, err)
 		}
-		desiredHash := ""
-		if desiredDeployment.Spec.Template.Annotations != nil {
-			desiredHash = desiredDeployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+		recordEnvOverrides(r.Recorder, agent, &agent.Status.Conditions, overridden)
+		if err := applyReferencedContentHash(ctx, contentReader(r.Client, r.APIReader), agent, &desiredDeployment.Spec.Template); err != nil {
+			log.Error(err, "failed to hash referenced ConfigMaps and Secrets")
+			return ctrl.Result{}, err
 		}
+		applyAutoRollout(agent, desiredDeployment)
+		currentHash := deploymentHash(deployment)
+		desiredHash := deploymentHash(desiredDeployment)
+
+		// Replicas may differ if the deployment was scaled down while a dependency was missing
+		replicasChanged := deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != *desiredDeployment.Spec.Replicas
+		rolloutChanged := deploymentRolloutChanged(deployment, desiredDeployment)
 
-		if currentHash != desiredHash {
+		changed := currentHash != desiredHash || replicasChanged || rolloutChanged
+		if changed && deploymentFrozen(agent) {
+			log.Info("Deployment is frozen; skipping update", "name", deployment.Name,
+				"currentHash", currentHash, "desiredHash", desiredHash)
+			if r.Recorder != nil {
+				r.Recorder.Event(agent, corev1.EventTypeNormal, "DeploymentFrozen",
+					fmt.Sprintf("Deployment %s differs from the spec but is not updated while %s is set", deployment.Name, freezeDeploymentAnnotation))
+			}
+		} else if changed {
 			log.Info("Updating Deployment due to spec change", "name", deployment.Name,
 				"currentHash", currentHash, "desiredHash", desiredHash)
 			// Update the deployment spec to trigger rolling update
 			deployment.Spec.Template = desiredDeployment.Spec.Template
+			deployment.Spec.Replicas = desiredDeployment.Spec.Replicas
+			setDeploymentRollout(deployment, desiredDeployment)
+			setDeploymentHash(deployment, desiredDeployment)
 			if err := r.Update(ctx, deployment); err != nil {
 				log.Error(err, "failed to update Deployment")
 				return ctrl.Result{}, err
@@ -235,173 +357,939 @@ func (r *AgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	if exposeEnabled {
 		service := &corev1.Service{}
 		serviceName := fmt.Sprintf("agent-%s", agent.Name)
-		err = r.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: agent.Namespace}, service)
+		err = getExisting(ctx, r.Client, r.APIReader, types.NamespacedName{Name: serviceName, Namespace: agent.Namespace}, service)
 
 		if err != nil && apierrors.IsNotFound(err) {
 			service = r.constructService(agent)
-			if err := controllerutil.SetControllerReference(agent, service, r.Scheme); err != nil {
+			if err := util.SetControllerReference(agent, service, r.Scheme); err != nil {
 				log.Error(err, "failed to set controller reference")
 				return ctrl.Result{}, err
 			}
 
 			log.Info("Creating Service", "name", service.Name)
 			if err := r.Create(ctx, service); err != nil {
-				log.Error(err, "failed to create Service")
-				agent.Status.Phase = "Failed"
-				agent.Status.Message = fmt.Sprintf("Failed to create Service: %v", err)
-				r.Status().Update(ctx, agent)
-				return ctrl.Result{}, err
+				return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "ServiceCreateFailed", err, "Failed to create Service")
 			}
 		} else if err != nil {
 			log.Error(err, "failed to get Service")
 			return ctrl.Result{}, err
+		} else {
+			if ok, err := adoptResource(ctx, r.Client, r.Scheme, r.Recorder, agent, service); !ok {
+				return ctrl.Result{}, err
+			}
+
+			// Service exists - check if the port needs to be updated (servicePort changed)
+			desiredService := r.constructService(agent)
+			if servicePortsChanged(service, desiredService) {
+				log.Info("Updating Service due to port change", "name", service.Name)
+				service.Spec.Ports = desiredService.Spec.Ports
+				if err := r.Update(ctx, service); err != nil {
+					log.Error(err, "failed to update Service")
+					return ctrl.Result{}, err
+				}
+			}
 		}
 
 		// Set endpoint for A2A (base URL only - clients append paths like /.well-known/agent)
 		agent.Status.Endpoint = fmt.Sprintf("http://%s.%s.svc.cluster.local:8000", serviceName, agent.Namespace)
+		agent.Status.Endpoints = map[string]string{
+			"internal": fmt.Sprintf("http://%s.%s:8000", serviceName, agent.Namespace),
+			"fqdn":     agent.Status.Endpoint,
+		}
 
 		// Create HTTPRoute if Gateway API is enabled
-		timeout := ""
-		if agent.Spec.GatewayRoute != nil && agent.Spec.GatewayRoute.Timeout != "" {
-			timeout = agent.Spec.GatewayRoute.Timeout
+		if gatewayRouteEnabled(agent.Spec.GatewayRoute) {
+			if agentTrafficSplitRequested(agent) && !features.Enabled(features.AgentTrafficSplit) {
+				warnFeatureGateDisabled(ctx, r.Recorder, agent, features.AgentTrafficSplit, "trafficSplit")
+			}
+			routeParams, err := agentHTTPRouteParams(agent, serviceName)
+			if err != nil {
+				return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "InvalidTrafficSplit", nil, err.Error())
+			}
+			if err := gateway.ReconcileHTTPRoute(ctx, r.Client, r.Scheme, agent, routeParams, log); err != nil {
+				return ctrl.Result{}, routeError(ctx, r.Client, r.Recorder, agent, err)
+			}
+			if endpoint := gateway.RouteEndpoint(routeParams); endpoint != "" {
+				agent.Status.Endpoints["gateway"] = endpoint
+			}
+			if len(routeParams.Gateways) > 0 {
+				for name, endpoint := range gateway.RouteEndpoints(routeParams) {
+					agent.Status.Endpoints["gateway-"+name] = endpoint
+				}
+			}
+		} else if err := gateway.DeleteHTTPRoute(ctx, r.Client, agent, gateway.ResourceTypeAgent, agent.Name, agent.Namespace, log); err != nil {
+			log.Error(err, "failed to delete HTTPRoute")
 		}
-		if err := gateway.ReconcileHTTPRoute(ctx, r.Client, r.Scheme, agent, gateway.HTTPRouteParams{
-			ResourceType: gateway.ResourceTypeAgent,
-			ResourceName: agent.Name,
-			Namespace:    agent.Namespace,
-			ServiceName:  serviceName,
-			ServicePort:  8000,
-			Labels:       map[string]string{"app": "agent", "agent": agent.Name},
-			Timeout:      timeout,
-		}, log); err != nil {
-			log.Error(err, "failed to reconcile HTTPRoute")
+	} else {
+		agent.Status.Endpoints = nil
+		if err := gateway.DeleteHTTPRoute(ctx, r.Client, agent, gateway.ResourceTypeAgent, agent.Name, agent.Namespace, log); err != nil {
+			log.Error(err, "failed to delete HTTPRoute")
 		}
 	}
 
 	// Update status
 	agent.Status.LinkedResources = make(map[string]string)
-	agent.Status.LinkedResources["modelapi"] = agent.Spec.ModelAPI
+	agent.Status.LinkedResources["modelapi"] = modelAPINames[0]
+	if len(modelAPINames) > 1 {
+		agent.Status.LinkedResources["modelapis"] = strings.Join(modelAPINames, ",")
+	}
+
+	agent.Status.Card = agentCardSummary(agent, resolvedMCPServers, peerAgents)
 
 	// Copy deployment status for rolling update visibility
+	previousDeployment := agent.Status.Deployment
 	agent.Status.Deployment = util.CopyDeploymentStatus(deployment)
+	util.TrackPendingSince(agent.Status.Deployment, previousDeployment, metav1.Now())
 
-	// Check deployment readiness
-	if deployment.Status.ReadyReplicas > 0 {
+	// Check deployment readiness (zero desired replicas means the agent is suspended). Ready
+	// pods of a previous pod template don't make the agent Ready while a rollout is running.
+	rolloutMessage, rolledOut := util.DeploymentRolloutComplete(deployment)
+	if *deployment.Spec.Replicas == 0 {
+		agent.Status.Ready = false
+		agent.Status.Phase = "Suspended"
+	} else if deployment.Status.ReadyReplicas == 0 {
+		agent.Status.Phase = "Pending"
+		agent.Status.Ready = false
+	} else if !rolledOut {
+		agent.Status.Phase = "Progressing"
+		agent.Status.Ready = false
+	} else {
 		agent.Status.Ready = true
 		agent.Status.Phase = "Ready"
+	}
+
+	agent.Status.ObservedReplicas = *deployment.Spec.Replicas
+	agent.Status.ReadyReplicas = deployment.Status.ReadyReplicas
+	degraded := agent.Status.ReadyReplicas > 0 && agent.Status.ReadyReplicas < agent.Status.ObservedReplicas
+
+	if agent.Status.Phase == "Suspended" {
+		agent.Status.Message = "Agent suspended: deployment scaled to zero replicas"
+	} else if agent.Status.Phase == "Progressing" {
+		agent.Status.Message = "Deployment rollout in progress: " + rolloutMessage
+	} else if degraded {
+		agent.Status.Message = fmt.Sprintf("Deployment degraded: %d/%d replicas ready", agent.Status.ReadyReplicas, agent.Status.ObservedReplicas)
 	} else {
-		agent.Status.Phase = "Pending"
+		agent.Status.Message = fmt.Sprintf("Deployment ready replicas: %d/%d", deployment.Status.ReadyReplicas, *deployment.Spec.Replicas)
+	}
+
+	// A rollout that stopped making progress is surfaced as Failed
+	if message, exceeded := util.DeploymentProgressDeadlineExceeded(deployment); exceeded && agent.Status.Phase != "Suspended" {
+		agent.Status.Phase = "Failed"
 		agent.Status.Ready = false
+		agent.Status.Message = "Deployment rollout exceeded its progress deadline: " + message
+	}
+
+	// A rollout that never got a ready replica is surfaced as Failed after readyTimeout
+	result := ctrl.Result{}
+	if agent.Status.Phase == "Pending" {
+		remaining, exceeded := readyTimeoutExceeded(agent.Spec.ReadyTimeout, agent.Status.Deployment)
+		if exceeded {
+			agent.Status.Phase = "Failed"
+			agent.Status.Message = readyTimeoutMessage(agent.Spec.ReadyTimeout, deployment)
+		}
+		result.RequeueAfter = remaining
+	}
+
+	// Explain missing replicas with the first failing pod (e.g. ImagePullBackOff)
+	if agent.Status.Phase != "Suspended" && agent.Status.ReadyReplicas < agent.Status.ObservedReplicas {
+		if detail := podFailureMessage(ctx, r.Clientset, deployment); detail != "" {
+			agent.Status.Message += "; " + detail
+		}
+	}
+	setReadyCondition(&agent.Status.Conditions, agent.Generation, agent.Status.Ready, agent.Status.Phase, agent.Status.Message)
+	setDegradedCondition(&agent.Status.Conditions, agent.Generation, agent.Status.ObservedReplicas, agent.Status.ReadyReplicas)
+
+	if err := patchStatus(ctx, r.Client, agent); err != nil {
+		log.Error(err, "failed to update status")
+		return ctrl.Result{}, err
+	}
+
+	return result, nil
+}
+
+// reconcileJob creates the Job for an agent in job mode and mirrors its completion status.
+// Job pod templates are immutable, so spec changes only apply once the Job is deleted.
+func (r *AgentReconciler) reconcileJob(ctx context.Context, agent *kaosv1alpha1.Agent, modelAPINames []string, modelapis []*kaosv1alpha1.ModelAPI, mcpServers map[string]string, peerAgents map[string]string) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	job := &batchv1.Job{}
+	jobName := fmt.Sprintf("agent-%s", agent.Name)
+	err := r.Get(ctx, types.NamespacedName{Name: jobName, Namespace: agent.Namespace}, job)
+
+	if err != nil && apierrors.IsNotFound(err) {
+		var overridden []string
+		job, overridden, err = r.constructJob(agent, modelapis, mcpServers, peerAgents)
+		if err != nil {
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "JobConstructFailed", err, "Failed to construct Job")
+		}
+		recordEnvOverrides(r.Recorder, agent, &agent.Status.Conditions, overridden)
+		if err := applyReferencedContentHash(ctx, contentReader(r.Client, r.APIReader), agent, &job.Spec.Template); err != nil {
+			log.Error(err, "failed to hash referenced ConfigMaps and Secrets")
+			return ctrl.Result{}, err
+		}
+		if err := util.SetControllerReference(agent, job, r.Scheme); err != nil {
+			log.Error(err, "failed to set controller reference")
+			return ctrl.Result{}, err
+		}
+
+		log.Info("Creating Job", "name", job.Name)
+		if err := r.Create(ctx, job); err != nil {
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "JobCreateFailed", err, "Failed to create Job")
+		}
+	} else if err != nil {
+		log.Error(err, "failed to get Job")
+		return ctrl.Result{}, err
+	}
+
+	// Update status
+	agent.Status.LinkedResources = make(map[string]string)
+	agent.Status.LinkedResources["modelapi"] = modelAPINames[0]
+	if len(modelAPINames) > 1 {
+		agent.Status.LinkedResources["modelapis"] = strings.Join(modelAPINames, ",")
+	}
+
+	agent.Status.Job = util.CopyJobStatus(job)
+	agent.Status.Phase = util.JobPhase(job)
+	agent.Status.Ready = agent.Status.Phase == "Succeeded"
+	agent.Status.Message = fmt.Sprintf("Job active: %d, succeeded: %d, failed: %d",
+		job.Status.Active, job.Status.Succeeded, job.Status.Failed)
+	setReadyCondition(&agent.Status.Conditions, agent.Generation, agent.Status.Ready, agent.Status.Phase, agent.Status.Message)
+
+	if err := patchStatus(ctx, r.Client, agent); err != nil {
+		log.Error(err, "failed to update status")
+		return ctrl.Result{}, err
 	}
 
-	agent.Status.Message = fmt.Sprintf("Deployment ready replicas: %d/%d", deployment.Status.ReadyReplicas, *deployment.Spec.Replicas)
+	return ctrl.Result{}, nil
+}
+
+// reconcileCronJob creates or updates the CronJob for a scheduled agent in job mode and
+// mirrors its last/next run times.
+func (r *AgentReconciler) reconcileCronJob(ctx context.Context, agent *kaosv1alpha1.Agent, modelAPINames []string, modelapis []*kaosv1alpha1.ModelAPI, mcpServers map[string]string, peerAgents map[string]string) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	// Validate the cron expression before creating the CronJob
+	if _, err := util.ParseSchedule(agent.Spec.Job.Schedule); err != nil {
+		return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "InvalidSchedule", nil, err.Error())
+	}
+
+	desiredCronJob, overridden, err := r.constructCronJob(agent, modelapis, mcpServers, peerAgents)
+	if err != nil {
+		return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "CronJobConstructFailed", err, "Failed to construct CronJob")
+	}
+	recordEnvOverrides(r.Recorder, agent, &agent.Status.Conditions, overridden)
+	if err := applyReferencedContentHash(ctx, contentReader(r.Client, r.APIReader), agent, &desiredCronJob.Spec.JobTemplate.Spec.Template); err != nil {
+		log.Error(err, "failed to hash referenced ConfigMaps and Secrets")
+		return ctrl.Result{}, err
+	}
+
+	cronJob := &batchv1.CronJob{}
+	err = r.Get(ctx, types.NamespacedName{Name: desiredCronJob.Name, Namespace: agent.Namespace}, cronJob)
 
-	if err := r.Status().Update(ctx, agent); err != nil {
+	if err != nil && apierrors.IsNotFound(err) {
+		cronJob = desiredCronJob
+		if err := util.SetControllerReference(agent, cronJob, r.Scheme); err != nil {
+			log.Error(err, "failed to set controller reference")
+			return ctrl.Result{}, err
+		}
+
+		log.Info("Creating CronJob", "name", cronJob.Name, "schedule", cronJob.Spec.Schedule)
+		if err := r.Create(ctx, cronJob); err != nil {
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, agent, "CronJobCreateFailed", err, "Failed to create CronJob")
+		}
+	} else if err != nil {
+		log.Error(err, "failed to get CronJob")
+		return ctrl.Result{}, err
+	} else {
+		// CronJob exists - update if the schedule or job template changed
+		currentHash := cronJob.Spec.JobTemplate.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+		desiredHash := desiredCronJob.Spec.JobTemplate.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+		if currentHash != desiredHash || cronJob.Spec.Schedule != desiredCronJob.Spec.Schedule {
+			log.Info("Updating CronJob due to spec change", "name", cronJob.Name,
+				"schedule", desiredCronJob.Spec.Schedule)
+			cronJob.Spec.Schedule = desiredCronJob.Spec.Schedule
+			cronJob.Spec.JobTemplate = desiredCronJob.Spec.JobTemplate
+			if err := r.Update(ctx, cronJob); err != nil {
+				log.Error(err, "failed to update CronJob")
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	// Update status
+	agent.Status.LinkedResources = make(map[string]string)
+	agent.Status.LinkedResources["modelapi"] = modelAPINames[0]
+	if len(modelAPINames) > 1 {
+		agent.Status.LinkedResources["modelapis"] = strings.Join(modelAPINames, ",")
+	}
+
+	agent.Status.CronJob = util.CopyCronJobStatus(cronJob, time.Now())
+	if agent.Status.CronJob.Active > 0 {
+		agent.Status.Phase = "Running"
+	} else {
+		agent.Status.Phase = "Ready"
+	}
+	agent.Status.Ready = true
+	agent.Status.Message = fmt.Sprintf("Scheduled: %s", cronJob.Spec.Schedule)
+	setReadyCondition(&agent.Status.Conditions, agent.Generation, agent.Status.Ready, agent.Status.Phase, agent.Status.Message)
+
+	if err := patchStatus(ctx, r.Client, agent); err != nil {
 		log.Error(err, "failed to update status")
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	// Requeue at the next scheduled run so next-run status stays current
+	if next := agent.Status.CronJob.NextScheduleTime; next != nil {
+		return ctrl.Result{RequeueAfter: time.Until(next.Time) + time.Second}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// agentModelAPINames returns the ordered ModelAPI names referenced by the agent.
+// spec.modelAPIs takes precedence; otherwise the single spec.modelAPI is used.
+func agentModelAPINames(agent *kaosv1alpha1.Agent) []string {
+	if len(agent.Spec.ModelAPIs) > 0 {
+		return agent.Spec.ModelAPIs
+	}
+	return []string{agent.Spec.ModelAPI}
+}
+
+// agentMCPServerNames returns the MCPServer names referenced by the agent, from
+// spec.mcpServers followed by any additional names in spec.mcpServerRefs.
+func agentMCPServerNames(agent *kaosv1alpha1.Agent) []string {
+	names := make([]string, 0, len(agent.Spec.MCPServers)+len(agent.Spec.MCPServerRefs))
+	seen := make(map[string]bool)
+	for _, name := range agent.Spec.MCPServers {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, ref := range agent.Spec.MCPServerRefs {
+		if !seen[ref.Name] {
+			seen[ref.Name] = true
+			names = append(names, ref.Name)
+		}
+	}
+	return names
+}
+
+// validateInlineConfigSize checks that the agent description and instructions, which are
+// passed to the runtime as env vars, stay within the configured byte limit.
+func validateInlineConfigSize(agent *kaosv1alpha1.Agent, limit int) error {
+	if agent.Spec.Config == nil {
+		return nil
+	}
+	if err := util.CheckInlineTextSize("config.instructions", agent.Spec.Config.Instructions, limit,
+		"use config.instructionsFrom to load it from a ConfigMap or Secret"); err != nil {
+		return err
+	}
+	return util.CheckInlineTextSize("config.description", agent.Spec.Config.Description, limit,
+		"shorten the description")
+}
+
+// validateContainerCommand rejects container.command or container.args when podSpec also
+// overrides them on the agent container, since the podSpec merge would silently win.
+func validateContainerCommand(agent *kaosv1alpha1.Agent) error {
+	if agent.Spec.Container == nil || (agent.Spec.Container.Command == nil && agent.Spec.Container.Args == nil) {
+		return nil
+	}
+	if agent.Spec.PodSpec == nil {
+		return nil
+	}
+	for _, container := range agent.Spec.PodSpec.Containers {
+		if container.Name == "agent" && (container.Command != nil || container.Args != nil) {
+			return fmt.Errorf("container.command and container.args cannot be combined with a podSpec override of the agent container's command or args")
+		}
+	}
+	return nil
+}
+
+// renderAgentConfigText returns the agent description and instructions with template
+// actions rendered against the agent's name, namespace, peers and model.
+func renderAgentConfigText(agent *kaosv1alpha1.Agent) (string, string, error) {
+	if agent.Spec.Config == nil {
+		return "", "", nil
+	}
+	data := util.InstructionsTemplateData{
+		Name:      agent.Name,
+		Namespace: agent.Namespace,
+		Model:     agent.Spec.Model,
+	}
+	if agent.Spec.AgentNetwork != nil {
+		data.Peers = agent.Spec.AgentNetwork.Access
+	}
+
+	description, err := util.RenderInstructionsTemplate("config.description", agent.Spec.Config.Description, data)
+	if err != nil {
+		return "", "", err
+	}
+	instructions, err := util.RenderInstructionsTemplate("config.instructions", agent.Spec.Config.Instructions, data)
+	if err != nil {
+		return "", "", err
+	}
+	return description, instructions, nil
+}
+
+// defaultMaxConcurrentRequests is the in-flight request limit of agents that do not set
+// config.maxConcurrentRequests
+const defaultMaxConcurrentRequests int32 = 16
+
+// maxConcurrentRequests returns the agent's config.maxConcurrentRequests, or the default
+func maxConcurrentRequests(agent *kaosv1alpha1.Agent) int32 {
+	if agent.Spec.Config != nil && agent.Spec.Config.MaxConcurrentRequests != nil {
+		return *agent.Spec.Config.MaxConcurrentRequests
+	}
+	return defaultMaxConcurrentRequests
+}
+
+// modelFallbacks returns the models modelapi falls back to, in order, when a request for
+// model fails, from its proxyConfig.fallbacks
+func modelFallbacks(modelapi *kaosv1alpha1.ModelAPI, model string) []string {
+	if modelapi == nil || modelapi.Spec.ProxyConfig == nil {
+		return nil
+	}
+	return modelapi.Spec.ProxyConfig.Fallbacks[model]
+}
+
+// debugMemoryEndpoints returns whether the agent exposes its /memory/* debug endpoints,
+// spec.config.debugMemoryEndpoints when set, else the operator default. Returns nil when
+// neither is configured, so the runtime default applies. An invalid operator default is
+// rejected at startup.
+func debugMemoryEndpoints(agent *kaosv1alpha1.Agent) *bool {
+	if agent.Spec.Config != nil && agent.Spec.Config.DebugMemoryEndpoints != nil {
+		return agent.Spec.Config.DebugMemoryEndpoints
+	}
+	enabled, _ := util.GetDefaultDebugMemoryEndpoints()
+	return enabled
+}
+
+// sessionMemoryShared reports whether agent sessions are consistent across replicas,
+// either because memory is disabled or because a shared backend is configured.
+func sessionMemoryShared(agent *kaosv1alpha1.Agent) bool {
+	if agent.Spec.Config == nil || agent.Spec.Config.Memory == nil {
+		return false
+	}
+	mem := agent.Spec.Config.Memory
+	if mem.Enabled != nil && !*mem.Enabled {
+		return true
+	}
+	return mem.Backend == kaosv1alpha1.MemoryBackendRedis
+}
+
+// memoryNotSharedMessage describes why the agent's inmemory sessions are not shared, or
+// returns "" when they are. The inmemory backend keeps sessions per process, so neither
+// multiple replicas nor multiple workers in a pod see each other's sessions.
+func memoryNotSharedMessage(agent *kaosv1alpha1.Agent) string {
+	if sessionMemoryShared(agent) {
+		return ""
+	}
+	var multiple []string
+	if agent.Spec.Replicas != nil && *agent.Spec.Replicas > 1 {
+		multiple = append(multiple, "replica")
+	}
+	if agent.Spec.Workers != nil && *agent.Spec.Workers > 1 {
+		multiple = append(multiple, "worker")
+	}
+	if len(multiple) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Agent has more than one %s but uses the inmemory memory backend; set config.memory.backend to redis to share sessions",
+		strings.Join(multiple, " and more than one "))
+}
+
+// handleMissingModelAPI marks the agent as Failed when a referenced ModelAPI does not exist and,
+// if scaleDownOnMissingModelAPI is enabled, scales the agent deployment to zero.
+func (r *AgentReconciler) handleMissingModelAPI(ctx context.Context, agent *kaosv1alpha1.Agent, modelAPIName string) error {
+	log := log.FromContext(ctx)
+	log.Info("ModelAPI not found", "modelAPI", modelAPIName)
+
+	message := fmt.Sprintf("ModelAPI %q not found", modelAPIName)
+
+	scaleDown := agent.Spec.ScaleDownOnMissingModelAPI != nil && *agent.Spec.ScaleDownOnMissingModelAPI &&
+		!deploymentFrozen(agent)
+	if scaleDown {
+		deployment := &appsv1.Deployment{}
+		deploymentName := fmt.Sprintf("agent-%s", agent.Name)
+		err := r.Get(ctx, types.NamespacedName{Name: deploymentName, Namespace: agent.Namespace}, deployment)
+		if err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, "failed to get Deployment")
+			return err
+		}
+		if err == nil {
+			if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+				log.Info("Scaling Deployment to zero while ModelAPI is missing", "name", deployment.Name)
+				zero := int32(0)
+				deployment.Spec.Replicas = &zero
+				if err := r.Update(ctx, deployment); err != nil {
+					log.Error(err, "failed to scale down Deployment")
+					return err
+				}
+			}
+			agent.Status.Deployment = util.CopyDeploymentStatus(deployment)
+		}
+		message += "; deployment scaled to zero until it is recreated"
+	}
+
+	return reconcileError(ctx, r.Client, r.Recorder, agent, "ModelAPINotFound", nil, message)
+}
+
+// constructPodSpec builds the agent pod spec shared by Deployments (service mode) and
+// Jobs (job mode), and returns the generated env vars container.env overrides. Probes are
+// only added in service mode.
+func (r *AgentReconciler) constructPodSpec(agent *kaosv1alpha1.Agent, modelapis []*kaosv1alpha1.ModelAPI, mcpServers map[string]string, peerAgents map[string]string) (corev1.PodSpec, []string, error) {
+	// Build environment variables
+	env, overridden := r.constructEnvVars(agent, modelapis, mcpServers, peerAgents)
+
+	// Get agent image from environment (required - set via ConfigMap)
+	agentImage := os.Getenv("DEFAULT_AGENT_IMAGE")
+	if agentImage == "" {
+		return corev1.PodSpec{}, nil, &util.MissingEnvError{Name: "DEFAULT_AGENT_IMAGE"}
+	}
+
+	container := corev1.Container{
+		Name:            "agent",
+		Image:           agentImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          "http",
+				ContainerPort: 8000,
+				Protocol:      corev1.ProtocolTCP,
+			},
+		},
+		Env: env,
+	}
+
+	// Custom runtime images may need a different entrypoint; unset keeps the image's own
+	if agent.Spec.Container != nil {
+		container.Command = agent.Spec.Container.Command
+		container.Args = agent.Spec.Container.Args
+	}
+
+	isJob := agent.Spec.Mode == kaosv1alpha1.AgentModeJob
+	if !isJob {
+		container.LivenessProbe = &corev1.Probe{
+			ProbeHandler: probeHandler(agent.Spec.Protocol, 8000, corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   "/health",
+					Port:   intstr.FromInt(8000),
+					Scheme: corev1.URISchemeHTTP,
+				},
+			}),
+			InitialDelaySeconds: 30,
+			PeriodSeconds:       10,
+		}
+		container.ReadinessProbe = &corev1.Probe{
+			ProbeHandler: probeHandler(agent.Spec.Protocol, 8000, corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   "/ready",
+					Port:   intstr.FromInt(8000),
+					Scheme: corev1.URISchemeHTTP,
+				},
+			}),
+			InitialDelaySeconds: 10,
+			PeriodSeconds:       5,
+		}
+	}
+
+	// Optionally ping the primary ModelAPI on start to warm up the connection
+	container.Lifecycle = constructWarmUpLifecycle(agent, modelapis)
+
+	// Mount instructions from a ConfigMap or Secret as a file rather than an env var
+	var volumes []corev1.Volume
+	if volume := constructInstructionsVolume(agent); volume != nil {
+		volumes = append(volumes, *volume)
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      instructionsVolumeName,
+			MountPath: instructionsMountPath,
+			ReadOnly:  true,
+		})
+	}
+	if volume := constructCABundleVolume(agent.Spec.CABundle); volume != nil {
+		volumes = append(volumes, *volume)
+		container.VolumeMounts = append(container.VolumeMounts, caBundleVolumeMount())
+	}
+
+	basePodSpec := corev1.PodSpec{
+		Containers: []corev1.Container{container},
+		Volumes:    volumes,
+	}
+
+	// Jobs require an explicit restart policy (default: Never)
+	if isJob {
+		basePodSpec.RestartPolicy = corev1.RestartPolicyNever
+		if agent.Spec.Job != nil && agent.Spec.Job.RestartPolicy != "" {
+			basePodSpec.RestartPolicy = agent.Spec.Job.RestartPolicy
+		}
+	}
+
+	// Optionally wait for dependency endpoints to respond before starting the agent
+	if agent.Spec.WaitForDependencyEndpoints != nil && *agent.Spec.WaitForDependencyEndpoints {
+		initContainer, err := r.constructWaitInitContainer(modelapis, mcpServers)
+		if err != nil {
+			return corev1.PodSpec{}, nil, err
+		}
+		if initContainer != nil {
+			basePodSpec.InitContainers = []corev1.Container{*initContainer}
+		}
+	}
+
+	// Apply podSpec override using strategic merge patch if provided
+	finalPodSpec := basePodSpec
+	if agent.Spec.PodSpec != nil {
+		merged, err := util.MergePodSpec(basePodSpec, *agent.Spec.PodSpec)
+		if err != nil {
+			return corev1.PodSpec{}, nil, err
+		}
+		finalPodSpec = merged
+	}
+
+	// Reference images by digest when pinning is enabled
+	if err := util.PinPodSpecImages(&finalPodSpec); err != nil {
+		return corev1.PodSpec{}, nil, err
+	}
+
+	return finalPodSpec, overridden, nil
+}
+
+// constructInstructionsVolume returns the volume for config.instructionsFrom, exposing the
+// referenced key as a single file, or nil when instructions are not loaded from a source.
+func constructInstructionsVolume(agent *kaosv1alpha1.Agent) *corev1.Volume {
+	if agent.Spec.Config == nil || agent.Spec.Config.InstructionsFrom == nil {
+		return nil
+	}
+	source := agent.Spec.Config.InstructionsFrom
+
+	volume := &corev1.Volume{Name: instructionsVolumeName}
+	if ref := source.ConfigMapKeyRef; ref != nil {
+		volume.VolumeSource.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: ref.LocalObjectReference,
+			Items:                []corev1.KeyToPath{{Key: ref.Key, Path: instructionsFileName}},
+			Optional:             ref.Optional,
+		}
+	} else if ref := source.SecretKeyRef; ref != nil {
+		volume.VolumeSource.Secret = &corev1.SecretVolumeSource{
+			SecretName: ref.Name,
+			Items:      []corev1.KeyToPath{{Key: ref.Key, Path: instructionsFileName}},
+			Optional:   ref.Optional,
+		}
+	} else {
+		return nil
+	}
+	return volume
+}
+
+// checkInstructionsSource returns an error when config.instructionsFrom names a
+// ConfigMap or Secret key that does not exist, unless the key reference is optional
+func (r *AgentReconciler) checkInstructionsSource(ctx context.Context, agent *kaosv1alpha1.Agent) error {
+	if agent.Spec.Config == nil || agent.Spec.Config.InstructionsFrom == nil {
+		return nil
+	}
+	source := agent.Spec.Config.InstructionsFrom
+	reader := contentReader(r.Client, r.APIReader)
+
+	var found bool
+	var optional *bool
+	var kind, name, key string
+	if ref := source.ConfigMapKeyRef; ref != nil {
+		kind, name, key, optional = "ConfigMap", ref.Name, ref.Key, ref.Optional
+		configMap := &corev1.ConfigMap{}
+		err := reader.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: agent.Namespace}, configMap)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err == nil {
+			_, inData := configMap.Data[ref.Key]
+			_, inBinaryData := configMap.BinaryData[ref.Key]
+			found = inData || inBinaryData
+		}
+	} else if ref := source.SecretKeyRef; ref != nil {
+		kind, name, key, optional = "Secret", ref.Name, ref.Key, ref.Optional
+		secret := &corev1.Secret{}
+		err := reader.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: agent.Namespace}, secret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if err == nil {
+			_, found = secret.Data[ref.Key]
+		}
+	}
+
+	if !found && (optional == nil || !*optional) {
+		return fmt.Errorf("key %q not found in %s %s", key, kind, name)
+	}
+	return nil
+}
+
+// constructDeployment creates a Deployment for the Agent, and returns the generated env
+// vars container.env overrides
+func (r *AgentReconciler) constructDeployment(agent *kaosv1alpha1.Agent, modelapis []*kaosv1alpha1.ModelAPI, mcpServers map[string]string, peerAgents map[string]string) (*appsv1.Deployment, []string, error) {
+	labels := map[string]string{
+		"app":   "agent",
+		"agent": agent.Name,
+	}
+
+	replicas := int32(1)
+	if agent.Spec.Replicas != nil {
+		replicas = *agent.Spec.Replicas
+	}
+
+	finalPodSpec, overridden, err := r.constructPodSpec(agent, modelapis, mcpServers, peerAgents)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Compute hash of the pod spec for change detection
+	podSpecHash := util.ComputePodSpecHash(finalPodSpec)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("agent-%s", agent.Name),
+			Namespace: agent.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Strategy:                deploymentStrategy(agent.Spec.DeploymentStrategy, false),
+			MinReadySeconds:         minReadySeconds(agent.Spec.MinReadySeconds),
+			ProgressDeadlineSeconds: progressDeadlineSeconds(agent.Spec.ProgressDeadlineSeconds),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						util.PodSpecHashAnnotation: podSpecHash,
+					},
+				},
+				Spec: finalPodSpec,
+			},
+		},
+	}
+
+	return deployment, overridden, nil
 }
 
-// constructDeployment creates a Deployment for the Agent
-func (r *AgentReconciler) constructDeployment(agent *kaosv1alpha1.Agent, modelapi *kaosv1alpha1.ModelAPI, mcpServers map[string]string, peerAgents map[string]string) (*appsv1.Deployment, error) {
+// constructJobSpec builds the Job spec shared by Jobs and CronJob job templates, and
+// returns the generated env vars container.env overrides
+func (r *AgentReconciler) constructJobSpec(agent *kaosv1alpha1.Agent, modelapis []*kaosv1alpha1.ModelAPI, mcpServers map[string]string, peerAgents map[string]string) (batchv1.JobSpec, []string, error) {
 	labels := map[string]string{
 		"app":   "agent",
 		"agent": agent.Name,
 	}
 
-	replicas := int32(1)
-
-	// Build environment variables
-	env := r.constructEnvVars(agent, modelapi, mcpServers, peerAgents)
-
-	// Get agent image from environment (required - set via ConfigMap)
-	agentImage := os.Getenv("DEFAULT_AGENT_IMAGE")
-	if agentImage == "" {
-		return nil, fmt.Errorf("DEFAULT_AGENT_IMAGE environment variable is required but not set")
+	finalPodSpec, overridden, err := r.constructPodSpec(agent, modelapis, mcpServers, peerAgents)
+	if err != nil {
+		return batchv1.JobSpec{}, nil, err
 	}
 
-	container := corev1.Container{
-		Name:            "agent",
-		Image:           agentImage,
-		ImagePullPolicy: corev1.PullIfNotPresent,
-		Ports: []corev1.ContainerPort{
-			{
-				Name:          "http",
-				ContainerPort: 8000,
-				Protocol:      corev1.ProtocolTCP,
-			},
-		},
-		Env: env,
-		LivenessProbe: &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				HTTPGet: &corev1.HTTPGetAction{
-					Path:   "/health",
-					Port:   intstr.FromInt(8000),
-					Scheme: corev1.URISchemeHTTP,
+	jobSpec := batchv1.JobSpec{
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: labels,
+				Annotations: map[string]string{
+					util.PodSpecHashAnnotation: util.ComputePodSpecHash(finalPodSpec),
 				},
 			},
-			InitialDelaySeconds: 30,
-			PeriodSeconds:       10,
+			Spec: finalPodSpec,
 		},
-		ReadinessProbe: &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
-				HTTPGet: &corev1.HTTPGetAction{
-					Path:   "/ready",
-					Port:   intstr.FromInt(8000),
-					Scheme: corev1.URISchemeHTTP,
-				},
+	}
+
+	if agent.Spec.Job != nil {
+		jobSpec.BackoffLimit = agent.Spec.Job.BackoffLimit
+		jobSpec.ActiveDeadlineSeconds = agent.Spec.Job.ActiveDeadlineSeconds
+	}
+
+	return jobSpec, overridden, nil
+}
+
+// constructJob creates a Job for an Agent in job mode, and returns the generated env vars
+// container.env overrides
+func (r *AgentReconciler) constructJob(agent *kaosv1alpha1.Agent, modelapis []*kaosv1alpha1.ModelAPI, mcpServers map[string]string, peerAgents map[string]string) (*batchv1.Job, []string, error) {
+	jobSpec, overridden, err := r.constructJobSpec(agent, modelapis, mcpServers, peerAgents)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("agent-%s", agent.Name),
+			Namespace: agent.Namespace,
+			Labels: map[string]string{
+				"app":   "agent",
+				"agent": agent.Name,
 			},
-			InitialDelaySeconds: 10,
-			PeriodSeconds:       5,
 		},
+		Spec: jobSpec,
 	}
 
-	basePodSpec := corev1.PodSpec{
-		Containers: []corev1.Container{container},
-	}
+	return job, overridden, nil
+}
 
-	// Apply podSpec override using strategic merge patch if provided
-	finalPodSpec := basePodSpec
-	if agent.Spec.PodSpec != nil {
-		merged, err := util.MergePodSpec(basePodSpec, *agent.Spec.PodSpec)
-		if err == nil {
-			finalPodSpec = merged
-		}
+// constructCronJob creates a CronJob for an Agent in job mode with a schedule, and returns
+// the generated env vars container.env overrides
+func (r *AgentReconciler) constructCronJob(agent *kaosv1alpha1.Agent, modelapis []*kaosv1alpha1.ModelAPI, mcpServers map[string]string, peerAgents map[string]string) (*batchv1.CronJob, []string, error) {
+	labels := map[string]string{
+		"app":   "agent",
+		"agent": agent.Name,
 	}
 
-	// Compute hash of the pod spec for change detection
-	podSpecHash := util.ComputePodSpecHash(finalPodSpec)
+	jobSpec, overridden, err := r.constructJobSpec(agent, modelapis, mcpServers, peerAgents)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	deployment := &appsv1.Deployment{
+	cronJob := &batchv1.CronJob{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("agent-%s", agent.Name),
 			Namespace: agent.Namespace,
 			Labels:    labels,
 		},
-		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicas,
-			Selector: &metav1.LabelSelector{
-				MatchLabels: labels,
-			},
-			Template: corev1.PodTemplateSpec{
+		Spec: batchv1.CronJobSpec{
+			Schedule: agent.Spec.Job.Schedule,
+			JobTemplate: batchv1.JobTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: labels,
-					Annotations: map[string]string{
-						util.PodSpecHashAnnotation: podSpecHash,
-					},
 				},
-				Spec: finalPodSpec,
+				Spec: jobSpec,
+			},
+		},
+	}
+
+	return cronJob, overridden, nil
+}
+
+// waitForEndpointsScript polls each URL passed as a positional argument until it responds.
+// Any HTTP response counts as reachable; only connection/DNS failures are retried.
+const waitForEndpointsScript = `for url in "$@"; do
+  until curl -s -o /dev/null --max-time 5 "$url"; do
+    echo "waiting for $url"
+    sleep 2
+  done
+  echo "$url is reachable"
+done`
+
+// warmUpScript requests the URL passed as its first argument and ignores any failure, so
+// an unreachable ModelAPI never kills the agent container from the postStart hook
+const warmUpScript = `import sys, urllib.request
+try:
+    urllib.request.urlopen(sys.argv[1], timeout=10).close()
+except Exception as exc:
+    print(f"warm-up request to {sys.argv[1]} failed: {exc}")`
+
+// constructWarmUpLifecycle returns the postStart hook that pings the primary ModelAPI when
+// spec.warmUp is set, or nil when warm-up is disabled or the ModelAPI has no endpoint yet.
+func constructWarmUpLifecycle(agent *kaosv1alpha1.Agent, modelapis []*kaosv1alpha1.ModelAPI) *corev1.Lifecycle {
+	if agent.Spec.WarmUp == nil || len(modelapis) == 0 || modelapis[0].Status.Endpoint == "" {
+		return nil
+	}
+	endpoint, err := url.Parse(modelapis[0].Status.Endpoint)
+	if err != nil || endpoint.Hostname() == "" {
+		return nil
+	}
+
+	path := agent.Spec.WarmUp.Path
+	if path == "" {
+		path = modelAPIHealthPath(modelapis[0])
+	}
+	if path == "" {
+		path = "/"
+	}
+	path = strings.TrimSuffix(endpoint.Path, "/") + path
+
+	if agent.Spec.WarmUp.Handler != kaosv1alpha1.AgentWarmUpHandlerHTTPGet {
+		target := *endpoint
+		target.Path = path
+		// "|| true" keeps the hook succeeding even if python itself fails to run
+		return &corev1.Lifecycle{
+			PostStart: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{
+					"sh", "-c", `python -c "$0" "$1" || true`, warmUpScript, target.String(),
+				}},
+			},
+		}
+	}
+
+	scheme := corev1.URISchemeHTTP
+	port := 80
+	if endpoint.Scheme == "https" {
+		scheme = corev1.URISchemeHTTPS
+		port = 443
+	}
+	if endpoint.Port() != "" {
+		if p, err := strconv.Atoi(endpoint.Port()); err == nil {
+			port = p
+		}
+	}
+	return &corev1.Lifecycle{
+		PostStart: &corev1.LifecycleHandler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Host:   endpoint.Hostname(),
+				Port:   intstr.FromInt(port),
+				Path:   path,
+				Scheme: scheme,
 			},
 		},
 	}
+}
+
+// sortedKeys returns the keys of m in sorted order. Env vars and init container
+// args derived from maps must use it so the pod-spec hash is stable across
+// reconciles (Go map iteration order is random).
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// constructWaitInitContainer creates an init container that waits for the ModelAPI health
+// endpoints (in order) and MCPServer endpoints (sorted) to respond. Returns nil if there
+// are no endpoints to wait for.
+func (r *AgentReconciler) constructWaitInitContainer(modelapis []*kaosv1alpha1.ModelAPI, mcpServers map[string]string) (*corev1.Container, error) {
+	var urls []string
+	for _, modelapi := range modelapis {
+		// External ModelAPIs without a healthPath have nothing to wait on
+		if modelapi.Spec.Mode == kaosv1alpha1.ModelAPIModeExternal && modelAPIHealthPath(modelapi) == "" {
+			continue
+		}
+		if modelapi.Status.Endpoint != "" {
+			urls = append(urls, modelapi.Status.Endpoint+modelAPIHealthPath(modelapi))
+		}
+	}
+
+	for _, name := range sortedKeys(mcpServers) {
+		if mcpServers[name] != "" {
+			urls = append(urls, mcpServers[name])
+		}
+	}
+
+	if len(urls) == 0 {
+		return nil, nil
+	}
+
+	// Get wait image from environment (required when the init container is enabled)
+	waitImage := os.Getenv("DEFAULT_WAIT_IMAGE")
+	if waitImage == "" {
+		return nil, &util.MissingEnvError{Name: "DEFAULT_WAIT_IMAGE"}
+	}
 
-	return deployment, nil
+	return &corev1.Container{
+		Name:            "wait-for-dependencies",
+		Image:           waitImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"sh", "-c", waitForEndpointsScript, "wait-for-dependencies"},
+		Args:            urls,
+	}, nil
 }
 
-// constructEnvVars builds environment variables for the agent
-func (r *AgentReconciler) constructEnvVars(agent *kaosv1alpha1.Agent, modelapi *kaosv1alpha1.ModelAPI, mcpServers map[string]string, peerAgents map[string]string) []corev1.EnvVar {
+// constructEnvVars builds environment variables for the agent, and returns the generated
+// env vars container.env overrides
+func (r *AgentReconciler) constructEnvVars(agent *kaosv1alpha1.Agent, modelapis []*kaosv1alpha1.ModelAPI, mcpServers map[string]string, peerAgents map[string]string) ([]corev1.EnvVar, []string) {
 	var env []corev1.EnvVar
 
 	// Agent identity and configuration
@@ -411,38 +1299,80 @@ func (r *AgentReconciler) constructEnvVars(agent *kaosv1alpha1.Agent, modelapi *
 	})
 
 	if agent.Spec.Config != nil {
-		if agent.Spec.Config.Description != "" {
+		// Templates were validated during reconcile, so the error is not checked here
+		description, instructions, _ := renderAgentConfigText(agent)
+		if description != "" {
 			env = append(env, corev1.EnvVar{
 				Name:  "AGENT_DESCRIPTION",
-				Value: agent.Spec.Config.Description,
+				Value: description,
 			})
 		}
 
-		if agent.Spec.Config.Instructions != "" {
+		if instructions != "" {
 			env = append(env, corev1.EnvVar{
 				Name:  "AGENT_INSTRUCTIONS",
-				Value: agent.Spec.Config.Instructions,
+				Value: instructions,
 			})
 		}
-	}
 
-	// Add user-provided container env vars
-	if agent.Spec.Container != nil {
-		env = append(env, agent.Spec.Container.Env...)
+		if agent.Spec.Config.InstructionsFrom != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  "AGENT_INSTRUCTIONS_FILE",
+				Value: instructionsMountPath + "/" + instructionsFileName,
+			})
+		}
 	}
 
-	// ModelAPI configuration
+	// ModelAPI configuration (primary)
 	env = append(env, corev1.EnvVar{
 		Name:  "MODEL_API_URL",
-		Value: modelapi.Status.Endpoint,
+		Value: modelapis[0].Status.Endpoint,
 	})
 
+	// Ordered ModelAPI URLs (primary first, then fallbacks) for runtime failover
+	if len(modelapis) > 1 {
+		modelAPIURLs := make([]string, 0, len(modelapis))
+		for _, modelapi := range modelapis {
+			modelAPIURLs = append(modelAPIURLs, modelapi.Status.Endpoint)
+		}
+		env = append(env, corev1.EnvVar{
+			Name:  "MODEL_API_URLS",
+			Value: strings.Join(modelAPIURLs, ","),
+		})
+	}
+
 	// MODEL_NAME from required spec.model field
 	env = append(env, corev1.EnvVar{
 		Name:  "MODEL_NAME",
 		Value: agent.Spec.Model,
 	})
 
+	// Fallback chain the primary ModelAPI applies to the agent's model
+	if fallbacks := modelFallbacks(modelapis[0], agent.Spec.Model); len(fallbacks) > 0 {
+		env = append(env, corev1.EnvVar{
+			Name:  "MODEL_FALLBACKS",
+			Value: strings.Join(fallbacks, ","),
+		})
+	}
+
+	// In-flight request limit, always set so every agent is bounded
+	env = append(env, corev1.EnvVar{
+		Name:  "AGENT_MAX_CONCURRENT_REQUESTS",
+		Value: fmt.Sprintf("%d", maxConcurrentRequests(agent)),
+	})
+	if agent.Spec.Config != nil && agent.Spec.Config.OverflowPolicy != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "AGENT_OVERFLOW_POLICY",
+			Value: string(agent.Spec.Config.OverflowPolicy),
+		})
+	}
+	if agent.Spec.Config != nil && agent.Spec.Config.QueueDepth != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "AGENT_QUEUE_DEPTH",
+			Value: fmt.Sprintf("%d", *agent.Spec.Config.QueueDepth),
+		})
+	}
+
 	// Reasoning loop configuration
 	if agent.Spec.Config != nil && agent.Spec.Config.ReasoningLoopMaxSteps != nil {
 		env = append(env, corev1.EnvVar{
@@ -451,6 +1381,78 @@ func (r *AgentReconciler) constructEnvVars(agent *kaosv1alpha1.Agent, modelapi *
 		})
 	}
 
+	// Default headers for ModelAPI requests, serialized as JSON (map keys are sorted,
+	// so the value is stable across reconciles)
+	if agent.Spec.Config != nil && len(agent.Spec.Config.ModelHeaders) > 0 {
+		headers, _ := json.Marshal(agent.Spec.Config.ModelHeaders)
+		env = append(env, corev1.EnvVar{
+			Name:  "MODEL_DEFAULT_HEADERS",
+			Value: string(headers),
+		})
+	}
+
+	// Per-capability model routes, serialized as JSON like the headers
+	if agent.Spec.Config != nil && len(agent.Spec.Config.ModelRoutes) > 0 {
+		routes, _ := json.Marshal(agent.Spec.Config.ModelRoutes)
+		env = append(env, corev1.EnvVar{
+			Name:  "MODEL_ROUTES",
+			Value: string(routes),
+		})
+	}
+
+	// Generation defaults configuration
+	if agent.Spec.Config != nil && agent.Spec.Config.Generation != nil {
+		gen := agent.Spec.Config.Generation
+		if gen.Temperature != "" {
+			env = append(env, corev1.EnvVar{
+				Name:  "MODEL_TEMPERATURE",
+				Value: gen.Temperature,
+			})
+		}
+		if gen.TopP != "" {
+			env = append(env, corev1.EnvVar{
+				Name:  "MODEL_TOP_P",
+				Value: gen.TopP,
+			})
+		}
+		if gen.MaxTokens != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  "MODEL_MAX_TOKENS",
+				Value: fmt.Sprintf("%d", *gen.MaxTokens),
+			})
+		}
+	}
+
+	// Session bounds configuration
+	if agent.Spec.Config != nil && agent.Spec.Config.SessionTTLSeconds != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "AGENT_SESSION_TTL_SECONDS",
+			Value: fmt.Sprintf("%d", *agent.Spec.Config.SessionTTLSeconds),
+		})
+	}
+	if agent.Spec.Config != nil && agent.Spec.Config.MaxHistoryMessages != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "AGENT_MAX_HISTORY_MESSAGES",
+			Value: fmt.Sprintf("%d", *agent.Spec.Config.MaxHistoryMessages),
+		})
+	}
+
+	// Server worker processes, read by uvicorn
+	if agent.Spec.Workers != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "WEB_CONCURRENCY",
+			Value: fmt.Sprintf("%d", *agent.Spec.Workers),
+		})
+	}
+
+	// Request timeout, matching the HTTPRoute timeout
+	if agent.Spec.RequestTimeout != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "AGENT_REQUEST_TIMEOUT",
+			Value: agent.Spec.RequestTimeout,
+		})
+	}
+
 	// Memory configuration
 	if agent.Spec.Config != nil && agent.Spec.Config.Memory != nil {
 		mem := agent.Spec.Config.Memory
@@ -484,16 +1486,39 @@ func (r *AgentReconciler) constructEnvVars(agent *kaosv1alpha1.Agent, modelapi *
 				Value: fmt.Sprintf("%d", *mem.MaxSessionEvents),
 			})
 		}
+		if mem.Backend != "" {
+			env = append(env, corev1.EnvVar{
+				Name:  "AGENT_MEMORY_BACKEND",
+				Value: string(mem.Backend),
+			})
+		}
+		if mem.Backend == kaosv1alpha1.MemoryBackendRedis && mem.Redis != nil {
+			env = append(env, corev1.EnvVar{
+				Name:  "AGENT_MEMORY_URL",
+				Value: mem.Redis.URL,
+			})
+			if mem.Redis.PasswordSecretRef != nil {
+				env = append(env, corev1.EnvVar{
+					Name: "AGENT_MEMORY_PASSWORD",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: mem.Redis.PasswordSecretRef,
+					},
+				})
+			}
+		}
+	}
+
+	// Debug memory endpoints, from the agent or the operator default when configured
+	if enabled := debugMemoryEndpoints(agent); enabled != nil {
+		env = append(env, corev1.EnvVar{
+			Name:  "AGENT_DEBUG_MEMORY_ENDPOINTS",
+			Value: fmt.Sprintf("%t", *enabled),
+		})
 	}
 
 	// MCP Servers configuration
 	if len(mcpServers) > 0 {
-		mcpNames := make([]string, 0, len(mcpServers))
-		for name := range mcpServers {
-			mcpNames = append(mcpNames, name)
-		}
-		// Sort for deterministic order (prevents hash oscillation)
-		sort.Strings(mcpNames)
+		mcpNames := sortedKeys(mcpServers)
 
 		env = append(env, corev1.EnvVar{
 			Name:  "MCP_SERVERS",
@@ -508,16 +1533,48 @@ func (r *AgentReconciler) constructEnvVars(agent *kaosv1alpha1.Agent, modelapi *
 				Value: endpoint,
 			})
 		}
+
+		// Add per-server tool filters, prefixes and call limits from the structured mcpServerRefs form
+		// (sorted by name, matching MCP_SERVERS, so reordering refs does not roll pods)
+		refs := append([]kaosv1alpha1.AgentMCPServerRef(nil), agent.Spec.MCPServerRefs...)
+		sort.Slice(refs, func(i, j int) bool { return refs[i].Name < refs[j].Name })
+		for _, ref := range refs {
+			if len(ref.AllowTools) > 0 {
+				env = append(env, corev1.EnvVar{
+					Name:  fmt.Sprintf("MCP_SERVER_%s_ALLOW", ref.Name),
+					Value: strings.Join(ref.AllowTools, ","),
+				})
+			}
+			if len(ref.DenyTools) > 0 {
+				env = append(env, corev1.EnvVar{
+					Name:  fmt.Sprintf("MCP_SERVER_%s_DENY", ref.Name),
+					Value: strings.Join(ref.DenyTools, ","),
+				})
+			}
+			if ref.Prefix != "" {
+				env = append(env, corev1.EnvVar{
+					Name:  fmt.Sprintf("MCP_SERVER_%s_PREFIX", ref.Name),
+					Value: ref.Prefix,
+				})
+			}
+			if ref.Timeout != "" {
+				env = append(env, corev1.EnvVar{
+					Name:  fmt.Sprintf("MCP_SERVER_%s_TIMEOUT", ref.Name),
+					Value: ref.Timeout,
+				})
+			}
+			if ref.MaxConcurrent != nil {
+				env = append(env, corev1.EnvVar{
+					Name:  fmt.Sprintf("MCP_SERVER_%s_MAX_CONCURRENT", ref.Name),
+					Value: fmt.Sprintf("%d", *ref.MaxConcurrent),
+				})
+			}
+		}
 	}
 
 	// Peer Agents configuration
 	if len(peerAgents) > 0 {
-		peerNames := make([]string, 0, len(peerAgents))
-		for name := range peerAgents {
-			peerNames = append(peerNames, name)
-		}
-		// Sort for deterministic order (prevents hash oscillation)
-		sort.Strings(peerNames)
+		peerNames := sortedKeys(peerAgents)
 
 		env = append(env, corev1.EnvVar{
 			Name:  "PEER_AGENTS",
@@ -551,12 +1608,25 @@ func (r *AgentReconciler) constructEnvVars(agent *kaosv1alpha1.Agent, modelapi *
 		env = append(env, otelEnv...)
 	}
 
-	// Add LOG_LEVEL env var (if not already set by user in spec.config.env)
-	if logLevelEnv := util.BuildLogLevelEnvVar(env); logLevelEnv != nil {
+	// Trust the spec.caBundle certificates for outbound TLS
+	env = append(env, caBundleEnv(agent.Spec.CABundle)...)
+
+	// Route outbound calls through the egress proxy, if any
+	env = append(env, util.BuildEgressProxyEnvVars(util.MergeEgressProxy(agent.Spec.EgressProxy))...)
+
+	var userEnv []corev1.EnvVar
+	if agent.Spec.Container != nil {
+		userEnv = agent.Spec.Container.Env
+	}
+
+	// Add LOG_LEVEL env var (if not already set by user in spec.container.env)
+	if logLevelEnv := util.BuildLogLevelEnvVar(slices.Concat(env, userEnv)); logLevelEnv != nil {
 		env = append(env, logLevelEnv...)
 	}
 
-	return env
+	// Generated entries come first and user entries (including valueFrom) last, so the
+	// user wins; the result has one entry per name
+	return finalizeEnv(env, userEnv)
 }
 
 // constructService creates a Service for A2A communication
@@ -576,7 +1646,6 @@ func (r *AgentReconciler) constructService(agent *kaosv1alpha1.Agent) *corev1.Se
 			Type: corev1.ServiceTypeClusterIP,
 			Ports: []corev1.ServicePort{
 				{
-					Name:       "http",
 					Port:       8000,
 					TargetPort: intstr.FromInt(8000),
 					Protocol:   corev1.ProtocolTCP,
@@ -586,6 +1655,11 @@ func (r *AgentReconciler) constructService(agent *kaosv1alpha1.Agent) *corev1.Se
 		},
 	}
 
+	applyServicePortConfig(&service.Spec.Ports[0], agent.Spec.Protocol, agent.Spec.ServicePort)
+	if isGRPC(agent.Spec.Protocol) {
+		service.Spec.Ports = append(service.Spec.Ports, grpcGatewayServicePort(8000))
+	}
+
 	return service
 }
 
@@ -602,43 +1676,36 @@ func (r *AgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 
 		requests := []ctrl.Request{}
 		for _, agent := range agentList.Items {
-			if agent.Spec.ModelAPI == modelapi.Name {
-				requests = append(requests, ctrl.Request{
-					NamespacedName: types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace},
-				})
-			}
-		}
-		return requests
-	})
-
-	// Map MCPServer changes to related Agents
-	mapMCPServerToAgents := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []ctrl.Request {
-		mcpserver := obj.(*kaosv1alpha1.MCPServer)
-		// Find all Agents in the same namespace
-		agentList := &kaosv1alpha1.AgentList{}
-		if err := r.List(ctx, agentList, client.InNamespace(mcpserver.Namespace)); err != nil {
-			return []ctrl.Request{}
-		}
-
-		requests := []ctrl.Request{}
-		for _, agent := range agentList.Items {
-			for _, mcpName := range agent.Spec.MCPServers {
-				if mcpName == mcpserver.Name {
+			for _, modelAPIName := range agentModelAPINames(&agent) {
+				if modelAPIName == modelapi.Name {
 					requests = append(requests, ctrl.Request{
 						NamespacedName: types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace},
 					})
+					break
 				}
 			}
 		}
 		return requests
 	})
 
+	// Index Agents by referenced MCPServer so MCPServer events map to dependent Agents
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &kaosv1alpha1.Agent{}, agentMCPServerIndex, indexAgentMCPServers); err != nil {
+		return err
+	}
+
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&kaosv1alpha1.Agent{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&batchv1.Job{}).
+		Owns(&batchv1.CronJob{}).
 		Owns(&corev1.Service{}).
 		Watches(&kaosv1alpha1.ModelAPI{}, mapModelAPIToAgents).
-		Watches(&kaosv1alpha1.MCPServer{}, mapMCPServerToAgents)
+		Watches(&kaosv1alpha1.MCPServer{}, handler.EnqueueRequestsFromMapFunc(r.agentsForMCPServer),
+			ctrlbuilder.WithPredicates(mcpServerEndpointChanged)).
+		Watches(&corev1.ConfigMap{}, mapReferencedObjectToOwners(r.Client, "Agent", "ConfigMap"),
+			ctrlbuilder.OnlyMetadata).
+		Watches(&corev1.Secret{}, mapReferencedObjectToOwners(r.Client, "Agent", "Secret"),
+			ctrlbuilder.OnlyMetadata)
 
 	// Own HTTPRoutes if Gateway API is enabled
 	if gateway.GetConfig().Enabled {
@@ -648,37 +1715,158 @@ func (r *AgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return builder.Complete(r)
 }
 
-// validateAgentModel checks if the agent's model is supported by the ModelAPI
-func (r *AgentReconciler) validateAgentModel(agent *kaosv1alpha1.Agent, modelapi *kaosv1alpha1.ModelAPI) error {
-	agentModel := agent.Spec.Model
+// gatewayRouteEnabled reports whether a resource with the given gatewayRoute should have
+// an HTTPRoute; routes are enabled unless gatewayRoute.enabled is explicitly false.
+func gatewayRouteEnabled(route *kaosv1alpha1.GatewayRoute) bool {
+	return route == nil || route.Enabled == nil || *route.Enabled
+}
 
-	// Get supported models from spec (models is required with MinItems=1)
-	var supportedModels []string
-	if modelapi.Spec.Mode == kaosv1alpha1.ModelAPIModeProxy && modelapi.Spec.ProxyConfig != nil {
-		supportedModels = modelapi.Spec.ProxyConfig.Models
-	} else if modelapi.Spec.Mode == kaosv1alpha1.ModelAPIModeHosted && modelapi.Spec.HostedConfig != nil {
-		supportedModels = []string{modelapi.Spec.HostedConfig.Model}
+// gatewayRoutePathPrefix returns the custom path prefix of gatewayRoute, or "" to use the
+// computed /{namespace}/{type}/{name} path
+func gatewayRoutePathPrefix(route *kaosv1alpha1.GatewayRoute) string {
+	if route == nil {
+		return ""
 	}
+	return route.PathPrefix
+}
 
-	for _, pattern := range supportedModels {
-		// Full wildcard matches everything
-		if pattern == "*" {
-			return nil
-		}
+// gatewayRouteSectionName returns the Gateway listener gatewayRoute binds to, or "" to
+// use the operator-wide default
+func gatewayRouteSectionName(route *kaosv1alpha1.GatewayRoute) string {
+	if route == nil {
+		return ""
+	}
+	return route.SectionName
+}
+
+// gatewayRouteGateways returns the Gateways gatewayRoute attaches to, or nil to use the
+// operator-wide Gateway
+func gatewayRouteGateways(route *kaosv1alpha1.GatewayRoute) []gateway.GatewayTarget {
+	if route == nil || len(route.Gateways) == 0 {
+		return nil
+	}
+	targets := make([]gateway.GatewayTarget, 0, len(route.Gateways))
+	for _, target := range route.Gateways {
+		targets = append(targets, gateway.GatewayTarget{
+			Name:        target.Name,
+			Namespace:   target.Namespace,
+			SectionName: target.SectionName,
+			Host:        target.Host,
+		})
+	}
+	return targets
+}
+
+// gatewayRouteCORS returns the CORS headers configured on gatewayRoute, or nil when
+// CORS is not enabled
+func gatewayRouteCORS(route *kaosv1alpha1.GatewayRoute) *gateway.CORSConfig {
+	if route == nil || route.CORS == nil {
+		return nil
+	}
+	return &gateway.CORSConfig{
+		AllowOrigins: route.CORS.AllowOrigins,
+		AllowMethods: route.CORS.AllowMethods,
+		AllowHeaders: route.CORS.AllowHeaders,
+	}
+}
+
+// gatewayRouteRequestHeaders returns the request headers the Gateway sets for gatewayRoute
+func gatewayRouteRequestHeaders(route *kaosv1alpha1.GatewayRoute) map[string]string {
+	if route == nil {
+		return nil
+	}
+	return route.RequestHeaders
+}
+
+// agentHTTPRouteParams returns the HTTPRoute parameters for the agent's Service. The
+// timeout is gatewayRoute.timeout if set, otherwise requestTimeout; empty means the
+// gateway default timeout is used.
+func agentHTTPRouteParams(agent *kaosv1alpha1.Agent, serviceName string) (gateway.HTTPRouteParams, error) {
+	timeout := agent.Spec.RequestTimeout
+	if agent.Spec.GatewayRoute != nil && agent.Spec.GatewayRoute.Timeout != "" {
+		timeout = agent.Spec.GatewayRoute.Timeout
+	}
+	backends, err := agentTrafficSplitBackends(agent, serviceName)
+	if err != nil {
+		return gateway.HTTPRouteParams{}, err
+	}
+	return gateway.HTTPRouteParams{
+		ResourceType:   gateway.ResourceTypeAgent,
+		ResourceName:   agent.Name,
+		Namespace:      agent.Namespace,
+		ServiceName:    serviceName,
+		ServicePort:    gatewayServicePort(agent.Spec.Protocol),
+		Labels:         map[string]string{"app": "agent", "agent": agent.Name},
+		PathPrefix:     gatewayRoutePathPrefix(agent.Spec.GatewayRoute),
+		SectionName:    gatewayRouteSectionName(agent.Spec.GatewayRoute),
+		Gateways:       gatewayRouteGateways(agent.Spec.GatewayRoute),
+		Timeout:        timeout,
+		Backends:       backends,
+		CORS:           gatewayRouteCORS(agent.Spec.GatewayRoute),
+		RequestHeaders: gatewayRouteRequestHeaders(agent.Spec.GatewayRoute),
+	}, nil
+}
+
+// agentTrafficSplitRequested reports whether the agent lists trafficSplit backends
+func agentTrafficSplitRequested(agent *kaosv1alpha1.Agent) bool {
+	return agent.Spec.TrafficSplit != nil && len(agent.Spec.TrafficSplit.Backends) > 0
+}
 
-		// Exact match
-		if pattern == agentModel {
-			return nil
+// agentTrafficSplitBackends returns the weighted Services for the agent's HTTPRoute, or
+// nil when no traffic split is configured or the AgentTrafficSplit feature gate is off.
+// The agent's own Service receives 100 minus the sum of the backend weights.
+func agentTrafficSplitBackends(agent *kaosv1alpha1.Agent, serviceName string) ([]gateway.WeightedBackend, error) {
+	if !agentTrafficSplitRequested(agent) || !features.Enabled(features.AgentTrafficSplit) {
+		return nil, nil
+	}
+
+	// Also enforced by CEL; re-checked since the split is computed from the weights
+	total := int32(0)
+	// Backends are expected to speak the agent's protocol, as canaries of the same runtime
+	port := gatewayServicePort(agent.Spec.Protocol)
+	backends := []gateway.WeightedBackend{{ServiceName: serviceName, ServicePort: port}}
+	for _, backend := range agent.Spec.TrafficSplit.Backends {
+		if backend.Agent == agent.Name {
+			return nil, fmt.Errorf("trafficSplit backend %q must not reference the agent itself", backend.Agent)
 		}
+		total += backend.Weight
+		backends = append(backends, gateway.WeightedBackend{
+			ServiceName: fmt.Sprintf("agent-%s", backend.Agent),
+			ServicePort: port,
+			Weight:      backend.Weight,
+		})
+	}
+	if total > 100 {
+		return nil, fmt.Errorf("trafficSplit backend weights add up to %d, must not exceed 100", total)
+	}
+	backends[0].Weight = 100 - total
+
+	if err := gateway.ValidateWeights(backends); err != nil {
+		return nil, err
+	}
+	return backends, nil
+}
 
-		// Provider wildcard: "openai/*" matches "openai/gpt-4"
-		if strings.HasSuffix(pattern, "/*") {
-			prefix := strings.TrimSuffix(pattern, "*")
-			if strings.HasPrefix(agentModel, prefix) {
-				return nil
+// validateAgentModel checks if the agent's model is supported by the ModelAPI
+func (r *AgentReconciler) validateAgentModel(ctx context.Context, agent *kaosv1alpha1.Agent, modelapi *kaosv1alpha1.ModelAPI) error {
+	agentModel := agent.Spec.Model
+
+	// Get supported models from spec (models is required with MinItems=1), or from the
+	// proxy itself when live validation is enabled
+	supportedModels := r.agentValidationModels(ctx, agent, modelapi)
+
+	if !validation.ModelMatchesPatterns(agentModel, supportedModels) {
+		return fmt.Errorf("model %q not supported by ModelAPI %q (supported: %v)", agentModel, modelapi.Name, supportedModels)
+	}
+
+	// Every model route target must be servable too (sorted for a stable error message)
+	if agent.Spec.Config != nil {
+		for _, tag := range sortedKeys(agent.Spec.Config.ModelRoutes) {
+			model := agent.Spec.Config.ModelRoutes[tag]
+			if !validation.ModelMatchesPatterns(model, supportedModels) {
+				return fmt.Errorf("modelRoutes[%q] model %q not supported by ModelAPI %q (supported: %v)", tag, model, modelapi.Name, supportedModels)
 			}
 		}
 	}
-
-	return fmt.Errorf("model %q not supported by ModelAPI %q (supported: %v)", agentModel, modelapi.Name, supportedModels)
+	return nil
 }
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+const (
+	// caBundleVolumeName is the pod volume holding the bundle from spec.caBundle
+	caBundleVolumeName = "ca-bundle"
+	// caBundleMountPath is where the CA bundle volume is mounted in the main container
+	caBundleMountPath = "/etc/kaos/ca"
+	// caBundleFileName is the file name of the mounted CA bundle
+	caBundleFileName = "ca.crt"
+)
+
+// constructCABundleVolume returns the volume projecting the spec.caBundle key to
+// caBundleFileName, or nil when no bundle is configured
+func constructCABundleVolume(source *kaosv1alpha1.CABundleSource) *corev1.Volume {
+	if source == nil {
+		return nil
+	}
+	volume := &corev1.Volume{Name: caBundleVolumeName}
+	if ref := source.ConfigMapKeyRef; ref != nil {
+		volume.VolumeSource.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: ref.LocalObjectReference,
+			Items:                []corev1.KeyToPath{{Key: ref.Key, Path: caBundleFileName}},
+			Optional:             ref.Optional,
+		}
+	} else if ref := source.SecretKeyRef; ref != nil {
+		volume.VolumeSource.Secret = &corev1.SecretVolumeSource{
+			SecretName: ref.Name,
+			Items:      []corev1.KeyToPath{{Key: ref.Key, Path: caBundleFileName}},
+			Optional:   ref.Optional,
+		}
+	} else {
+		return nil
+	}
+	return volume
+}
+
+// caBundleVolumeMount mounts the CA bundle volume read-only at caBundleMountPath
+func caBundleVolumeMount() corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      caBundleVolumeName,
+		MountPath: caBundleMountPath,
+		ReadOnly:  true,
+	}
+}
+
+// caBundleEnv points OpenSSL-based clients (Python ssl and httpx, Go) through
+// SSL_CERT_FILE and the requests library through REQUESTS_CA_BUNDLE at the mounted
+// bundle, or returns nil when no bundle is configured
+func caBundleEnv(source *kaosv1alpha1.CABundleSource) []corev1.EnvVar {
+	if constructCABundleVolume(source) == nil {
+		return nil
+	}
+	path := caBundleMountPath + "/" + caBundleFileName
+	return []corev1.EnvVar{
+		{Name: "SSL_CERT_FILE", Value: path},
+		{Name: "REQUESTS_CA_BUNDLE", Value: path},
+	}
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+var _ = ginkgo.Describe("custom CA bundle", func() {
+	bundleEnv := []corev1.EnvVar{
+		{Name: "SSL_CERT_FILE", Value: "/etc/kaos/ca/ca.crt"},
+		{Name: "REQUESTS_CA_BUNDLE", Value: "/etc/kaos/ca/ca.crt"},
+	}
+
+	ginkgo.It("mounts the bundle into the model-api container", func() {
+		setEnv("DEFAULT_LITELLM_IMAGE", "litellm:test")
+
+		modelapi := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "onprem", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models:  []string{"openai/llama-3"},
+					APIBase: "https://llm.corp.internal",
+				},
+			},
+		}
+		plain, _, err := (&ModelAPIReconciler{}).constructDeployment(modelapi)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(plain.Spec.Template.Spec.Containers[0].Env).NotTo(gomega.ContainElements(bundleEnv))
+
+		modelapi.Spec.CABundle = &kaosv1alpha1.CABundleSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "corp-ca"},
+				Key:                  "bundle.pem",
+			},
+		}
+		deployment, _, err := (&ModelAPIReconciler{}).constructDeployment(modelapi)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		spec := deployment.Spec.Template.Spec
+		gomega.Expect(spec.Volumes).To(gomega.ContainElement(corev1.Volume{
+			Name: caBundleVolumeName,
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+				SecretName: "corp-ca",
+				Items:      []corev1.KeyToPath{{Key: "bundle.pem", Path: caBundleFileName}},
+			}},
+		}))
+		gomega.Expect(spec.Containers[0].VolumeMounts).To(gomega.ContainElement(caBundleVolumeMount()))
+		gomega.Expect(spec.Containers[0].Env).To(gomega.ContainElements(bundleEnv))
+		gomega.Expect(deployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]).NotTo(
+			gomega.Equal(plain.Spec.Template.Annotations[util.PodSpecHashAnnotation]))
+	})
+})
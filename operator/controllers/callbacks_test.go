@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+var _ = ginkgo.Describe("LiteLLM callbacks", func() {
+	ginkgo.BeforeEach(func() {
+		setEnv("DEFAULT_LITELLM_IMAGE", "litellm:test")
+	})
+
+	callbackModelAPI := func(callbacks ...string) *kaosv1alpha1.ModelAPI {
+		return &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models:    []string{"gpt-4o"},
+					Callbacks: callbacks,
+					CallbackEnv: []corev1.EnvVar{
+						{Name: "LANGFUSE_HOST", Value: "https://langfuse.example.com"},
+						{Name: "LANGFUSE_SECRET_KEY", ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "langfuse"},
+								Key:                  "secret-key",
+							},
+						}},
+					},
+				},
+			},
+		}
+	}
+
+	ginkgo.It("renders the callbacks block alongside the otel callbacks", func() {
+		config := (&ModelAPIReconciler{}).generateLiteLLMConfig(
+			callbackModelAPI("langfuse", "lakera_prompt_injection").Spec.ProxyConfig,
+			&kaosv1alpha1.TelemetryConfig{Enabled: true, Endpoint: "http://otel:4317"})
+
+		gomega.Expect(config).To(gomega.ContainSubstring(
+			"litellm_settings:\n  drop_params: true\n" +
+				"  success_callback: [\"otel\"]\n  failure_callback: [\"otel\"]\n" +
+				"  callbacks: [\"langfuse\", \"lakera_prompt_injection\"]\n"))
+	})
+
+	ginkgo.It("omits the callbacks block when none are configured", func() {
+		config := (&ModelAPIReconciler{}).generateLiteLLMConfig(callbackModelAPI().Spec.ProxyConfig, nil)
+		gomega.Expect(config).NotTo(gomega.ContainSubstring("callbacks:"))
+	})
+
+	ginkgo.It("passes the callback env vars, including Secret refs, to the container", func() {
+		container, _, err := (&ModelAPIReconciler{}).constructContainer(callbackModelAPI("langfuse"))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		env := map[string]corev1.EnvVar{}
+		for _, e := range container.Env {
+			env[e.Name] = e
+		}
+		gomega.Expect(env["LANGFUSE_HOST"].Value).To(gomega.Equal("https://langfuse.example.com"))
+		gomega.Expect(env["LANGFUSE_SECRET_KEY"].ValueFrom.SecretKeyRef.Name).To(gomega.Equal("langfuse"))
+		gomega.Expect(env["LANGFUSE_SECRET_KEY"].ValueFrom.SecretKeyRef.Key).To(gomega.Equal("secret-key"))
+	})
+
+	ginkgo.It("rolls the pods when the callbacks change", func() {
+		first, _, err := (&ModelAPIReconciler{}).constructDeployment(callbackModelAPI("langfuse"))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		second, _, err := (&ModelAPIReconciler{}).constructDeployment(callbackModelAPI("langfuse", "lakera_prompt_injection"))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		gomega.Expect(second.Spec.Template.Spec).To(gomega.Equal(first.Spec.Template.Spec))
+		gomega.Expect(second.Spec.Template.Annotations[litellmConfigHashAnnotation]).NotTo(
+			gomega.Equal(first.Spec.Template.Annotations[litellmConfigHashAnnotation]))
+		gomega.Expect(second.Spec.Template.Annotations[util.PodSpecHashAnnotation]).NotTo(
+			gomega.Equal(first.Spec.Template.Annotations[util.PodSpecHashAnnotation]))
+	})
+})
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("agent container command override", func() {
+	var (
+		agent     *kaosv1alpha1.Agent
+		modelapis []*kaosv1alpha1.ModelAPI
+	)
+
+	ginkgo.BeforeEach(func() {
+		setDefaultAgentImage()
+
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "mock-model"},
+		}
+		modelapis = []*kaosv1alpha1.ModelAPI{{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Status:     kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}}
+	})
+
+	agentContainer := func() corev1.Container {
+		podSpec, _, err := (&AgentReconciler{}).constructPodSpec(agent, modelapis, nil, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		return podSpec.Containers[0]
+	}
+
+	ginkgo.It("keeps the image entrypoint by default", func() {
+		container := agentContainer()
+		gomega.Expect(container.Command).To(gomega.BeNil())
+		gomega.Expect(container.Args).To(gomega.BeNil())
+	})
+
+	ginkgo.It("applies container.command and container.args to the agent container", func() {
+		agent.Spec.Container = &kaosv1alpha1.ContainerOverride{
+			Command: []string{"python", "-m", "custom_runtime"},
+			Args:    []string{"--port", "8000"},
+		}
+		gomega.Expect(validateContainerCommand(agent)).To(gomega.Succeed())
+
+		container := agentContainer()
+		gomega.Expect(container.Command).To(gomega.Equal([]string{"python", "-m", "custom_runtime"}))
+		gomega.Expect(container.Args).To(gomega.Equal([]string{"--port", "8000"}))
+	})
+
+	ginkgo.It("rejects a command override combined with a podSpec command for the agent container", func() {
+		agent.Spec.Container = &kaosv1alpha1.ContainerOverride{Args: []string{"--debug"}}
+		agent.Spec.PodSpec = &corev1.PodSpec{Containers: []corev1.Container{{Name: "agent", Command: []string{"sh"}}}}
+		gomega.Expect(validateContainerCommand(agent)).To(gomega.MatchError(gomega.ContainSubstring("podSpec")))
+
+		// A podSpec override of other containers, or other fields, is fine
+		agent.Spec.PodSpec = &corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "agent", WorkingDir: "/app"},
+			{Name: "sidecar", Command: []string{"sh"}},
+		}}
+		gomega.Expect(validateContainerCommand(agent)).To(gomega.Succeed())
+	})
+})
@@ -0,0 +1,107 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("LiteLLM database", func() {
+	ginkgo.BeforeEach(func() {
+		setEnv("DEFAULT_LITELLM_IMAGE", "litellm:test")
+	})
+
+	secretKey := func(name, key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}
+	}
+
+	databaseModelAPI := func(database *kaosv1alpha1.ProxyDatabaseConfig) *kaosv1alpha1.ModelAPI {
+		return &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models:   []string{"gpt-4o"},
+					Database: database,
+				},
+			},
+		}
+	}
+
+	keyManagement := &kaosv1alpha1.ProxyDatabaseConfig{
+		URLSecretRef: *secretKey("litellm-db", "url"),
+		MasterKey: &kaosv1alpha1.ApiKeySource{ValueFrom: &kaosv1alpha1.ApiKeyValueFrom{
+			SecretKeyRef: secretKey("litellm", "master-key"),
+		}},
+	}
+
+	envByName := func(modelapi *kaosv1alpha1.ModelAPI) map[string]corev1.EnvVar {
+		container, _, err := (&ModelAPIReconciler{}).constructContainer(modelapi)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		env := map[string]corev1.EnvVar{}
+		for _, e := range container.Env {
+			env[e.Name] = e
+		}
+		return env
+	}
+
+	ginkgo.It("sources DATABASE_URL and LITELLM_MASTER_KEY from their Secrets", func() {
+		env := envByName(databaseModelAPI(keyManagement))
+
+		gomega.Expect(env["DATABASE_URL"].Value).To(gomega.BeEmpty())
+		gomega.Expect(env["DATABASE_URL"].ValueFrom.SecretKeyRef).To(gomega.Equal(secretKey("litellm-db", "url")))
+		gomega.Expect(env["LITELLM_MASTER_KEY"].ValueFrom.SecretKeyRef).To(gomega.Equal(secretKey("litellm", "master-key")))
+	})
+
+	ginkgo.It("enables key management in the generated config", func() {
+		config := (&ModelAPIReconciler{}).generateLiteLLMConfig(databaseModelAPI(keyManagement).Spec.ProxyConfig, nil)
+
+		gomega.Expect(config).To(gomega.ContainSubstring(`
+general_settings:
+  database_url: "os.environ/DATABASE_URL"
+  master_key: "os.environ/LITELLM_MASTER_KEY"
+`))
+	})
+
+	ginkgo.It("stores spend without enforcing keys when no master key is set", func() {
+		modelapi := databaseModelAPI(&kaosv1alpha1.ProxyDatabaseConfig{URLSecretRef: *secretKey("litellm-db", "url")})
+		config := (&ModelAPIReconciler{}).generateLiteLLMConfig(modelapi.Spec.ProxyConfig, nil)
+
+		gomega.Expect(config).To(gomega.ContainSubstring("general_settings:\n  database_url: \"os.environ/DATABASE_URL\"\n"))
+		gomega.Expect(config).NotTo(gomega.ContainSubstring("master_key"))
+		gomega.Expect(envByName(modelapi)).NotTo(gomega.HaveKey("LITELLM_MASTER_KEY"))
+	})
+
+	ginkgo.It("adds no database settings by default", func() {
+		modelapi := databaseModelAPI(nil)
+		gomega.Expect((&ModelAPIReconciler{}).generateLiteLLMConfig(modelapi.Spec.ProxyConfig, nil)).NotTo(
+			gomega.ContainSubstring("general_settings"))
+		gomega.Expect(envByName(modelapi)).NotTo(gomega.HaveKey("DATABASE_URL"))
+	})
+
+	ginkgo.It("runs the migrations in an init container only when a database is configured", func() {
+		deployment, _, err := (&ModelAPIReconciler{}).constructDeployment(databaseModelAPI(keyManagement))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		spec := deployment.Spec.Template.Spec
+		gomega.Expect(spec.InitContainers).To(gomega.HaveLen(1))
+		migrate := spec.InitContainers[0]
+		gomega.Expect(migrate.Name).To(gomega.Equal("migrate-db"))
+		gomega.Expect(migrate.Image).To(gomega.Equal("litellm:test"))
+		gomega.Expect(migrate.Command).To(gomega.Equal([]string{"python", "litellm/proxy/prisma_migration.py"}))
+		gomega.Expect(migrate.Env).To(gomega.ConsistOf(corev1.EnvVar{
+			Name:      "DATABASE_URL",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: secretKey("litellm-db", "url")},
+		}))
+		// The proxy leaves the schema to the init container
+		gomega.Expect(envByName(databaseModelAPI(keyManagement))["DISABLE_SCHEMA_UPDATE"].Value).To(gomega.Equal("true"))
+
+		deployment, _, err = (&ModelAPIReconciler{}).constructDeployment(databaseModelAPI(nil))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(deployment.Spec.Template.Spec.InitContainers).To(gomega.BeEmpty())
+		gomega.Expect(envByName(databaseModelAPI(nil))).NotTo(gomega.HaveKey("DISABLE_SCHEMA_UPDATE"))
+	})
+})
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("agent debug memory endpoints", func() {
+	var (
+		agent     *kaosv1alpha1.Agent
+		modelapis []*kaosv1alpha1.ModelAPI
+	)
+
+	ginkgo.BeforeEach(func() {
+		setDefaultAgentImage()
+
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "mock-model"},
+		}
+		modelapis = []*kaosv1alpha1.ModelAPI{{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Status:     kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}}
+	})
+
+	ginkgo.It("leaves the endpoints to the runtime default when unconfigured", func() {
+		gomega.Expect(agentEnv(agent, modelapis...)).NotTo(gomega.HaveKey("AGENT_DEBUG_MEMORY_ENDPOINTS"))
+	})
+
+	ginkgo.It("disables the endpoints when the agent turns them off", func() {
+		disabled := false
+		agent.Spec.Config = &kaosv1alpha1.AgentConfig{DebugMemoryEndpoints: &disabled}
+		gomega.Expect(agentEnv(agent, modelapis...)).To(gomega.HaveKeyWithValue("AGENT_DEBUG_MEMORY_ENDPOINTS", "false"))
+	})
+
+	ginkgo.It("follows the operator default unless the agent overrides it", func() {
+		setEnv("DEFAULT_AGENT_DEBUG_MEMORY_ENDPOINTS", "false")
+		gomega.Expect(agentEnv(agent, modelapis...)).To(gomega.HaveKeyWithValue("AGENT_DEBUG_MEMORY_ENDPOINTS", "false"))
+
+		enabled := true
+		agent.Spec.Config = &kaosv1alpha1.AgentConfig{DebugMemoryEndpoints: &enabled}
+		gomega.Expect(agentEnv(agent, modelapis...)).To(gomega.HaveKeyWithValue("AGENT_DEBUG_MEMORY_ENDPOINTS", "true"))
+	})
+})
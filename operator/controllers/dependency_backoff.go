@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// dependencyBackoffBase is the first requeue delay after a dependency is not found
+	dependencyBackoffBase = 5 * time.Second
+	// dependencyBackoffMax caps the requeue delay for a persistently missing dependency
+	dependencyBackoffMax = 5 * time.Minute
+)
+
+// dependencyBackoff returns the per-agent backoff used while a referenced ModelAPI or
+// MCPServer does not exist. Creating the dependency triggers a reconcile through the
+// watches, so the requeue is only a fallback and can back off to the cap.
+func (r *AgentReconciler) dependencyBackoff() workqueue.TypedRateLimiter[types.NamespacedName] {
+	r.backoffOnce.Do(func() {
+		r.backoff = workqueue.NewTypedItemExponentialFailureRateLimiter[types.NamespacedName](dependencyBackoffBase, dependencyBackoffMax)
+	})
+	return r.backoff
+}
+
+// requeueMissingDependency returns a result requeueing the agent after the next backoff
+// interval, doubling the interval for each consecutive call until it is reset
+func (r *AgentReconciler) requeueMissingDependency(key types.NamespacedName) ctrl.Result {
+	return ctrl.Result{RequeueAfter: r.dependencyBackoff().When(key)}
+}
+
+// resetDependencyBackoff clears the backoff once the agent's dependencies resolve
+func (r *AgentReconciler) resetDependencyBackoff(key types.NamespacedName) {
+	r.dependencyBackoff().Forget(key)
+}
@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("dependency backoff", func() {
+	var (
+		ctx context.Context
+		c   client.Client
+		r   *AgentReconciler
+		req ctrl.Request
+	)
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		setDefaultAgentImage()
+
+		scheme := runtime.NewScheme()
+		gomega.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+		gomega.Expect(kaosv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "mock-model"},
+		}
+		c = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(agent).
+			WithStatusSubresource(&kaosv1alpha1.ModelAPI{}, &kaosv1alpha1.Agent{}).
+			Build()
+		r = &AgentReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+		req = ctrl.Request{NamespacedName: types.NamespacedName{Name: "agent", Namespace: "default"}}
+	})
+
+	// requeueAfter reconciles the agent and returns the requested requeue delay
+	requeueAfter := func() time.Duration {
+		result, err := r.Reconcile(ctx, req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		return result.RequeueAfter
+	}
+
+	ginkgo.It("grows the requeue interval while the ModelAPI is not found", func() {
+		gomega.Expect(requeueAfter()).To(gomega.Equal(dependencyBackoffBase))
+		gomega.Expect(requeueAfter()).To(gomega.Equal(2 * dependencyBackoffBase))
+		gomega.Expect(requeueAfter()).To(gomega.Equal(4 * dependencyBackoffBase))
+
+		stored := &kaosv1alpha1.Agent{}
+		gomega.Expect(c.Get(ctx, req.NamespacedName, stored)).To(gomega.Succeed())
+		gomega.Expect(stored.Status.Phase).To(gomega.Equal("Failed"))
+	})
+
+	ginkgo.It("caps the requeue interval", func() {
+		for i := 0; i < 10; i++ {
+			requeueAfter()
+		}
+		gomega.Expect(requeueAfter()).To(gomega.Equal(dependencyBackoffMax))
+	})
+
+	ginkgo.It("resets the backoff once the ModelAPI resolves", func() {
+		requeueAfter()
+		requeueAfter()
+
+		gomega.Expect(c.Create(ctx, &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:        kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"mock-model"}},
+			},
+			Status: kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		})).To(gomega.Succeed())
+		requeueAfter()
+
+		gomega.Expect(c.Delete(ctx, &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+		})).To(gomega.Succeed())
+		gomega.Expect(requeueAfter()).To(gomega.Equal(dependencyBackoffBase))
+	})
+
+	ginkgo.It("backs off while a referenced MCPServer is not found", func() {
+		gomega.Expect(c.Create(ctx, &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:        kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"mock-model"}},
+			},
+			Status: kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		})).To(gomega.Succeed())
+		agent := &kaosv1alpha1.Agent{}
+		gomega.Expect(c.Get(ctx, req.NamespacedName, agent)).To(gomega.Succeed())
+		agent.Spec.MCPServers = []string{"tools"}
+		gomega.Expect(c.Update(ctx, agent)).To(gomega.Succeed())
+
+		gomega.Expect(requeueAfter()).To(gomega.Equal(dependencyBackoffBase))
+		gomega.Expect(requeueAfter()).To(gomega.Equal(2 * dependencyBackoffBase))
+
+		gomega.Expect(c.Get(ctx, req.NamespacedName, agent)).To(gomega.Succeed())
+		gomega.Expect(agent.Status.Phase).To(gomega.Equal("Failed"))
+	})
+})
@@ -0,0 +1,84 @@
+package controllers
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// referencingAgents returns the sorted names of Agents in namespace whose references, as
+// returned by refs (e.g. agentModelAPINames), include name. Agents that are being deleted
+// are skipped.
+func referencingAgents(ctx context.Context, c client.Client, namespace, name string, refs func(*kaosv1alpha1.Agent) []string) ([]string, error) {
+	agentList := &kaosv1alpha1.AgentList{}
+	if err := c.List(ctx, agentList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for i := range agentList.Items {
+		agent := &agentList.Items[i]
+		if agent.DeletionTimestamp != nil {
+			continue
+		}
+		for _, ref := range refs(agent) {
+			if ref == name {
+				names = append(names, agent.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// agentMCPServerIndex is the Agent field index holding the names of the MCPServers an
+// Agent references, so MCPServer events map to dependent Agents without a full list scan
+const agentMCPServerIndex = "spec.mcpServerNames"
+
+// indexAgentMCPServers is the agentMCPServerIndex extractor
+func indexAgentMCPServers(obj client.Object) []string {
+	return agentMCPServerNames(obj.(*kaosv1alpha1.Agent))
+}
+
+// agentsForMCPServer returns a request for each Agent that references the MCPServer,
+// looked up through agentMCPServerIndex
+func (r *AgentReconciler) agentsForMCPServer(ctx context.Context, obj client.Object) []ctrl.Request {
+	agentList := &kaosv1alpha1.AgentList{}
+	if err := r.List(ctx, agentList,
+		client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{agentMCPServerIndex: obj.GetName()}); err != nil {
+		return []ctrl.Request{}
+	}
+
+	requests := make([]ctrl.Request, 0, len(agentList.Items))
+	for _, agent := range agentList.Items {
+		requests = append(requests, ctrl.Request{
+			NamespacedName: types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace},
+		})
+	}
+	return requests
+}
+
+// mcpServerEndpointChanged passes MCPServer creates and deletes, and only those updates
+// that change what Agents consume from it: its readiness, endpoint and the tools listed
+// in the agent card summary
+var mcpServerEndpointChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldMCP, okOld := e.ObjectOld.(*kaosv1alpha1.MCPServer)
+		newMCP, okNew := e.ObjectNew.(*kaosv1alpha1.MCPServer)
+		if !okOld || !okNew {
+			return true
+		}
+		return oldMCP.Status.Ready != newMCP.Status.Ready || oldMCP.Status.Endpoint != newMCP.Status.Endpoint ||
+			!slices.Equal(oldMCP.Status.AvailableTools, newMCP.Status.AvailableTools)
+	},
+}
@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("status.dependents", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+	)
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme = runtime.NewScheme()
+		gomega.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+		gomega.Expect(kaosv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+	})
+
+	newAgent := func(name string, spec kaosv1alpha1.AgentSpec) *kaosv1alpha1.Agent {
+		spec.Model = "mock-model"
+		return &kaosv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}, Spec: spec}
+	}
+
+	ginkgo.It("lists the Agents referencing an MCPServer and skips deleting Agents", func() {
+		deleting := newAgent("deleting", kaosv1alpha1.AgentSpec{ModelAPI: "llm", MCPServers: []string{"tools"}})
+		deleting.Finalizers = []string{agentFinalizerName}
+		deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+		var c client.Client = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(
+				newAgent("by-name", kaosv1alpha1.AgentSpec{ModelAPI: "llm", MCPServers: []string{"tools"}}),
+				newAgent("by-ref", kaosv1alpha1.AgentSpec{ModelAPI: "llm", MCPServerRefs: []kaosv1alpha1.AgentMCPServerRef{{Name: "tools"}}}),
+				newAgent("other", kaosv1alpha1.AgentSpec{ModelAPI: "llm", MCPServers: []string{"search"}}),
+				deleting,
+			).
+			Build()
+
+		names, err := referencingAgents(ctx, c, "default", "tools", agentMCPServerNames)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(names).To(gomega.Equal([]string{"by-name", "by-ref"}))
+	})
+})
+
+var _ = ginkgo.Describe("MCPServer reverse index", func() {
+	var (
+		ctx context.Context
+		c   client.Client
+		r   *AgentReconciler
+		key types.NamespacedName
+	)
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		setDefaultAgentImage()
+
+		scheme := runtime.NewScheme()
+		gomega.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+		gomega.Expect(kaosv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+
+		modelapi := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:        kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"mock-model"}},
+			},
+			Status: kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}
+		mcpserver := &kaosv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "tools", Namespace: "default"},
+			Status:     kaosv1alpha1.MCPServerStatus{Endpoint: "http://mcpserver-tools:8000", Ready: true},
+		}
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "mock-model", MCPServers: []string{"tools"}},
+		}
+		unrelated := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "mock-model", MCPServers: []string{"search"}},
+		}
+		key = types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}
+
+		c = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(modelapi, mcpserver, agent, unrelated).
+			WithStatusSubresource(&kaosv1alpha1.ModelAPI{}, &kaosv1alpha1.MCPServer{}, &kaosv1alpha1.Agent{}).
+			WithIndex(&kaosv1alpha1.Agent{}, agentMCPServerIndex, indexAgentMCPServers).
+			Build()
+		r = &AgentReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+	})
+
+	// mcpServerURL returns the MCP_SERVER_tools_URL env value of the agent's Deployment
+	mcpServerURL := func() string {
+		deployment := &appsv1.Deployment{}
+		gomega.Expect(c.Get(ctx, types.NamespacedName{Name: "agent-agent", Namespace: "default"}, deployment)).To(gomega.Succeed())
+		for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+			if env.Name == "MCP_SERVER_tools_URL" {
+				return env.Value
+			}
+		}
+		return ""
+	}
+
+	ginkgo.It("re-reconciles dependent Agents when an MCPServer endpoint changes", func() {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(mcpServerURL()).To(gomega.Equal("http://mcpserver-tools:8000"))
+
+		oldMCP := &kaosv1alpha1.MCPServer{}
+		gomega.Expect(c.Get(ctx, types.NamespacedName{Name: "tools", Namespace: "default"}, oldMCP)).To(gomega.Succeed())
+		newMCP := oldMCP.DeepCopy()
+		newMCP.Status.Endpoint = "http://mcpserver-tools.tools-ns:8000"
+		gomega.Expect(c.Status().Update(ctx, newMCP)).To(gomega.Succeed())
+
+		gomega.Expect(mcpServerEndpointChanged.Update(event.UpdateEvent{ObjectOld: oldMCP, ObjectNew: newMCP})).To(gomega.BeTrue())
+		requests := r.agentsForMCPServer(ctx, newMCP)
+		gomega.Expect(requests).To(gomega.Equal([]ctrl.Request{{NamespacedName: key}}))
+
+		for _, req := range requests {
+			_, err := r.Reconcile(ctx, req)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+		gomega.Expect(mcpServerURL()).To(gomega.Equal("http://mcpserver-tools.tools-ns:8000"))
+	})
+
+	ginkgo.It("ignores MCPServer updates that do not change readiness, endpoint or tools", func() {
+		oldMCP := &kaosv1alpha1.MCPServer{}
+		gomega.Expect(c.Get(ctx, types.NamespacedName{Name: "tools", Namespace: "default"}, oldMCP)).To(gomega.Succeed())
+		newMCP := oldMCP.DeepCopy()
+		newMCP.Status.Dependents = []string{"agent"}
+		gomega.Expect(mcpServerEndpointChanged.Update(event.UpdateEvent{ObjectOld: oldMCP, ObjectNew: newMCP})).To(gomega.BeFalse())
+
+		newMCP.Status.AvailableTools = []string{"echo"}
+		gomega.Expect(mcpServerEndpointChanged.Update(event.UpdateEvent{ObjectOld: oldMCP, ObjectNew: newMCP})).To(gomega.BeTrue())
+
+		newMCP.Status.Ready = false
+		gomega.Expect(mcpServerEndpointChanged.Update(event.UpdateEvent{ObjectOld: oldMCP, ObjectNew: newMCP})).To(gomega.BeTrue())
+		gomega.Expect(mcpServerEndpointChanged.Delete(event.DeleteEvent{Object: oldMCP})).To(gomega.BeTrue())
+	})
+})
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// deploymentStrategy returns the Deployment strategy for the resource's deploymentStrategy.
+// An unset type is RollingUpdate when maxSurge or maxUnavailable is given, otherwise
+// Recreate if recreateByDefault, otherwise left to the API server default (RollingUpdate).
+func deploymentStrategy(spec *kaosv1alpha1.DeploymentStrategy, recreateByDefault bool) appsv1.DeploymentStrategy {
+	strategy := appsv1.DeploymentStrategy{}
+	rolling := spec != nil && (spec.MaxSurge != nil || spec.MaxUnavailable != nil)
+	if spec != nil {
+		strategy.Type = spec.Type
+	}
+	if strategy.Type == "" && rolling {
+		strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
+	} else if strategy.Type == "" && recreateByDefault {
+		strategy.Type = appsv1.RecreateDeploymentStrategyType
+	}
+
+	if rolling && strategy.Type == appsv1.RollingUpdateDeploymentStrategyType {
+		strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{
+			MaxSurge:       spec.MaxSurge,
+			MaxUnavailable: spec.MaxUnavailable,
+		}
+	}
+	return strategy
+}
+
+// deploymentStrategyChanged reports whether the current strategy differs from the desired
+// one. Both are compared with the API server defaults filled in, so an unset desired
+// strategy matches the defaulted strategy of an existing Deployment.
+func deploymentStrategyChanged(current, desired appsv1.DeploymentStrategy) bool {
+	return !equality.Semantic.DeepEqual(defaultedStrategy(current), defaultedStrategy(desired))
+}
+
+// defaultedStrategy applies the Deployment strategy defaults (RollingUpdate, 25% maxSurge
+// and maxUnavailable)
+func defaultedStrategy(strategy appsv1.DeploymentStrategy) appsv1.DeploymentStrategy {
+	if strategy.Type == "" {
+		strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
+	}
+	if strategy.Type != appsv1.RollingUpdateDeploymentStrategyType {
+		return appsv1.DeploymentStrategy{Type: strategy.Type}
+	}
+
+	defaultValue := intstr.FromString("25%")
+	rolling := appsv1.RollingUpdateDeployment{MaxSurge: &defaultValue, MaxUnavailable: &defaultValue}
+	if strategy.RollingUpdate != nil {
+		if strategy.RollingUpdate.MaxSurge != nil {
+			rolling.MaxSurge = strategy.RollingUpdate.MaxSurge
+		}
+		if strategy.RollingUpdate.MaxUnavailable != nil {
+			rolling.MaxUnavailable = strategy.RollingUpdate.MaxUnavailable
+		}
+	}
+	strategy.RollingUpdate = &rolling
+	return strategy
+}
+
+// defaultProgressDeadlineSeconds matches the Deployment default, so a rollout that makes
+// no progress for 10 minutes reports Progressing=False
+const defaultProgressDeadlineSeconds int32 = 600
+
+// progressDeadlineSeconds returns the resource's progressDeadlineSeconds or the default
+func progressDeadlineSeconds(seconds *int32) *int32 {
+	deadline := defaultProgressDeadlineSeconds
+	if seconds != nil {
+		deadline = *seconds
+	}
+	return &deadline
+}
+
+// minReadySeconds returns the resource's minReadySeconds, or 0 when unset
+func minReadySeconds(seconds *int32) int32 {
+	if seconds == nil {
+		return 0
+	}
+	return *seconds
+}
+
+// deploymentRolloutChanged reports whether the rollout settings outside the pod template
+// (strategy, minReadySeconds, progressDeadlineSeconds) differ from the desired Deployment
+func deploymentRolloutChanged(current, desired *appsv1.Deployment) bool {
+	return deploymentStrategyChanged(current.Spec.Strategy, desired.Spec.Strategy) ||
+		current.Spec.MinReadySeconds != desired.Spec.MinReadySeconds ||
+		!equality.Semantic.DeepEqual(current.Spec.ProgressDeadlineSeconds, desired.Spec.ProgressDeadlineSeconds)
+}
+
+// setDeploymentRollout copies the desired rollout settings onto the current Deployment
+func setDeploymentRollout(current, desired *appsv1.Deployment) {
+	current.Spec.Strategy = desired.Spec.Strategy
+	current.Spec.MinReadySeconds = desired.Spec.MinReadySeconds
+	current.Spec.ProgressDeadlineSeconds = desired.Spec.ProgressDeadlineSeconds
+}
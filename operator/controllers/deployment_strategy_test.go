@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("deployment strategy", func() {
+	ginkgo.BeforeEach(func() {
+		setDefaultAgentImage()
+		setEnv("DEFAULT_OLLAMA_IMAGE", "alpine/ollama:test")
+	})
+
+	hostedModelAPI := func(persist *kaosv1alpha1.PersistModelsConfig, strategy *kaosv1alpha1.DeploymentStrategy) *kaosv1alpha1.ModelAPI {
+		return &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "ollama", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:               kaosv1alpha1.ModelAPIModeHosted,
+				HostedConfig:       &kaosv1alpha1.HostedConfig{Model: "smollm2:135m", PersistModels: persist},
+				DeploymentStrategy: strategy,
+			},
+		}
+	}
+
+	ginkgo.It("uses Recreate for a Hosted ModelAPI with persistence enabled", func() {
+		deployment, _, err := (&ModelAPIReconciler{}).constructDeployment(hostedModelAPI(&kaosv1alpha1.PersistModelsConfig{}, nil))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(deployment.Spec.Strategy.Type).To(gomega.Equal(appsv1.RecreateDeploymentStrategyType))
+		gomega.Expect(deployment.Spec.Strategy.RollingUpdate).To(gomega.BeNil())
+	})
+
+	ginkgo.It("lets deploymentStrategy override the default", func() {
+		maxSurge := intstr.FromInt32(0)
+		maxUnavailable := intstr.FromInt32(1)
+		deployment, _, err := (&ModelAPIReconciler{}).constructDeployment(hostedModelAPI(
+			&kaosv1alpha1.PersistModelsConfig{AccessMode: corev1.ReadWriteMany},
+			&kaosv1alpha1.DeploymentStrategy{MaxSurge: &maxSurge, MaxUnavailable: &maxUnavailable},
+		))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(deployment.Spec.Strategy.Type).To(gomega.Equal(appsv1.RollingUpdateDeploymentStrategyType))
+		gomega.Expect(deployment.Spec.Strategy.RollingUpdate.MaxSurge).To(gomega.Equal(&maxSurge))
+		gomega.Expect(deployment.Spec.Strategy.RollingUpdate.MaxUnavailable).To(gomega.Equal(&maxUnavailable))
+	})
+
+	ginkgo.It("applies deploymentStrategy to Agent Deployments", func() {
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:           "llm",
+				Model:              "mock-model",
+				DeploymentStrategy: &kaosv1alpha1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			},
+		}
+		modelapi := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec:       kaosv1alpha1.ModelAPISpec{Mode: kaosv1alpha1.ModelAPIModeProxy},
+			Status:     kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000"},
+		}
+		deployment, _, err := (&AgentReconciler{}).constructDeployment(agent, []*kaosv1alpha1.ModelAPI{modelapi}, nil, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(deployment.Spec.Strategy.Type).To(gomega.Equal(appsv1.RecreateDeploymentStrategyType))
+	})
+
+	ginkgo.It("treats an unset strategy as the API server default when comparing", func() {
+		percent := intstr.FromString("25%")
+		defaulted := appsv1.DeploymentStrategy{
+			Type:          appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDeployment{MaxSurge: &percent, MaxUnavailable: &percent},
+		}
+		gomega.Expect(deploymentStrategyChanged(defaulted, appsv1.DeploymentStrategy{})).To(gomega.BeFalse())
+		gomega.Expect(deploymentStrategyChanged(defaulted, deploymentStrategy(nil, true))).To(gomega.BeTrue())
+
+		maxSurge := intstr.FromInt32(2)
+		desired := deploymentStrategy(&kaosv1alpha1.DeploymentStrategy{MaxSurge: &maxSurge}, false)
+		gomega.Expect(deploymentStrategyChanged(defaulted, desired)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("propagates minReadySeconds and progressDeadlineSeconds", func() {
+		minReady := int32(30)
+		deadline := int32(900)
+		modelapi := hostedModelAPI(nil, nil)
+		modelapi.Spec.MinReadySeconds = &minReady
+		modelapi.Spec.ProgressDeadlineSeconds = &deadline
+
+		deployment, _, err := (&ModelAPIReconciler{}).constructDeployment(modelapi)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(deployment.Spec.MinReadySeconds).To(gomega.Equal(minReady))
+		gomega.Expect(*deployment.Spec.ProgressDeadlineSeconds).To(gomega.Equal(deadline))
+
+		// The deadline defaults to 600s so stuck rollouts always surface
+		deployment, _, err = (&ModelAPIReconciler{}).constructDeployment(hostedModelAPI(nil, nil))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(deployment.Spec.MinReadySeconds).To(gomega.BeZero())
+		gomega.Expect(*deployment.Spec.ProgressDeadlineSeconds).To(gomega.Equal(defaultProgressDeadlineSeconds))
+	})
+})
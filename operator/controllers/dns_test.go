@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+var _ = ginkgo.Describe("ModelAPI DNS settings", func() {
+	ginkgo.BeforeEach(func() {
+		setEnv("DEFAULT_LITELLM_IMAGE", "litellm:test")
+	})
+
+	proxyModelAPI := func() *kaosv1alpha1.ModelAPI {
+		return &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "onprem", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models:  []string{"openai/llama-3"},
+					APIBase: "http://llm.corp.internal:8080",
+				},
+			},
+		}
+	}
+
+	ginkgo.It("applies hostAliases and DNS settings to the pod template", func() {
+		modelapi := proxyModelAPI()
+		plain, _, err := (&ModelAPIReconciler{}).constructDeployment(modelapi)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		ndots := "2"
+		modelapi.Spec.HostAliases = []corev1.HostAlias{{IP: "10.0.0.12", Hostnames: []string{"llm.corp.internal"}}}
+		modelapi.Spec.DNSPolicy = corev1.DNSNone
+		modelapi.Spec.DNSConfig = &corev1.PodDNSConfig{
+			Nameservers: []string{"10.0.0.53"},
+			Searches:    []string{"corp.internal"},
+			Options:     []corev1.PodDNSConfigOption{{Name: "ndots", Value: &ndots}},
+		}
+		deployment, _, err := (&ModelAPIReconciler{}).constructDeployment(modelapi)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		spec := deployment.Spec.Template.Spec
+		gomega.Expect(spec.HostAliases).To(gomega.ConsistOf(corev1.HostAlias{IP: "10.0.0.12", Hostnames: []string{"llm.corp.internal"}}))
+		gomega.Expect(spec.DNSPolicy).To(gomega.Equal(corev1.DNSNone))
+		gomega.Expect(spec.DNSConfig.Nameservers).To(gomega.Equal([]string{"10.0.0.53"}))
+
+		// Changing the DNS settings rolls the pods
+		gomega.Expect(deployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]).NotTo(
+			gomega.Equal(plain.Spec.Template.Annotations[util.PodSpecHashAnnotation]))
+	})
+
+	ginkgo.It("leaves the pod DNS defaults untouched when unset", func() {
+		deployment, _, err := (&ModelAPIReconciler{}).constructDeployment(proxyModelAPI())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		spec := deployment.Spec.Template.Spec
+		gomega.Expect(spec.HostAliases).To(gomega.BeEmpty())
+		gomega.Expect(spec.DNSPolicy).To(gomega.BeEmpty())
+		gomega.Expect(spec.DNSConfig).To(gomega.BeNil())
+	})
+})
@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	mcpruntime "github.com/axsaucedo/kaos/operator/pkg/runtime"
+)
+
+var _ = ginkgo.Describe("egress proxy env", func() {
+	ginkgo.BeforeEach(func() {
+		setDefaultAgentImage()
+		for key, value := range map[string]string{
+			"DEFAULT_HTTP_PROXY":   "http://proxy.corp:3128",
+			"DEFAULT_HTTPS_PROXY":  "http://proxy.corp:3128",
+			"DEFAULT_NO_PROXY":     "corp.internal",
+			"DEFAULT_OLLAMA_IMAGE": "ollama:test",
+		} {
+			setEnv(key, value)
+		}
+	})
+
+	// expectProxyEnv asserts the proxy env vars and returns the NO_PROXY entries
+	expectProxyEnv := func(env []corev1.EnvVar, httpsProxy string) []string {
+		gomega.Expect(env).To(gomega.ContainElement(corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy.corp:3128"}))
+		gomega.Expect(env).To(gomega.ContainElement(corev1.EnvVar{Name: "HTTPS_PROXY", Value: httpsProxy}))
+		for _, e := range env {
+			if e.Name == "NO_PROXY" {
+				entries := strings.Split(e.Value, ",")
+				gomega.Expect(entries).To(gomega.ContainElements(".svc", "cluster.local", "corp.internal"))
+				return entries
+			}
+		}
+		ginkgo.Fail("NO_PROXY is not set")
+		return nil
+	}
+
+	ginkgo.It("injects the operator defaults into the agent container", func() {
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "mock-model"},
+		}
+		modelapis := []*kaosv1alpha1.ModelAPI{{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Status:     kaosv1alpha1.ModelAPIStatus{Endpoint: "http://modelapi-llm.default.svc.cluster.local:8000", Ready: true},
+		}}
+		spec, _, err := (&AgentReconciler{}).constructPodSpec(agent, modelapis, nil, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		expectProxyEnv(spec.Containers[0].Env, "http://proxy.corp:3128")
+	})
+
+	ginkgo.It("applies per-resource overrides to the model-api and model pull containers", func() {
+		modelapi := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "ollama", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:         kaosv1alpha1.ModelAPIModeHosted,
+				HostedConfig: &kaosv1alpha1.HostedConfig{Model: "smollm2:135m"},
+				EgressProxy: &kaosv1alpha1.EgressProxyConfig{
+					HTTPSProxy: "http://secure-proxy.corp:3128",
+					NoProxy:    []string{"registry.corp.internal"},
+				},
+			},
+		}
+		deployment, _, err := (&ModelAPIReconciler{}).constructDeployment(modelapi)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		spec := deployment.Spec.Template.Spec
+		noProxy := expectProxyEnv(spec.Containers[0].Env, "http://secure-proxy.corp:3128")
+		gomega.Expect(noProxy).To(gomega.ContainElement("registry.corp.internal"))
+		expectProxyEnv(spec.InitContainers[0].Env, "http://secure-proxy.corp:3128")
+	})
+
+	ginkgo.It("injects the proxy into the mcp-server container and lets container.env win", func() {
+		mcpserver := &kaosv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "tools", Namespace: "default"},
+			Spec: kaosv1alpha1.MCPServerSpec{
+				Runtime: mcpruntime.CustomRuntime,
+				Container: &kaosv1alpha1.ContainerOverride{
+					Image: "tools:test",
+					Env:   []corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://team-proxy:8080"}},
+				},
+			},
+		}
+		container, _, err := (&MCPServerReconciler{}).constructContainerFromRuntime(context.Background(), mcpserver)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		expectProxyEnv(container.Env, "http://team-proxy:8080")
+	})
+})
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("agent status.endpoints", func() {
+	var (
+		ctx   context.Context
+		c     client.Client
+		r     *AgentReconciler
+		agent *kaosv1alpha1.Agent
+		key   types.NamespacedName
+	)
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		setDefaultAgentImage()
+		for name, value := range map[string]string{
+			"GATEWAY_API_ENABLED": "true",
+			"GATEWAY_NAME":        "kaos-gateway",
+			"GATEWAY_NAMESPACE":   "kaos-system",
+			"GATEWAY_HOST":        "kaos.example.com",
+		} {
+			setEnv(name, value)
+		}
+
+		scheme := runtime.NewScheme()
+		gomega.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+		gomega.Expect(kaosv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+		gomega.Expect(gatewayv1.Install(scheme)).To(gomega.Succeed())
+
+		modelapi := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "team-a"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:        kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"mock-model"}},
+			},
+			Status: kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "coordinator", Namespace: "team-a"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "mock-model"},
+		}
+		key = types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}
+
+		c = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(modelapi, agent).
+			WithStatusSubresource(&kaosv1alpha1.ModelAPI{}, &kaosv1alpha1.Agent{}).
+			Build()
+		r = &AgentReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+	})
+
+	reconcile := func() *kaosv1alpha1.Agent {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		stored := &kaosv1alpha1.Agent{}
+		gomega.Expect(c.Get(ctx, key, stored)).To(gomega.Succeed())
+		return stored
+	}
+
+	ginkgo.It("fails with InvalidGatewayRoute and omits the gateway endpoints when the route is invalid", func() {
+		gomega.Expect(c.Get(ctx, key, agent)).To(gomega.Succeed())
+		agent.Spec.GatewayRoute = &kaosv1alpha1.GatewayRoute{Gateways: []kaosv1alpha1.GatewayTarget{
+			{Name: "internal", Host: "kaos.internal"},
+			{Name: "internal", Namespace: "edge", Host: "kaos.example.com"},
+		}}
+		gomega.Expect(c.Update(ctx, agent)).To(gomega.Succeed())
+
+		stored := reconcile()
+		gomega.Expect(stored.Status.Phase).To(gomega.Equal("Failed"))
+		gomega.Expect(stored.Status.Message).To(gomega.ContainSubstring("gateway internal is listed more than once"))
+		gomega.Expect(meta.FindStatusCondition(stored.Status.Conditions, ConditionTypeReady).Reason).To(gomega.Equal("InvalidGatewayRoute"))
+		gomega.Expect(stored.Status.Endpoints).To(gomega.HaveKey("internal"))
+		gomega.Expect(stored.Status.Endpoints).NotTo(gomega.HaveKey("gateway"))
+		gomega.Expect(stored.Status.Endpoints).NotTo(gomega.HaveKey("gateway-internal"))
+		gomega.Expect(r.Recorder.(*record.FakeRecorder).Events).To(gomega.Receive(gomega.ContainSubstring("InvalidGatewayRoute")))
+	})
+})
@@ -0,0 +1,102 @@
+package controllers
+
+import (
+	"fmt"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+var _ = ginkgo.Describe("agent env ordering", func() {
+	var (
+		r         *AgentReconciler
+		agent     *kaosv1alpha1.Agent
+		modelapis []*kaosv1alpha1.ModelAPI
+	)
+
+	ginkgo.BeforeEach(func() {
+		setDefaultAgentImage()
+
+		r = &AgentReconciler{}
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI: "llm",
+				Model:    "mock-model",
+				MCPServerRefs: []kaosv1alpha1.AgentMCPServerRef{
+					{Name: "zeta", Prefix: "z"},
+					{Name: "alpha", AllowTools: []string{"echo"}},
+				},
+			},
+		}
+		modelapis = []*kaosv1alpha1.ModelAPI{{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Status:     kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}}
+	})
+
+	// buildMaps returns fresh maps each call so Go's randomised iteration order
+	// differs between calls, as it does between reconciles
+	buildMaps := func() (map[string]string, map[string]string) {
+		mcpServers := map[string]string{}
+		peerAgents := map[string]string{}
+		for i := 0; i < 16; i++ {
+			mcpServers[fmt.Sprintf("mcp-%02d", i)] = fmt.Sprintf("http://mcp-%02d:8000", i)
+			peerAgents[fmt.Sprintf("peer-%02d", i)] = fmt.Sprintf("http://peer-%02d:8000", i)
+		}
+		return mcpServers, peerAgents
+	}
+
+	ginkgo.It("produces identical env ordering and pod-spec hash for the same spec", func() {
+		mcpServers, peerAgents := buildMaps()
+		first, _, err := r.constructPodSpec(agent, modelapis, mcpServers, peerAgents)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		for i := 0; i < 20; i++ {
+			mcpServers, peerAgents := buildMaps()
+			next, _, err := r.constructPodSpec(agent, modelapis, mcpServers, peerAgents)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(next.Containers[0].Env).To(gomega.Equal(first.Containers[0].Env))
+			gomega.Expect(util.ComputePodSpecHash(next)).To(gomega.Equal(util.ComputePodSpecHash(first)))
+		}
+	})
+
+	ginkgo.It("does not change the hash when mcpServerRefs are reordered", func() {
+		mcpServers := map[string]string{"alpha": "http://alpha:8000", "zeta": "http://zeta:8000"}
+		first, _, err := r.constructPodSpec(agent, modelapis, mcpServers, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		refs := agent.Spec.MCPServerRefs
+		agent.Spec.MCPServerRefs = []kaosv1alpha1.AgentMCPServerRef{refs[1], refs[0]}
+		reordered, _, err := r.constructPodSpec(agent, modelapis, mcpServers, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		gomega.Expect(util.ComputePodSpecHash(reordered)).To(gomega.Equal(util.ComputePodSpecHash(first)))
+	})
+
+	ginkgo.It("lets a user MODEL_API_URL win with a single entry", func() {
+		agent.Spec.Container = &kaosv1alpha1.ContainerOverride{Env: []corev1.EnvVar{
+			{Name: "MODEL_API_URL", Value: "http://stale:8000"},
+			{Name: "MODEL_API_URL", Value: "http://override:8000"},
+		}}
+		podSpec, _, err := r.constructPodSpec(agent, modelapis, nil, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		var values []string
+		for _, env := range podSpec.Containers[0].Env {
+			if env.Name == "MODEL_API_URL" {
+				values = append(values, env.Value)
+			}
+		}
+		gomega.Expect(values).To(gomega.Equal([]string{"http://override:8000"}))
+
+		// User entries come after the generated ones
+		env := podSpec.Containers[0].Env
+		gomega.Expect(env[len(env)-1].Name).To(gomega.Equal("MODEL_API_URL"))
+	})
+})
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+// ConditionTypeEnvOverridden is the status condition listing the operator-generated env
+// vars that container.env overrides
+const ConditionTypeEnvOverridden = "EnvOverridden"
+
+// ConditionTypeMemoryNotShared is the status condition reporting that an agent runs more
+// than one replica or worker on the inmemory memory backend
+const ConditionTypeMemoryNotShared = "MemoryNotShared"
+
+// finalizeEnv applies the user's container.env on top of the generated env, leaving one
+// entry per name, and returns the names of the generated vars the user overrides
+func finalizeEnv(env, userEnv []corev1.EnvVar) ([]corev1.EnvVar, []string) {
+	return util.PreserveUserEnv(env, userEnv), util.OverriddenEnvNames(env, userEnv)
+}
+
+// recordEnvOverrides sets the EnvOverridden condition of obj from the overridden env var
+// names returned by its construct function
+func recordEnvOverrides(recorder record.EventRecorder, obj client.Object, conditions *[]metav1.Condition, overridden []string) {
+	var message string
+	if len(overridden) > 0 {
+		message = fmt.Sprintf("container.env overrides operator-generated env vars: %s", strings.Join(overridden, ", "))
+	}
+	setWarningCondition(recorder, obj, conditions, ConditionTypeEnvOverridden, message)
+}
+
+// setWarningCondition records a configuration warning as a True condition of
+// conditionType, or removes the condition when message is empty. The Warning event,
+// with the condition type as reason, is only emitted when the message changes, so a
+// warning that persists across reconciles is reported once.
+func setWarningCondition(recorder record.EventRecorder, obj client.Object, conditions *[]metav1.Condition, conditionType, message string) {
+	if message == "" {
+		meta.RemoveStatusCondition(conditions, conditionType)
+		return
+	}
+
+	existing := meta.FindStatusCondition(*conditions, conditionType)
+	changed := existing == nil || existing.Message != message
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: obj.GetGeneration(),
+		Reason:             conditionType,
+		Message:            message,
+	})
+	if changed && recorder != nil {
+		recorder.Event(obj, corev1.EventTypeWarning, conditionType, message)
+	}
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("finalizeEnv", func() {
+	ginkgo.It("dedupes overlapping env across sources and returns the user overrides", func() {
+		// Telemetry, log level, runtime params and user env concatenated
+		env := []corev1.EnvVar{
+			{Name: "MCP_TOOLS_STRING", Value: "tools"},
+			{Name: "OTEL_SERVICE_NAME", Value: "tools"},
+			{Name: "OTEL_SERVICE_NAME", Value: "tools-2"},
+			{Name: "LOG_LEVEL", Value: "INFO"},
+		}
+		userEnv := []corev1.EnvVar{
+			{Name: "LOG_LEVEL", Value: "DEBUG"},
+			{Name: "EXTRA", Value: "1"},
+		}
+
+		got, overridden := finalizeEnv(env, userEnv)
+		gomega.Expect(got).To(gomega.Equal([]corev1.EnvVar{
+			{Name: "MCP_TOOLS_STRING", Value: "tools"},
+			{Name: "OTEL_SERVICE_NAME", Value: "tools-2"},
+			{Name: "LOG_LEVEL", Value: "DEBUG"},
+			{Name: "EXTRA", Value: "1"},
+		}))
+		gomega.Expect(overridden).To(gomega.Equal([]string{"LOG_LEVEL"}))
+	})
+
+	ginkgo.It("returns no overrides when the user overrides nothing", func() {
+		got, overridden := finalizeEnv([]corev1.EnvVar{{Name: "A", Value: "1"}}, []corev1.EnvVar{{Name: "B", Value: "2"}})
+		gomega.Expect(got).To(gomega.HaveLen(2))
+		gomega.Expect(overridden).To(gomega.BeEmpty())
+	})
+})
+
+var _ = ginkgo.Describe("recordEnvOverrides", func() {
+	var (
+		recorder *record.FakeRecorder
+		obj      *kaosv1alpha1.MCPServer
+	)
+
+	ginkgo.BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+		obj = &kaosv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Name: "tools", Namespace: "default"}}
+	})
+
+	ginkgo.It("warns once while the overridden names stay the same", func() {
+		recordEnvOverrides(recorder, obj, &obj.Status.Conditions, []string{"LOG_LEVEL"})
+		gomega.Expect(recorder.Events).To(gomega.Receive(gomega.Equal(
+			"Warning EnvOverridden container.env overrides operator-generated env vars: LOG_LEVEL")))
+		condition := meta.FindStatusCondition(obj.Status.Conditions, ConditionTypeEnvOverridden)
+		gomega.Expect(condition).NotTo(gomega.BeNil())
+		gomega.Expect(condition.Status).To(gomega.Equal(metav1.ConditionTrue))
+
+		recordEnvOverrides(recorder, obj, &obj.Status.Conditions, []string{"LOG_LEVEL"})
+		gomega.Expect(recorder.Events).NotTo(gomega.Receive())
+	})
+
+	ginkgo.It("warns again when the overridden names change", func() {
+		recordEnvOverrides(recorder, obj, &obj.Status.Conditions, []string{"LOG_LEVEL"})
+		gomega.Expect(recorder.Events).To(gomega.Receive())
+
+		recordEnvOverrides(recorder, obj, &obj.Status.Conditions, []string{"LOG_LEVEL", "OTEL_SERVICE_NAME"})
+		gomega.Expect(recorder.Events).To(gomega.Receive(gomega.Equal(
+			"Warning EnvOverridden container.env overrides operator-generated env vars: LOG_LEVEL, OTEL_SERVICE_NAME")))
+	})
+
+	ginkgo.It("clears the condition once nothing is overridden", func() {
+		recordEnvOverrides(recorder, obj, &obj.Status.Conditions, []string{"LOG_LEVEL"})
+		gomega.Expect(recorder.Events).To(gomega.Receive())
+
+		recordEnvOverrides(recorder, obj, &obj.Status.Conditions, nil)
+		gomega.Expect(meta.FindStatusCondition(obj.Status.Conditions, ConditionTypeEnvOverridden)).To(gomega.BeNil())
+		gomega.Expect(recorder.Events).NotTo(gomega.Receive())
+	})
+})
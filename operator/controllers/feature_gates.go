@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/axsaucedo/kaos/operator/pkg/features"
+)
+
+// warnFeatureGateDisabled logs and records a warning that field of obj is ignored
+// because feature is disabled, so a setting behind an off gate is not silently dropped
+func warnFeatureGateDisabled(ctx context.Context, recorder record.EventRecorder, obj client.Object, feature features.Feature, field string) {
+	message := fmt.Sprintf("%s is ignored while the %s feature gate is disabled; enable it with --feature-gates=%s=true", field, feature, feature)
+	log.FromContext(ctx).Info("WARNING: "+message, "name", obj.GetName())
+	if recorder != nil {
+		recorder.Event(obj, corev1.EventTypeWarning, "FeatureGateDisabled", message)
+	}
+}
@@ -0,0 +1,15 @@
+package controllers
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// freezeDeploymentAnnotation is a break-glass switch that stops the controller from
+// updating the resource's existing Deployment, so a manual hotfix is not reverted.
+// Status and the other managed resources are still reconciled.
+const freezeDeploymentAnnotation = "kaos.tools/freeze-deployment"
+
+// deploymentFrozen reports whether obj has the freeze-deployment annotation set to "true"
+func deploymentFrozen(obj client.Object) bool {
+	return obj.GetAnnotations()[freezeDeploymentAnnotation] == "true"
+}
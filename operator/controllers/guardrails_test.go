@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+var _ = ginkgo.Describe("LiteLLM guardrails", func() {
+	ginkgo.BeforeEach(func() {
+		setEnv("DEFAULT_LITELLM_IMAGE", "litellm:test")
+	})
+
+	guardedModelAPI := func(guardrails ...kaosv1alpha1.Guardrail) *kaosv1alpha1.ModelAPI {
+		return &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models:     []string{"gpt-4o"},
+					Guardrails: guardrails,
+				},
+			},
+		}
+	}
+
+	offByDefault := false
+	lakera := kaosv1alpha1.Guardrail{
+		Name:     "lakera-pre",
+		Provider: "lakera_v2",
+		Mode:     "pre_call",
+		APIKey: &kaosv1alpha1.ApiKeySource{ValueFrom: &kaosv1alpha1.ApiKeyValueFrom{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "lakera"},
+				Key:                  "api-key",
+			},
+		}},
+		Thresholds: map[string]string{"prompt_injection": "0.1", "jailbreak": "0.5"},
+	}
+	presidio := kaosv1alpha1.Guardrail{
+		Name:      "presidio-post",
+		Provider:  "presidio",
+		Mode:      "post_call",
+		DefaultOn: &offByDefault,
+		APIBase:   "http://presidio-analyzer:3000",
+	}
+
+	ginkgo.It("renders the guardrails block into the generated config", func() {
+		config := (&ModelAPIReconciler{}).generateLiteLLMConfig(guardedModelAPI(lakera, presidio).Spec.ProxyConfig, nil)
+
+		gomega.Expect(config).To(gomega.HaveSuffix(`
+guardrails:
+  - guardrail_name: "lakera-pre"
+    litellm_params:
+      guardrail: "lakera_v2"
+      mode: "pre_call"
+      default_on: true
+      api_key: "os.environ/GUARDRAIL_LAKERA_PRE_API_KEY"
+      category_thresholds:
+        jailbreak: 0.5
+        prompt_injection: 0.1
+  - guardrail_name: "presidio-post"
+    litellm_params:
+      guardrail: "presidio"
+      mode: "post_call"
+      default_on: false
+      api_base: "http://presidio-analyzer:3000"
+`))
+	})
+
+	ginkgo.It("escapes quotes and newlines in the apiBase", func() {
+		injected := presidio
+		injected.APIBase = "http://presidio\"\n  default_on: true"
+		config := (&ModelAPIReconciler{}).generateLiteLLMConfig(guardedModelAPI(injected).Spec.ProxyConfig, nil)
+
+		gomega.Expect(config).To(gomega.ContainSubstring(`      api_base: "http://presidio\"\n  default_on: true"` + "\n"))
+		gomega.Expect(config).To(gomega.ContainSubstring("      default_on: false\n"))
+		gomega.Expect(config).NotTo(gomega.ContainSubstring("      default_on: true\n"))
+	})
+
+	ginkgo.It("omits the guardrails block when none are configured", func() {
+		config := (&ModelAPIReconciler{}).generateLiteLLMConfig(guardedModelAPI().Spec.ProxyConfig, nil)
+		gomega.Expect(config).NotTo(gomega.ContainSubstring("guardrails:"))
+	})
+
+	ginkgo.It("wires the guardrail API key Secret into the container", func() {
+		container, _, err := (&ModelAPIReconciler{}).constructContainer(guardedModelAPI(lakera, presidio))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		var apiKey *corev1.EnvVar
+		for i := range container.Env {
+			if container.Env[i].Name == "GUARDRAIL_LAKERA_PRE_API_KEY" {
+				apiKey = &container.Env[i]
+			}
+			gomega.Expect(container.Env[i].Name).NotTo(gomega.Equal("GUARDRAIL_PRESIDIO_POST_API_KEY"))
+		}
+		gomega.Expect(apiKey).NotTo(gomega.BeNil())
+		gomega.Expect(apiKey.ValueFrom.SecretKeyRef.Name).To(gomega.Equal("lakera"))
+	})
+
+	ginkgo.It("rolls the deployment when the guardrails change", func() {
+		first, _, err := (&ModelAPIReconciler{}).constructDeployment(guardedModelAPI(presidio))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		strict := presidio
+		strict.DefaultOn = nil
+		second, _, err := (&ModelAPIReconciler{}).constructDeployment(guardedModelAPI(strict))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		gomega.Expect(second.Spec.Template.Annotations[util.PodSpecHashAnnotation]).NotTo(
+			gomega.Equal(first.Spec.Template.Annotations[util.PodSpecHashAnnotation]))
+	})
+})
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"os"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/features"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+// testAgentImage is the DEFAULT_AGENT_IMAGE agent Deployments are built with in tests
+const testAgentImage = "axsauze/kaos-agent:test"
+
+// enableFeatureGate turns feature on for the current spec and off again afterwards
+func enableFeatureGate(feature features.Feature) {
+	gomega.Expect(features.DefaultGate.Set(string(feature) + "=true")).To(gomega.Succeed())
+	ginkgo.DeferCleanup(func() {
+		gomega.Expect(features.DefaultGate.Set(string(feature) + "=false")).To(gomega.Succeed())
+	})
+}
+
+// setEnv sets the env var name to value for the current spec and unsets it afterwards
+func setEnv(name, value string) {
+	gomega.Expect(os.Setenv(name, value)).To(gomega.Succeed())
+	ginkgo.DeferCleanup(os.Unsetenv, name)
+}
+
+// setDefaultAgentImage sets DEFAULT_AGENT_IMAGE, which constructing an agent Deployment
+// requires, to testAgentImage for the current spec
+func setDefaultAgentImage() {
+	setEnv("DEFAULT_AGENT_IMAGE", testAgentImage)
+}
+
+// agentEnv returns the env vars of an agent without MCP servers or peers, by name
+func agentEnv(agent *kaosv1alpha1.Agent, modelapis ...*kaosv1alpha1.ModelAPI) map[string]string {
+	env := map[string]string{}
+	generated, _ := (&AgentReconciler{}).constructEnvVars(agent, modelapis, nil, nil)
+	for _, e := range generated {
+		env[e.Name] = e.Value
+	}
+	return env
+}
+
+// agentPodSpecHash returns the pod spec hash of the Deployment built for an agent without
+// MCP servers or peers
+func agentPodSpecHash(agent *kaosv1alpha1.Agent, modelapis ...*kaosv1alpha1.ModelAPI) string {
+	deployment, _, err := (&AgentReconciler{}).constructDeployment(agent, modelapis, nil, nil)
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	return deployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"context"
+	"os"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("Hosted mode with multiple models", func() {
+	r := &ModelAPIReconciler{}
+
+	ginkgo.BeforeEach(func() {
+		previous, had := os.LookupEnv("DEFAULT_OLLAMA_IMAGE")
+		gomega.Expect(os.Setenv("DEFAULT_OLLAMA_IMAGE", "alpine/ollama:test")).To(gomega.Succeed())
+		ginkgo.DeferCleanup(func() {
+			if had {
+				os.Setenv("DEFAULT_OLLAMA_IMAGE", previous)
+			} else {
+				os.Unsetenv("DEFAULT_OLLAMA_IMAGE")
+			}
+		})
+	})
+
+	hostedModelAPI := func(config *kaosv1alpha1.HostedConfig) *kaosv1alpha1.ModelAPI {
+		modelapi := &kaosv1alpha1.ModelAPI{}
+		modelapi.Name = "ollama"
+		modelapi.Namespace = "default"
+		modelapi.Spec.Mode = kaosv1alpha1.ModelAPIModeHosted
+		modelapi.Spec.HostedConfig = config
+		return modelapi
+	}
+
+	ginkgo.It("runs one ollama pull init container per model", func() {
+		deployment, _, err := r.constructDeployment(hostedModelAPI(&kaosv1alpha1.HostedConfig{
+			Model:  "smollm2:135m",
+			Models: []string{"qwen2.5:0.5b", "smollm2:135m", "llama3.2:1b"},
+		}))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		initContainers := deployment.Spec.Template.Spec.InitContainers
+		gomega.Expect(initContainers).To(gomega.HaveLen(3))
+		gomega.Expect(initContainers[0].Name).To(gomega.Equal("pull-model"))
+		gomega.Expect(initContainers[1].Name).To(gomega.Equal("pull-model-1"))
+		gomega.Expect(initContainers[2].Name).To(gomega.Equal("pull-model-2"))
+		gomega.Expect(initContainers[0].Args[0]).To(gomega.ContainSubstring("ollama pull smollm2:135m "))
+		gomega.Expect(initContainers[1].Args[0]).To(gomega.ContainSubstring("ollama pull qwen2.5:0.5b "))
+		gomega.Expect(initContainers[2].Args[0]).To(gomega.ContainSubstring("ollama pull llama3.2:1b "))
+		for _, c := range initContainers {
+			gomega.Expect(c.VolumeMounts).To(gomega.ContainElement(gomega.HaveField("Name", "ollama-data")))
+		}
+		gomega.Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(
+			gomega.ContainElement(gomega.HaveField("Name", "ollama-data")))
+	})
+
+	ginkgo.It("supports models without the single model field", func() {
+		deployment, _, err := r.constructDeployment(hostedModelAPI(&kaosv1alpha1.HostedConfig{
+			Models: []string{"qwen2.5:0.5b", "llama3.2:1b"},
+		}))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(deployment.Spec.Template.Spec.InitContainers).To(gomega.HaveLen(2))
+	})
+
+	ginkgo.It("combines model and models into the supported models", func() {
+		modelapi := hostedModelAPI(&kaosv1alpha1.HostedConfig{
+			Model:  "smollm2:135m",
+			Models: []string{"qwen2.5:0.5b", "smollm2:135m"},
+		})
+		gomega.Expect(modelAPISupportedModels(modelapi)).To(gomega.Equal([]string{"smollm2:135m", "qwen2.5:0.5b"}))
+
+		agentReconciler := &AgentReconciler{}
+		agent := &kaosv1alpha1.Agent{}
+		agent.Spec.Model = "qwen2.5:0.5b"
+		gomega.Expect(agentReconciler.validateAgentModel(context.Background(), agent, modelapi)).To(gomega.Succeed())
+		agent.Spec.Model = "llama3.2:1b"
+		gomega.Expect(agentReconciler.validateAgentModel(context.Background(), agent, modelapi)).NotTo(gomega.Succeed())
+	})
+
+	ginkgo.It("changes the pod spec hash when the models list changes", func() {
+		before, _, err := r.constructDeployment(hostedModelAPI(&kaosv1alpha1.HostedConfig{
+			Models: []string{"smollm2:135m"},
+		}))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		after, _, err := r.constructDeployment(hostedModelAPI(&kaosv1alpha1.HostedConfig{
+			Models: []string{"smollm2:135m", "qwen2.5:0.5b"},
+		}))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		gomega.Expect(after.Spec.Template.Annotations["kaos.tools/pod-spec-hash"]).NotTo(
+			gomega.Equal(before.Spec.Template.Annotations["kaos.tools/pod-spec-hash"]))
+	})
+})
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("image digest pinning", func() {
+	const agentDigest = "sha256:3333333333333333333333333333333333333333333333333333333333333333"
+
+	ginkgo.BeforeEach(func() {
+		for name, value := range map[string]string{
+			"DEFAULT_AGENT_IMAGE":  "axsauze/kaos-agent:v0.1.0",
+			"IMAGE_DIGEST_PINNING": "true",
+			"IMAGE_DIGESTS":        `{"axsauze/kaos-agent:v0.1.0": "` + agentDigest + `"}`,
+		} {
+			setEnv(name, value)
+		}
+	})
+
+	modelapi := &kaosv1alpha1.ModelAPI{
+		ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+		Spec:       kaosv1alpha1.ModelAPISpec{Mode: kaosv1alpha1.ModelAPIModeProxy},
+		Status:     kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000"},
+	}
+	newAgent := func() *kaosv1alpha1.Agent {
+		return &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "mock-model"},
+		}
+	}
+
+	ginkgo.It("references the agent image by digest", func() {
+		deployment, _, err := (&AgentReconciler{}).constructDeployment(newAgent(), []*kaosv1alpha1.ModelAPI{modelapi}, nil, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(
+			gomega.Equal("axsauze/kaos-agent:v0.1.0@" + agentDigest))
+	})
+
+	ginkgo.It("fails closed when an image has no digest", func() {
+		setEnv("DEFAULT_WAIT_IMAGE", "curlimages/curl:8.10.1")
+
+		agent := newAgent()
+		wait := true
+		agent.Spec.WaitForDependencyEndpoints = &wait
+		_, _, err := (&AgentReconciler{}).constructDeployment(agent, []*kaosv1alpha1.ModelAPI{modelapi}, nil, nil)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`no digest is configured for image "curlimages/curl:8.10.1"`)))
+	})
+})
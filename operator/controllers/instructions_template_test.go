@@ -0,0 +1,41 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("templated agent instructions", func() {
+	var (
+		agent     *kaosv1alpha1.Agent
+		modelapis []*kaosv1alpha1.ModelAPI
+	)
+
+	ginkgo.BeforeEach(func() {
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "researcher", Namespace: "team-a"},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI: "llm",
+				Model:    "mock-model",
+				Config: &kaosv1alpha1.AgentConfig{
+					Description:  "{{.Name}} agent",
+					Instructions: "You are {{.Name}} in {{.Namespace}} using {{.Model}}. Peers:{{range .Peers}} {{.}}{{end}}",
+				},
+				AgentNetwork: &kaosv1alpha1.AgentNetworkConfig{Access: []string{"writer", "reviewer"}},
+			},
+		}
+		modelapis = []*kaosv1alpha1.ModelAPI{{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "team-a"},
+			Status:     kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}}
+	})
+
+	ginkgo.It("renders the agent metadata into the env vars", func() {
+		env := agentEnv(agent, modelapis...)
+		gomega.Expect(env).To(gomega.HaveKeyWithValue("AGENT_DESCRIPTION", "researcher agent"))
+		gomega.Expect(env).To(gomega.HaveKeyWithValue("AGENT_INSTRUCTIONS", "You are researcher in team-a using mock-model. Peers: writer reviewer"))
+	})
+})
@@ -0,0 +1,137 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = Describe("Adopting existing resources", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	var (
+		agentName string
+		agent     *kaosv1alpha1.Agent
+		key       types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		modelAPIName := uniqueAgentName("adopt-modelapi")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+
+		agentName = uniqueAgentName("adopt-agent")
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+			},
+		}
+		key = types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+	})
+
+	// createOrphanDeployment creates the agent's Deployment as an earlier install without
+	// owner references would have left it
+	createOrphanDeployment := func(ownerReferences ...metav1.OwnerReference) {
+		labels := map[string]string{"app": "agent"}
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            key.Name,
+				Namespace:       namespace,
+				OwnerReferences: ownerReferences,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "agent", Image: "old:image"}},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+		DeferCleanup(func() {
+			k8sClient.Delete(ctx, deployment)
+		})
+	}
+
+	It("adopts an unowned Deployment and reconciles its spec", func() {
+		createOrphanDeployment()
+		createAgent(ctx, agent)
+
+		deployment := &appsv1.Deployment{}
+		Eventually(func() string {
+			if err := k8sClient.Get(ctx, key, deployment); err != nil {
+				return ""
+			}
+			return deployment.Spec.Template.Spec.Containers[0].Image
+		}, timeout, interval).Should(Equal("axsauze/kaos-agent:test"))
+		Expect(metav1.IsControlledBy(deployment, agent)).To(BeTrue())
+		Expect(deployment.Labels).To(HaveKeyWithValue("agent", agentName))
+		Expect(deployment.Spec.Template.Annotations).To(HaveKey("kaos.tools/pod-spec-hash"))
+	})
+
+	It("adopts an unowned Service", func() {
+		service := &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      key.Name,
+				Namespace: namespace,
+			},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{{Name: "http", Port: 8000}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, service)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, service)
+		}()
+		createAgent(ctx, agent)
+
+		Eventually(func() bool {
+			if err := k8sClient.Get(ctx, key, service); err != nil {
+				return false
+			}
+			return metav1.IsControlledBy(service, agent)
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	It("does not adopt a Deployment controlled by another owner", func() {
+		createOrphanDeployment(metav1.OwnerReference{
+			APIVersion: "kaos.tools/v1alpha1",
+			Kind:       "Agent",
+			Name:       "other",
+			UID:        "other-uid",
+			Controller: boolPtr(true),
+		})
+		createAgent(ctx, agent)
+
+		Eventually(func() bool {
+			updated := &kaosv1alpha1.Agent{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, updated); err != nil {
+				return false
+			}
+			return updated.Status.Phase == "Failed" &&
+				strings.Contains(updated.Status.Message, "controlled by Agent other")
+		}, timeout, interval).Should(BeTrue())
+
+		deployment := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, key, deployment)).To(Succeed())
+		Expect(metav1.GetControllerOf(deployment).UID).To(Equal(types.UID("other-uid")))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Image).To(Equal("old:image"))
+	})
+})
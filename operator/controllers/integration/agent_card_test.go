@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = Describe("Agent card summary", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	// createMCPServerWithTools creates an MCPServer and reports tools in its status
+	createMCPServerWithTools := func(name string, tools ...string) {
+		mcp := &kaosv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.MCPServerSpec{
+				Runtime: "python-string",
+				Params:  "def noop() -> str:\n    return ''",
+			},
+		}
+		Expect(k8sClient.Create(ctx, mcp)).To(Succeed())
+		DeferCleanup(func() {
+			k8sClient.Delete(ctx, mcp)
+		})
+		Eventually(func() error {
+			current := &kaosv1alpha1.MCPServer{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, current); err != nil {
+				return err
+			}
+			current.Status.AvailableTools = tools
+			return k8sClient.Status().Update(ctx, current)
+		}, timeout, interval).Should(Succeed())
+	}
+
+	It("reflects the tools of the referenced MCPServers in status", func() {
+		modelAPIName := uniqueAgentName("card-modelapi")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+		docs := uniqueMCPServerName("card-docs")
+		createMCPServerWithTools(docs, "search", "fetch", "delete")
+		calc := uniqueMCPServerName("card-calc")
+		createMCPServerWithTools(calc, "add")
+
+		agentName := uniqueAgentName("card-agent")
+		createAgent(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				MCPServers:          []string{calc},
+				MCPServerRefs: []kaosv1alpha1.AgentMCPServerRef{
+					{Name: docs, DenyTools: []string{"delete"}, Prefix: "docs"},
+				},
+				Config: &kaosv1alpha1.AgentConfig{Description: "Answers questions about {{ .Name }}"},
+			},
+		})
+
+		agent := &kaosv1alpha1.Agent{}
+		Eventually(func() []string {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, agent); err != nil {
+				return nil
+			}
+			if agent.Status.Card == nil {
+				return nil
+			}
+			return agent.Status.Card.Skills
+		}, timeout, interval).Should(Equal([]string{"add", "docs_search", "docs_fetch"}))
+		Expect(agent.Status.Card.Description).To(Equal("Answers questions about " + agentName))
+		Expect(agent.Status.Card.Capabilities).To(Equal([]string{"message_processing", "task_execution", "tool_execution"}))
+	})
+})
@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -542,4 +543,1393 @@ var _ = Describe("Agent Controller", func() {
 		}
 		Expect(foundModelName).To(Equal("openai/gpt-4-turbo"))
 	})
+	It("should fail and scale down agent when its ModelAPI is deleted", func() {
+		modelAPIName := uniqueAgentName("missing-modelapi")
+		agentName := uniqueAgentName("missing-agent")
+
+		newModelAPI := func() *kaosv1alpha1.ModelAPI {
+			return &kaosv1alpha1.ModelAPI{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      modelAPIName,
+					Namespace: namespace,
+					// Deletion of a referenced ModelAPI is blocked unless forced
+					Annotations: map[string]string{"kaos.tools/force-delete": "true"},
+				},
+				Spec: kaosv1alpha1.ModelAPISpec{
+					Mode: kaosv1alpha1.ModelAPIModeProxy,
+					ProxyConfig: &kaosv1alpha1.ProxyConfig{
+						Models: []string{"mock-model"},
+					},
+				},
+			}
+		}
+		modelAPI := newModelAPI()
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:                   modelAPIName,
+				Model:                      "mock-model",
+				WaitForDependencies:        boolPtr(false),
+				ScaleDownOnMissingModelAPI: boolPtr(true),
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		deploymentKey := types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, deploymentKey, deployment)
+		}, timeout, interval).Should(Succeed())
+
+		// Delete the ModelAPI and wait for it to be gone
+		Expect(k8sClient.Delete(ctx, modelAPI)).To(Succeed())
+		Eventually(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{Name: modelAPIName, Namespace: namespace}, &kaosv1alpha1.ModelAPI{})
+			return apierrors.IsNotFound(err)
+		}, timeout, interval).Should(BeTrue())
+
+		// Agent should be Failed with a clear message
+		Eventually(func() bool {
+			updated := &kaosv1alpha1.Agent{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, updated); err != nil {
+				return false
+			}
+			return updated.Status.Phase == "Failed" &&
+				strings.Contains(updated.Status.Message, fmt.Sprintf("ModelAPI %q not found", modelAPIName))
+		}, timeout, interval).Should(BeTrue())
+
+		// Deployment should be scaled to zero
+		Eventually(func() int32 {
+			if err := k8sClient.Get(ctx, deploymentKey, deployment); err != nil {
+				return -1
+			}
+			return *deployment.Spec.Replicas
+		}, timeout, interval).Should(Equal(int32(0)))
+
+		// Recreate the ModelAPI - agent should heal and scale back up
+		modelAPI = newModelAPI()
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		Eventually(func() int32 {
+			if err := k8sClient.Get(ctx, deploymentKey, deployment); err != nil {
+				return -1
+			}
+			return *deployment.Spec.Replicas
+		}, timeout, interval).Should(Equal(int32(1)))
+
+		Eventually(func() string {
+			updated := &kaosv1alpha1.Agent{}
+			k8sClient.Get(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, updated)
+			return updated.Status.Phase
+		}, timeout, interval).ShouldNot(Equal("Failed"))
+	})
+
+	It("should emit ordered MODEL_API_URLS for primary and fallback ModelAPIs", func() {
+		primaryName := uniqueAgentName("primary-modelapi")
+		fallbackName := uniqueAgentName("fallback-modelapi")
+		agentName := uniqueAgentName("fallback-agent")
+
+		endpoints := map[string]string{}
+		for _, name := range []string{primaryName, fallbackName} {
+			modelAPI := &kaosv1alpha1.ModelAPI{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: kaosv1alpha1.ModelAPISpec{
+					Mode: kaosv1alpha1.ModelAPIModeProxy,
+					ProxyConfig: &kaosv1alpha1.ProxyConfig{
+						Models: []string{"mock-model"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+			defer func() {
+				k8sClient.Delete(ctx, modelAPI)
+			}()
+
+			// Wait for the ModelAPI endpoint to be populated
+			key := types.NamespacedName{Name: name, Namespace: namespace}
+			Eventually(func() string {
+				updated := &kaosv1alpha1.ModelAPI{}
+				k8sClient.Get(ctx, key, updated)
+				return updated.Status.Endpoint
+			}, timeout, interval).ShouldNot(BeEmpty())
+			updated := &kaosv1alpha1.ModelAPI{}
+			Expect(k8sClient.Get(ctx, key, updated)).To(Succeed())
+			endpoints[name] = updated.Status.Endpoint
+		}
+
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPIs:           []string{primaryName, fallbackName},
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{
+				Name:      fmt.Sprintf("agent-%s", agentName),
+				Namespace: namespace,
+			}, deployment)
+		}, timeout, interval).Should(Succeed())
+
+		envMap := make(map[string]string)
+		for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+			envMap[env.Name] = env.Value
+		}
+		Expect(envMap["MODEL_API_URL"]).To(Equal(endpoints[primaryName]))
+		Expect(envMap["MODEL_API_URLS"]).To(Equal(endpoints[primaryName] + "," + endpoints[fallbackName]))
+	})
+
+	It("should add wait init container with dependency URLs when enabled", func() {
+		modelAPIName := uniqueAgentName("wait-modelapi")
+		mcpName := uniqueAgentName("wait-mcp")
+		agentName := uniqueAgentName("wait-agent")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		mcpServer := &kaosv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      mcpName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.MCPServerSpec{
+				Runtime: "python-string",
+				Params:  "def echo(x: str) -> str: return x",
+			},
+		}
+		Expect(k8sClient.Create(ctx, mcpServer)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, mcpServer)
+		}()
+
+		// Wait for dependency endpoints to be populated
+		modelAPIEndpoint := ""
+		Eventually(func() string {
+			updated := &kaosv1alpha1.ModelAPI{}
+			k8sClient.Get(ctx, types.NamespacedName{Name: modelAPIName, Namespace: namespace}, updated)
+			modelAPIEndpoint = updated.Status.Endpoint
+			return modelAPIEndpoint
+		}, timeout, interval).ShouldNot(BeEmpty())
+		mcpEndpoint := ""
+		Eventually(func() string {
+			updated := &kaosv1alpha1.MCPServer{}
+			k8sClient.Get(ctx, types.NamespacedName{Name: mcpName, Namespace: namespace}, updated)
+			mcpEndpoint = updated.Status.Endpoint
+			return mcpEndpoint
+		}, timeout, interval).ShouldNot(BeEmpty())
+
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:                   modelAPIName,
+				Model:                      "mock-model",
+				MCPServers:                 []string{mcpName},
+				WaitForDependencies:        boolPtr(false),
+				WaitForDependencyEndpoints: boolPtr(true),
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{
+				Name:      fmt.Sprintf("agent-%s", agentName),
+				Namespace: namespace,
+			}, deployment)
+		}, timeout, interval).Should(Succeed())
+
+		initContainers := deployment.Spec.Template.Spec.InitContainers
+		Expect(initContainers).To(HaveLen(1))
+		Expect(initContainers[0].Name).To(Equal("wait-for-dependencies"))
+		Expect(initContainers[0].Image).To(Equal("curlimages/curl:test"))
+		Expect(initContainers[0].Args).To(Equal([]string{
+			modelAPIEndpoint + "/health/liveliness",
+			mcpEndpoint,
+		}))
+	})
+
+	It("should create a Job without Service or probes in job mode", func() {
+		modelAPIName := uniqueAgentName("job-modelapi")
+		agentName := uniqueAgentName("job-agent")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		backoffLimit := int32(2)
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				Mode:                kaosv1alpha1.AgentModeJob,
+				WaitForDependencies: boolPtr(false),
+				Job: &kaosv1alpha1.AgentJobConfig{
+					BackoffLimit:  &backoffLimit,
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		objectKey := types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+		job := &batchv1.Job{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, objectKey, job)
+		}, timeout, interval).Should(Succeed())
+
+		Expect(*job.Spec.BackoffLimit).To(Equal(int32(2)))
+		podSpec := job.Spec.Template.Spec
+		Expect(podSpec.RestartPolicy).To(Equal(corev1.RestartPolicyOnFailure))
+		Expect(podSpec.Containers[0].LivenessProbe).To(BeNil())
+		Expect(podSpec.Containers[0].ReadinessProbe).To(BeNil())
+
+		envMap := make(map[string]string)
+		for _, env := range podSpec.Containers[0].Env {
+			envMap[env.Name] = env.Value
+		}
+		Expect(envMap["AGENT_NAME"]).To(Equal(agentName))
+		Expect(envMap["MODEL_NAME"]).To(Equal("mock-model"))
+
+		// No Deployment or Service should be created in job mode
+		Consistently(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, objectKey, &appsv1.Deployment{})) &&
+				apierrors.IsNotFound(k8sClient.Get(ctx, objectKey, &corev1.Service{}))
+		}, 2*time.Second, interval).Should(BeTrue())
+	})
+
+	It("should surface Job completion in agent status", func() {
+		modelAPIName := uniqueAgentName("jobdone-modelapi")
+		agentName := uniqueAgentName("jobdone-agent")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				Mode:                kaosv1alpha1.AgentModeJob,
+				WaitForDependencies: boolPtr(false),
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		jobKey := types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+		job := &batchv1.Job{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, jobKey, job)
+		}, timeout, interval).Should(Succeed())
+
+		// envtest has no Job controller, so simulate successful completion
+		now := metav1.Now()
+		job.Status.StartTime = &now
+		job.Status.CompletionTime = &now
+		job.Status.Succeeded = 1
+		job.Status.Conditions = []batchv1.JobCondition{{
+			Type:               batchv1.JobComplete,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: now,
+		}}
+		Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+
+		Eventually(func() string {
+			updated := &kaosv1alpha1.Agent{}
+			k8sClient.Get(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, updated)
+			return updated.Status.Phase
+		}, timeout, interval).Should(Equal("Succeeded"))
+
+		updated := &kaosv1alpha1.Agent{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, updated)).To(Succeed())
+		Expect(updated.Status.Ready).To(BeTrue())
+		Expect(updated.Status.Job).NotTo(BeNil())
+		Expect(updated.Status.Job.Succeeded).To(Equal(int32(1)))
+	})
+
+	It("should reject job mode agents that expose a Service", func() {
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uniqueAgentName("job-expose"),
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI: "any-modelapi",
+				Model:    "mock-model",
+				Mode:     kaosv1alpha1.AgentModeJob,
+				AgentNetwork: &kaosv1alpha1.AgentNetworkConfig{
+					Expose: boolPtr(true),
+				},
+			},
+		}
+		err := k8sClient.Create(ctx, agent)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("agentNetwork.expose must be false in job mode"))
+	})
+
+	It("should create a CronJob with the configured schedule", func() {
+		modelAPIName := uniqueAgentName("cron-modelapi")
+		agentName := uniqueAgentName("cron-agent")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				Mode:                kaosv1alpha1.AgentModeJob,
+				WaitForDependencies: boolPtr(false),
+				Job: &kaosv1alpha1.AgentJobConfig{
+					Schedule: "*/15 * * * *",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		objectKey := types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+		cronJob := &batchv1.CronJob{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, objectKey, cronJob)
+		}, timeout, interval).Should(Succeed())
+
+		Expect(cronJob.Spec.Schedule).To(Equal("*/15 * * * *"))
+		container := cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+		envMap := make(map[string]string)
+		for _, env := range container.Env {
+			envMap[env.Name] = env.Value
+		}
+		Expect(envMap["AGENT_NAME"]).To(Equal(agentName))
+
+		// A scheduled agent should not create a one-off Job
+		Expect(apierrors.IsNotFound(k8sClient.Get(ctx, objectKey, &batchv1.Job{}))).To(BeTrue())
+
+		// Status should report the schedule and next run
+		Eventually(func() bool {
+			updated := &kaosv1alpha1.Agent{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, updated); err != nil {
+				return false
+			}
+			return updated.Status.CronJob != nil &&
+				updated.Status.CronJob.Schedule == "*/15 * * * *" &&
+				updated.Status.CronJob.NextScheduleTime != nil
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	It("should fail agent with an invalid cron schedule", func() {
+		modelAPIName := uniqueAgentName("badcron-modelapi")
+		agentName := uniqueAgentName("badcron-agent")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				Mode:                kaosv1alpha1.AgentModeJob,
+				WaitForDependencies: boolPtr(false),
+				Job: &kaosv1alpha1.AgentJobConfig{
+					Schedule: "every hour",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		Eventually(func() string {
+			updated := &kaosv1alpha1.Agent{}
+			k8sClient.Get(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, updated)
+			return updated.Status.Message
+		}, timeout, interval).Should(ContainSubstring("invalid cron schedule"))
+	})
+
+	It("should suspend and resume agent when replicas changes to and from zero", func() {
+		modelAPIName := uniqueAgentName("suspend-modelapi")
+		agentName := uniqueAgentName("suspend-agent")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		agentKey := types.NamespacedName{Name: agentName, Namespace: namespace}
+		deploymentKey := types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+		deployment := &appsv1.Deployment{}
+		getReplicas := func() int32 {
+			if err := k8sClient.Get(ctx, deploymentKey, deployment); err != nil {
+				return -1
+			}
+			return *deployment.Spec.Replicas
+		}
+		getPhase := func() string {
+			updated := &kaosv1alpha1.Agent{}
+			k8sClient.Get(ctx, agentKey, updated)
+			return updated.Status.Phase
+		}
+		setReplicas := func(replicas int32) {
+			Eventually(func() error {
+				updated := &kaosv1alpha1.Agent{}
+				if err := k8sClient.Get(ctx, agentKey, updated); err != nil {
+					return err
+				}
+				updated.Spec.Replicas = &replicas
+				return k8sClient.Update(ctx, updated)
+			}, timeout, interval).Should(Succeed())
+		}
+
+		// Defaults to one replica
+		Eventually(getReplicas, timeout, interval).Should(Equal(int32(1)))
+
+		// Suspend
+		setReplicas(0)
+		Eventually(getReplicas, timeout, interval).Should(Equal(int32(0)))
+		Eventually(getPhase, timeout, interval).Should(Equal("Suspended"))
+
+		// Resume
+		setReplicas(1)
+		Eventually(getReplicas, timeout, interval).Should(Equal(int32(1)))
+		Eventually(getPhase, timeout, interval).ShouldNot(Equal("Suspended"))
+	})
+
+	It("should inject memory backend env vars for the redis backend", func() {
+		modelAPIName := uniqueAgentName("redis-modelapi")
+		agentName := uniqueAgentName("redis-agent")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		replicas := int32(2)
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				Replicas:            &replicas,
+				WaitForDependencies: boolPtr(false),
+				Config: &kaosv1alpha1.AgentConfig{
+					Memory: &kaosv1alpha1.MemoryConfig{
+						Backend: kaosv1alpha1.MemoryBackendRedis,
+						Redis: &kaosv1alpha1.RedisMemoryConfig{
+							URL: "redis://redis.default.svc.cluster.local:6379/0",
+							PasswordSecretRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "redis-auth"},
+								Key:                  "password",
+							},
+						},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{
+				Name:      fmt.Sprintf("agent-%s", agentName),
+				Namespace: namespace,
+			}, deployment)
+		}, timeout, interval).Should(Succeed())
+
+		envMap := make(map[string]corev1.EnvVar)
+		for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+			envMap[env.Name] = env
+		}
+		Expect(envMap["AGENT_MEMORY_BACKEND"].Value).To(Equal("redis"))
+		Expect(envMap["AGENT_MEMORY_URL"].Value).To(Equal("redis://redis.default.svc.cluster.local:6379/0"))
+		Expect(envMap).To(HaveKey("AGENT_MEMORY_PASSWORD"))
+		password := envMap["AGENT_MEMORY_PASSWORD"]
+		Expect(password.Value).To(BeEmpty())
+		Expect(password.ValueFrom).NotTo(BeNil())
+		Expect(password.ValueFrom.SecretKeyRef.Name).To(Equal("redis-auth"))
+		Expect(password.ValueFrom.SecretKeyRef.Key).To(Equal("password"))
+	})
+
+	It("should reject the redis memory backend without connection details", func() {
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uniqueAgentName("redis-missing"),
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI: "any-modelapi",
+				Model:    "mock-model",
+				Config: &kaosv1alpha1.AgentConfig{
+					Memory: &kaosv1alpha1.MemoryConfig{
+						Backend: kaosv1alpha1.MemoryBackendRedis,
+					},
+				},
+			},
+		}
+		err := k8sClient.Create(ctx, agent)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("memory.redis is required when backend is redis"))
+	})
+
+	It("should set session bound env vars and roll the deployment when they change", func() {
+		modelAPIName := uniqueAgentName("session-modelapi")
+		agentName := uniqueAgentName("session-agent")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		sessionTTL := int32(3600)
+		maxHistory := int32(50)
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				Config: &kaosv1alpha1.AgentConfig{
+					SessionTTLSeconds:  &sessionTTL,
+					MaxHistoryMessages: &maxHistory,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		deploymentKey := types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, deploymentKey, deployment)
+		}, timeout, interval).Should(Succeed())
+
+		envMap := make(map[string]string)
+		for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+			envMap[env.Name] = env.Value
+		}
+		Expect(envMap["AGENT_SESSION_TTL_SECONDS"]).To(Equal("3600"))
+		Expect(envMap["AGENT_MAX_HISTORY_MESSAGES"]).To(Equal("50"))
+
+		initialHash := deployment.Spec.Template.Annotations["kaos.tools/pod-spec-hash"]
+		Expect(initialHash).NotTo(BeEmpty())
+
+		Eventually(func() error {
+			current := &kaosv1alpha1.Agent{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, current); err != nil {
+				return err
+			}
+			newMaxHistory := int32(100)
+			current.Spec.Config.MaxHistoryMessages = &newMaxHistory
+			return k8sClient.Update(ctx, current)
+		}, timeout, interval).Should(Succeed())
+
+		Eventually(func() string {
+			if err := k8sClient.Get(ctx, deploymentKey, deployment); err != nil {
+				return ""
+			}
+			return deployment.Spec.Template.Annotations["kaos.tools/pod-spec-hash"]
+		}, timeout, interval).ShouldNot(Or(BeEmpty(), Equal(initialHash)), "Deployment hash should change after session config update")
+	})
+
+	It("should reject negative session bounds", func() {
+		negative := int32(-1)
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uniqueAgentName("session-invalid"),
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI: "any-modelapi",
+				Model:    "mock-model",
+				Config: &kaosv1alpha1.AgentConfig{
+					SessionTTLSeconds: &negative,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).NotTo(Succeed())
+	})
+
+	It("should emit tool filter and prefix env vars for structured mcpServerRefs", func() {
+		modelAPIName := uniqueAgentName("filter-modelapi")
+		allowMCPName := uniqueAgentName("filter-allow-mcp")
+		denyMCPName := uniqueAgentName("filter-deny-mcp")
+		agentName := uniqueAgentName("filter-agent")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		for _, mcpName := range []string{allowMCPName, denyMCPName} {
+			mcpServer := &kaosv1alpha1.MCPServer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      mcpName,
+					Namespace: namespace,
+				},
+				Spec: kaosv1alpha1.MCPServerSpec{
+					Runtime: "python-string",
+					Params:  "def echo(x: str) -> str: return x",
+				},
+			}
+			Expect(k8sClient.Create(ctx, mcpServer)).To(Succeed())
+			defer func() {
+				k8sClient.Delete(ctx, mcpServer)
+			}()
+		}
+
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:   modelAPIName,
+				Model:      "mock-model",
+				MCPServers: []string{allowMCPName},
+				MCPServerRefs: []kaosv1alpha1.AgentMCPServerRef{
+					{Name: allowMCPName, AllowTools: []string{"echo", "search"}},
+					{Name: denyMCPName, DenyTools: []string{"delete"}, Prefix: "admin"},
+				},
+				WaitForDependencies: boolPtr(false),
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{
+				Name:      fmt.Sprintf("agent-%s", agentName),
+				Namespace: namespace,
+			}, deployment)
+		}, timeout, interval).Should(Succeed())
+
+		envMap := make(map[string]string)
+		for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+			envMap[env.Name] = env.Value
+		}
+		Expect(strings.Split(envMap["MCP_SERVERS"], ",")).To(ConsistOf(allowMCPName, denyMCPName))
+		Expect(envMap).To(HaveKey(fmt.Sprintf("MCP_SERVER_%s_URL", denyMCPName)))
+		Expect(envMap[fmt.Sprintf("MCP_SERVER_%s_ALLOW", allowMCPName)]).To(Equal("echo,search"))
+		Expect(envMap[fmt.Sprintf("MCP_SERVER_%s_DENY", denyMCPName)]).To(Equal("delete"))
+		Expect(envMap).NotTo(HaveKey(fmt.Sprintf("MCP_SERVER_%s_DENY", allowMCPName)))
+
+		// Tool name prefixes are only emitted when configured
+		Expect(envMap[fmt.Sprintf("MCP_SERVER_%s_PREFIX", denyMCPName)]).To(Equal("admin"))
+		Expect(envMap).NotTo(HaveKey(fmt.Sprintf("MCP_SERVER_%s_PREFIX", allowMCPName)))
+	})
+
+	It("should serialize model headers into MODEL_DEFAULT_HEADERS", func() {
+		modelAPIName := uniqueAgentName("headers-modelapi")
+		agentName := uniqueAgentName("headers-agent")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				Config: &kaosv1alpha1.AgentConfig{
+					ModelHeaders: map[string]string{
+						"X-Tenant-Id":   "acme",
+						"X-Cost-Center": "research",
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{
+				Name:      fmt.Sprintf("agent-%s", agentName),
+				Namespace: namespace,
+			}, deployment)
+		}, timeout, interval).Should(Succeed())
+
+		envMap := make(map[string]string)
+		for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+			envMap[env.Name] = env.Value
+		}
+		Expect(envMap["MODEL_DEFAULT_HEADERS"]).To(Equal(`{"X-Cost-Center":"research","X-Tenant-Id":"acme"}`))
+	})
+
+	It("should reject invalid model header names", func() {
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uniqueAgentName("headers-invalid"),
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI: "any-modelapi",
+				Model:    "mock-model",
+				Config: &kaosv1alpha1.AgentConfig{
+					ModelHeaders: map[string]string{"X Tenant": "acme"},
+				},
+			},
+		}
+		err := k8sClient.Create(ctx, agent)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("modelHeaders keys must be valid HTTP header names"))
+	})
+
+	It("should emit generation default env vars", func() {
+		modelAPIName := uniqueAgentName("generation-modelapi")
+		agentName := uniqueAgentName("generation-agent")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		maxTokens := int32(1024)
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				Config: &kaosv1alpha1.AgentConfig{
+					Generation: &kaosv1alpha1.GenerationConfig{
+						Temperature: "0.7",
+						TopP:        "0.9",
+						MaxTokens:   &maxTokens,
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{
+				Name:      fmt.Sprintf("agent-%s", agentName),
+				Namespace: namespace,
+			}, deployment)
+		}, timeout, interval).Should(Succeed())
+
+		envMap := make(map[string]string)
+		for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+			envMap[env.Name] = env.Value
+		}
+		Expect(envMap["MODEL_TEMPERATURE"]).To(Equal("0.7"))
+		Expect(envMap["MODEL_TOP_P"]).To(Equal("0.9"))
+		Expect(envMap["MODEL_MAX_TOKENS"]).To(Equal("1024"))
+	})
+
+	It("should reject out-of-range generation defaults", func() {
+		newAgent := func(generation *kaosv1alpha1.GenerationConfig) *kaosv1alpha1.Agent {
+			return &kaosv1alpha1.Agent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uniqueAgentName("generation-invalid"),
+					Namespace: namespace,
+				},
+				Spec: kaosv1alpha1.AgentSpec{
+					ModelAPI: "any-modelapi",
+					Model:    "mock-model",
+					Config: &kaosv1alpha1.AgentConfig{
+						Generation: generation,
+					},
+				},
+			}
+		}
+
+		err := k8sClient.Create(ctx, newAgent(&kaosv1alpha1.GenerationConfig{Temperature: "2.5"}))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("temperature must be between 0 and 2"))
+
+		err = k8sClient.Create(ctx, newAgent(&kaosv1alpha1.GenerationConfig{TopP: "1.5"}))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("topP must be between 0 and 1"))
+
+		err = k8sClient.Create(ctx, newAgent(&kaosv1alpha1.GenerationConfig{Temperature: "-1"}))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail agent when inline instructions exceed the size limit", func() {
+		modelAPIName := uniqueAgentName("large-modelapi")
+		agentName := uniqueAgentName("large-agent")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				Config: &kaosv1alpha1.AgentConfig{
+					Instructions: strings.Repeat("a", 70000),
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		Eventually(func() string {
+			updated := &kaosv1alpha1.Agent{}
+			k8sClient.Get(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, updated)
+			return updated.Status.Message
+		}, timeout, interval).Should(ContainSubstring("config.instructionsFrom"))
+
+		updated := &kaosv1alpha1.Agent{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, updated)).To(Succeed())
+		Expect(updated.Status.Phase).To(Equal("Failed"))
+
+		// No Deployment is created for oversized instructions
+		deployment := &appsv1.Deployment{}
+		err := k8sClient.Get(ctx, types.NamespacedName{
+			Name:      fmt.Sprintf("agent-%s", agentName),
+			Namespace: namespace,
+		}, deployment)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should mount instructions from a ConfigMap instead of env", func() {
+		modelAPIName := uniqueAgentName("instr-modelapi")
+		agentName := uniqueAgentName("instr-agent")
+		configMapName := uniqueAgentName("instr-cm")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configMapName,
+				Namespace: namespace,
+			},
+			Data: map[string]string{"prompt.md": "You are a helpful agent."},
+		}
+		Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, configMap)
+		}()
+
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				Config: &kaosv1alpha1.AgentConfig{
+					InstructionsFrom: &kaosv1alpha1.InstructionsSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+							Key:                  "prompt.md",
+						},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{
+				Name:      fmt.Sprintf("agent-%s", agentName),
+				Namespace: namespace,
+			}, deployment)
+		}, timeout, interval).Should(Succeed())
+
+		podSpec := deployment.Spec.Template.Spec
+		Expect(podSpec.Volumes).To(HaveLen(1))
+		Expect(podSpec.Volumes[0].Name).To(Equal("agent-instructions"))
+		Expect(podSpec.Volumes[0].ConfigMap).NotTo(BeNil())
+		Expect(podSpec.Volumes[0].ConfigMap.Name).To(Equal(configMapName))
+		Expect(podSpec.Volumes[0].ConfigMap.Items).To(Equal([]corev1.KeyToPath{{Key: "prompt.md", Path: "instructions"}}))
+
+		container := podSpec.Containers[0]
+		Expect(container.VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      "agent-instructions",
+			MountPath: "/etc/kaos/instructions",
+			ReadOnly:  true,
+		}))
+		envMap := make(map[string]string)
+		for _, env := range container.Env {
+			envMap[env.Name] = env.Value
+		}
+		Expect(envMap["AGENT_INSTRUCTIONS_FILE"]).To(Equal("/etc/kaos/instructions/instructions"))
+		Expect(envMap).NotTo(HaveKey("AGENT_INSTRUCTIONS"))
+	})
+
+	It("should reject setting both instructions and instructionsFrom", func() {
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uniqueAgentName("instr-both"),
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI: "any-modelapi",
+				Model:    "mock-model",
+				Config: &kaosv1alpha1.AgentConfig{
+					Instructions: "inline",
+					InstructionsFrom: &kaosv1alpha1.InstructionsSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "prompts"},
+							Key:                  "agent",
+						},
+					},
+				},
+			},
+		}
+		err := k8sClient.Create(ctx, agent)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("only one of instructions or instructionsFrom may be set"))
+	})
+
+	It("should roll the deployment when the instructions ConfigMap changes", func() {
+		modelAPIName := uniqueAgentName("instr-roll-modelapi")
+		agentName := uniqueAgentName("instr-roll-agent")
+		configMapName := uniqueAgentName("instr-roll-cm")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				Config: &kaosv1alpha1.AgentConfig{
+					InstructionsFrom: &kaosv1alpha1.InstructionsSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: configMapName},
+							Key:                  "prompt.md",
+						},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		// The agent fails until the referenced ConfigMap exists
+		Eventually(func() string {
+			updated := &kaosv1alpha1.Agent{}
+			k8sClient.Get(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, updated)
+			return updated.Status.Message
+		}, timeout, interval).Should(ContainSubstring(fmt.Sprintf("key \"prompt.md\" not found in ConfigMap %s", configMapName)))
+
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      configMapName,
+				Namespace: namespace,
+			},
+			Data: map[string]string{"prompt.md": "You are a helpful agent."},
+		}
+		Expect(k8sClient.Create(ctx, configMap)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, configMap)
+		}()
+
+		deploymentKey := types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, deploymentKey, deployment)
+		}, timeout, interval).Should(Succeed())
+
+		initialHash := deployment.Spec.Template.Annotations["kaos.tools/pod-spec-hash"]
+		initialContentHash := deployment.Spec.Template.Annotations["kaos.tools/referenced-content-hash"]
+		Expect(initialContentHash).NotTo(BeEmpty())
+
+		// Editing the ConfigMap triggers a rolling update
+		Eventually(func() error {
+			current := &corev1.ConfigMap{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: configMapName, Namespace: namespace}, current); err != nil {
+				return err
+			}
+			current.Data["prompt.md"] = "You are a very helpful agent."
+			return k8sClient.Update(ctx, current)
+		}, timeout, interval).Should(Succeed())
+
+		Eventually(func() string {
+			if err := k8sClient.Get(ctx, deploymentKey, deployment); err != nil {
+				return ""
+			}
+			return deployment.Spec.Template.Annotations["kaos.tools/referenced-content-hash"]
+		}, timeout, interval).ShouldNot(Or(BeEmpty(), Equal(initialContentHash)))
+		Expect(deployment.Spec.Template.Annotations["kaos.tools/pod-spec-hash"]).NotTo(Equal(initialHash))
+	})
+
+	It("should preserve user value and valueFrom env entries over generated ones", func() {
+		modelAPIName := uniqueAgentName("env-from-modelapi")
+		agentName := uniqueAgentName("env-from-agent")
+
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      modelAPIName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				Config: &kaosv1alpha1.AgentConfig{
+					Telemetry: &kaosv1alpha1.TelemetryConfig{
+						Enabled:  true,
+						Endpoint: "http://otel-collector:4317",
+					},
+				},
+				Container: &kaosv1alpha1.ContainerOverride{
+					Env: []corev1.EnvVar{
+						{Name: "CUSTOM_FLAG", Value: "on"},
+						{Name: "OPENAI_API_KEY", ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "llm-keys"},
+								Key:                  "openai",
+							},
+						}},
+						{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", ValueFrom: &corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "otel-creds"},
+								Key:                  "endpoint",
+							},
+						}},
+					},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}, deployment)
+		}, timeout, interval).Should(Succeed())
+
+		envByName := map[string]corev1.EnvVar{}
+		counts := map[string]int{}
+		for _, e := range deployment.Spec.Template.Spec.Containers[0].Env {
+			envByName[e.Name] = e
+			counts[e.Name]++
+		}
+
+		Expect(envByName["CUSTOM_FLAG"].Value).To(Equal("on"))
+		Expect(envByName["OPENAI_API_KEY"].ValueFrom).NotTo(BeNil())
+		Expect(envByName["OPENAI_API_KEY"].ValueFrom.SecretKeyRef.Name).To(Equal("llm-keys"))
+
+		// The generated telemetry endpoint must not clobber the user's secret reference
+		Expect(counts["OTEL_EXPORTER_OTLP_ENDPOINT"]).To(Equal(1))
+		Expect(envByName["OTEL_EXPORTER_OTLP_ENDPOINT"].Value).To(BeEmpty())
+		Expect(envByName["OTEL_EXPORTER_OTLP_ENDPOINT"].ValueFrom.SecretKeyRef.Name).To(Equal("otel-creds"))
+		Expect(envByName["OTEL_SERVICE_NAME"].Value).To(Equal(agentName))
+	})
 })
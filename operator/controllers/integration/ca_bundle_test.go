@@ -0,0 +1,87 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+var _ = Describe("Agent custom CA bundle", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	It("mounts the bundle into the agent container and rolls the pods when it changes", func() {
+		modelAPIName := uniqueAgentName("ca-modelapi")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+
+		ca := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: uniqueAgentName("corp-ca"), Namespace: namespace},
+			Data:       map[string]string{"ca.crt": "-----BEGIN CERTIFICATE-----\none\n-----END CERTIFICATE-----\n"},
+		}
+		Expect(k8sClient.Create(ctx, ca)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, ca)
+		}()
+
+		agentName := uniqueAgentName("ca-agent")
+		createAgent(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				CABundle: &kaosv1alpha1.CABundleSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: ca.Name},
+						Key:                  "ca.crt",
+					},
+				},
+			},
+		})
+
+		deploymentKey := types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, deploymentKey, deployment)
+		}, timeout, interval).Should(Succeed())
+
+		spec := deployment.Spec.Template.Spec
+		Expect(spec.Volumes).To(ContainElement(HaveField("Name", "ca-bundle")))
+		Expect(spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      "ca-bundle",
+			MountPath: "/etc/kaos/ca",
+			ReadOnly:  true,
+		}))
+		Expect(spec.Containers[0].Env).To(ContainElements(
+			corev1.EnvVar{Name: "SSL_CERT_FILE", Value: "/etc/kaos/ca/ca.crt"},
+			corev1.EnvVar{Name: "REQUESTS_CA_BUNDLE", Value: "/etc/kaos/ca/ca.crt"},
+		))
+		initial := deployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: ca.Name, Namespace: namespace}, ca); err != nil {
+				return err
+			}
+			ca.Data["ca.crt"] = "-----BEGIN CERTIFICATE-----\ntwo\n-----END CERTIFICATE-----\n"
+			return k8sClient.Update(ctx, ca)
+		}, timeout, interval).Should(Succeed())
+		Eventually(func() string {
+			if err := k8sClient.Get(ctx, deploymentKey, deployment); err != nil {
+				return ""
+			}
+			return deployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+		}, timeout, interval).ShouldNot(Equal(initial))
+	})
+})
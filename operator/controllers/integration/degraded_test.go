@@ -0,0 +1,88 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/controllers"
+)
+
+var _ = Describe("Agent Degraded condition", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	var key, deploymentKey types.NamespacedName
+
+	BeforeEach(func() {
+		modelAPIName := uniqueAgentName("degraded-modelapi")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+
+		agentName := uniqueAgentName("degraded-agent")
+		replicas := int32(3)
+		createAgent(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				Replicas:            &replicas,
+			},
+		})
+		key = types.NamespacedName{Name: agentName, Namespace: namespace}
+		deploymentKey = types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+	})
+
+	// degradedCondition returns the agent's Degraded condition once its status reports
+	// ready of the 3 replicas
+	degradedCondition := func(ready int32) *metav1.Condition {
+		var condition *metav1.Condition
+		Eventually(func() bool {
+			agent := &kaosv1alpha1.Agent{}
+			if err := k8sClient.Get(ctx, key, agent); err != nil {
+				return false
+			}
+			condition = meta.FindStatusCondition(agent.Status.Conditions, controllers.ConditionTypeDegraded)
+			return agent.Status.ReadyReplicas == ready && agent.Status.ObservedReplicas == 3 && condition != nil
+		}, timeout, interval).Should(BeTrue())
+		return condition
+	}
+
+	It("reports Degraded while only some replicas are ready", func() {
+		setDeploymentReplicas(ctx, deploymentKey, 3, 1)
+		degraded := degradedCondition(1)
+		Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+		Expect(degraded.Reason).To(Equal("ReplicasUnavailable"))
+		Expect(degraded.Message).To(Equal("1/3 replicas ready"))
+
+		agent := &kaosv1alpha1.Agent{}
+		Expect(k8sClient.Get(ctx, key, agent)).To(Succeed())
+		Expect(agent.Status.Ready).To(BeTrue())
+		Expect(agent.Status.Message).To(ContainSubstring("1/3"))
+
+		setDeploymentReplicas(ctx, deploymentKey, 3, 3)
+		degraded = degradedCondition(3)
+		Expect(degraded.Status).To(Equal(metav1.ConditionFalse))
+		Expect(degraded.Reason).To(Equal("AllReplicasReady"))
+	})
+
+	It("does not report Degraded when no replica is ready", func() {
+		setDeploymentReplicas(ctx, deploymentKey, 3, 0)
+		degraded := degradedCondition(0)
+		Expect(degraded.Status).To(Equal(metav1.ConditionFalse))
+		Expect(degraded.Reason).To(Equal("NoReplicasReady"))
+
+		agent := &kaosv1alpha1.Agent{}
+		Expect(k8sClient.Get(ctx, key, agent)).To(Succeed())
+		Expect(agent.Status.Ready).To(BeFalse())
+	})
+})
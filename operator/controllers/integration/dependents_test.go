@@ -0,0 +1,47 @@
+package integration
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = Describe("Status dependents", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	It("lists the Agents referencing a ModelAPI", func() {
+		modelAPIName := uniqueModelAPIName("dependents")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+
+		newAgent := func(name string, spec kaosv1alpha1.AgentSpec) *kaosv1alpha1.Agent {
+			spec.Model = "mock-model"
+			spec.WaitForDependencies = boolPtr(false)
+			return &kaosv1alpha1.Agent{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: spec,
+			}
+		}
+		writer := uniqueAgentName("dependent-b")
+		researcher := uniqueAgentName("dependent-a")
+		createAgent(ctx, newAgent(writer, kaosv1alpha1.AgentSpec{ModelAPI: modelAPIName}))
+		createAgent(ctx, newAgent(researcher, kaosv1alpha1.AgentSpec{ModelAPIs: []string{"other", modelAPIName}}))
+		createAgent(ctx, newAgent(uniqueAgentName("unrelated"), kaosv1alpha1.AgentSpec{ModelAPI: "other"}))
+
+		Eventually(func() []string {
+			updated := &kaosv1alpha1.ModelAPI{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: modelAPIName, Namespace: namespace}, updated); err != nil {
+				return nil
+			}
+			return updated.Status.Dependents
+		}, timeout, interval).Should(Equal([]string{researcher, writer}))
+	})
+})
@@ -0,0 +1,67 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = Describe("Deployment strategy", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	It("marks the ModelAPI Failed when the rollout exceeds its progress deadline", func() {
+		name := uniqueModelAPIName("progress-deadline")
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeHosted,
+				HostedConfig: &kaosv1alpha1.HostedConfig{
+					Model: "smollm2:135m",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		// Simulate the Deployment controller giving up on a wedged rollout
+		deploymentKey := types.NamespacedName{Name: fmt.Sprintf("modelapi-%s", name), Namespace: namespace}
+		Eventually(func() error {
+			deployment := &appsv1.Deployment{}
+			if err := k8sClient.Get(ctx, deploymentKey, deployment); err != nil {
+				return err
+			}
+			deployment.Status.Conditions = []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: fmt.Sprintf("ReplicaSet %q has timed out progressing.", deploymentKey.Name+"-abc"),
+			}}
+			return k8sClient.Status().Update(ctx, deployment)
+		}, timeout, interval).Should(Succeed())
+
+		updated := &kaosv1alpha1.ModelAPI{}
+		Eventually(func() string {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, updated); err != nil {
+				return ""
+			}
+			return updated.Status.Phase
+		}, timeout, interval).Should(Equal("Failed"))
+		Expect(updated.Status.Ready).To(BeFalse())
+		Expect(updated.Status.Message).To(ContainSubstring("has timed out progressing"))
+		Expect(updated.Status.Deployment.Conditions).To(ContainElement(HaveField("Reason", "ProgressDeadlineExceeded")))
+	})
+})
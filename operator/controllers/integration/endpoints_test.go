@@ -0,0 +1,148 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/controllers"
+)
+
+var _ = Describe("Agent status endpoints", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	var (
+		modelAPIName string
+		agentName    string
+		key          types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		enableGatewayAPI()
+
+		modelAPIName = uniqueAgentName("endpoints-modelapi")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+		agentName = uniqueAgentName("endpoints-agent")
+		key = types.NamespacedName{Name: agentName, Namespace: namespace}
+	})
+
+	createEndpointsAgent := func(route *kaosv1alpha1.GatewayRoute) {
+		createAgent(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				GatewayRoute:        route,
+			},
+		})
+	}
+
+	// endpoints returns the agent's status.endpoints once they include the internal one
+	endpoints := func() map[string]string {
+		agent := &kaosv1alpha1.Agent{}
+		if err := k8sClient.Get(ctx, key, agent); err != nil {
+			return nil
+		}
+		if _, ok := agent.Status.Endpoints["internal"]; !ok {
+			return nil
+		}
+		return agent.Status.Endpoints
+	}
+
+	It("reports the internal, fqdn and gateway endpoints", func() {
+		createEndpointsAgent(nil)
+
+		fqdn := fmt.Sprintf("http://agent-%s.%s.svc.cluster.local:8000", agentName, namespace)
+		Eventually(endpoints, timeout, interval).Should(Equal(map[string]string{
+			"internal": fmt.Sprintf("http://agent-%s.%s:8000", agentName, namespace),
+			"fqdn":     fqdn,
+			"gateway":  fmt.Sprintf("http://kaos.example.com/%s/agent/%s", namespace, agentName),
+		}))
+
+		agent := &kaosv1alpha1.Agent{}
+		Expect(k8sClient.Get(ctx, key, agent)).To(Succeed())
+		Expect(agent.Status.Endpoint).To(Equal(fqdn))
+	})
+
+	It("reports an endpoint per gateway and attaches the route to each", func() {
+		createEndpointsAgent(&kaosv1alpha1.GatewayRoute{Gateways: []kaosv1alpha1.GatewayTarget{
+			{Name: "internal", Host: "kaos.internal"},
+			{Name: "external", Namespace: "edge", SectionName: "https", Host: "kaos.example.com"},
+		}})
+
+		path := fmt.Sprintf("/%s/agent/%s", namespace, agentName)
+		Eventually(endpoints, timeout, interval).Should(And(
+			HaveKeyWithValue("gateway", "http://kaos.internal"+path),
+			HaveKeyWithValue("gateway-internal", "http://kaos.internal"+path),
+			HaveKeyWithValue("gateway-external", "http://kaos.example.com"+path),
+		))
+
+		route := &gatewayv1.HTTPRoute{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}, route)).To(Succeed())
+		Expect(route.Spec.ParentRefs).To(HaveLen(2))
+		Expect(string(route.Spec.ParentRefs[0].Name)).To(Equal("internal"))
+		Expect(string(*route.Spec.ParentRefs[0].Namespace)).To(Equal(namespace))
+		Expect(string(route.Spec.ParentRefs[1].Name)).To(Equal("external"))
+		Expect(string(*route.Spec.ParentRefs[1].Namespace)).To(Equal("edge"))
+		Expect(string(*route.Spec.ParentRefs[1].SectionName)).To(Equal("https"))
+	})
+
+	It("omits the gateway endpoint when the route is disabled", func() {
+		createEndpointsAgent(&kaosv1alpha1.GatewayRoute{Enabled: boolPtr(false)})
+
+		Eventually(endpoints, timeout, interval).Should(HaveKey("fqdn"))
+		Expect(endpoints()).NotTo(HaveKey("gateway"))
+	})
+
+	It("fails with InvalidGatewayRoute while another route holds the path prefix", func() {
+		pathPrefix := "/" + agentName
+		pathType := gatewayv1.PathMatchPathPrefix
+		other := &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uniqueAgentName("other-route"),
+				Namespace: namespace,
+				Labels:    map[string]string{"app": "agent"},
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "kaos-gateway"}},
+				},
+				Rules: []gatewayv1.HTTPRouteRule{{
+					Matches: []gatewayv1.HTTPRouteMatch{{
+						Path: &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &pathPrefix},
+					}},
+				}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, other)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, other)
+		}()
+
+		createEndpointsAgent(&kaosv1alpha1.GatewayRoute{PathPrefix: pathPrefix})
+
+		agent := &kaosv1alpha1.Agent{}
+		Eventually(func() bool {
+			if err := k8sClient.Get(ctx, key, agent); err != nil {
+				return false
+			}
+			return agent.Status.Phase == "Failed" &&
+				strings.Contains(agent.Status.Message, fmt.Sprintf("already used by HTTPRoute %s/%s", namespace, other.Name))
+		}, timeout, interval).Should(BeTrue())
+		Expect(meta.FindStatusCondition(agent.Status.Conditions, controllers.ConditionTypeReady).Reason).To(Equal("InvalidGatewayRoute"))
+		Expect(agent.Status.Endpoints).NotTo(HaveKey("gateway"))
+	})
+})
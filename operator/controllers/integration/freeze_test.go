@@ -0,0 +1,73 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+var _ = Describe("freeze-deployment annotation", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	It("does not update the frozen Deployment but keeps status current", func() {
+		modelAPIName := uniqueAgentName("freeze-modelapi")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+
+		agentName := uniqueAgentName("freeze-agent")
+		createAgent(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+			},
+		})
+		key := types.NamespacedName{Name: agentName, Namespace: namespace}
+		deploymentKey := types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+
+		podSpecHash := func() string {
+			deployment := &appsv1.Deployment{}
+			if err := k8sClient.Get(ctx, deploymentKey, deployment); err != nil {
+				return ""
+			}
+			return deployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+		}
+		Eventually(podSpecHash, timeout, interval).ShouldNot(BeEmpty())
+		hash := podSpecHash()
+
+		// Freeze, then change the spec
+		updateAgent(ctx, key, func(agent *kaosv1alpha1.Agent) {
+			agent.Annotations = map[string]string{"kaos.tools/freeze-deployment": "true"}
+			agent.Spec.Config = &kaosv1alpha1.AgentConfig{Instructions: "Answer in French."}
+		})
+
+		// The Deployment becomes ready
+		setDeploymentReplicas(ctx, deploymentKey, 1, 1)
+		Eventually(func() bool {
+			agent := &kaosv1alpha1.Agent{}
+			if err := k8sClient.Get(ctx, key, agent); err != nil {
+				return false
+			}
+			return agent.Status.Ready && agent.Status.Deployment != nil && agent.Status.Deployment.ReadyReplicas == 1
+		}, timeout, interval).Should(BeTrue())
+		Consistently(podSpecHash, "2s", interval).Should(Equal(hash))
+
+		// Unfreezing applies the pending change
+		updateAgent(ctx, key, func(agent *kaosv1alpha1.Agent) {
+			delete(agent.Annotations, "kaos.tools/freeze-deployment")
+		})
+		Eventually(podSpecHash, timeout, interval).ShouldNot(Equal(hash))
+	})
+})
@@ -0,0 +1,89 @@
+package integration
+
+import (
+	"context"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// createProxyModelAPI creates a Proxy mode ModelAPI serving mock-model and deletes it
+// after the current spec
+func createProxyModelAPI(ctx context.Context, name, namespace string) *kaosv1alpha1.ModelAPI {
+	modelAPI := &kaosv1alpha1.ModelAPI{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: kaosv1alpha1.ModelAPISpec{
+			Mode: kaosv1alpha1.ModelAPIModeProxy,
+			ProxyConfig: &kaosv1alpha1.ProxyConfig{
+				Models: []string{"mock-model"},
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+	DeferCleanup(func() {
+		k8sClient.Delete(ctx, modelAPI)
+	})
+	return modelAPI
+}
+
+// createAgent creates agent and deletes it after the current spec
+func createAgent(ctx context.Context, agent *kaosv1alpha1.Agent) {
+	Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+	DeferCleanup(func() {
+		k8sClient.Delete(ctx, agent)
+	})
+}
+
+// updateAgent applies mutate to the stored Agent, retrying on conflicts with the controller
+func updateAgent(ctx context.Context, key types.NamespacedName, mutate func(*kaosv1alpha1.Agent)) {
+	Eventually(func() error {
+		current := &kaosv1alpha1.Agent{}
+		if err := k8sClient.Get(ctx, key, current); err != nil {
+			return err
+		}
+		mutate(current)
+		return k8sClient.Update(ctx, current)
+	}, timeout, interval).Should(Succeed())
+}
+
+// setDeploymentReplicas stands in for the Deployment controller, which envtest does not
+// run, reporting replicas of which ready are available
+func setDeploymentReplicas(ctx context.Context, key types.NamespacedName, replicas, ready int32) {
+	Eventually(func() error {
+		deployment := &appsv1.Deployment{}
+		if err := k8sClient.Get(ctx, key, deployment); err != nil {
+			return err
+		}
+		deployment.Status = appsv1.DeploymentStatus{
+			ObservedGeneration: deployment.Generation,
+			Replicas:           replicas,
+			UpdatedReplicas:    replicas,
+			ReadyReplicas:      ready,
+			AvailableReplicas:  ready,
+		}
+		return k8sClient.Status().Update(ctx, deployment)
+	}, timeout, interval).Should(Succeed())
+}
+
+// enableGatewayAPI turns on HTTPRoute management for the current spec, attaching routes
+// to the kaos-gateway Gateway in the default namespace under the kaos.example.com host
+func enableGatewayAPI() {
+	for name, value := range map[string]string{
+		"GATEWAY_API_ENABLED": "true",
+		"GATEWAY_NAME":        "kaos-gateway",
+		"GATEWAY_NAMESPACE":   "default",
+		"GATEWAY_HOST":        "kaos.example.com",
+	} {
+		Expect(os.Setenv(name, value)).To(Succeed())
+		DeferCleanup(os.Unsetenv, name)
+	}
+}
@@ -0,0 +1,105 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = Describe("HTTPRoute cleanup", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	var modelAPIName, agentName string
+
+	BeforeEach(func() {
+		enableGatewayAPI()
+
+		modelAPIName = uniqueAgentName("route-modelapi")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+		agentName = uniqueAgentName("route-agent")
+	})
+
+	newAgent := func() *kaosv1alpha1.Agent {
+		return &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+			},
+		}
+	}
+
+	routeExists := func() bool {
+		err := k8sClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}, &gatewayv1.HTTPRoute{})
+		return !apierrors.IsNotFound(err)
+	}
+
+	It("deletes the agent's HTTPRoute when expose is switched off", func() {
+		createAgent(ctx, newAgent())
+		Eventually(routeExists, timeout, interval).Should(BeTrue())
+
+		updateAgent(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, func(a *kaosv1alpha1.Agent) {
+			a.Spec.AgentNetwork = &kaosv1alpha1.AgentNetworkConfig{Expose: boolPtr(false)}
+		})
+		Eventually(routeExists, timeout, interval).Should(BeFalse())
+	})
+
+	It("deletes the agent's HTTPRoute when gatewayRoute.enabled is false", func() {
+		createAgent(ctx, newAgent())
+		Eventually(routeExists, timeout, interval).Should(BeTrue())
+
+		key := types.NamespacedName{Name: agentName, Namespace: namespace}
+		updateAgent(ctx, key, func(a *kaosv1alpha1.Agent) {
+			a.Spec.GatewayRoute = &kaosv1alpha1.GatewayRoute{Enabled: boolPtr(false)}
+		})
+		Eventually(routeExists, timeout, interval).Should(BeFalse())
+
+		// Re-enabling recreates the route
+		updateAgent(ctx, key, func(a *kaosv1alpha1.Agent) {
+			a.Spec.GatewayRoute = nil
+		})
+		Eventually(routeExists, timeout, interval).Should(BeTrue())
+	})
+
+	It("keeps an HTTPRoute of the same name that the agent does not own", func() {
+		route := &gatewayv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("agent-%s", agentName),
+				Namespace: namespace,
+			},
+			Spec: gatewayv1.HTTPRouteSpec{
+				CommonRouteSpec: gatewayv1.CommonRouteSpec{
+					ParentRefs: []gatewayv1.ParentReference{{Name: "kaos-gateway"}},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, route)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, route)
+		}()
+
+		agent := newAgent()
+		agent.Spec.AgentNetwork = &kaosv1alpha1.AgentNetworkConfig{Expose: boolPtr(false)}
+		createAgent(ctx, agent)
+
+		// Once the agent has been reconciled, the route it does not own is left in place
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}, &appsv1.Deployment{})
+		}, timeout, interval).Should(Succeed())
+		Consistently(routeExists, "2s", interval).Should(BeTrue())
+	})
+})
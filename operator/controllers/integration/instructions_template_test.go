@@ -0,0 +1,48 @@
+package integration
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = Describe("Templated agent instructions", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	It("fails the agent when the template references a disallowed field", func() {
+		modelAPIName := uniqueAgentName("template-modelapi")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+
+		agentName := uniqueAgentName("template-agent")
+		createAgent(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				Config: &kaosv1alpha1.AgentConfig{
+					Instructions: "Your spec is {{.Spec}}",
+				},
+			},
+		})
+
+		Eventually(func() bool {
+			updated := &kaosv1alpha1.Agent{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, updated); err != nil {
+				return false
+			}
+			return updated.Status.Phase == "Failed" &&
+				strings.Contains(updated.Status.Message, "config.instructions references .Spec")
+		}, timeout, interval).Should(BeTrue())
+	})
+})
@@ -0,0 +1,107 @@
+package integration
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = Describe("ModelAPI deletion protection", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	var (
+		modelAPIName string
+		agentName    string
+		modelAPIKey  types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		modelAPIName = uniqueModelAPIName("protected")
+		modelAPIKey = types.NamespacedName{Name: modelAPIName, Namespace: namespace}
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+
+		// The ModelAPI is listed after another one, so every reference is checked
+		agentName = uniqueAgentName("referencing-agent")
+		createAgent(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPIs:           []string{"other", modelAPIName},
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+			},
+		})
+
+		// Wait for the controller to add its finalizer before deleting
+		Eventually(func() []string {
+			current := &kaosv1alpha1.ModelAPI{}
+			if err := k8sClient.Get(ctx, modelAPIKey, current); err != nil {
+				return nil
+			}
+			return current.Finalizers
+		}, timeout, interval).ShouldNot(BeEmpty())
+	})
+
+	deleteModelAPI := func() {
+		Expect(k8sClient.Delete(ctx, &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: modelAPIName, Namespace: namespace},
+		})).To(Succeed())
+	}
+
+	modelAPIGone := func() bool {
+		return apierrors.IsNotFound(k8sClient.Get(ctx, modelAPIKey, &kaosv1alpha1.ModelAPI{}))
+	}
+
+	It("blocks deletion while an Agent references the ModelAPI and proceeds once it is gone", func() {
+		deleteModelAPI()
+
+		Eventually(func() bool {
+			current := &kaosv1alpha1.ModelAPI{}
+			if err := k8sClient.Get(ctx, modelAPIKey, current); err != nil {
+				return false
+			}
+			return current.Status.Phase == "Terminating" &&
+				strings.Contains(current.Status.Message, "referenced by Agents "+agentName+";")
+		}, timeout, interval).Should(BeTrue())
+		Consistently(modelAPIGone, "2s", interval).Should(BeFalse())
+
+		Expect(k8sClient.Delete(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: agentName, Namespace: namespace},
+		})).To(Succeed())
+		Eventually(modelAPIGone, timeout, interval).Should(BeTrue())
+	})
+
+	It("proceeds once the Agent is repointed", func() {
+		deleteModelAPI()
+		Consistently(modelAPIGone, "2s", interval).Should(BeFalse())
+
+		updateAgent(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, func(a *kaosv1alpha1.Agent) {
+			a.Spec.ModelAPIs = []string{"other"}
+		})
+		Eventually(modelAPIGone, timeout, interval).Should(BeTrue())
+	})
+
+	It("deletes a referenced ModelAPI when the force-delete annotation is set", func() {
+		Eventually(func() error {
+			current := &kaosv1alpha1.ModelAPI{}
+			if err := k8sClient.Get(ctx, modelAPIKey, current); err != nil {
+				return err
+			}
+			current.Annotations = map[string]string{"kaos.tools/force-delete": "true"}
+			return k8sClient.Update(ctx, current)
+		}, timeout, interval).Should(Succeed())
+
+		deleteModelAPI()
+		Eventually(modelAPIGone, timeout, interval).Should(BeTrue())
+	})
+})
@@ -3,6 +3,8 @@ package integration
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -15,6 +17,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/features"
 )
 
 // uniqueModelAPIName generates unique names to avoid conflicts between tests
@@ -341,6 +344,219 @@ var _ = Describe("ModelAPI Controller", func() {
 		}, timeout, interval).Should(BeTrue(), "Deployment should be updated with new model")
 	})
 
+	It("should swap through a warm standby when model is changed in Hosted mode", func() {
+		Expect(features.DefaultGate.Set("WarmStandby=true")).To(Succeed())
+		defer features.DefaultGate.Set("WarmStandby=false")
+
+		name := uniqueModelAPIName("hosted-standby")
+		warmStandby := true
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeHosted,
+				HostedConfig: &kaosv1alpha1.HostedConfig{
+					Model:       "smollm2:135m",
+					WarmStandby: &warmStandby,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		mainKey := types.NamespacedName{Name: fmt.Sprintf("modelapi-%s", name), Namespace: namespace}
+		standbyKey := types.NamespacedName{Name: fmt.Sprintf("modelapi-%s-standby", name), Namespace: namespace}
+		initArgs := func(key types.NamespacedName) string {
+			deployment := &appsv1.Deployment{}
+			if err := k8sClient.Get(ctx, key, deployment); err != nil {
+				return ""
+			}
+			return deployment.Spec.Template.Spec.InitContainers[0].Args[0]
+		}
+		serviceSelector := func() map[string]string {
+			service := &corev1.Service{}
+			if err := k8sClient.Get(ctx, mainKey, service); err != nil {
+				return nil
+			}
+			return service.Spec.Selector
+		}
+
+		Eventually(func() string { return initArgs(mainKey) }, timeout, interval).Should(ContainSubstring("smollm2:135m"))
+		setDeploymentReplicas(ctx, mainKey, 1, 1)
+		mainSelector := serviceSelector()
+
+		// Update the model
+		Eventually(func() error {
+			current := &kaosv1alpha1.ModelAPI{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, current); err != nil {
+				return err
+			}
+			current.Spec.HostedConfig.Model = "llama2:7b"
+			return k8sClient.Update(ctx, current)
+		}, timeout, interval).Should(Succeed())
+
+		// The standby loads the new model while the main Deployment keeps serving the old one
+		Eventually(func() string { return initArgs(standbyKey) }, timeout, interval).Should(ContainSubstring("llama2:7b"))
+		Consistently(func() string { return initArgs(mainKey) }, "2s", interval).Should(ContainSubstring("smollm2:135m"))
+		standby := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, standbyKey, standby)).To(Succeed())
+		Expect(standby.OwnerReferences).To(HaveLen(1))
+		Expect(standby.OwnerReferences[0].Kind).To(Equal("ModelAPI"))
+
+		// Once the standby is ready the Service moves to it and the main Deployment is updated
+		setDeploymentReplicas(ctx, standbyKey, 1, 1)
+		Eventually(serviceSelector, timeout, interval).Should(Equal(map[string]string{
+			"app":                     "modelapi",
+			"kaos.tools/warm-standby": name,
+		}))
+		Eventually(func() string { return initArgs(mainKey) }, timeout, interval).Should(ContainSubstring("llama2:7b"))
+
+		// The Service moves back and the standby is removed once the main Deployment has rolled out
+		setDeploymentReplicas(ctx, mainKey, 1, 1)
+		Eventually(serviceSelector, timeout, interval).Should(Equal(mainSelector))
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, standbyKey, &appsv1.Deployment{}))
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	It("should pull every model and report them as supported in Hosted mode with models list", func() {
+		name := uniqueModelAPIName("hosted-multi")
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeHosted,
+				HostedConfig: &kaosv1alpha1.HostedConfig{
+					Model:  "smollm2:135m",
+					Models: []string{"qwen2.5:0.5b"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		// Verify one pull init container per model
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{
+				Name:      fmt.Sprintf("modelapi-%s", name),
+				Namespace: namespace,
+			}, deployment)
+		}, timeout, interval).Should(Succeed())
+		Expect(deployment.Spec.Template.Spec.InitContainers).To(HaveLen(2))
+		Expect(deployment.Spec.Template.Spec.InitContainers[0].Args[0]).To(ContainSubstring("ollama pull smollm2:135m"))
+		Expect(deployment.Spec.Template.Spec.InitContainers[1].Args[0]).To(ContainSubstring("ollama pull qwen2.5:0.5b"))
+
+		// Verify status lists the combined supported models
+		Eventually(func() []string {
+			updated := &kaosv1alpha1.ModelAPI{}
+			k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, updated)
+			return updated.Status.SupportedModels
+		}, timeout, interval).Should(Equal([]string{"smollm2:135m", "qwen2.5:0.5b"}))
+	})
+
+	It("should create an owned PVC and mount it when persistModels is enabled in Hosted mode", func() {
+		name := uniqueModelAPIName("hosted-persist")
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeHosted,
+				HostedConfig: &kaosv1alpha1.HostedConfig{
+					Model:         "smollm2:135m",
+					PersistModels: &kaosv1alpha1.PersistModelsConfig{},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		// Verify the PVC is created and owned by the ModelAPI
+		claim := &corev1.PersistentVolumeClaim{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{
+				Name:      fmt.Sprintf("modelapi-%s-models", name),
+				Namespace: namespace,
+			}, claim)
+		}, timeout, interval).Should(Succeed())
+		Expect(claim.Spec.AccessModes).To(ConsistOf(corev1.ReadWriteOnce))
+		Expect(claim.Spec.Resources.Requests.Storage().String()).To(Equal("10Gi"))
+		Expect(claim.OwnerReferences).To(HaveLen(1))
+		Expect(claim.OwnerReferences[0].Name).To(Equal(name))
+
+		// Verify the Deployment mounts the PVC instead of an emptyDir
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{
+				Name:      fmt.Sprintf("modelapi-%s", name),
+				Namespace: namespace,
+			}, deployment)
+		}, timeout, interval).Should(Succeed())
+		var dataVolume *corev1.Volume
+		for i := range deployment.Spec.Template.Spec.Volumes {
+			if deployment.Spec.Template.Spec.Volumes[i].Name == "ollama-data" {
+				dataVolume = &deployment.Spec.Template.Spec.Volumes[i]
+			}
+		}
+		Expect(dataVolume).NotTo(BeNil())
+		Expect(dataVolume.PersistentVolumeClaim).NotTo(BeNil())
+		Expect(dataVolume.PersistentVolumeClaim.ClaimName).To(Equal(claim.Name))
+		Expect(deployment.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+			Name:      "ollama-data",
+			MountPath: "/root/.ollama",
+		}))
+		Expect(deployment.Spec.Strategy.Type).To(Equal(appsv1.RecreateDeploymentStrategyType))
+	})
+
+	It("should reject Hosted mode without any model", func() {
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uniqueModelAPIName("hosted-empty"),
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:         kaosv1alpha1.ModelAPIModeHosted,
+				HostedConfig: &kaosv1alpha1.HostedConfig{},
+			},
+		}
+		err := k8sClient.Create(ctx, modelAPI)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("hostedConfig requires at least one of model or models"))
+	})
+
+	It("should reject warmStandby with a ReadWriteOnce model cache", func() {
+		warmStandby := true
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uniqueModelAPIName("hosted-standby-rwo"),
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeHosted,
+				HostedConfig: &kaosv1alpha1.HostedConfig{
+					Model:         "smollm2:135m",
+					PersistModels: &kaosv1alpha1.PersistModelsConfig{},
+					WarmStandby:   &warmStandby,
+				},
+			},
+		}
+		err := k8sClient.Create(ctx, modelAPI)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("warmStandby requires persistModels.accessMode ReadWriteMany"))
+	})
+
 	It("should trigger rolling update when models list is changed in Proxy mode", func() {
 		name := uniqueModelAPIName("proxy-update")
 		modelAPI := &kaosv1alpha1.ModelAPI{
@@ -611,6 +827,230 @@ model_list:
 		Expect(configMap.Data["config.yaml"]).To(ContainSubstring("openai/gpt-4"))
 		Expect(configMap.Data["config.yaml"]).To(ContainSubstring("openai/gpt-3.5-turbo"))
 	})
+	It("should not create a Deployment in External mode and expose the endpoint to agents", func() {
+		name := uniqueModelAPIName("external-api")
+		agentName := uniqueModelAPIName("external-agent")
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeExternal,
+				ExternalConfig: &kaosv1alpha1.ExternalConfig{
+					Endpoint: "https://llm.example.com/v1/",
+					Models:   []string{"openai/*"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		// Verify status reports the external endpoint as ready
+		key := types.NamespacedName{Name: name, Namespace: namespace}
+		updated := &kaosv1alpha1.ModelAPI{}
+		Eventually(func() bool {
+			if err := k8sClient.Get(ctx, key, updated); err != nil {
+				return false
+			}
+			return updated.Status.Ready
+		}, timeout, interval).Should(BeTrue())
+		Expect(updated.Status.Phase).To(Equal("Ready"))
+		Expect(updated.Status.Endpoint).To(Equal("https://llm.example.com/v1"))
+		Expect(updated.Status.Deployment).To(BeNil())
+
+		// Verify no Deployment or ConfigMap is created
+		Consistently(func() bool {
+			err := k8sClient.Get(ctx, types.NamespacedName{
+				Name:      fmt.Sprintf("modelapi-%s", name),
+				Namespace: namespace,
+			}, &appsv1.Deployment{})
+			return apierrors.IsNotFound(err)
+		}, 2*time.Second, interval).Should(BeTrue())
+		err := k8sClient.Get(ctx, types.NamespacedName{
+			Name:      fmt.Sprintf("litellm-config-%s", name),
+			Namespace: namespace,
+		}, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		// Verify an Agent referencing the ModelAPI receives the external endpoint
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI: name,
+				Model:    "openai/gpt-4o",
+			},
+		}
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{
+				Name:      fmt.Sprintf("agent-%s", agentName),
+				Namespace: namespace,
+			}, deployment)
+		}, timeout, interval).Should(Succeed())
+
+		envMap := make(map[string]string)
+		for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+			envMap[env.Name] = env.Value
+		}
+		Expect(envMap["MODEL_API_URL"]).To(Equal("https://llm.example.com/v1"))
+	})
+
+	It("should delete the Deployment, Service and ConfigMap when switched from Proxy to External mode", func() {
+		name := uniqueModelAPIName("proxy-to-external")
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models:  []string{"*"},
+					APIBase: "http://localhost:11434",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		deploymentKey := types.NamespacedName{Name: fmt.Sprintf("modelapi-%s", name), Namespace: namespace}
+		configMapKey := types.NamespacedName{Name: fmt.Sprintf("litellm-config-%s", name), Namespace: namespace}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, deploymentKey, &appsv1.Deployment{})
+		}, timeout, interval).Should(Succeed())
+		Eventually(func() error {
+			return k8sClient.Get(ctx, deploymentKey, &corev1.Service{})
+		}, timeout, interval).Should(Succeed())
+		Eventually(func() error {
+			return k8sClient.Get(ctx, configMapKey, &corev1.ConfigMap{})
+		}, timeout, interval).Should(Succeed())
+
+		// Switch to an external endpoint
+		Eventually(func() error {
+			current := &kaosv1alpha1.ModelAPI{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, current); err != nil {
+				return err
+			}
+			current.Spec.Mode = kaosv1alpha1.ModelAPIModeExternal
+			current.Spec.ProxyConfig = nil
+			current.Spec.ExternalConfig = &kaosv1alpha1.ExternalConfig{
+				Endpoint: "https://llm.example.com/v1",
+				Models:   []string{"*"},
+			}
+			return k8sClient.Update(ctx, current)
+		}, timeout, interval).Should(Succeed())
+
+		// Verify the workload created for Proxy mode is removed
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, deploymentKey, &appsv1.Deployment{}))
+		}, timeout, interval).Should(BeTrue())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, deploymentKey, &corev1.Service{}))
+		}, timeout, interval).Should(BeTrue())
+		Eventually(func() bool {
+			return apierrors.IsNotFound(k8sClient.Get(ctx, configMapKey, &corev1.ConfigMap{}))
+		}, timeout, interval).Should(BeTrue())
+
+		Eventually(func() string {
+			updated := &kaosv1alpha1.ModelAPI{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, updated); err != nil {
+				return ""
+			}
+			return updated.Status.Endpoint
+		}, timeout, interval).Should(Equal("https://llm.example.com/v1"))
+	})
+
+	It("should gate External mode readiness on the health check", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/health" {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		name := uniqueModelAPIName("external-health")
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeExternal,
+				ExternalConfig: &kaosv1alpha1.ExternalConfig{
+					Endpoint:   server.URL,
+					Models:     []string{"*"},
+					HealthPath: "/health",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		key := types.NamespacedName{Name: name, Namespace: namespace}
+		Eventually(func() bool {
+			updated := &kaosv1alpha1.ModelAPI{}
+			if err := k8sClient.Get(ctx, key, updated); err != nil {
+				return false
+			}
+			return updated.Status.Ready && updated.Status.Endpoint == server.URL
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	It("should keep External mode Pending when the health check fails", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		name := uniqueModelAPIName("external-unhealthy")
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeExternal,
+				ExternalConfig: &kaosv1alpha1.ExternalConfig{
+					Endpoint:   server.URL,
+					Models:     []string{"*"},
+					HealthPath: "/health",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		key := types.NamespacedName{Name: name, Namespace: namespace}
+		updated := &kaosv1alpha1.ModelAPI{}
+		Eventually(func() string {
+			if err := k8sClient.Get(ctx, key, updated); err != nil {
+				return ""
+			}
+			return updated.Status.Message
+		}, timeout, interval).Should(ContainSubstring("health check failed"))
+		Expect(updated.Status.Ready).To(BeFalse())
+		Expect(updated.Status.Phase).To(Equal("Pending"))
+	})
+
 })
 
 // containsSubstring checks if s contains substr (helper for test assertions)
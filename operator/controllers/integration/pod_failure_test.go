@@ -0,0 +1,114 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = Describe("Pod failures in status.message", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	var (
+		agentName     string
+		key           types.NamespacedName
+		deploymentKey types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		modelAPIName := uniqueAgentName("podfail-modelapi")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+
+		agentName = uniqueAgentName("podfail-agent")
+		createAgent(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+			},
+		})
+		key = types.NamespacedName{Name: agentName, Namespace: namespace}
+		deploymentKey = types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+	})
+
+	// createAgentPod creates a pod of the agent's Deployment reporting state for its
+	// container, then reports the Deployment with no ready replica; envtest runs no
+	// kubelet or Deployment controller, so both are set by hand
+	createAgentPod := func(state corev1.ContainerState, lastState corev1.ContainerState) string {
+		podName := fmt.Sprintf("agent-%s-1", agentName)
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      podName,
+				Namespace: namespace,
+				Labels:    map[string]string{"app": "agent", "agent": agentName},
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "agent", Image: "axsauze/kaos-agent:test"}}},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+		DeferCleanup(func() {
+			k8sClient.Delete(ctx, pod)
+		})
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:                 "agent",
+			Image:                "axsauze/kaos-agent:test",
+			State:                state,
+			LastTerminationState: lastState,
+		}}
+		Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+
+		Eventually(func() error {
+			return k8sClient.Get(ctx, deploymentKey, &appsv1.Deployment{})
+		}, timeout, interval).Should(Succeed())
+		setDeploymentReplicas(ctx, deploymentKey, 1, 0)
+		return podName
+	}
+
+	message := func() string {
+		agent := &kaosv1alpha1.Agent{}
+		if err := k8sClient.Get(ctx, key, agent); err != nil {
+			return ""
+		}
+		return agent.Status.Message
+	}
+
+	It("surfaces a container stuck in ImagePullBackOff", func() {
+		podName := createAgentPod(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+			Reason: "ImagePullBackOff", Message: `Back-off pulling image "axsauze/kaos-agent:missing"`,
+		}}, corev1.ContainerState{})
+
+		Eventually(message, timeout, interval).Should(Equal(fmt.Sprintf(
+			`Deployment ready replicas: 0/1; container agent in pod %s is ImagePullBackOff: Back-off pulling image "axsauze/kaos-agent:missing"`, podName)))
+		agent := &kaosv1alpha1.Agent{}
+		Expect(k8sClient.Get(ctx, key, agent)).To(Succeed())
+		Expect(agent.Status.Phase).To(Equal("Pending"))
+	})
+
+	It("reports a crash-looping container without reading its logs", func() {
+		podName := createAgentPod(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+			Reason: "CrashLoopBackOff", Message: "back-off 40s restarting failed container",
+		}}, corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}})
+
+		Eventually(message, timeout, interval).Should(Equal(fmt.Sprintf(
+			`Deployment ready replicas: 0/1; container agent in pod %s is CrashLoopBackOff: back-off 40s restarting failed container`, podName)))
+	})
+
+	It("keeps the replica message when no pod is failing", func() {
+		createAgentPod(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}, corev1.ContainerState{})
+
+		Eventually(message, timeout, interval).Should(HavePrefix("Deployment ready replicas: 0/1"))
+		Consistently(message, "2s", interval).Should(Equal("Deployment ready replicas: 0/1"))
+	})
+})
@@ -0,0 +1,82 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/controllers"
+)
+
+var _ = Describe("podSpec container names", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	var agentName string
+
+	createPodSpecAgent := func(containerName string) {
+		modelAPIName := uniqueAgentName("podspec-modelapi")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+
+		agentName = uniqueAgentName("podspec-agent")
+		createAgent(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				PodSpec: &corev1.PodSpec{Containers: []corev1.Container{{
+					Name: containerName,
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					},
+				}}},
+			},
+		})
+	}
+
+	It("merges an override that targets the generated container", func() {
+		createPodSpecAgent("agent")
+
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}, deployment)
+		}, timeout, interval).Should(Succeed())
+		containers := deployment.Spec.Template.Spec.Containers
+		Expect(containers).To(HaveLen(1))
+		Expect(containers[0].Resources.Limits.Memory().String()).To(Equal("1Gi"))
+	})
+
+	It("fails the Agent when an override names no generated container", func() {
+		createPodSpecAgent("main")
+
+		agent := &kaosv1alpha1.Agent{}
+		Eventually(func() bool {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, agent); err != nil {
+				return false
+			}
+			return agent.Status.Phase == "Failed" &&
+				strings.Contains(agent.Status.Message, `podSpec.containers entry "main" does not match a generated container (agent)`)
+		}, timeout, interval).Should(BeTrue())
+		condition := meta.FindStatusCondition(agent.Status.Conditions, controllers.ConditionTypeReady)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal("InvalidPodSpec"))
+
+		err := k8sClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}, &appsv1.Deployment{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,132 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/controllers"
+)
+
+var _ = Describe("Agent Progressing phase", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	var key, deploymentKey types.NamespacedName
+
+	BeforeEach(func() {
+		modelAPIName := uniqueAgentName("progressing-modelapi")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+
+		agentName := uniqueAgentName("progressing-agent")
+		createAgent(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+			},
+		})
+		key = types.NamespacedName{Name: agentName, Namespace: namespace}
+		deploymentKey = types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+	})
+
+	// setDeploymentStatus replaces the Deployment status, marking the current generation
+	// observed unless stale is set
+	setDeploymentStatus := func(status appsv1.DeploymentStatus, stale bool) {
+		Eventually(func() error {
+			deployment := &appsv1.Deployment{}
+			if err := k8sClient.Get(ctx, deploymentKey, deployment); err != nil {
+				return err
+			}
+			status.ObservedGeneration = deployment.Generation
+			if stale {
+				status.ObservedGeneration--
+			}
+			deployment.Status = status
+			return k8sClient.Status().Update(ctx, deployment)
+		}, timeout, interval).Should(Succeed())
+	}
+
+	// waitForPhase waits for the agent to report phase and returns it
+	waitForPhase := func(phase string) *kaosv1alpha1.Agent {
+		agent := &kaosv1alpha1.Agent{}
+		Eventually(func() string {
+			if err := k8sClient.Get(ctx, key, agent); err != nil {
+				return ""
+			}
+			return agent.Status.Phase
+		}, timeout, interval).Should(Equal(phase))
+		return agent
+	}
+
+	It("reports Progressing until the new pod template is rolled out", func() {
+		setDeploymentReplicas(ctx, deploymentKey, 1, 1)
+		agent := waitForPhase("Ready")
+		Expect(agent.Status.Ready).To(BeTrue())
+
+		// Change the spec; the old pod still serves before the Deployment controller
+		// observes the new generation
+		deployment := &appsv1.Deployment{}
+		Expect(k8sClient.Get(ctx, deploymentKey, deployment)).To(Succeed())
+		generation := deployment.Generation
+		updateAgent(ctx, key, func(agent *kaosv1alpha1.Agent) {
+			agent.Spec.Container = &kaosv1alpha1.ContainerOverride{
+				Env: []corev1.EnvVar{{Name: "CUSTOM_FLAG", Value: "on"}},
+			}
+		})
+		Eventually(func() int64 {
+			if err := k8sClient.Get(ctx, deploymentKey, deployment); err != nil {
+				return 0
+			}
+			return deployment.Generation
+		}, timeout, interval).Should(BeNumerically(">", generation))
+		setDeploymentStatus(appsv1.DeploymentStatus{
+			Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1,
+		}, true)
+		agent = waitForPhase("Progressing")
+		Expect(agent.Status.Ready).To(BeFalse())
+		ready := meta.FindStatusCondition(agent.Status.Conditions, controllers.ConditionTypeReady)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal("Progressing"))
+
+		// A new pod is surging while the old one is still running
+		setDeploymentStatus(appsv1.DeploymentStatus{
+			Replicas: 2, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1,
+		}, false)
+		Eventually(func() string {
+			if err := k8sClient.Get(ctx, key, agent); err != nil {
+				return ""
+			}
+			return agent.Status.Message
+		}, timeout, interval).Should(ContainSubstring("1 old replicas pending termination"))
+		Expect(agent.Status.Phase).To(Equal("Progressing"))
+
+		// The new replica is available and the old one is gone
+		setDeploymentReplicas(ctx, deploymentKey, 1, 1)
+		agent = waitForPhase("Ready")
+		Expect(agent.Status.Ready).To(BeTrue())
+	})
+
+	It("stays Pending while no replica is ready", func() {
+		setDeploymentReplicas(ctx, deploymentKey, 1, 0)
+		Consistently(func() string {
+			agent := &kaosv1alpha1.Agent{}
+			if err := k8sClient.Get(ctx, key, agent); err != nil {
+				return ""
+			}
+			return agent.Status.Phase
+		}, "2s", interval).Should(Equal("Pending"))
+	})
+})
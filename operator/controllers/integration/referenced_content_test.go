@@ -0,0 +1,135 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+var _ = Describe("Referenced ConfigMap and Secret content", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	var (
+		modelAPIName string
+		agentName    string
+		workloadKey  types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		modelAPIName = uniqueAgentName("content-modelapi")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+		agentName = uniqueAgentName("content-agent")
+		workloadKey = types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+	})
+
+	It("rolls the pods when a Secret referenced through podSpec is rotated", func() {
+		keys := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: uniqueAgentName("tool-keys"), Namespace: namespace},
+			Data:       map[string][]byte{"SEARCH_API_KEY": []byte("key-1")},
+		}
+		Expect(k8sClient.Create(ctx, keys)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, keys)
+		}()
+
+		createAgent(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				PodSpec: &corev1.PodSpec{Containers: []corev1.Container{{
+					Name: "agent",
+					EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: keys.Name},
+					}}},
+				}}},
+			},
+		})
+
+		podSpecHash := func() string {
+			deployment := &appsv1.Deployment{}
+			if err := k8sClient.Get(ctx, workloadKey, deployment); err != nil {
+				return ""
+			}
+			return deployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+		}
+		Eventually(podSpecHash, timeout, interval).ShouldNot(BeEmpty())
+		initial := podSpecHash()
+
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: keys.Name, Namespace: namespace}, keys); err != nil {
+				return err
+			}
+			keys.Data["SEARCH_API_KEY"] = []byte("key-2")
+			return k8sClient.Update(ctx, keys)
+		}, timeout, interval).Should(Succeed())
+		Eventually(podSpecHash, timeout, interval).ShouldNot(Equal(initial))
+	})
+
+	It("updates a scheduled agent's CronJob when its instructions are edited", func() {
+		prompts := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: uniqueAgentName("prompts"), Namespace: namespace},
+			Data:       map[string]string{"system.md": "Be brief."},
+		}
+		Expect(k8sClient.Create(ctx, prompts)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, prompts)
+		}()
+
+		createAgent(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				Mode:                kaosv1alpha1.AgentModeJob,
+				Job:                 &kaosv1alpha1.AgentJobConfig{Schedule: "0 * * * *"},
+				Config: &kaosv1alpha1.AgentConfig{
+					InstructionsFrom: &kaosv1alpha1.InstructionsSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: prompts.Name},
+							Key:                  "system.md",
+						},
+					},
+				},
+			},
+		})
+
+		cronJobHash := func() string {
+			cronJob := &batchv1.CronJob{}
+			if err := k8sClient.Get(ctx, workloadKey, cronJob); err != nil {
+				return ""
+			}
+			return cronJob.Spec.JobTemplate.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+		}
+		Eventually(cronJobHash, timeout, interval).ShouldNot(BeEmpty())
+		initial := cronJobHash()
+
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: prompts.Name, Namespace: namespace}, prompts); err != nil {
+				return err
+			}
+			prompts.Data["system.md"] = "Be thorough."
+			return k8sClient.Update(ctx, prompts)
+		}, timeout, interval).Should(Succeed())
+		Eventually(cronJobHash, timeout, interval).ShouldNot(Equal(initial))
+	})
+})
@@ -0,0 +1,69 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = Describe("gatewayRoute.requestHeaders", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	headersModelAPI := func(name string, headers map[string]string) *kaosv1alpha1.ModelAPI {
+		return &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"mock-model"},
+				},
+				GatewayRoute: &kaosv1alpha1.GatewayRoute{RequestHeaders: headers},
+			},
+		}
+	}
+
+	It("sets the headers on the HTTPRoute", func() {
+		enableGatewayAPI()
+
+		name := uniqueModelAPIName("headers")
+		modelAPI := headersModelAPI(name, map[string]string{"x-tenant-id": "acme"})
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		route := &gatewayv1.HTTPRoute{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("modelapi-%s", name), Namespace: namespace}, route)
+		}, timeout, interval).Should(Succeed())
+		Expect(route.Spec.Rules[0].Filters[0].RequestHeaderModifier.Set).To(ConsistOf(
+			gatewayv1.HTTPHeader{Name: "x-tenant-id", Value: "acme"},
+		))
+
+		// The ModelAPI reports its endpoint on the Gateway
+		Eventually(func() map[string]string {
+			updated := &kaosv1alpha1.ModelAPI{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, updated); err != nil {
+				return nil
+			}
+			return updated.Status.GatewayEndpoints
+		}, timeout, interval).Should(HaveKey("kaos-gateway"))
+	})
+
+	It("rejects an invalid header name at admission", func() {
+		err := k8sClient.Create(ctx, headersModelAPI(uniqueModelAPIName("headers-invalid"), map[string]string{"x tenant": "acme"}))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("requestHeaders keys must be valid HTTP header names"))
+	})
+})
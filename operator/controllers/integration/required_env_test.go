@@ -0,0 +1,167 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = Describe("MCPServer required runtime env", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	var key types.NamespacedName
+
+	// createSlack creates a slack MCPServer with container
+	createSlack := func(container *kaosv1alpha1.ContainerOverride) {
+		key = types.NamespacedName{Name: uniqueMCPServerName("slack-env"), Namespace: namespace}
+		mcp := &kaosv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+			Spec:       kaosv1alpha1.MCPServerSpec{Runtime: "slack", Container: container},
+		}
+		Expect(k8sClient.Create(ctx, mcp)).To(Succeed())
+		DeferCleanup(func() {
+			k8sClient.Delete(ctx, mcp)
+		})
+	}
+
+	// waitForPhase waits for the MCPServer to report phase and returns it
+	waitForPhase := func(phase string) *kaosv1alpha1.MCPServer {
+		mcp := &kaosv1alpha1.MCPServer{}
+		Eventually(func() string {
+			if err := k8sClient.Get(ctx, key, mcp); err != nil {
+				return ""
+			}
+			return mcp.Status.Phase
+		}, timeout, interval).Should(Equal(phase))
+		return mcp
+	}
+
+	updateMCPServer := func(mutate func(*kaosv1alpha1.MCPServer)) {
+		Eventually(func() error {
+			current := &kaosv1alpha1.MCPServer{}
+			if err := k8sClient.Get(ctx, key, current); err != nil {
+				return err
+			}
+			mutate(current)
+			return k8sClient.Update(ctx, current)
+		}, timeout, interval).Should(Succeed())
+	}
+
+	createSecret := func(name string, data map[string][]byte) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       data,
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(func() {
+			k8sClient.Delete(ctx, secret)
+		})
+		return secret
+	}
+
+	deploymentKey := func() types.NamespacedName {
+		return types.NamespacedName{Name: fmt.Sprintf("mcpserver-%s", key.Name), Namespace: namespace}
+	}
+
+	It("fails with MissingConfig naming the env vars the slack runtime lacks", func() {
+		createSlack(&kaosv1alpha1.ContainerOverride{
+			Env: []corev1.EnvVar{{Name: "SLACK_TEAM_ID", Value: "T123"}},
+		})
+
+		mcp := waitForPhase("Failed")
+		Expect(mcp.Status.Message).To(Equal("Runtime slack requires env vars that are not set: SLACK_BOT_TOKEN"))
+		Expect(mcp.Status.Conditions).To(ContainElement(And(
+			HaveField("Type", "Ready"),
+			HaveField("Reason", "MissingConfig"),
+		)))
+
+		// No Deployment is created to crash-loop
+		Consistently(func() error {
+			return k8sClient.Get(ctx, deploymentKey(), &appsv1.Deployment{})
+		}, "2s", interval).ShouldNot(Succeed())
+	})
+
+	It("accepts required env set directly or through an envFrom Secret", func() {
+		secret := createSecret(uniqueMCPServerName("slack-credentials"), map[string][]byte{"BOT_TOKEN": []byte("xoxb-123")})
+		createSlack(&kaosv1alpha1.ContainerOverride{
+			Env: []corev1.EnvVar{{Name: "SLACK_TEAM_ID", Value: "T123"}},
+		})
+		waitForPhase("Failed")
+
+		// The Secret's keys count once it is referenced with the matching prefix
+		updateMCPServer(func(mcp *kaosv1alpha1.MCPServer) {
+			mcp.Spec.PodSpec = &corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "mcp-server",
+				EnvFrom: []corev1.EnvFromSource{{
+					Prefix:    "SLACK_",
+					SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name}},
+				}},
+			}}}
+		})
+
+		waitForPhase("Pending")
+		Eventually(func() error {
+			return k8sClient.Get(ctx, deploymentKey(), &appsv1.Deployment{})
+		}, timeout, interval).Should(Succeed())
+	})
+
+	It("injects the runtime's secretEnv from secretRef and checks the keys exist", func() {
+		secretName := uniqueMCPServerName("slack-secret")
+		secret := createSecret(secretName, map[string][]byte{"SLACK_BOT_TOKEN": []byte("xoxb-123")})
+		createSlack(nil)
+
+		// A Secret without the team ID key does not satisfy the runtime
+		updateMCPServer(func(mcp *kaosv1alpha1.MCPServer) {
+			mcp.Spec.SecretRef = &corev1.LocalObjectReference{Name: secretName}
+		})
+		Eventually(func() string {
+			mcp := &kaosv1alpha1.MCPServer{}
+			if err := k8sClient.Get(ctx, key, mcp); err != nil {
+				return ""
+			}
+			return mcp.Status.Message
+		}, timeout, interval).Should(Equal("Runtime slack requires env vars that are not set: SLACK_TEAM_ID"))
+
+		// Secret events only map to MCPServers that have a Deployment, so touch the
+		// MCPServer rather than wait for the periodic recheck
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: secretName, Namespace: namespace}, secret); err != nil {
+				return err
+			}
+			secret.Data["SLACK_TEAM_ID"] = []byte("T123")
+			return k8sClient.Update(ctx, secret)
+		}, timeout, interval).Should(Succeed())
+		updateMCPServer(func(mcp *kaosv1alpha1.MCPServer) {
+			mcp.Labels = map[string]string{"secret-rotated": "true"}
+		})
+		waitForPhase("Pending")
+
+		deployment := &appsv1.Deployment{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, deploymentKey(), deployment)
+		}, timeout, interval).Should(Succeed())
+		env := deployment.Spec.Template.Spec.Containers[0].Env
+		optional := true
+		Expect(env).To(ContainElements(
+			corev1.EnvVar{Name: "SLACK_BOT_TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName}, Key: "SLACK_BOT_TOKEN",
+			}}},
+			corev1.EnvVar{Name: "SLACK_TEAM_ID", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName}, Key: "SLACK_TEAM_ID",
+			}}},
+			// Secret env vars the runtime does not require are optional
+			corev1.EnvVar{Name: "SLACK_SIGNING_SECRET", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName}, Key: "SLACK_SIGNING_SECRET", Optional: &optional,
+			}}},
+		))
+	})
+})
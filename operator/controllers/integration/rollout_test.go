@@ -0,0 +1,148 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+var _ = Describe("disable-auto-rollout annotation", func() {
+	ctx := context.Background()
+	const namespace = "default"
+	const disableAutoRolloutAnnotation = "kaos.tools/disable-auto-rollout"
+
+	var (
+		key           types.NamespacedName
+		deploymentKey types.NamespacedName
+		prompts       *corev1.ConfigMap
+	)
+
+	BeforeEach(func() {
+		modelAPIName := uniqueAgentName("rollout-modelapi")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+
+		prompts = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: uniqueAgentName("rollout-prompts"), Namespace: namespace},
+			Data:       map[string]string{"system.md": "Be brief."},
+		}
+		Expect(k8sClient.Create(ctx, prompts)).To(Succeed())
+		DeferCleanup(func() {
+			k8sClient.Delete(ctx, prompts)
+		})
+
+		agentName := uniqueAgentName("rollout-agent")
+		createAgent(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        agentName,
+				Namespace:   namespace,
+				Annotations: map[string]string{disableAutoRolloutAnnotation: "true"},
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+				Config: &kaosv1alpha1.AgentConfig{
+					InstructionsFrom: &kaosv1alpha1.InstructionsSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: prompts.Name},
+							Key:                  "system.md",
+						},
+					},
+				},
+			},
+		})
+		key = types.NamespacedName{Name: agentName, Namespace: namespace}
+		deploymentKey = types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}
+	})
+
+	// getDeployment waits for the agent Deployment to carry its pod spec hash
+	getDeployment := func() *appsv1.Deployment {
+		deployment := &appsv1.Deployment{}
+		Eventually(func() bool {
+			if err := k8sClient.Get(ctx, deploymentKey, deployment); err != nil {
+				return false
+			}
+			return deployment.Annotations[util.PodSpecHashAnnotation] != "" ||
+				deployment.Spec.Template.Annotations[util.PodSpecHashAnnotation] != ""
+		}, timeout, interval).Should(BeTrue())
+		return deployment
+	}
+
+	editPrompts := func() {
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: prompts.Name, Namespace: namespace}, prompts); err != nil {
+				return err
+			}
+			prompts.Data["system.md"] = "Be thorough."
+			return k8sClient.Update(ctx, prompts)
+		}, timeout, interval).Should(Succeed())
+	}
+
+	It("keeps the hash annotations off the pod template", func() {
+		deployment := getDeployment()
+		Expect(deployment.Spec.Template.Annotations).NotTo(HaveKey(util.PodSpecHashAnnotation))
+		Expect(deployment.Spec.Template.Annotations).NotTo(HaveKey("kaos.tools/referenced-content-hash"))
+		Expect(deployment.Annotations[util.PodSpecHashAnnotation]).NotTo(BeEmpty())
+	})
+
+	It("updates the Deployment without touching the pod template on content changes", func() {
+		initial := getDeployment()
+
+		editPrompts()
+
+		updated := &appsv1.Deployment{}
+		Eventually(func() string {
+			if err := k8sClient.Get(ctx, deploymentKey, updated); err != nil {
+				return ""
+			}
+			return updated.Annotations[util.PodSpecHashAnnotation]
+		}, timeout, interval).ShouldNot(Or(BeEmpty(), Equal(initial.Annotations[util.PodSpecHashAnnotation])))
+		Expect(updated.Spec.Template).To(Equal(initial.Spec.Template))
+	})
+
+	It("still applies spec changes to the pod template", func() {
+		getDeployment()
+
+		updateAgent(ctx, key, func(agent *kaosv1alpha1.Agent) {
+			agent.Spec.Container = &kaosv1alpha1.ContainerOverride{
+				Env: []corev1.EnvVar{{Name: "CUSTOM_FLAG", Value: "on"}},
+			}
+		})
+
+		Eventually(func() []corev1.EnvVar {
+			deployment := &appsv1.Deployment{}
+			if err := k8sClient.Get(ctx, deploymentKey, deployment); err != nil {
+				return nil
+			}
+			return deployment.Spec.Template.Spec.Containers[0].Env
+		}, timeout, interval).Should(ContainElement(corev1.EnvVar{Name: "CUSTOM_FLAG", Value: "on"}))
+	})
+
+	It("moves the hash back to the pod template when re-enabled", func() {
+		getDeployment()
+
+		updateAgent(ctx, key, func(agent *kaosv1alpha1.Agent) {
+			delete(agent.Annotations, disableAutoRolloutAnnotation)
+		})
+		editPrompts()
+
+		Eventually(func() bool {
+			deployment := &appsv1.Deployment{}
+			if err := k8sClient.Get(ctx, deploymentKey, deployment); err != nil {
+				return false
+			}
+			_, onDeployment := deployment.Annotations[util.PodSpecHashAnnotation]
+			_, onTemplate := deployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+			return onTemplate && !onDeployment
+		}, timeout, interval).Should(BeTrue())
+	})
+})
@@ -0,0 +1,54 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = Describe("Agent Service port", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	It("updates the existing agent Service when servicePort changes", func() {
+		modelAPIName := uniqueAgentName("port-modelapi")
+		createProxyModelAPI(ctx, modelAPIName, namespace)
+
+		agentName := uniqueAgentName("port-agent")
+		createAgent(ctx, &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      agentName,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:            modelAPIName,
+				Model:               "mock-model",
+				WaitForDependencies: boolPtr(false),
+			},
+		})
+
+		port := func() corev1.ServicePort {
+			service := &corev1.Service{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("agent-%s", agentName), Namespace: namespace}, service); err != nil {
+				return corev1.ServicePort{}
+			}
+			return service.Spec.Ports[0]
+		}
+		Eventually(func() string { return port().Name }, timeout, interval).Should(Equal("http"))
+
+		updateAgent(ctx, types.NamespacedName{Name: agentName, Namespace: namespace}, func(agent *kaosv1alpha1.Agent) {
+			agent.Spec.ServicePort = &kaosv1alpha1.ServicePortConfig{Name: "http-a2a", AppProtocol: "http"}
+		})
+
+		Eventually(func() string { return port().Name }, timeout, interval).Should(Equal("http-a2a"))
+		Expect(port().AppProtocol).To(HaveValue(Equal("http")))
+		Expect(port().Port).To(Equal(int32(8000)))
+	})
+})
@@ -0,0 +1,72 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	mcpruntime "github.com/axsaucedo/kaos/operator/pkg/runtime"
+)
+
+var _ = Describe("Shared resources namespace", func() {
+	ctx := context.Background()
+
+	createNamespace := func(name string) {
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		DeferCleanup(func() {
+			k8sClient.Delete(ctx, ns)
+		})
+	}
+
+	It("resolves an MCPServer runtime from the registry in the central namespace", func() {
+		sharedNamespace := uniqueMCPServerName("kaos-shared")
+		createNamespace(sharedNamespace)
+		Expect(os.Setenv("SHARED_RESOURCES_NAMESPACE", sharedNamespace)).To(Succeed())
+		DeferCleanup(os.Unsetenv, "SHARED_RESOURCES_NAMESPACE")
+
+		registry := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: mcpruntime.ConfigMapName, Namespace: sharedNamespace},
+			Data: map[string]string{mcpruntime.RegistryKey: `
+runtimes:
+  python-string:
+    type: python
+    image: axsauze/kaos-mcp-python-string:shared
+    paramsEnvVar: MCP_TOOLS_STRING
+`},
+		}
+		Expect(k8sClient.Create(ctx, registry)).To(Succeed())
+
+		// The MCPServer lives in a team namespace, away from both registries
+		teamNamespace := uniqueMCPServerName("team-a")
+		createNamespace(teamNamespace)
+		name := uniqueMCPServerName("shared-tools")
+		mcp := &kaosv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: teamNamespace},
+			Spec: kaosv1alpha1.MCPServerSpec{
+				Runtime: "python-string",
+				Params:  "def noop() -> str:\n    return ''",
+			},
+		}
+		Expect(k8sClient.Create(ctx, mcp)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, mcp)
+		}()
+
+		Eventually(func() string {
+			deployment := &appsv1.Deployment{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("mcpserver-%s", name), Namespace: teamNamespace}, deployment); err != nil {
+				return ""
+			}
+			return deployment.Spec.Template.Spec.Containers[0].Image
+		}, timeout, interval).Should(Equal("axsauze/kaos-mcp-python-string:shared"))
+	})
+})
@@ -6,15 +6,22 @@ package integration
 
 import (
 	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -22,9 +29,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/yaml"
 
 	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
 	"github.com/axsaucedo/kaos/operator/controllers"
+	"github.com/axsaucedo/kaos/operator/pkg/validation"
 )
 
 var (
@@ -47,8 +58,14 @@ var _ = BeforeSuite(func() {
 
 	By("bootstrapping test environment")
 	testEnv = &envtest.Environment{
-		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "config", "crd", "bases")},
+		CRDDirectoryPaths: []string{
+			filepath.Join("..", "..", "config", "crd", "bases"),
+			gatewayAPICRDDir(),
+		},
 		ErrorIfCRDPathMissing: true,
+		WebhookInstallOptions: envtest.WebhookInstallOptions{
+			ValidatingWebhooks: loadValidatingWebhooks(),
+		},
 	}
 
 	// Find envtest binaries
@@ -64,6 +81,9 @@ var _ = BeforeSuite(func() {
 	err = kaosv1alpha1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = gatewayv1.Install(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
 	Expect(err).NotTo(HaveOccurred())
 	Expect(k8sClient).NotTo(BeNil())
@@ -92,6 +112,8 @@ runtimes:
     image: zencoderai/slack-mcp:latest
     command: ["--transport", "http", "--port", "8000"]
     transport: http
+    requiredEnv: [SLACK_BOT_TOKEN, SLACK_TEAM_ID]
+    secretEnv: [SLACK_BOT_TOKEN, SLACK_TEAM_ID, SLACK_SIGNING_SECRET]
 `,
 		},
 	}
@@ -102,29 +124,56 @@ runtimes:
 	os.Setenv("DEFAULT_MCP_SERVER_IMAGE", "axsauze/kaos-mcp-server:test")
 	os.Setenv("DEFAULT_LITELLM_IMAGE", "ghcr.io/berriai/litellm:test")
 	os.Setenv("DEFAULT_OLLAMA_IMAGE", "alpine/ollama:latest")
+	os.Setenv("DEFAULT_WAIT_IMAGE", "curlimages/curl:test")
 
 	// Start controller manager with all controllers
+	webhookOpts := &testEnv.WebhookInstallOptions
 	k8sManager, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme: scheme.Scheme,
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Host:    webhookOpts.LocalServingHost,
+			Port:    webhookOpts.LocalServingPort,
+			CertDir: webhookOpts.LocalServingCertDir,
+		}),
 	})
 	Expect(err).ToNot(HaveOccurred())
 
+	controllers.SetStatusReader(k8sManager.GetAPIReader())
+
+	err = validation.SetupModelAPIWebhookWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = validation.SetupAgentWebhookWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	clientset, err := kubernetes.NewForConfig(cfg)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&controllers.AgentReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: k8sManager.GetScheme(),
+		Client:    k8sManager.GetClient(),
+		APIReader: k8sManager.GetAPIReader(),
+		Clientset: clientset,
+		Scheme:    k8sManager.GetScheme(),
+		Recorder:  k8sManager.GetEventRecorderFor("agent-controller"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&controllers.MCPServerReconciler{
 		Client:          k8sManager.GetClient(),
+		APIReader:       k8sManager.GetAPIReader(),
+		Clientset:       clientset,
 		Scheme:          k8sManager.GetScheme(),
+		Recorder:        k8sManager.GetEventRecorderFor("mcpserver-controller"),
 		SystemNamespace: "default",
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
 	err = (&controllers.ModelAPIReconciler{
-		Client: k8sManager.GetClient(),
-		Scheme: k8sManager.GetScheme(),
+		Client:    k8sManager.GetClient(),
+		APIReader: k8sManager.GetAPIReader(),
+		Clientset: clientset,
+		Scheme:    k8sManager.GetScheme(),
+		Recorder:  k8sManager.GetEventRecorderFor("modelapi-controller"),
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -133,6 +182,17 @@ runtimes:
 		err = k8sManager.Start(ctx)
 		Expect(err).ToNot(HaveOccurred())
 	}()
+
+	// Wait for the webhook server to accept TLS connections
+	dialer := &net.Dialer{Timeout: time.Second}
+	addrPort := fmt.Sprintf("%s:%d", webhookOpts.LocalServingHost, webhookOpts.LocalServingPort)
+	Eventually(func() error {
+		conn, err := tls.DialWithDialer(dialer, "tcp", addrPort, &tls.Config{InsecureSkipVerify: true})
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}, timeout, interval).Should(Succeed())
 })
 
 var _ = AfterSuite(func() {
@@ -148,6 +208,34 @@ const (
 	interval = time.Millisecond * 250
 )
 
+// webhookTestNamespaceLabel scopes the validating webhooks to labelled namespaces in
+// the suite, so other tests can still exercise the reconcile-time fallback checks
+const webhookTestNamespaceLabel = "kaos.tools/webhook-test"
+
+// loadValidatingWebhooks reads the generated webhook manifests and restricts them to
+// namespaces carrying webhookTestNamespaceLabel
+func loadValidatingWebhooks() []*admissionv1.ValidatingWebhookConfiguration {
+	data, err := os.ReadFile(filepath.Join("..", "..", "config", "webhook", "manifests.yaml"))
+	Expect(err).NotTo(HaveOccurred())
+
+	config := &admissionv1.ValidatingWebhookConfiguration{}
+	Expect(yaml.Unmarshal(data, config)).To(Succeed())
+	for i := range config.Webhooks {
+		config.Webhooks[i].NamespaceSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{webhookTestNamespaceLabel: "true"},
+		}
+	}
+	return []*admissionv1.ValidatingWebhookConfiguration{config}
+}
+
+// gatewayAPICRDDir locates the standard Gateway API CRDs in the module cache, so the
+// HTTPRoutes created when the Gateway API is enabled can be stored
+func gatewayAPICRDDir() string {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", "sigs.k8s.io/gateway-api").Output()
+	Expect(err).NotTo(HaveOccurred())
+	return filepath.Join(strings.TrimSpace(string(out)), "config", "crd", "standard")
+}
+
 // getFirstFoundEnvTestBinaryDir finds envtest binaries for IDE support
 func getFirstFoundEnvTestBinaryDir() string {
 	basePath := filepath.Join("..", "..", "bin", "k8s")
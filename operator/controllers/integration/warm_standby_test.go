@@ -0,0 +1,146 @@
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/features"
+)
+
+var _ = Describe("Hosted mode warm standby", func() {
+	ctx := context.Background()
+	const namespace = "default"
+
+	var (
+		name       string
+		mainKey    types.NamespacedName
+		standbyKey types.NamespacedName
+	)
+
+	BeforeEach(func() {
+		Expect(features.DefaultGate.Set("WarmStandby=true")).To(Succeed())
+		DeferCleanup(features.DefaultGate.Set, "WarmStandby=false")
+
+		name = uniqueModelAPIName("standby")
+		warmStandby := true
+		modelAPI := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeHosted,
+				HostedConfig: &kaosv1alpha1.HostedConfig{
+					Model:       "smollm2:135m",
+					WarmStandby: &warmStandby,
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		DeferCleanup(func() {
+			k8sClient.Delete(ctx, modelAPI)
+		})
+
+		mainKey = types.NamespacedName{Name: fmt.Sprintf("modelapi-%s", name), Namespace: namespace}
+		standbyKey = types.NamespacedName{Name: fmt.Sprintf("modelapi-%s-standby", name), Namespace: namespace}
+	})
+
+	// pulledModel returns the model pulled by the Deployment's init container
+	pulledModel := func(key types.NamespacedName) func() string {
+		return func() string {
+			deployment := &appsv1.Deployment{}
+			if err := k8sClient.Get(ctx, key, deployment); err != nil {
+				return ""
+			}
+			return deployment.Spec.Template.Spec.InitContainers[0].Args[0]
+		}
+	}
+
+	updateModelAPI := func(mutate func(*kaosv1alpha1.ModelAPI)) {
+		Eventually(func() error {
+			current := &kaosv1alpha1.ModelAPI{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, current); err != nil {
+				return err
+			}
+			mutate(current)
+			return k8sClient.Update(ctx, current)
+		}, timeout, interval).Should(Succeed())
+	}
+
+	changeModel := func(model string) {
+		updateModelAPI(func(modelAPI *kaosv1alpha1.ModelAPI) {
+			modelAPI.Spec.HostedConfig.Model = model
+		})
+	}
+
+	standbyGone := func() bool {
+		return apierrors.IsNotFound(k8sClient.Get(ctx, standbyKey, &appsv1.Deployment{}))
+	}
+
+	// serveInitialModel waits for the main Deployment and reports it ready
+	serveInitialModel := func() {
+		Eventually(pulledModel(mainKey), timeout, interval).Should(ContainSubstring("smollm2:135m"))
+		setDeploymentReplicas(ctx, mainKey, 1, 1)
+	}
+
+	It("restarts the standby when the spec changes again during the swap", func() {
+		serveInitialModel()
+
+		changeModel("qwen2.5:0.5b")
+		Eventually(pulledModel(standbyKey), timeout, interval).Should(ContainSubstring("qwen2.5:0.5b"))
+
+		changeModel("llama3.2:1b")
+		Eventually(pulledModel(standbyKey), timeout, interval).Should(ContainSubstring("llama3.2:1b"))
+		Consistently(pulledModel(mainKey), "2s", interval).Should(ContainSubstring("smollm2:135m"))
+	})
+
+	It("removes the standby and updates in place when warmStandby is turned off", func() {
+		serveInitialModel()
+
+		changeModel("qwen2.5:0.5b")
+		Eventually(pulledModel(standbyKey), timeout, interval).Should(ContainSubstring("qwen2.5:0.5b"))
+
+		updateModelAPI(func(modelAPI *kaosv1alpha1.ModelAPI) {
+			modelAPI.Spec.HostedConfig.WarmStandby = nil
+		})
+		Eventually(pulledModel(mainKey), timeout, interval).Should(ContainSubstring("qwen2.5:0.5b"))
+		Eventually(standbyGone, timeout, interval).Should(BeTrue())
+
+		service := &corev1.Service{}
+		Expect(k8sClient.Get(ctx, mainKey, service)).To(Succeed())
+		Expect(service.Spec.Selector).To(HaveKeyWithValue("modelapi", name))
+	})
+
+	It("updates the Deployment in place while the WarmStandby feature gate is off", func() {
+		Expect(features.DefaultGate.Set("WarmStandby=false")).To(Succeed())
+		serveInitialModel()
+
+		changeModel("qwen2.5:0.5b")
+		Eventually(pulledModel(mainKey), timeout, interval).Should(ContainSubstring("qwen2.5:0.5b"))
+		Expect(standbyGone()).To(BeTrue())
+
+		Eventually(func() []string {
+			events := &corev1.EventList{}
+			if err := k8sClient.List(ctx, events, client.InNamespace(namespace)); err != nil {
+				return nil
+			}
+			var reasons []string
+			for _, event := range events.Items {
+				if event.InvolvedObject.Name == name {
+					reasons = append(reasons, event.Reason)
+				}
+			}
+			return reasons
+		}, timeout, interval).Should(ContainElement("FeatureGateDisabled"))
+	})
+})
@@ -0,0 +1,177 @@
+package integration
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = Describe("ModelAPI validating webhook", func() {
+	ctx := context.Background()
+	var namespace string
+
+	BeforeEach(func() {
+		namespace = uniqueModelAPIName("webhook-test")
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   namespace,
+				Labels: map[string]string{webhookTestNamespaceLabel: "true"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		DeferCleanup(func() {
+			k8sClient.Delete(ctx, ns)
+		})
+	})
+
+	proxyModelAPI := func(name string, models []string, configYaml string) *kaosv1alpha1.ModelAPI {
+		return &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models:     models,
+					ConfigYaml: &kaosv1alpha1.ConfigYamlSource{FromString: configYaml},
+				},
+			},
+		}
+	}
+
+	const mismatchedConfigYaml = `
+model_list:
+  - model_name: "openai/gpt-4"
+    litellm_params:
+      model: "openai/gpt-4"
+  - model_name: "gemini/gemini-pro"
+    litellm_params:
+      model: "gemini/gemini-pro"
+`
+
+	It("should reject a configYaml model not in the models list at admission", func() {
+		modelAPI := proxyModelAPI(uniqueModelAPIName("webhook-mismatch"),
+			[]string{"openai/gpt-4", "anthropic/claude-3"}, mismatchedConfigYaml)
+
+		err := k8sClient.Create(ctx, modelAPI)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("vmodelapi.kaos.tools"))
+		Expect(err.Error()).To(ContainSubstring(`model_name "gemini/gemini-pro" in configYaml not found in models list`))
+	})
+
+	It("should admit a configYaml covered by a provider wildcard", func() {
+		modelAPI := proxyModelAPI(uniqueModelAPIName("webhook-wildcard"),
+			[]string{"openai/*", "gemini/*"}, mismatchedConfigYaml)
+
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+	})
+
+	It("should reject an update that narrows models below the configYaml", func() {
+		name := uniqueModelAPIName("webhook-update")
+		modelAPI := proxyModelAPI(name, []string{"*"}, mismatchedConfigYaml)
+		Expect(k8sClient.Create(ctx, modelAPI)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, modelAPI)
+		}()
+
+		// Retry on conflicts with the controller (e.g. adding its finalizer)
+		Eventually(func() string {
+			current := &kaosv1alpha1.ModelAPI{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, current); err != nil {
+				return err.Error()
+			}
+			current.Spec.ProxyConfig.Models = []string{"openai/*"}
+			if err := k8sClient.Update(ctx, current); err != nil {
+				return err.Error()
+			}
+			return "admitted"
+		}, timeout, interval).Should(ContainSubstring(`model_name "gemini/gemini-pro" in configYaml not found`))
+
+		stored := &kaosv1alpha1.ModelAPI{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, stored)).To(Succeed())
+		Expect(stored.Spec.ProxyConfig.Models).To(Equal([]string{"*"}))
+	})
+})
+
+var _ = Describe("Agent validating webhook", func() {
+	ctx := context.Background()
+	var namespace string
+
+	BeforeEach(func() {
+		namespace = uniqueModelAPIName("agent-webhook-test")
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   namespace,
+				Labels: map[string]string{webhookTestNamespaceLabel: "true"},
+			},
+		}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		DeferCleanup(func() {
+			k8sClient.Delete(ctx, ns)
+		})
+	})
+
+	networkedAgent := func(name string, access ...string) *kaosv1alpha1.Agent {
+		expose := true
+		return &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:     "missing-modelapi",
+				Model:        "mock-model",
+				AgentNetwork: &kaosv1alpha1.AgentNetworkConfig{Expose: &expose, Access: access},
+			},
+		}
+	}
+
+	It("should reject an agent listing itself in agentNetwork.access at admission", func() {
+		err := k8sClient.Create(ctx, networkedAgent("self-ref", "other", "self-ref"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("vagent.kaos.tools"))
+		Expect(err.Error()).To(ContainSubstring(`agentNetwork.access must not contain the agent's own name "self-ref"`))
+	})
+
+	It("should reject an update that adds a self-reference", func() {
+		agent := networkedAgent("self-ref-update", "other")
+		Expect(k8sClient.Create(ctx, agent)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, agent)
+		}()
+
+		// Retry on conflicts with the controller (e.g. adding its finalizer)
+		Eventually(func() string {
+			current := &kaosv1alpha1.Agent{}
+			if err := k8sClient.Get(ctx, types.NamespacedName{Name: agent.Name, Namespace: namespace}, current); err != nil {
+				return err.Error()
+			}
+			current.Spec.AgentNetwork.Access = append(current.Spec.AgentNetwork.Access, agent.Name)
+			if err := k8sClient.Update(ctx, current); err != nil {
+				return err.Error()
+			}
+			return "admitted"
+		}, timeout, interval).Should(ContainSubstring("must not contain the agent's own name"))
+	})
+
+	It("should admit agents that list each other", func() {
+		first := networkedAgent("mutual-a", "mutual-b")
+		second := networkedAgent("mutual-b", "mutual-a")
+		Expect(k8sClient.Create(ctx, first)).To(Succeed())
+		Expect(k8sClient.Create(ctx, second)).To(Succeed())
+		defer func() {
+			k8sClient.Delete(ctx, first)
+			k8sClient.Delete(ctx, second)
+		}()
+	})
+})
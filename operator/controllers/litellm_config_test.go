@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("generateLiteLLMConfig", func() {
+	r := &ModelAPIReconciler{}
+	telemetry := &kaosv1alpha1.TelemetryConfig{Enabled: true, Endpoint: "http://otel:4317"}
+
+	ginkgo.It("renders byte-identical output for the same models in any order", func() {
+		first := r.generateLiteLLMConfig(&kaosv1alpha1.ProxyConfig{
+			Models:   []string{"gpt-4o", "*", "claude-3", "gpt-4o-mini"},
+			Provider: "openai",
+			APIBase:  "https://api.example.com/v1",
+		}, telemetry)
+		second := r.generateLiteLLMConfig(&kaosv1alpha1.ProxyConfig{
+			Models:   []string{"gpt-4o-mini", "claude-3", "gpt-4o", "*"},
+			Provider: "openai",
+			APIBase:  "https://api.example.com/v1",
+		}, telemetry)
+
+		gomega.Expect([]byte(second)).To(gomega.Equal([]byte(first)))
+		gomega.Expect(r.generateLiteLLMConfig(&kaosv1alpha1.ProxyConfig{
+			Models:   []string{"gpt-4o", "*", "claude-3", "gpt-4o-mini"},
+			Provider: "openai",
+			APIBase:  "https://api.example.com/v1",
+		}, telemetry)).To(gomega.Equal(first))
+	})
+
+	ginkgo.It("renders the model_list sorted and without duplicates", func() {
+		config := r.generateLiteLLMConfig(&kaosv1alpha1.ProxyConfig{
+			Models: []string{"b-model", "a-model", "b-model"},
+		}, nil)
+
+		gomega.Expect(strings.Count(config, `model_name: "b-model"`)).To(gomega.Equal(1))
+		gomega.Expect(strings.Index(config, `model_name: "a-model"`)).To(
+			gomega.BeNumerically("<", strings.Index(config, `model_name: "b-model"`)))
+	})
+
+	ginkgo.It("renders extraParams into every model's litellm_params", func() {
+		config := r.generateLiteLLMConfig(&kaosv1alpha1.ProxyConfig{
+			Models:   []string{"claude-3", "titan"},
+			Provider: "bedrock",
+			APIKey:   &kaosv1alpha1.ApiKeySource{Value: "key"},
+			ExtraParams: map[string]string{
+				"aws_region_name": "us-east-1",
+				"timeout":         "30",
+				"api_version":     "2024-02-01",
+				"stream":          "true",
+			},
+		}, nil)
+
+		gomega.Expect(config).To(gomega.ContainSubstring(`  - model_name: "claude-3"
+    litellm_params:
+      model: "bedrock/claude-3"
+      api_key: "os.environ/PROXY_API_KEY"
+      api_version: "2024-02-01"
+      aws_region_name: us-east-1
+      stream: true
+      timeout: 30
+`))
+		gomega.Expect(strings.Count(config, "aws_region_name: us-east-1")).To(gomega.Equal(2))
+	})
+
+	ginkgo.It("quotes extraParams values that are not plain YAML scalars", func() {
+		gomega.Expect(yamlScalar("us-east-1")).To(gomega.Equal("us-east-1"))
+		gomega.Expect(yamlScalar("0.2")).To(gomega.Equal("0.2"))
+		gomega.Expect(yamlScalar("yes")).To(gomega.Equal(`"yes"`))
+		gomega.Expect(yamlScalar("0123")).To(gomega.Equal(`"0123"`))
+		gomega.Expect(yamlScalar("a: b")).To(gomega.Equal(`"a: b"`))
+		gomega.Expect(yamlScalar("x\nmodel: evil")).To(gomega.Equal(`"x\nmodel: evil"`))
+		gomega.Expect(yamlScalar("")).To(gomega.Equal(`""`))
+	})
+})
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("agent max concurrent requests", func() {
+	var (
+		agent     *kaosv1alpha1.Agent
+		modelapis []*kaosv1alpha1.ModelAPI
+	)
+
+	ginkgo.BeforeEach(func() {
+		setDefaultAgentImage()
+
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "mock-model"},
+		}
+		modelapis = []*kaosv1alpha1.ModelAPI{{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Status:     kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}}
+	})
+
+	ginkgo.It("bounds concurrency by default", func() {
+		gomega.Expect(agentEnv(agent, modelapis...)).To(gomega.HaveKeyWithValue("AGENT_MAX_CONCURRENT_REQUESTS", "16"))
+	})
+
+	ginkgo.It("passes the configured limit and rolls the pods when it changes", func() {
+		before := agentPodSpecHash(agent, modelapis...)
+
+		limit := int32(4)
+		agent.Spec.Config = &kaosv1alpha1.AgentConfig{MaxConcurrentRequests: &limit}
+		gomega.Expect(agentEnv(agent, modelapis...)).To(gomega.HaveKeyWithValue("AGENT_MAX_CONCURRENT_REQUESTS", "4"))
+		gomega.Expect(agentPodSpecHash(agent, modelapis...)).NotTo(gomega.Equal(before))
+	})
+
+	ginkgo.It("leaves the overflow behaviour to the runtime by default", func() {
+		env := agentEnv(agent, modelapis...)
+		gomega.Expect(env).NotTo(gomega.HaveKey("AGENT_OVERFLOW_POLICY"))
+		gomega.Expect(env).NotTo(gomega.HaveKey("AGENT_QUEUE_DEPTH"))
+	})
+
+	ginkgo.It("passes the overflow policy and queue depth", func() {
+		depth := int32(32)
+		agent.Spec.Config = &kaosv1alpha1.AgentConfig{OverflowPolicy: kaosv1alpha1.OverflowPolicyQueue, QueueDepth: &depth}
+		env := agentEnv(agent, modelapis...)
+		gomega.Expect(env).To(gomega.HaveKeyWithValue("AGENT_OVERFLOW_POLICY", "queue"))
+		gomega.Expect(env).To(gomega.HaveKeyWithValue("AGENT_QUEUE_DEPTH", "32"))
+	})
+})
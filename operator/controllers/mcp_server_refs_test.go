@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("agent mcpServerRefs call limits", func() {
+	var (
+		agent      *kaosv1alpha1.Agent
+		modelapis  []*kaosv1alpha1.ModelAPI
+		mcpServers map[string]string
+	)
+
+	ginkgo.BeforeEach(func() {
+		setDefaultAgentImage()
+
+		maxConcurrent := int32(2)
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI: "llm",
+				Model:    "mock-model",
+				MCPServerRefs: []kaosv1alpha1.AgentMCPServerRef{
+					{Name: "search", Timeout: "2m", MaxConcurrent: &maxConcurrent},
+					{Name: "calc"},
+				},
+			},
+		}
+		modelapis = []*kaosv1alpha1.ModelAPI{{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Status:     kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}}
+		mcpServers = map[string]string{"search": "http://mcpserver-search:8000", "calc": "http://mcpserver-calc:8000"}
+	})
+
+	mcpEnv := func() []corev1.EnvVar {
+		var env []corev1.EnvVar
+		generated, _ := (&AgentReconciler{}).constructEnvVars(agent, modelapis, mcpServers, nil)
+		for _, e := range generated {
+			if strings.HasPrefix(e.Name, "MCP_SERVER_") {
+				env = append(env, e)
+			}
+		}
+		return env
+	}
+
+	ginkgo.It("passes the timeout and concurrency limit of each server", func() {
+		gomega.Expect(mcpEnv()).To(gomega.Equal([]corev1.EnvVar{
+			{Name: "MCP_SERVER_calc_URL", Value: "http://mcpserver-calc:8000"},
+			{Name: "MCP_SERVER_search_URL", Value: "http://mcpserver-search:8000"},
+			{Name: "MCP_SERVER_search_TIMEOUT", Value: "2m"},
+			{Name: "MCP_SERVER_search_MAX_CONCURRENT", Value: "2"},
+		}))
+	})
+})
@@ -3,9 +3,10 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
-	"gopkg.in/yaml.v3"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -13,42 +14,41 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
 	"github.com/axsaucedo/kaos/operator/pkg/gateway"
+	"github.com/axsaucedo/kaos/operator/pkg/metrics"
+	mcpruntime "github.com/axsaucedo/kaos/operator/pkg/runtime"
+	"github.com/axsaucedo/kaos/operator/pkg/tracing"
 	"github.com/axsaucedo/kaos/operator/pkg/util"
 )
 
 const mcpServerFinalizerName = "kaos.tools/mcpserver-finalizer"
 
-// RuntimeConfig represents a runtime definition from the ConfigMap
-type RuntimeConfig struct {
-	Type         string   `yaml:"type"`
-	Image        string   `yaml:"image"`
-	Description  string   `yaml:"description,omitempty"`
-	Command      []string `yaml:"command,omitempty"`
-	Args         []string `yaml:"args,omitempty"`
-	ParamsEnvVar string   `yaml:"paramsEnvVar,omitempty"`
-	Transport    string   `yaml:"transport,omitempty"`
-	RequiredEnv  []string `yaml:"requiredEnv,omitempty"`
-}
-
-// RuntimeRegistry represents the full runtime registry from ConfigMap
-type RuntimeRegistry struct {
-	Runtimes map[string]RuntimeConfig `yaml:"runtimes"`
-}
-
 // MCPServerReconciler reconciles a MCPServer object
 type MCPServerReconciler struct {
 	client.Client
 	Log             logr.Logger
 	Scheme          *runtime.Scheme
+	Recorder        record.EventRecorder
 	SystemNamespace string
+
+	// Clientset reads the pods of a Deployment that is missing replicas, to
+	// explain the failure in status.message (optional)
+	Clientset kubernetes.Interface
+	// APIReader reads Deployments and Services the cache does not hold, such as unlabelled
+	// pre-existing ones hidden by CACHE_LABEL_SELECTOR, so they are adopted (optional)
+	APIReader client.Reader
 }
 
 //+kubebuilder:rbac:groups=kaos.tools,resources=mcpservers,verbs=get;list;watch;create;update;patch;delete
@@ -57,10 +57,22 @@ type MCPServerReconciler struct {
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcile(ctx, "MCPServer", req.NamespacedName)
+	result, err := metrics.ObserveReconcile("MCPServer", func() (ctrl.Result, error) {
+		return r.reconcile(ctx, req)
+	})
+	tracing.End(span, err)
+	return result, err
+}
+
+// reconcile performs a single reconciliation of the MCPServer
+func (r *MCPServerReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 
 	mcpserver := &kaosv1alpha1.MCPServer{}
@@ -91,14 +103,19 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		}
 	}
 
-	// Set initial status
+	// Set initial status; it is persisted by the single status update at the end of
+	// the reconcile (or by the status update of an early return)
 	if mcpserver.Status.Phase == "" {
 		mcpserver.Status.Phase = "Pending"
 		mcpserver.Status.Ready = false
-		if err := r.Status().Update(ctx, mcpserver); err != nil {
-			log.Error(err, "failed to update status")
-			return ctrl.Result{}, err
-		}
+	}
+
+	// Record the Agents that depend on this MCPServer
+	if dependents, err := referencingAgents(ctx, r.Client, mcpserver.Namespace, mcpserver.Name, agentMCPServerNames); err != nil {
+		log.Error(err, "failed to list dependent Agents")
+		return ctrl.Result{}, err
+	} else {
+		mcpserver.Status.Dependents = dependents
 	}
 
 	// Validate telemetry config
@@ -107,58 +124,85 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		log.Info("WARNING: telemetry.enabled=true but endpoint is empty; telemetry will not function", "mcpserver", mcpserver.Name)
 	}
 
+	// Fail with a clear message when the runtime's required env is not configured,
+	// instead of letting the pod crash-loop
+	if missing, err := r.runtimeMissingEnv(ctx, mcpserver); err != nil {
+		log.Error(err, "failed to check the runtime's required env")
+		return ctrl.Result{}, err
+	} else if len(missing) > 0 {
+		err := reconcileError(ctx, r.Client, r.Recorder, mcpserver, "MissingConfig", nil,
+			fmt.Sprintf("Runtime %s requires env vars that are not set: %s", mcpserver.Spec.Runtime, strings.Join(missing, ", ")))
+		// ConfigMap and Secret events only map to MCPServers that have a Deployment, so recheck
+		// in case an envFrom source is filled in
+		return ctrl.Result{RequeueAfter: time.Minute}, err
+	}
+
 	// Create or update Deployment
 	deployment := &appsv1.Deployment{}
 	deploymentName := fmt.Sprintf("mcpserver-%s", mcpserver.Name)
-	err := r.Get(ctx, types.NamespacedName{Name: deploymentName, Namespace: mcpserver.Namespace}, deployment)
+	err := getExisting(ctx, r.Client, r.APIReader, types.NamespacedName{Name: deploymentName, Namespace: mcpserver.Namespace}, deployment)
 
 	if err != nil && apierrors.IsNotFound(err) {
 		// Create new Deployment
-		deployment, err = r.constructDeployment(ctx, mcpserver)
+		var overridden []string
+		deployment, overridden, err = r.constructDeployment(ctx, mcpserver)
 		if err != nil {
-			log.Error(err, "failed to construct Deployment")
-			mcpserver.Status.Phase = "Failed"
-			mcpserver.Status.Message = fmt.Sprintf("Failed to construct Deployment: %v", err)
-			r.Status().Update(ctx, mcpserver)
+			return ctrl.Result{}, constructError(ctx, r.Client, r.Recorder, mcpserver, err)
+		}
+		recordEnvOverrides(r.Recorder, mcpserver, &mcpserver.Status.Conditions, overridden)
+		if err := applyReferencedContentHash(ctx, contentReader(r.Client, r.APIReader), mcpserver, &deployment.Spec.Template); err != nil {
+			log.Error(err, "failed to hash referenced ConfigMaps and Secrets")
 			return ctrl.Result{}, err
 		}
-		if err := controllerutil.SetControllerReference(mcpserver, deployment, r.Scheme); err != nil {
+		applyAutoRollout(mcpserver, deployment)
+		if err := util.SetControllerReference(mcpserver, deployment, r.Scheme); err != nil {
 			log.Error(err, "failed to set controller reference")
 			return ctrl.Result{}, err
 		}
 
 		log.Info("Creating Deployment", "name", deployment.Name)
 		if err := r.Create(ctx, deployment); err != nil {
-			log.Error(err, "failed to create Deployment")
-			mcpserver.Status.Phase = "Failed"
-			mcpserver.Status.Message = fmt.Sprintf("Failed to create Deployment: %v", err)
-			r.Status().Update(ctx, mcpserver)
-			return ctrl.Result{}, err
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, mcpserver, "DeploymentCreateFailed", err, "Failed to create Deployment")
 		}
 	} else if err != nil {
 		log.Error(err, "failed to get Deployment")
 		return ctrl.Result{}, err
 	} else {
-		// Deployment exists - check if spec has changed using hash annotation
-		desiredDeployment, err := r.constructDeployment(ctx, mcpserver)
-		if err != nil {
-			log.Error(err, "failed to construct Deployment for comparison")
+		if ok, err := adoptResource(ctx, r.Client, r.Scheme, r.Recorder, mcpserver, deployment); !ok {
 			return ctrl.Result{}, err
 		}
-		currentHash := ""
-		if deployment.Spec.Template.Annotations != nil {
-			currentHash = deployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+
+		// Deployment exists - check if spec has changed using hash annotation
+		desiredDeployment, overridden, err := r.constructDeployment(ctx, mcpserver)
+		if err != nil {
+			return ctrl.Result{}, constructError(ctx, r.Client, r.Recorder, mcpserver, err)
 		}
-		desiredHash := ""
-		if desiredDeployment.Spec.Template.Annotations != nil {
-			desiredHash = desiredDeployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+		recordEnvOverrides(r.Recorder, mcpserver, &mcpserver.Status.Conditions, overridden)
+		if err := applyReferencedContentHash(ctx, contentReader(r.Client, r.APIReader), mcpserver, &desiredDeployment.Spec.Template); err != nil {
+			log.Error(err, "failed to hash referenced ConfigMaps and Secrets")
+			return ctrl.Result{}, err
 		}
+		applyAutoRollout(mcpserver, desiredDeployment)
+		currentHash := deploymentHash(deployment)
+		desiredHash := deploymentHash(desiredDeployment)
+
+		rolloutChanged := deploymentRolloutChanged(deployment, desiredDeployment)
 
-		if currentHash != desiredHash {
+		changed := currentHash != desiredHash || rolloutChanged
+		if changed && deploymentFrozen(mcpserver) {
+			log.Info("Deployment is frozen; skipping update", "name", deployment.Name,
+				"currentHash", currentHash, "desiredHash", desiredHash)
+			if r.Recorder != nil {
+				r.Recorder.Event(mcpserver, corev1.EventTypeNormal, "DeploymentFrozen",
+					fmt.Sprintf("Deployment %s differs from the spec but is not updated while %s is set", deployment.Name, freezeDeploymentAnnotation))
+			}
+		} else if changed {
 			log.Info("Updating Deployment due to spec change", "name", deployment.Name,
 				"currentHash", currentHash, "desiredHash", desiredHash)
 			// Update the deployment spec to trigger rolling update
 			deployment.Spec.Template = desiredDeployment.Spec.Template
+			setDeploymentRollout(deployment, desiredDeployment)
+			setDeploymentHash(deployment, desiredDeployment)
 			if err := r.Update(ctx, deployment); err != nil {
 				log.Error(err, "failed to update Deployment")
 				return ctrl.Result{}, err
@@ -169,27 +213,38 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// Create or update Service
 	service := &corev1.Service{}
 	serviceName := fmt.Sprintf("mcpserver-%s", mcpserver.Name)
-	err = r.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: mcpserver.Namespace}, service)
+	err = getExisting(ctx, r.Client, r.APIReader, types.NamespacedName{Name: serviceName, Namespace: mcpserver.Namespace}, service)
 
 	if err != nil && apierrors.IsNotFound(err) {
 		// Create new Service
 		service = r.constructService(mcpserver)
-		if err := controllerutil.SetControllerReference(mcpserver, service, r.Scheme); err != nil {
+		if err := util.SetControllerReference(mcpserver, service, r.Scheme); err != nil {
 			log.Error(err, "failed to set controller reference")
 			return ctrl.Result{}, err
 		}
 
 		log.Info("Creating Service", "name", service.Name)
 		if err := r.Create(ctx, service); err != nil {
-			log.Error(err, "failed to create Service")
-			mcpserver.Status.Phase = "Failed"
-			mcpserver.Status.Message = fmt.Sprintf("Failed to create Service: %v", err)
-			r.Status().Update(ctx, mcpserver)
-			return ctrl.Result{}, err
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, mcpserver, "ServiceCreateFailed", err, "Failed to create Service")
 		}
 	} else if err != nil {
 		log.Error(err, "failed to get Service")
 		return ctrl.Result{}, err
+	} else {
+		if ok, err := adoptResource(ctx, r.Client, r.Scheme, r.Recorder, mcpserver, service); !ok {
+			return ctrl.Result{}, err
+		}
+
+		// Service exists - check if the port needs to be updated (servicePort changed)
+		desiredService := r.constructService(mcpserver)
+		if servicePortsChanged(service, desiredService) {
+			log.Info("Updating Service due to port change", "name", service.Name)
+			service.Spec.Ports = desiredService.Spec.Ports
+			if err := r.Update(ctx, service); err != nil {
+				log.Error(err, "failed to update Service")
+				return ctrl.Result{}, err
+			}
+		}
 	}
 
 	// Update status
@@ -200,20 +255,35 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	if mcpserver.Spec.GatewayRoute != nil && mcpserver.Spec.GatewayRoute.Timeout != "" {
 		timeout = mcpserver.Spec.GatewayRoute.Timeout
 	}
-	if err := gateway.ReconcileHTTPRoute(ctx, r.Client, r.Scheme, mcpserver, gateway.HTTPRouteParams{
-		ResourceType: gateway.ResourceTypeMCP,
-		ResourceName: mcpserver.Name,
-		Namespace:    mcpserver.Namespace,
-		ServiceName:  serviceName,
-		ServicePort:  8000,
-		Labels:       map[string]string{"app": "mcpserver", "mcpserver": mcpserver.Name},
-		Timeout:      timeout,
-	}, log); err != nil {
-		log.Error(err, "failed to reconcile HTTPRoute")
+	mcpserver.Status.GatewayEndpoints = nil
+	if gatewayRouteEnabled(mcpserver.Spec.GatewayRoute) {
+		routeParams := gateway.HTTPRouteParams{
+			ResourceType:   gateway.ResourceTypeMCP,
+			ResourceName:   mcpserver.Name,
+			Namespace:      mcpserver.Namespace,
+			ServiceName:    serviceName,
+			ServicePort:    gatewayServicePort(mcpserver.Spec.Protocol),
+			Labels:         map[string]string{"app": "mcpserver", "mcpserver": mcpserver.Name},
+			PathPrefix:     gatewayRoutePathPrefix(mcpserver.Spec.GatewayRoute),
+			SectionName:    gatewayRouteSectionName(mcpserver.Spec.GatewayRoute),
+			Gateways:       gatewayRouteGateways(mcpserver.Spec.GatewayRoute),
+			Timeout:        timeout,
+			CORS:           gatewayRouteCORS(mcpserver.Spec.GatewayRoute),
+			RequestHeaders: gatewayRouteRequestHeaders(mcpserver.Spec.GatewayRoute),
+		}
+		if err := gateway.ReconcileHTTPRoute(ctx, r.Client, r.Scheme, mcpserver, routeParams, log); err != nil {
+			mcpserver.Status.GatewayEndpoints = nil
+			return ctrl.Result{}, routeError(ctx, r.Client, r.Recorder, mcpserver, err)
+		}
+		mcpserver.Status.GatewayEndpoints = gateway.RouteEndpoints(routeParams)
+	} else if err := gateway.DeleteHTTPRoute(ctx, r.Client, mcpserver, gateway.ResourceTypeMCP, mcpserver.Name, mcpserver.Namespace, log); err != nil {
+		log.Error(err, "failed to delete HTTPRoute")
 	}
 
 	// Copy deployment status for rolling update visibility
+	previousDeployment := mcpserver.Status.Deployment
 	mcpserver.Status.Deployment = util.CopyDeploymentStatus(deployment)
+	util.TrackPendingSince(mcpserver.Status.Deployment, previousDeployment, metav1.Now())
 
 	// Check deployment readiness
 	if deployment.Status.ReadyReplicas > 0 {
@@ -226,16 +296,43 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	mcpserver.Status.Message = fmt.Sprintf("Deployment ready replicas: %d/%d", deployment.Status.ReadyReplicas, *deployment.Spec.Replicas)
 
-	if err := r.Status().Update(ctx, mcpserver); err != nil {
+	// A rollout that stopped making progress is surfaced as Failed
+	if message, exceeded := util.DeploymentProgressDeadlineExceeded(deployment); exceeded {
+		mcpserver.Status.Phase = "Failed"
+		mcpserver.Status.Ready = false
+		mcpserver.Status.Message = "Deployment rollout exceeded its progress deadline: " + message
+	}
+
+	// A rollout that never got a ready replica is surfaced as Failed after readyTimeout
+	result := ctrl.Result{}
+	if mcpserver.Status.Phase == "Pending" {
+		remaining, exceeded := readyTimeoutExceeded(mcpserver.Spec.ReadyTimeout, mcpserver.Status.Deployment)
+		if exceeded {
+			mcpserver.Status.Phase = "Failed"
+			mcpserver.Status.Message = readyTimeoutMessage(mcpserver.Spec.ReadyTimeout, deployment)
+		}
+		result.RequeueAfter = remaining
+	}
+
+	// Explain missing replicas with the first failing pod (e.g. ImagePullBackOff)
+	if deployment.Status.ReadyReplicas < *deployment.Spec.Replicas {
+		if detail := podFailureMessage(ctx, r.Clientset, deployment); detail != "" {
+			mcpserver.Status.Message += "; " + detail
+		}
+	}
+	setReadyCondition(&mcpserver.Status.Conditions, mcpserver.Generation, mcpserver.Status.Ready, mcpserver.Status.Phase, mcpserver.Status.Message)
+
+	if err := patchStatus(ctx, r.Client, mcpserver); err != nil {
 		log.Error(err, "failed to update status")
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
-// constructDeployment creates a Deployment for the MCPServer
-func (r *MCPServerReconciler) constructDeployment(ctx context.Context, mcpserver *kaosv1alpha1.MCPServer) (*appsv1.Deployment, error) {
+// constructDeployment creates a Deployment for the MCPServer, and returns the generated env
+// vars container.env overrides
+func (r *MCPServerReconciler) constructDeployment(ctx context.Context, mcpserver *kaosv1alpha1.MCPServer) (*appsv1.Deployment, []string, error) {
 	labels := map[string]string{
 		"app":       "mcpserver",
 		"mcpserver": mcpserver.Name,
@@ -244,9 +341,9 @@ func (r *MCPServerReconciler) constructDeployment(ctx context.Context, mcpserver
 	replicas := int32(1)
 
 	// Construct container based on runtime
-	container, err := r.constructContainerFromRuntime(ctx, mcpserver)
+	container, overridden, err := r.constructContainerFromRuntime(ctx, mcpserver)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	basePodSpec := corev1.PodSpec{
@@ -262,9 +359,15 @@ func (r *MCPServerReconciler) constructDeployment(ctx context.Context, mcpserver
 	finalPodSpec := basePodSpec
 	if mcpserver.Spec.PodSpec != nil {
 		merged, err := util.MergePodSpec(basePodSpec, *mcpserver.Spec.PodSpec)
-		if err == nil {
-			finalPodSpec = merged
+		if err != nil {
+			return nil, nil, err
 		}
+		finalPodSpec = merged
+	}
+
+	// Reference images by digest when pinning is enabled
+	if err := util.PinPodSpecImages(&finalPodSpec); err != nil {
+		return nil, nil, err
 	}
 
 	// Compute hash of the pod spec for change detection
@@ -281,6 +384,9 @@ func (r *MCPServerReconciler) constructDeployment(ctx context.Context, mcpserver
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
+			Strategy:                deploymentStrategy(mcpserver.Spec.DeploymentStrategy, false),
+			MinReadySeconds:         minReadySeconds(mcpserver.Spec.MinReadySeconds),
+			ProgressDeadlineSeconds: progressDeadlineSeconds(mcpserver.Spec.ProgressDeadlineSeconds),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: labels,
@@ -293,47 +399,52 @@ func (r *MCPServerReconciler) constructDeployment(ctx context.Context, mcpserver
 		},
 	}
 
-	return deployment, nil
+	return deployment, overridden, nil
 }
 
-// getRuntimeRegistry fetches and parses the runtime registry ConfigMap
-func (r *MCPServerReconciler) getRuntimeRegistry(ctx context.Context) (*RuntimeRegistry, error) {
-	cm := &corev1.ConfigMap{}
-	cmName := types.NamespacedName{
-		Name:      "kaos-mcp-runtimes",
-		Namespace: r.SystemNamespace,
+// runtimeMissingEnv returns the runtime's requiredEnv entries that the effective
+// mcp-server container does not set. Registry and construction errors are left to
+// constructDeployment to report.
+func (r *MCPServerReconciler) runtimeMissingEnv(ctx context.Context, mcpserver *kaosv1alpha1.MCPServer) ([]string, error) {
+	if mcpserver.Spec.Runtime == mcpruntime.CustomRuntime {
+		return nil, nil
 	}
-
-	if err := r.Get(ctx, cmName, cm); err != nil {
-		return nil, fmt.Errorf("failed to get runtime registry ConfigMap: %w", err)
+	registry, err := mcpruntime.Load(ctx, r, util.GetSharedResourcesNamespace(r.SystemNamespace), mcpserver.Namespace)
+	if err != nil {
+		return nil, nil
 	}
-
-	yamlData, ok := cm.Data["runtimes.yaml"]
-	if !ok {
-		return nil, fmt.Errorf("runtimes.yaml key not found in ConfigMap")
+	runtimeConfig, ok := registry.Runtimes[mcpserver.Spec.Runtime]
+	if !ok || len(runtimeConfig.RequiredEnv) == 0 {
+		return nil, nil
 	}
 
-	var registry RuntimeRegistry
-	if err := yaml.Unmarshal([]byte(yamlData), &registry); err != nil {
-		return nil, fmt.Errorf("failed to parse runtime registry: %w", err)
+	deployment, _, err := r.constructDeployment(ctx, mcpserver)
+	if err != nil {
+		return nil, nil
 	}
-
-	return &registry, nil
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name == "mcp-server" {
+			return missingRequiredEnv(ctx, contentReader(r.Client, r.APIReader), mcpserver.Namespace, container, runtimeConfig.RequiredEnv)
+		}
+	}
+	return nil, nil
 }
 
-// constructContainerFromRuntime creates a container based on the runtime configuration
-func (r *MCPServerReconciler) constructContainerFromRuntime(ctx context.Context, mcpserver *kaosv1alpha1.MCPServer) (corev1.Container, error) {
+// constructContainerFromRuntime creates a container based on the runtime configuration,
+// and returns the generated env vars container.env overrides
+func (r *MCPServerReconciler) constructContainerFromRuntime(ctx context.Context, mcpserver *kaosv1alpha1.MCPServer) (corev1.Container, []string, error) {
 	var env []corev1.EnvVar
 	var image string
 	var command []string
 	var args []string
+	var secretEnvDeclared bool
 
 	runtime := mcpserver.Spec.Runtime
 
 	// Handle custom runtime - requires container.image
-	if runtime == "custom" {
+	if runtime == mcpruntime.CustomRuntime {
 		if mcpserver.Spec.Container == nil || mcpserver.Spec.Container.Image == "" {
-			return corev1.Container{}, fmt.Errorf("custom runtime requires container.image to be set")
+			return corev1.Container{}, nil, fmt.Errorf("custom runtime requires container.image to be set")
 		}
 		image = mcpserver.Spec.Container.Image
 		if mcpserver.Spec.Container.Command != nil {
@@ -344,14 +455,14 @@ func (r *MCPServerReconciler) constructContainerFromRuntime(ctx context.Context,
 		}
 	} else {
 		// Lookup runtime from registry
-		registry, err := r.getRuntimeRegistry(ctx)
+		registry, err := mcpruntime.Load(ctx, r, util.GetSharedResourcesNamespace(r.SystemNamespace), mcpserver.Namespace)
 		if err != nil {
-			return corev1.Container{}, fmt.Errorf("failed to get runtime registry: %w", err)
+			return corev1.Container{}, nil, fmt.Errorf("failed to get runtime registry: %w", err)
 		}
 
 		runtimeConfig, ok := registry.Runtimes[runtime]
 		if !ok {
-			return corev1.Container{}, fmt.Errorf("unknown runtime: %s (not found in registry)", runtime)
+			return corev1.Container{}, nil, fmt.Errorf("unknown runtime: %s (not found in registry)", runtime)
 		}
 
 		image = runtimeConfig.Image
@@ -365,18 +476,31 @@ func (r *MCPServerReconciler) constructContainerFromRuntime(ctx context.Context,
 				Value: mcpserver.Spec.Params,
 			})
 		}
+
+		// Reference the runtime's secret env vars from spec.secretRef
+		env = append(env, runtimeSecretEnv(mcpserver.Spec.SecretRef, runtimeConfig)...)
+		secretEnvDeclared = len(runtimeConfig.SecretEnv) > 0
+	}
+
+	if mcpserver.Spec.SecretRef != nil && !secretEnvDeclared {
+		log.FromContext(ctx).Info("WARNING: secretRef is set but the runtime declares no secretEnv; it is ignored",
+			"mcpserver", mcpserver.Name, "runtime", runtime)
+		if r.Recorder != nil {
+			r.Recorder.Event(mcpserver, corev1.EventTypeWarning, "SecretRefUnused",
+				fmt.Sprintf("Runtime %s declares no secretEnv, so secretRef %s is not used; set the env vars through container.env or podSpec envFrom", runtime, mcpserver.Spec.SecretRef.Name))
+		}
 	}
 
 	// Allow container override for image, command, args (for all runtimes, not just custom)
 	if mcpserver.Spec.Container != nil {
-		if mcpserver.Spec.Container.Image != "" && runtime != "custom" {
+		if mcpserver.Spec.Container.Image != "" && runtime != mcpruntime.CustomRuntime {
 			// Only override if it wasn't already set by custom runtime
 			image = mcpserver.Spec.Container.Image
 		}
-		if mcpserver.Spec.Container.Command != nil && runtime != "custom" {
+		if mcpserver.Spec.Container.Command != nil && runtime != mcpruntime.CustomRuntime {
 			command = mcpserver.Spec.Container.Command
 		}
-		if mcpserver.Spec.Container.Args != nil && runtime != "custom" {
+		if mcpserver.Spec.Container.Args != nil && runtime != mcpruntime.CustomRuntime {
 			args = mcpserver.Spec.Container.Args
 		}
 	}
@@ -402,6 +526,17 @@ func (r *MCPServerReconciler) constructContainerFromRuntime(ctx context.Context,
 		env = append(env, logLevelEnv...)
 	}
 
+	// Route outbound calls through the egress proxy, if any
+	env = append(env, util.BuildEgressProxyEnvVars(util.MergeEgressProxy(mcpserver.Spec.EgressProxy))...)
+
+	// Generated entries come first and user entries (including valueFrom) last, so the
+	// user wins; the result has one entry per name
+	var userEnv []corev1.EnvVar
+	if mcpserver.Spec.Container != nil {
+		userEnv = mcpserver.Spec.Container.Env
+	}
+	env, overridden := finalizeEnv(env, userEnv)
+
 	container := corev1.Container{
 		Name:            "mcp-server",
 		Image:           image,
@@ -417,22 +552,22 @@ func (r *MCPServerReconciler) constructContainerFromRuntime(ctx context.Context,
 		},
 		Env: env,
 		LivenessProbe: &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
+			ProbeHandler: probeHandler(mcpserver.Spec.Protocol, 8000, corev1.ProbeHandler{
 				TCPSocket: &corev1.TCPSocketAction{
 					Port: intstr.FromInt(8000),
 				},
-			},
+			}),
 			InitialDelaySeconds: 20,
 			PeriodSeconds:       10,
 			TimeoutSeconds:      3,
 			FailureThreshold:    3,
 		},
 		ReadinessProbe: &corev1.Probe{
-			ProbeHandler: corev1.ProbeHandler{
+			ProbeHandler: probeHandler(mcpserver.Spec.Protocol, 8000, corev1.ProbeHandler{
 				TCPSocket: &corev1.TCPSocketAction{
 					Port: intstr.FromInt(8000),
 				},
-			},
+			}),
 			InitialDelaySeconds: 15,
 			PeriodSeconds:       5,
 			TimeoutSeconds:      3,
@@ -446,12 +581,12 @@ func (r *MCPServerReconciler) constructContainerFromRuntime(ctx context.Context,
 			container.Resources = *mcpserver.Spec.Container.Resources
 		}
 		// Image override for non-custom runtimes
-		if runtime != "custom" && mcpserver.Spec.Container.Image != "" {
+		if runtime != mcpruntime.CustomRuntime && mcpserver.Spec.Container.Image != "" {
 			container.Image = mcpserver.Spec.Container.Image
 		}
 	}
 
-	return container, nil
+	return container, overridden, nil
 }
 
 // constructService creates a Service for the MCPServer
@@ -471,7 +606,6 @@ func (r *MCPServerReconciler) constructService(mcpserver *kaosv1alpha1.MCPServer
 			Type: corev1.ServiceTypeClusterIP,
 			Ports: []corev1.ServicePort{
 				{
-					Name:       "http",
 					Port:       8000,
 					TargetPort: intstr.FromInt(8000),
 					Protocol:   corev1.ProtocolTCP,
@@ -481,15 +615,39 @@ func (r *MCPServerReconciler) constructService(mcpserver *kaosv1alpha1.MCPServer
 		},
 	}
 
+	applyServicePortConfig(&service.Spec.Ports[0], mcpserver.Spec.Protocol, mcpserver.Spec.ServicePort)
+	if isGRPC(mcpserver.Spec.Protocol) {
+		service.Spec.Ports = append(service.Spec.Ports, grpcGatewayServicePort(8000))
+	}
+
 	return service
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *MCPServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Map Agent changes to the MCPServers they reference (before and after an update), so
+	// status.dependents stays current
+	mapAgentToMCPServers := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []ctrl.Request {
+		agent := obj.(*kaosv1alpha1.Agent)
+		requests := []ctrl.Request{}
+		for _, name := range agentMCPServerNames(agent) {
+			requests = append(requests, ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: name, Namespace: agent.Namespace},
+			})
+		}
+		return requests
+	})
+
 	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&kaosv1alpha1.MCPServer{}).
 		Owns(&appsv1.Deployment{}).
-		Owns(&corev1.Service{})
+		Owns(&corev1.Service{}).
+		Watches(&kaosv1alpha1.Agent{}, mapAgentToMCPServers,
+			ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.ConfigMap{}, mapReferencedObjectToOwners(r.Client, "MCPServer", "ConfigMap"),
+			ctrlbuilder.OnlyMetadata).
+		Watches(&corev1.Secret{}, mapReferencedObjectToOwners(r.Client, "MCPServer", "Secret"),
+			ctrlbuilder.OnlyMetadata)
 
 	if gateway.GetConfig().Enabled {
 		builder = builder.Owns(&gatewayv1.HTTPRoute{})
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("ModelAPI model aliases", func() {
+	var modelapi *kaosv1alpha1.ModelAPI
+
+	ginkgo.BeforeEach(func() {
+		modelapi = &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models:   []string{"gpt-4o", "gpt-4o-mini"},
+					Provider: "openai",
+					APIKey:   &kaosv1alpha1.ApiKeySource{Value: "sk-test"},
+					Aliases:  map[string]string{"fast": "gpt-4o-mini", "smart": "gpt-4o"},
+				},
+			},
+			Status: kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}
+	})
+
+	ginkgo.It("exposes each alias as a model_list entry routed like its target", func() {
+		config := (&ModelAPIReconciler{}).generateLiteLLMConfig(modelapi.Spec.ProxyConfig, nil)
+		gomega.Expect(config).To(gomega.ContainSubstring(`  - model_name: "fast"
+    litellm_params:
+      model: "openai/gpt-4o-mini"
+      api_key: "os.environ/PROXY_API_KEY"
+`))
+		gomega.Expect(config).To(gomega.ContainSubstring(`  - model_name: "smart"
+    litellm_params:
+      model: "openai/gpt-4o"
+`))
+	})
+
+	ginkgo.It("lists the aliases as supported models", func() {
+		gomega.Expect(modelAPISupportedModels(modelapi)).To(gomega.Equal([]string{"gpt-4o", "gpt-4o-mini", "fast", "smart"}))
+		gomega.Expect(modelapi.Spec.ProxyConfig.Models).To(gomega.HaveLen(2))
+	})
+
+	ginkgo.It("accepts an agent that uses an alias as its model", func() {
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "fast"},
+		}
+		gomega.Expect((&AgentReconciler{}).validateAgentModel(context.Background(), agent, modelapi)).To(gomega.Succeed())
+
+		agent.Spec.Model = "cheap"
+		gomega.Expect((&AgentReconciler{}).validateAgentModel(context.Background(), agent, modelapi)).NotTo(gomega.Succeed())
+	})
+})
@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("Hosted mode model cache", func() {
+	ginkgo.BeforeEach(func() {
+		previous, had := os.LookupEnv("DEFAULT_OLLAMA_IMAGE")
+		gomega.Expect(os.Setenv("DEFAULT_OLLAMA_IMAGE", "alpine/ollama:test")).To(gomega.Succeed())
+		ginkgo.DeferCleanup(func() {
+			if had {
+				os.Setenv("DEFAULT_OLLAMA_IMAGE", previous)
+			} else {
+				os.Unsetenv("DEFAULT_OLLAMA_IMAGE")
+			}
+		})
+	})
+
+	persistedModelAPI := func(persist *kaosv1alpha1.PersistModelsConfig) *kaosv1alpha1.ModelAPI {
+		return &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "ollama", Namespace: "default", UID: "modelapi-uid"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeHosted,
+				HostedConfig: &kaosv1alpha1.HostedConfig{
+					Model:         "smollm2:135m",
+					PersistModels: persist,
+				},
+			},
+		}
+	}
+
+	ollamaDataVolume := func(deployment *appsv1.Deployment) *corev1.Volume {
+		for i := range deployment.Spec.Template.Spec.Volumes {
+			if deployment.Spec.Template.Spec.Volumes[i].Name == "ollama-data" {
+				return &deployment.Spec.Template.Spec.Volumes[i]
+			}
+		}
+		return nil
+	}
+
+	ginkgo.It("mounts an emptyDir when persistModels is not set", func() {
+		deployment, _, err := (&ModelAPIReconciler{}).constructDeployment(persistedModelAPI(nil))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		volume := ollamaDataVolume(deployment)
+		gomega.Expect(volume).NotTo(gomega.BeNil())
+		gomega.Expect(volume.EmptyDir).NotTo(gomega.BeNil())
+		gomega.Expect(deployment.Spec.Strategy.Type).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("mounts the operator-created PVC at the Ollama models dir when enabled", func() {
+		deployment, _, err := (&ModelAPIReconciler{}).constructDeployment(persistedModelAPI(&kaosv1alpha1.PersistModelsConfig{}))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		volume := ollamaDataVolume(deployment)
+		gomega.Expect(volume).NotTo(gomega.BeNil())
+		gomega.Expect(volume.PersistentVolumeClaim).NotTo(gomega.BeNil())
+		gomega.Expect(volume.PersistentVolumeClaim.ClaimName).To(gomega.Equal("modelapi-ollama-models"))
+
+		mounts := append(deployment.Spec.Template.Spec.InitContainers[0].VolumeMounts,
+			deployment.Spec.Template.Spec.Containers[0].VolumeMounts...)
+		gomega.Expect(mounts).To(gomega.HaveLen(2))
+		for _, mount := range mounts {
+			gomega.Expect(mount.Name).To(gomega.Equal("ollama-data"))
+			gomega.Expect(mount.MountPath).To(gomega.Equal("/root/.ollama"))
+		}
+
+		// ReadWriteOnce by default, so rollouts must not overlap pods
+		gomega.Expect(deployment.Spec.Strategy.Type).To(gomega.Equal(appsv1.RecreateDeploymentStrategyType))
+	})
+
+	ginkgo.It("references an existing claim and keeps rolling updates for ReadWriteMany", func() {
+		deployment, _, err := (&ModelAPIReconciler{}).constructDeployment(persistedModelAPI(&kaosv1alpha1.PersistModelsConfig{
+			ClaimName:  "shared-models",
+			AccessMode: corev1.ReadWriteMany,
+		}))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		gomega.Expect(ollamaDataVolume(deployment).PersistentVolumeClaim.ClaimName).To(gomega.Equal("shared-models"))
+		gomega.Expect(deployment.Spec.Strategy.Type).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("creates an owned PVC only when no claimName is given", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		gomega.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+		gomega.Expect(kaosv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		r := &ModelAPIReconciler{Client: c, Scheme: scheme}
+
+		storageClass := "fast"
+		size := resource.MustParse("20Gi")
+		modelapi := persistedModelAPI(&kaosv1alpha1.PersistModelsConfig{
+			Size:             &size,
+			StorageClassName: &storageClass,
+		})
+		gomega.Expect(r.reconcileModelCacheClaim(ctx, modelapi)).To(gomega.Succeed())
+
+		claim := &corev1.PersistentVolumeClaim{}
+		gomega.Expect(c.Get(ctx, types.NamespacedName{Name: "modelapi-ollama-models", Namespace: "default"}, claim)).To(gomega.Succeed())
+		gomega.Expect(claim.Spec.AccessModes).To(gomega.Equal([]corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}))
+		gomega.Expect(claim.Spec.StorageClassName).To(gomega.Equal(&storageClass))
+		gomega.Expect(claim.Spec.Resources.Requests.Storage().String()).To(gomega.Equal("20Gi"))
+		gomega.Expect(claim.OwnerReferences).To(gomega.HaveLen(1))
+		gomega.Expect(claim.OwnerReferences[0].Name).To(gomega.Equal("ollama"))
+
+		// Reconciling again leaves the existing claim untouched
+		gomega.Expect(r.reconcileModelCacheClaim(ctx, modelapi)).To(gomega.Succeed())
+
+		existing := persistedModelAPI(&kaosv1alpha1.PersistModelsConfig{ClaimName: "shared-models"})
+		existing.Name = "other"
+		gomega.Expect(r.reconcileModelCacheClaim(ctx, existing)).To(gomega.Succeed())
+		claims := &corev1.PersistentVolumeClaimList{}
+		gomega.Expect(c.List(ctx, claims)).To(gomega.Succeed())
+		gomega.Expect(claims.Items).To(gomega.HaveLen(1))
+	})
+
+	ginkgo.It("checks for existing weights under a file lock before pulling into a cache", func() {
+		deployment, _, err := (&ModelAPIReconciler{}).constructDeployment(persistedModelAPI(&kaosv1alpha1.PersistModelsConfig{
+			AccessMode: corev1.ReadWriteMany,
+		}))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		command := deployment.Spec.Template.Spec.InitContainers[0].Args[0]
+		check := `[ -f "/root/.ollama/models/manifests/registry.ollama.ai/library/smollm2/135m" ]`
+		lock := "flock /root/.ollama/.kaos-pull.lock"
+		pull := "ollama pull smollm2:135m"
+
+		// Fast path check, then the lock, then a re-check inside the lock before pulling
+		gomega.Expect(strings.Index(command, check)).To(gomega.Equal(strings.Index(command, "if ") + len("if ")))
+		gomega.Expect(strings.Index(command, lock)).To(gomega.BeNumerically(">", strings.Index(command, check)))
+		gomega.Expect(strings.LastIndex(command, check)).To(gomega.BeNumerically(">", strings.Index(command, lock)))
+		gomega.Expect(strings.Index(command, pull)).To(gomega.BeNumerically(">", strings.LastIndex(command, check)))
+	})
+
+	ginkgo.It("always pulls into an emptyDir", func() {
+		deployment, _, err := (&ModelAPIReconciler{}).constructDeployment(persistedModelAPI(nil))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		command := deployment.Spec.Template.Spec.InitContainers[0].Args[0]
+		gomega.Expect(command).NotTo(gomega.ContainSubstring("flock"))
+		gomega.Expect(command).NotTo(gomega.ContainSubstring("manifests"))
+		gomega.Expect(command).To(gomega.ContainSubstring("ollama pull smollm2:135m"))
+	})
+
+	ginkgo.DescribeTable("resolves the Ollama manifest path",
+		func(model, expected string) {
+			gomega.Expect(ollamaManifestPath(model)).To(gomega.Equal("/root/.ollama/models/manifests/" + expected))
+		},
+		ginkgo.Entry("library model with tag", "smollm2:135m", "registry.ollama.ai/library/smollm2/135m"),
+		ginkgo.Entry("library model without tag", "mistral", "registry.ollama.ai/library/mistral/latest"),
+		ginkgo.Entry("namespaced model", "acme/coder:7b", "registry.ollama.ai/acme/coder/7b"),
+		ginkgo.Entry("custom registry", "hf.co/org/repo:Q4_K_M", "hf.co/org/repo/Q4_K_M"),
+		ginkgo.Entry("registry with port", "localhost:5000/team/model", "localhost:5000/team/model/latest"),
+	)
+
+	ginkgo.It("uses the default size when none is given", func() {
+		claim := constructModelCacheClaim(persistedModelAPI(&kaosv1alpha1.PersistModelsConfig{}))
+		gomega.Expect(claim.Spec.Resources.Requests.Storage().String()).To(gomega.Equal("10Gi"))
+		gomega.Expect(claim.Labels).To(gomega.HaveKeyWithValue("app", "modelapi"))
+	})
+})
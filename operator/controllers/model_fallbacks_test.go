@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("ModelAPI model fallbacks", func() {
+	var (
+		agent    *kaosv1alpha1.Agent
+		modelapi *kaosv1alpha1.ModelAPI
+	)
+
+	ginkgo.BeforeEach(func() {
+		setDefaultAgentImage()
+
+		modelapi = &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"gpt-4o", "gpt-4o-mini", "claude-3-5-sonnet"},
+					Fallbacks: map[string][]string{
+						"gpt-4o":      {"claude-3-5-sonnet", "gpt-4o-mini"},
+						"gpt-4o-mini": {"gpt-4o"},
+					},
+				},
+			},
+			Status: kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "gpt-4o"},
+		}
+	})
+
+	ginkgo.It("renders the fallbacks as router_settings", func() {
+		config := (&ModelAPIReconciler{}).generateLiteLLMConfig(modelapi.Spec.ProxyConfig, nil)
+		gomega.Expect(config).To(gomega.ContainSubstring(`
+router_settings:
+  fallbacks:
+    - "gpt-4o": ["claude-3-5-sonnet", "gpt-4o-mini"]
+    - "gpt-4o-mini": ["gpt-4o"]
+`))
+	})
+
+	ginkgo.It("serializes the fallback chain of the agent's model into MODEL_FALLBACKS", func() {
+		gomega.Expect(agentEnv(agent, modelapi)).To(gomega.HaveKeyWithValue("MODEL_FALLBACKS", "claude-3-5-sonnet,gpt-4o-mini"))
+	})
+
+	ginkgo.It("leaves MODEL_FALLBACKS unset when the model has no fallbacks", func() {
+		agent.Spec.Model = "claude-3-5-sonnet"
+		gomega.Expect(agentEnv(agent, modelapi)).NotTo(gomega.HaveKey("MODEL_FALLBACKS"))
+
+		modelapi.Spec.ProxyConfig.Fallbacks = nil
+		agent.Spec.Model = "gpt-4o"
+		gomega.Expect(agentEnv(agent, modelapi)).NotTo(gomega.HaveKey("MODEL_FALLBACKS"))
+		gomega.Expect((&ModelAPIReconciler{}).generateLiteLLMConfig(modelapi.Spec.ProxyConfig, nil)).NotTo(gomega.ContainSubstring("router_settings"))
+	})
+})
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("agent model routes", func() {
+	var (
+		agent    *kaosv1alpha1.Agent
+		modelapi *kaosv1alpha1.ModelAPI
+	)
+
+	ginkgo.BeforeEach(func() {
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI: "llm",
+				Model:    "openai/gpt-4o",
+				Config: &kaosv1alpha1.AgentConfig{
+					ModelRoutes: map[string]string{
+						"simple":    "openai/gpt-4o-mini",
+						"reasoning": "anthropic/claude-sonnet",
+					},
+				},
+			},
+		}
+		modelapi = &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:        kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"openai/*", "anthropic/*"}},
+			},
+			Status: kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}
+	})
+
+	ginkgo.It("accepts routes to models the ModelAPI supports", func() {
+		gomega.Expect((&AgentReconciler{}).validateAgentModel(context.Background(), agent, modelapi)).To(gomega.Succeed())
+	})
+
+	ginkgo.It("rejects a route to a model the ModelAPI does not support", func() {
+		agent.Spec.Config.ModelRoutes["local"] = "ollama/llama3"
+		err := (&AgentReconciler{}).validateAgentModel(context.Background(), agent, modelapi)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`modelRoutes["local"] model "ollama/llama3" not supported by ModelAPI "llm"`)))
+	})
+
+	ginkgo.It("serializes the routes as JSON in MODEL_ROUTES", func() {
+		value := agentEnv(agent, modelapi)["MODEL_ROUTES"]
+		gomega.Expect(value).To(gomega.Equal(`{"reasoning":"anthropic/claude-sonnet","simple":"openai/gpt-4o-mini"}`))
+
+		var routes map[string]string
+		gomega.Expect(json.Unmarshal([]byte(value), &routes)).To(gomega.Succeed())
+		gomega.Expect(routes).To(gomega.Equal(agent.Spec.Config.ModelRoutes))
+	})
+
+	ginkgo.It("omits MODEL_ROUTES without routes", func() {
+		agent.Spec.Config.ModelRoutes = nil
+		gomega.Expect(agentEnv(agent, modelapi)).NotTo(gomega.HaveKey("MODEL_ROUTES"))
+	})
+})
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// agentValidationModels returns the models the agent's model is validated against: the
+// ModelAPI's models list, or with live validation the models a ready Proxy ModelAPI serves.
+// When the live query is not possible or fails, the static list is used.
+func (r *AgentReconciler) agentValidationModels(ctx context.Context, agent *kaosv1alpha1.Agent, modelapi *kaosv1alpha1.ModelAPI) []string {
+	log := log.FromContext(ctx)
+
+	supportedModels := modelAPISupportedModels(modelapi)
+	if agent.Spec.ModelValidation != kaosv1alpha1.ModelValidationLive ||
+		modelapi.Spec.Mode != kaosv1alpha1.ModelAPIModeProxy || !modelapi.Status.Ready || modelapi.Status.Endpoint == "" {
+		return supportedModels
+	}
+
+	served, err := r.fetchServedModels(ctx, modelapi)
+	if err != nil {
+		log.Info("Live model validation unavailable, using the models list", "modelAPI", modelapi.Name, "error", err.Error())
+		return supportedModels
+	}
+	return served
+}
+
+// fetchServedModels lists the model ids the proxy serves at /v1/models, authenticating
+// with the proxy's master key when one is configured
+func (r *AgentReconciler) fetchServedModels(ctx context.Context, modelapi *kaosv1alpha1.ModelAPI) ([]string, error) {
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 5 * time.Second}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, modelapi.Status.Endpoint+"/v1/models", nil)
+	if err != nil {
+		return nil, err
+	}
+	if masterKey := proxyMasterKey(modelapi.Spec.ProxyConfig); masterKey != nil {
+		key, err := resolveAPIKey(ctx, contentReader(r.Client, r.APIReader), modelapi.Namespace, masterKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve master key: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("GET /v1/models returned %d", resp.StatusCode)
+	}
+	var models struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&models); err != nil {
+		return nil, fmt.Errorf("GET /v1/models returned an unexpected response: %w", err)
+	}
+	if len(models.Data) == 0 {
+		return nil, fmt.Errorf("GET /v1/models returned no models")
+	}
+	served := make([]string, 0, len(models.Data))
+	for _, model := range models.Data {
+		served = append(served, model.ID)
+	}
+	return served, nil
+}
+
+// proxyMasterKey returns the master key the proxy requires on requests, if any
+func proxyMasterKey(proxyConfig *kaosv1alpha1.ProxyConfig) *kaosv1alpha1.ApiKeySource {
+	switch {
+	case proxyConfig == nil:
+		return nil
+	case proxyConfig.Database != nil && proxyConfig.Database.MasterKey != nil:
+		return proxyConfig.Database.MasterKey
+	case proxyConfig.SpendTracking != nil:
+		return &proxyConfig.SpendTracking.MasterKey
+	}
+	return nil
+}
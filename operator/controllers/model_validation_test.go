@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("live model validation", func() {
+	var (
+		r        *AgentReconciler
+		modelapi *kaosv1alpha1.ModelAPI
+		agent    *kaosv1alpha1.Agent
+		down     bool
+		authSeen string
+	)
+
+	ginkgo.BeforeEach(func() {
+		down = false
+		authSeen = ""
+		stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			authSeen = req.Header.Get("Authorization")
+			if down || req.URL.Path != "/v1/models" {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`{"object": "list", "data": [{"id": "gpt-4o", "object": "model"}, {"id": "gpt-4o-mini", "object": "model"}]}`))
+		}))
+		ginkgo.DeferCleanup(stub.Close)
+		target, err := url.Parse(stub.URL)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		r = &AgentReconciler{HTTPClient: &http.Client{Transport: redirectTransport{target: target}}}
+		modelapi = &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"gpt-4o", "gpt-4o-mini", "openai/*"},
+					Database: &kaosv1alpha1.ProxyDatabaseConfig{
+						MasterKey: &kaosv1alpha1.ApiKeySource{Value: "sk-master"},
+					},
+				},
+			},
+			Status: kaosv1alpha1.ModelAPIStatus{Endpoint: "http://modelapi-llm.default:8000", Ready: true},
+		}
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:        "llm",
+				Model:           "gpt-4o-mini",
+				ModelValidation: kaosv1alpha1.ModelValidationLive,
+			},
+		}
+	})
+
+	ginkgo.It("accepts a model the proxy serves", func() {
+		gomega.Expect(r.validateAgentModel(context.Background(), agent, modelapi)).To(gomega.Succeed())
+		gomega.Expect(authSeen).To(gomega.Equal("Bearer sk-master"))
+	})
+
+	ginkgo.It("rejects a model the proxy does not serve even if a wildcard matches it", func() {
+		agent.Spec.Model = "openai/gpt-5"
+		err := r.validateAgentModel(context.Background(), agent, modelapi)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(`model "openai/gpt-5" not supported`)))
+	})
+
+	ginkgo.It("falls back to the models list when /v1/models is unavailable", func() {
+		down = true
+		agent.Spec.Model = "openai/gpt-5"
+		gomega.Expect(r.validateAgentModel(context.Background(), agent, modelapi)).To(gomega.Succeed())
+
+		agent.Spec.Model = "claude-3"
+		gomega.Expect(r.validateAgentModel(context.Background(), agent, modelapi)).NotTo(gomega.Succeed())
+	})
+
+	ginkgo.It("does not query the proxy with static validation", func() {
+		agent.Spec.ModelValidation = kaosv1alpha1.ModelValidationStatic
+		agent.Spec.Model = "openai/gpt-5"
+		gomega.Expect(r.validateAgentModel(context.Background(), agent, modelapi)).To(gomega.Succeed())
+		gomega.Expect(authSeen).To(gomega.BeEmpty())
+	})
+})
@@ -2,49 +2,100 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"net/http"
 	"os"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlbuilder "sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
-	"gopkg.in/yaml.v3"
 
 	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/features"
 	"github.com/axsaucedo/kaos/operator/pkg/gateway"
+	"github.com/axsaucedo/kaos/operator/pkg/metrics"
+	"github.com/axsaucedo/kaos/operator/pkg/tracing"
 	"github.com/axsaucedo/kaos/operator/pkg/util"
+	"github.com/axsaucedo/kaos/operator/pkg/validation"
 )
 
 const modelAPIFinalizerName = "kaos.tools/modelapi-finalizer"
 
+// modelAPIForceDeleteAnnotation lets a ModelAPI be deleted while Agents still reference it
+const modelAPIForceDeleteAnnotation = "kaos.tools/force-delete"
+
+// litellmConfigHashAnnotation records the hash of the LiteLLM config on the pod template
+const litellmConfigHashAnnotation = "kaos.tools/litellm-config-hash"
+
 // ModelAPIReconciler reconciles a ModelAPI object
 type ModelAPIReconciler struct {
 	client.Client
-	Log    logr.Logger
-	Scheme *runtime.Scheme
+	Log      logr.Logger
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Clientset reads the pods of a Deployment that is missing replicas, to
+	// explain the failure in status.message (optional)
+	Clientset kubernetes.Interface
+	// APIReader reads Deployments and Services the cache does not hold, such as unlabelled
+	// pre-existing ones hidden by CACHE_LABEL_SELECTOR, so they are adopted (optional)
+	APIReader client.Reader
+	// HTTPClient is used for External mode health checks (defaults to a client with a 5s timeout)
+	HTTPClient *http.Client
 }
 
+// externalHealthRecheckInterval is how often External mode endpoints with a
+// healthPath are re-checked
+const externalHealthRecheckInterval = 30 * time.Second
+
+// defaultModelCacheSize is the storage requested for operator-created model cache claims
+var defaultModelCacheSize = resource.MustParse("10Gi")
+
 //+kubebuilder:rbac:groups=kaos.tools,resources=modelapis,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kaos.tools,resources=modelapis/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=kaos.tools,resources=modelapis/finalizers,verbs=update
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods,verbs=list
+//+kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *ModelAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, span := tracing.StartReconcile(ctx, "ModelAPI", req.NamespacedName)
+	result, err := metrics.ObserveReconcile("ModelAPI", func() (ctrl.Result, error) {
+		return r.reconcile(ctx, req)
+	})
+	tracing.End(span, err)
+	return result, err
+}
+
+// reconcile performs a single reconciliation of the ModelAPI
+func (r *ModelAPIReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 
 	modelapi := &kaosv1alpha1.ModelAPI{}
@@ -56,6 +107,19 @@ func (r *ModelAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	// Handle deletion with finalizer
 	if modelapi.ObjectMeta.DeletionTimestamp != nil {
 		if controllerutil.ContainsFinalizer(modelapi, modelAPIFinalizerName) {
+			// Keep the ModelAPI while Agents still reference it, unless deletion is forced.
+			// The Agent watch re-triggers reconciliation once they are removed or repointed.
+			if modelapi.Annotations[modelAPIForceDeleteAnnotation] != "true" {
+				agents, err := referencingAgents(ctx, r.Client, modelapi.Namespace, modelapi.Name, agentModelAPINames)
+				if err != nil {
+					log.Error(err, "failed to list Agents referencing ModelAPI")
+					return ctrl.Result{}, err
+				}
+				if len(agents) > 0 {
+					return ctrl.Result{}, r.blockDeletion(ctx, modelapi, agents)
+				}
+			}
+
 			// Perform cleanup
 			log.Info("Deleting ModelAPI", "name", modelapi.Name)
 			controllerutil.RemoveFinalizer(modelapi, modelAPIFinalizerName)
@@ -76,14 +140,24 @@ func (r *ModelAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		}
 	}
 
-	// Set initial status
+	// Set initial status; it is persisted by the single status update at the end of
+	// the reconcile (or by the status update of an early return)
 	if modelapi.Status.Phase == "" {
 		modelapi.Status.Phase = "Pending"
 		modelapi.Status.Ready = false
-		if err := r.Status().Update(ctx, modelapi); err != nil {
-			log.Error(err, "failed to update status")
-			return ctrl.Result{}, err
-		}
+	}
+
+	// Record the Agents that depend on this ModelAPI
+	if dependents, err := referencingAgents(ctx, r.Client, modelapi.Namespace, modelapi.Name, agentModelAPINames); err != nil {
+		log.Error(err, "failed to list dependent Agents")
+		return ctrl.Result{}, err
+	} else {
+		modelapi.Status.Dependents = dependents
+	}
+
+	// External mode registers an existing endpoint; nothing is deployed
+	if modelapi.Spec.Mode == kaosv1alpha1.ModelAPIModeExternal {
+		return r.reconcileExternal(ctx, modelapi)
 	}
 
 	// Create ConfigMap for Proxy mode - always needed since we use config file mode
@@ -101,23 +175,40 @@ func (r *ModelAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		if telemetry != nil && telemetry.Enabled {
 			log.Info("WARNING: OpenTelemetry telemetry is not supported for Ollama (Hosted mode). "+
 				"Traces and metrics will not be collected.", "modelapi", modelapi.Name)
-			// Update status message to warn user
-			if modelapi.Status.Message == "" {
-				modelapi.Status.Message = "Warning: Telemetry enabled but Ollama does not support OTel natively"
-				r.Status().Update(ctx, modelapi)
+			if r.Recorder != nil {
+				r.Recorder.Event(modelapi, corev1.EventTypeWarning, "TelemetryNotSupported",
+					"Telemetry enabled but Ollama does not support OTel natively; traces and metrics will not be collected")
 			}
 		}
 	}
 
+	if warmStandbyRequested(modelapi) && !features.Enabled(features.WarmStandby) {
+		warnFeatureGateDisabled(ctx, r.Recorder, modelapi, features.WarmStandby, "hostedConfig.warmStandby")
+	}
+
 	// Validate configYaml against models list if both are provided
+	// (also enforced at admission when the validating webhook is enabled)
 	if needsConfigMap && modelapi.Spec.ProxyConfig.ConfigYaml != nil &&
 		modelapi.Spec.ProxyConfig.ConfigYaml.FromString != "" {
-		if err := r.validateConfigYamlModels(modelapi.Spec.ProxyConfig); err != nil {
-			log.Error(err, "configYaml validation failed")
-			modelapi.Status.Phase = "Failed"
-			modelapi.Status.Message = err.Error()
-			r.Status().Update(ctx, modelapi)
-			return ctrl.Result{}, nil
+		if err := validation.ValidateConfigYamlModels(modelapi.Spec.ProxyConfig); err != nil {
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, modelapi, "InvalidConfigYaml", nil, err.Error())
+		}
+	}
+
+	// Validate aliases, fallbacks, callbacks and guardrails
+	// (also enforced at admission when the validating webhook is enabled)
+	if needsConfigMap {
+		if err := validation.ValidateProxyAliases(modelapi.Spec.ProxyConfig); err != nil {
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, modelapi, "InvalidAliases", nil, err.Error())
+		}
+		if err := validation.ValidateProxyFallbacks(modelapi.Spec.ProxyConfig); err != nil {
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, modelapi, "InvalidFallbacks", nil, err.Error())
+		}
+		if err := validation.ValidateProxyCallbacks(modelapi.Spec.ProxyConfig); err != nil {
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, modelapi, "InvalidCallbacks", nil, err.Error())
+		}
+		if err := validation.ValidateProxyGuardrails(modelapi.Spec.ProxyConfig); err != nil {
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, modelapi, "InvalidGuardrails", nil, err.Error())
 		}
 	}
 
@@ -129,26 +220,22 @@ func (r *ModelAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		if err != nil && apierrors.IsNotFound(err) {
 			// Create new ConfigMap with user-provided config or auto-generated wildcard
 			configmap = r.constructConfigMap(modelapi)
-			if err := controllerutil.SetControllerReference(modelapi, configmap, r.Scheme); err != nil {
+			if err := util.SetControllerReference(modelapi, configmap, r.Scheme); err != nil {
 				log.Error(err, "failed to set controller reference for ConfigMap")
 				return ctrl.Result{}, err
 			}
 
 			log.Info("Creating ConfigMap", "name", configmap.Name)
 			if err := r.Create(ctx, configmap); err != nil {
-				log.Error(err, "failed to create ConfigMap")
-				modelapi.Status.Phase = "Failed"
-				modelapi.Status.Message = fmt.Sprintf("Failed to create ConfigMap: %v", err)
-				r.Status().Update(ctx, modelapi)
-				return ctrl.Result{}, err
+				return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, modelapi, "ConfigMapCreateFailed", err, "Failed to create ConfigMap")
 			}
 		} else if err != nil {
-			log.Error(err, "failed to get ConfigMap")
-			modelapi.Status.Phase = "Failed"
-			modelapi.Status.Message = fmt.Sprintf("Failed to get ConfigMap: %v", err)
-			r.Status().Update(ctx, modelapi)
-			return ctrl.Result{}, err
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, modelapi, "ConfigMapGetFailed", err, "Failed to get ConfigMap")
 		} else {
+			if ok, err := adoptResource(ctx, r.Client, r.Scheme, r.Recorder, modelapi, configmap); !ok {
+				return ctrl.Result{}, err
+			}
+
 			// ConfigMap exists - check if it needs updating
 			desiredConfigMap := r.constructConfigMap(modelapi)
 			if configmap.Data["config.yaml"] != desiredConfigMap.Data["config.yaml"] {
@@ -162,98 +249,145 @@ func (r *ModelAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		}
 	}
 
+	// Create the model cache PVC for Hosted mode when persistModels is enabled
+	if err := r.reconcileModelCacheClaim(ctx, modelapi); err != nil {
+		return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, modelapi, "ModelCacheClaimFailed", err, "Failed to reconcile model cache PersistentVolumeClaim")
+	}
+
 	// Create or update Deployment
 	deployment := &appsv1.Deployment{}
+	deploymentUpdated := false
 	deploymentName := fmt.Sprintf("modelapi-%s", modelapi.Name)
-	err := r.Get(ctx, types.NamespacedName{Name: deploymentName, Namespace: modelapi.Namespace}, deployment)
+	err := getExisting(ctx, r.Client, r.APIReader, types.NamespacedName{Name: deploymentName, Namespace: modelapi.Namespace}, deployment)
 
 	if err != nil && apierrors.IsNotFound(err) {
 		// Create new Deployment
-		deployment, err = r.constructDeployment(modelapi)
+		var overridden []string
+		deployment, overridden, err = r.constructDeployment(modelapi)
 		if err != nil {
-			log.Error(err, "failed to construct Deployment")
-			modelapi.Status.Phase = "Failed"
-			modelapi.Status.Message = fmt.Sprintf("Failed to construct Deployment: %v", err)
-			r.Status().Update(ctx, modelapi)
+			return ctrl.Result{}, constructError(ctx, r.Client, r.Recorder, modelapi, err)
+		}
+		recordEnvOverrides(r.Recorder, modelapi, &modelapi.Status.Conditions, overridden)
+		if err := applyReferencedContentHash(ctx, contentReader(r.Client, r.APIReader), modelapi, &deployment.Spec.Template); err != nil {
+			log.Error(err, "failed to hash referenced ConfigMaps and Secrets")
 			return ctrl.Result{}, err
 		}
-		if err := controllerutil.SetControllerReference(modelapi, deployment, r.Scheme); err != nil {
+		applyAutoRollout(modelapi, deployment)
+		if err := util.SetControllerReference(modelapi, deployment, r.Scheme); err != nil {
 			log.Error(err, "failed to set controller reference")
 			return ctrl.Result{}, err
 		}
 
 		log.Info("Creating Deployment", "name", deployment.Name)
 		if err := r.Create(ctx, deployment); err != nil {
-			log.Error(err, "failed to create Deployment")
-			modelapi.Status.Phase = "Failed"
-			modelapi.Status.Message = fmt.Sprintf("Failed to create Deployment: %v", err)
-			r.Status().Update(ctx, modelapi)
-			return ctrl.Result{}, err
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, modelapi, "DeploymentCreateFailed", err, "Failed to create Deployment")
 		}
 	} else if err != nil {
 		log.Error(err, "failed to get Deployment")
 		return ctrl.Result{}, err
 	} else {
-		// Deployment exists - check if spec has changed using hash annotation
-		desiredDeployment, err := r.constructDeployment(modelapi)
-		if err != nil {
-			log.Error(err, "failed to construct Deployment for comparison")
+		if ok, err := adoptResource(ctx, r.Client, r.Scheme, r.Recorder, modelapi, deployment); !ok {
 			return ctrl.Result{}, err
 		}
-		currentHash := ""
-		if deployment.Spec.Template.Annotations != nil {
-			currentHash = deployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+
+		// Deployment exists - check if spec has changed using hash annotation
+		desiredDeployment, overridden, err := r.constructDeployment(modelapi)
+		if err != nil {
+			return ctrl.Result{}, constructError(ctx, r.Client, r.Recorder, modelapi, err)
 		}
-		desiredHash := ""
-		if desiredDeployment.Spec.Template.Annotations != nil {
-			desiredHash = desiredDeployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+		recordEnvOverrides(r.Recorder, modelapi, &modelapi.Status.Conditions, overridden)
+		if err := applyReferencedContentHash(ctx, contentReader(r.Client, r.APIReader), modelapi, &desiredDeployment.Spec.Template); err != nil {
+			log.Error(err, "failed to hash referenced ConfigMaps and Secrets")
+			return ctrl.Result{}, err
 		}
+		applyAutoRollout(modelapi, desiredDeployment)
+		currentHash := deploymentHash(deployment)
+		desiredHash := deploymentHash(desiredDeployment)
+
+		rolloutChanged := deploymentRolloutChanged(deployment, desiredDeployment)
 
-		if currentHash != desiredHash {
-			log.Info("Updating Deployment due to spec change", "name", deployment.Name,
+		changed := currentHash != desiredHash || rolloutChanged
+		if changed && deploymentFrozen(modelapi) {
+			log.Info("Deployment is frozen; skipping update", "name", deployment.Name,
 				"currentHash", currentHash, "desiredHash", desiredHash)
-			// Update the deployment spec to trigger rolling update
-			deployment.Spec.Template = desiredDeployment.Spec.Template
-			if err := r.Update(ctx, deployment); err != nil {
-				log.Error(err, "failed to update Deployment")
-				return ctrl.Result{}, err
+			if r.Recorder != nil {
+				r.Recorder.Event(modelapi, corev1.EventTypeNormal, "DeploymentFrozen",
+					fmt.Sprintf("Deployment %s differs from the spec but is not updated while %s is set", deployment.Name, freezeDeploymentAnnotation))
+			}
+		} else if changed {
+			// With warmStandby, the new pod template is first loaded by the standby Deployment
+			standbyReady := true
+			if currentHash != desiredHash && warmStandbyEnabled(modelapi) {
+				if standbyReady, err = r.reconcileWarmStandby(ctx, modelapi, desiredDeployment); err != nil {
+					return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, modelapi, "WarmStandbyFailed", err, "Failed to reconcile warm standby Deployment")
+				}
+			}
+			if !standbyReady {
+				log.Info("Waiting for the warm standby to become ready before updating the Deployment", "name", deployment.Name)
+			} else {
+				log.Info("Updating Deployment due to spec change", "name", deployment.Name,
+					"currentHash", currentHash, "desiredHash", desiredHash)
+				// Update the deployment spec to trigger rolling update
+				deployment.Spec.Template = desiredDeployment.Spec.Template
+				setDeploymentRollout(deployment, desiredDeployment)
+				setDeploymentHash(deployment, desiredDeployment)
+				if err := r.Update(ctx, deployment); err != nil {
+					log.Error(err, "failed to update Deployment")
+					return ctrl.Result{}, err
+				}
+				deploymentUpdated = true
+			}
+		}
+
+		// A ReadWriteOnce model cache can only be mounted by pods on a single node
+		if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas > 1 && modelCacheReadWriteOnce(modelapi) {
+			log.Info("WARNING: replicas > 1 with a ReadWriteOnce model cache; pods on other nodes cannot mount it", "modelapi", modelapi.Name)
+			if r.Recorder != nil {
+				r.Recorder.Event(modelapi, corev1.EventTypeWarning, "ModelCacheNotShared",
+					"Deployment has more than one replica but hostedConfig.persistModels uses ReadWriteOnce; set accessMode to ReadWriteMany")
 			}
 		}
 	}
 
+	// Remove the warm standby once the Deployment has rolled out; its status is stale
+	// right after an update, so the check waits for the next reconcile
+	var standby *appsv1.Deployment
+	if !deploymentUpdated {
+		if standby, err = r.retireWarmStandby(ctx, modelapi, deployment); err != nil {
+			log.Error(err, "failed to reconcile warm standby Deployment")
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Create or update Service
 	service := &corev1.Service{}
 	serviceName := fmt.Sprintf("modelapi-%s", modelapi.Name)
-	err = r.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: modelapi.Namespace}, service)
+	err = getExisting(ctx, r.Client, r.APIReader, types.NamespacedName{Name: serviceName, Namespace: modelapi.Namespace}, service)
 
 	if err != nil && apierrors.IsNotFound(err) {
 		// Create new Service
 		service = r.constructService(modelapi)
-		if err := controllerutil.SetControllerReference(modelapi, service, r.Scheme); err != nil {
+		if err := util.SetControllerReference(modelapi, service, r.Scheme); err != nil {
 			log.Error(err, "failed to set controller reference")
 			return ctrl.Result{}, err
 		}
 
 		log.Info("Creating Service", "name", service.Name)
 		if err := r.Create(ctx, service); err != nil {
-			log.Error(err, "failed to create Service")
-			modelapi.Status.Phase = "Failed"
-			modelapi.Status.Message = fmt.Sprintf("Failed to create Service: %v", err)
-			r.Status().Update(ctx, modelapi)
-			return ctrl.Result{}, err
+			return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, modelapi, "ServiceCreateFailed", err, "Failed to create Service")
 		}
 	} else if err != nil {
 		log.Error(err, "failed to get Service")
 		return ctrl.Result{}, err
 	} else {
-		// Service exists - check if port needs to be updated (mode changed)
-		desiredService := r.constructService(modelapi)
-		currentPort := service.Spec.Ports[0].Port
-		desiredPort := desiredService.Spec.Ports[0].Port
+		if ok, err := adoptResource(ctx, r.Client, r.Scheme, r.Recorder, modelapi, service); !ok {
+			return ctrl.Result{}, err
+		}
 
-		if currentPort != desiredPort {
-			log.Info("Updating Service due to port change", "name", service.Name,
-				"currentPort", currentPort, "desiredPort", desiredPort)
+		// Service exists - check if the port needs to be updated (mode or servicePort changed)
+		desiredService := r.constructService(modelapi)
+		if servicePortsChanged(service, desiredService) {
+			log.Info("Updating Service due to port change", "name", service.Name)
 			service.Spec.Ports = desiredService.Spec.Ports
 			if err := r.Update(ctx, service); err != nil {
 				log.Error(err, "failed to update Service")
@@ -268,29 +402,46 @@ func (r *ModelAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		port = 11434
 	}
 	modelapi.Status.Endpoint = fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", serviceName, modelapi.Namespace, port)
+	modelapi.Status.SupportedModels = modelAPISupportedModels(modelapi)
 
 	// Create HTTPRoute if Gateway API is enabled
 	timeout := ""
 	if modelapi.Spec.GatewayRoute != nil && modelapi.Spec.GatewayRoute.Timeout != "" {
 		timeout = modelapi.Spec.GatewayRoute.Timeout
 	}
-	if err := gateway.ReconcileHTTPRoute(ctx, r.Client, r.Scheme, modelapi, gateway.HTTPRouteParams{
-		ResourceType: gateway.ResourceTypeModelAPI,
-		ResourceName: modelapi.Name,
-		Namespace:    modelapi.Namespace,
-		ServiceName:  serviceName,
-		ServicePort:  int32(port),
-		Labels:       map[string]string{"app": "modelapi", "modelapi": modelapi.Name},
-		Timeout:      timeout,
-	}, log); err != nil {
-		log.Error(err, "failed to reconcile HTTPRoute")
+	modelapi.Status.GatewayEndpoints = nil
+	if gatewayRouteEnabled(modelapi.Spec.GatewayRoute) {
+		routeParams := gateway.HTTPRouteParams{
+			ResourceType:   gateway.ResourceTypeModelAPI,
+			ResourceName:   modelapi.Name,
+			Namespace:      modelapi.Namespace,
+			ServiceName:    serviceName,
+			ServicePort:    int32(port),
+			Labels:         map[string]string{"app": "modelapi", "modelapi": modelapi.Name},
+			PathPrefix:     gatewayRoutePathPrefix(modelapi.Spec.GatewayRoute),
+			SectionName:    gatewayRouteSectionName(modelapi.Spec.GatewayRoute),
+			Gateways:       gatewayRouteGateways(modelapi.Spec.GatewayRoute),
+			Timeout:        timeout,
+			CORS:           gatewayRouteCORS(modelapi.Spec.GatewayRoute),
+			RequestHeaders: gatewayRouteRequestHeaders(modelapi.Spec.GatewayRoute),
+		}
+		if err := gateway.ReconcileHTTPRoute(ctx, r.Client, r.Scheme, modelapi, routeParams, log); err != nil {
+			modelapi.Status.GatewayEndpoints = nil
+			return ctrl.Result{}, routeError(ctx, r.Client, r.Recorder, modelapi, err)
+		}
+		modelapi.Status.GatewayEndpoints = gateway.RouteEndpoints(routeParams)
+	} else if err := gateway.DeleteHTTPRoute(ctx, r.Client, modelapi, gateway.ResourceTypeModelAPI, modelapi.Name, modelapi.Namespace, log); err != nil {
+		log.Error(err, "failed to delete HTTPRoute")
 	}
 
 	// Copy deployment status for rolling update visibility
+	previousDeployment := modelapi.Status.Deployment
 	modelapi.Status.Deployment = util.CopyDeploymentStatus(deployment)
+	util.TrackPendingSince(modelapi.Status.Deployment, previousDeployment, metav1.Now())
 
-	// Check deployment readiness
-	if deployment.Status.ReadyReplicas > 0 {
+	// Check deployment readiness; a ready warm standby serves while the Deployment rolls
+	standbyServing := standby != nil && standby.Status.ReadyReplicas > 0
+	if deployment.Status.ReadyReplicas > 0 || standbyServing {
 		modelapi.Status.Ready = true
 		modelapi.Status.Phase = "Ready"
 	} else {
@@ -299,17 +450,154 @@ func (r *ModelAPIReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 
 	modelapi.Status.Message = fmt.Sprintf("Deployment ready replicas: %d/%d", deployment.Status.ReadyReplicas, *deployment.Spec.Replicas)
+	if standby != nil {
+		modelapi.Status.Message += fmt.Sprintf("; warm standby ready replicas: %d/%d", standby.Status.ReadyReplicas, *standby.Spec.Replicas)
+	}
+
+	// A rollout that stopped making progress is surfaced as Failed
+	if message, exceeded := util.DeploymentProgressDeadlineExceeded(deployment); exceeded {
+		modelapi.Status.Phase = "Failed"
+		modelapi.Status.Ready = false
+		modelapi.Status.Message = "Deployment rollout exceeded its progress deadline: " + message
+	}
+
+	// A rollout that never got a ready replica is surfaced as Failed after readyTimeout
+	var readyTimeoutRemaining time.Duration
+	if modelapi.Status.Phase == "Pending" {
+		remaining, exceeded := readyTimeoutExceeded(modelapi.Spec.ReadyTimeout, modelapi.Status.Deployment)
+		if exceeded {
+			modelapi.Status.Phase = "Failed"
+			modelapi.Status.Message = readyTimeoutMessage(modelapi.Spec.ReadyTimeout, deployment)
+		}
+		readyTimeoutRemaining = remaining
+	}
+
+	// Explain missing replicas with the first failing pod (e.g. ImagePullBackOff)
+	if deployment.Status.ReadyReplicas < *deployment.Spec.Replicas {
+		if detail := podFailureMessage(ctx, r.Clientset, deployment); detail != "" {
+			modelapi.Status.Message += "; " + detail
+		}
+	}
+	setReadyCondition(&modelapi.Status.Conditions, modelapi.Generation, modelapi.Status.Ready, modelapi.Status.Phase, modelapi.Status.Message)
+
+	// Read spend from the proxy when spendTracking is set, and come back for the next read
+	result := ctrl.Result{RequeueAfter: earliestRequeue(r.reconcileSpend(ctx, modelapi), readyTimeoutRemaining)}
+
+	if err := patchStatus(ctx, r.Client, modelapi); err != nil {
+		log.Error(err, "failed to update status")
+		return ctrl.Result{}, err
+	}
+
+	return result, nil
+}
+
+// reconcileExternal handles External mode: no Deployment, Service or ConfigMap is
+// created. The status endpoint is taken from externalConfig.endpoint and the ModelAPI
+// is marked ready, after a successful HTTP GET of healthPath when one is configured.
+func (r *ModelAPIReconciler) reconcileExternal(ctx context.Context, modelapi *kaosv1alpha1.ModelAPI) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
 
-	if err := r.Status().Update(ctx, modelapi); err != nil {
+	external := modelapi.Spec.ExternalConfig
+	if external == nil {
+		return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, modelapi, "ExternalConfigMissing", nil, "externalConfig is required when mode is External")
+	}
+
+	// A ModelAPI switched from Hosted or Proxy mode no longer uses its workload
+	if err := r.deleteDeployedResources(ctx, modelapi); err != nil {
+		return ctrl.Result{}, reconcileError(ctx, r.Client, r.Recorder, modelapi, "CleanupFailed", err, "Failed to delete resources unused in External mode")
+	}
+
+	endpoint := strings.TrimSuffix(external.Endpoint, "/")
+	modelapi.Status.Endpoint = endpoint
+	modelapi.Status.SupportedModels = modelAPISupportedModels(modelapi)
+	modelapi.Status.Deployment = nil
+
+	result := ctrl.Result{}
+	modelapi.Status.Ready = true
+	modelapi.Status.Phase = "Ready"
+	modelapi.Status.Message = fmt.Sprintf("External endpoint %s", endpoint)
+	if external.HealthPath != "" {
+		// Keep re-checking so the ModelAPI reflects the endpoint going up or down
+		result.RequeueAfter = externalHealthRecheckInterval
+		if err := r.checkExternalHealth(ctx, endpoint+external.HealthPath); err != nil {
+			log.Info("External ModelAPI health check failed", "endpoint", endpoint, "error", err.Error())
+			modelapi.Status.Ready = false
+			modelapi.Status.Phase = "Pending"
+			modelapi.Status.Message = fmt.Sprintf("External endpoint health check failed: %v", err)
+		}
+	}
+	setReadyCondition(&modelapi.Status.Conditions, modelapi.Generation, modelapi.Status.Ready, modelapi.Status.Phase, modelapi.Status.Message)
+
+	if err := patchStatus(ctx, r.Client, modelapi); err != nil {
 		log.Error(err, "failed to update status")
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	return result, nil
+}
+
+// deleteDeployedResources deletes the Deployment, warm standby, Service, LiteLLM
+// ConfigMap, model cache PVC and HTTPRoute created for Hosted or Proxy mode. Only objects
+// controlled by the ModelAPI are deleted, so a user-provided claimName is left in place.
+func (r *ModelAPIReconciler) deleteDeployedResources(ctx context.Context, modelapi *kaosv1alpha1.ModelAPI) error {
+	log := log.FromContext(ctx)
+
+	owned := []struct {
+		kind string
+		obj  client.Object
+	}{
+		{"Deployment", &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("modelapi-%s", modelapi.Name)}}},
+		{"Deployment", &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: warmStandbyName(modelapi)}}},
+		{"Service", &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("modelapi-%s", modelapi.Name)}}},
+		{"ConfigMap", &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("litellm-config-%s", modelapi.Name)}}},
+		{"PersistentVolumeClaim", &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("modelapi-%s-models", modelapi.Name)}}},
+	}
+	for _, o := range owned {
+		err := r.Get(ctx, types.NamespacedName{Name: o.obj.GetName(), Namespace: modelapi.Namespace}, o.obj)
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if !metav1.IsControlledBy(o.obj, modelapi) {
+			continue
+		}
+		log.Info("Deleting "+o.kind+" unused in External mode", "name", o.obj.GetName())
+		if err := r.Delete(ctx, o.obj); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return gateway.DeleteHTTPRoute(ctx, r.Client, modelapi, gateway.ResourceTypeModelAPI, modelapi.Name, modelapi.Namespace, log)
+}
+
+// checkExternalHealth performs an HTTP GET of url and returns an error unless it
+// responds with a 2xx status
+func (r *ModelAPIReconciler) checkExternalHealth(ctx context.Context, url string) error {
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 5 * time.Second}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("GET %s returned %d", url, resp.StatusCode)
+	}
+	return nil
 }
 
-// constructDeployment creates a Deployment for the ModelAPI
-func (r *ModelAPIReconciler) constructDeployment(modelapi *kaosv1alpha1.ModelAPI) (*appsv1.Deployment, error) {
+// constructDeployment creates a Deployment for the ModelAPI, and returns the generated env
+// vars container.env overrides
+func (r *ModelAPIReconciler) constructDeployment(modelapi *kaosv1alpha1.ModelAPI) (*appsv1.Deployment, []string, error) {
 	labels := map[string]string{
 		"app":      "modelapi",
 		"modelapi": modelapi.Name,
@@ -332,38 +620,67 @@ func (r *ModelAPIReconciler) constructDeployment(modelapi *kaosv1alpha1.ModelAPI
 		})
 	}
 
+	if volume := constructCABundleVolume(modelapi.Spec.CABundle); volume != nil {
+		volumes = append(volumes, *volume)
+	}
+
 	// Build init containers for Hosted mode (pull the model)
 	initContainers := []corev1.Container{}
 	ollamaImage := os.Getenv("DEFAULT_OLLAMA_IMAGE")
 	if ollamaImage == "" && modelapi.Spec.Mode == kaosv1alpha1.ModelAPIModeHosted {
-		return nil, fmt.Errorf("DEFAULT_OLLAMA_IMAGE environment variable is required but not set")
+		return nil, nil, &util.MissingEnvError{Name: "DEFAULT_OLLAMA_IMAGE"}
 	}
-	if modelapi.Spec.Mode == kaosv1alpha1.ModelAPIModeHosted && modelapi.Spec.HostedConfig != nil && modelapi.Spec.HostedConfig.Model != "" {
-		// Init container starts Ollama server, pulls model, then exits
-		// The model is stored in the emptyDir volume shared with main container
+	hostedModels := modelAPIHostedModels(modelapi)
+	if len(hostedModels) > 0 {
+		// Each init container starts Ollama server, pulls one model, then exits
+		// The models are stored in the volume shared with main container: an emptyDir,
+		// or a PVC when persistModels is set so pulls survive restarts
+		dataSource := corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		}
+		if claimName := modelCacheClaimName(modelapi); claimName != "" {
+			dataSource = corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claimName,
+				},
+			}
+		}
 		volumes = append(volumes, corev1.Volume{
-			Name: "ollama-data",
-			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{},
-			},
-		})
-		initContainers = append(initContainers, corev1.Container{
-			Name:            "pull-model",
-			Image:           ollamaImage,
-			ImagePullPolicy: corev1.PullIfNotPresent,
-			Command:         []string{"/bin/sh", "-c"},
-			Args: []string{
-				fmt.Sprintf("ollama serve & OLLAMA_PID=$! && sleep 5 && ollama pull %s && kill $OLLAMA_PID", modelapi.Spec.HostedConfig.Model),
-			},
-			VolumeMounts: []corev1.VolumeMount{
-				{Name: "ollama-data", MountPath: "/root/.ollama"},
-			},
+			Name:         "ollama-data",
+			VolumeSource: dataSource,
 		})
+		cached := modelCacheClaimName(modelapi) != ""
+		for i, model := range hostedModels {
+			// The first pull keeps its original name so single-model deployments are unchanged
+			name := "pull-model"
+			if i > 0 {
+				name = fmt.Sprintf("pull-model-%d", i)
+			}
+			initContainers = append(initContainers, corev1.Container{
+				Name:            name,
+				Image:           ollamaImage,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Command:         []string{"/bin/sh", "-c"},
+				Args: []string{
+					ollamaPullCommand(model, cached),
+				},
+				// Models are pulled from the Ollama registry through the egress proxy, if any
+				Env: util.BuildEgressProxyEnvVars(util.MergeEgressProxy(modelapi.Spec.EgressProxy)),
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: "ollama-data", MountPath: "/root/.ollama"},
+				},
+			})
+		}
 	}
 
-	container, err := r.constructContainer(modelapi)
+	container, overridden, err := r.constructContainer(modelapi)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// With a database, migrations run to completion before the proxy starts
+	if database := proxyDatabase(modelapi); database != nil {
+		initContainers = append(initContainers, litellmMigrationContainer(database, container.Image))
 	}
 
 	basePodSpec := corev1.PodSpec{
@@ -371,20 +688,40 @@ func (r *ModelAPIReconciler) constructDeployment(modelapi *kaosv1alpha1.ModelAPI
 		Containers: []corev1.Container{
 			container,
 		},
-		Volumes: volumes,
+		Volumes:     volumes,
+		DNSPolicy:   modelapi.Spec.DNSPolicy,
+		DNSConfig:   modelapi.Spec.DNSConfig,
+		HostAliases: modelapi.Spec.HostAliases,
 	}
 
 	// Apply podSpec override using strategic merge patch if provided
 	finalPodSpec := basePodSpec
 	if modelapi.Spec.PodSpec != nil {
 		merged, err := util.MergePodSpec(basePodSpec, *modelapi.Spec.PodSpec)
-		if err == nil {
-			finalPodSpec = merged
+		if err != nil {
+			return nil, nil, err
 		}
+		finalPodSpec = merged
+	}
+
+	// Reference images by digest when pinning is enabled
+	if err := util.PinPodSpecImages(&finalPodSpec); err != nil {
+		return nil, nil, err
 	}
 
-	// Compute hash of the pod spec for change detection
+	// Compute hash of the pod spec for change detection. LiteLLM only reads its config
+	// at startup, so the config is folded in to roll the pods when it changes.
 	podSpecHash := util.ComputePodSpecHash(finalPodSpec)
+	annotations := map[string]string{}
+	if modelapi.Spec.Mode == kaosv1alpha1.ModelAPIModeProxy && modelapi.Spec.ProxyConfig != nil {
+		configHash := util.ComputeContentHash([]byte(r.constructConfigMap(modelapi).Data["config.yaml"]))
+		annotations[litellmConfigHashAnnotation] = configHash
+		podSpecHash = util.ComputeContentHash([]byte(podSpecHash + configHash))
+	}
+	annotations[util.PodSpecHashAnnotation] = podSpecHash
+
+	// Old and new pods cannot share a ReadWriteOnce model cache during a rolling update
+	strategy := deploymentStrategy(modelapi.Spec.DeploymentStrategy, modelCacheReadWriteOnce(modelapi))
 
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
@@ -397,23 +734,205 @@ func (r *ModelAPIReconciler) constructDeployment(modelapi *kaosv1alpha1.ModelAPI
 			Selector: &metav1.LabelSelector{
 				MatchLabels: labels,
 			},
+			Strategy:                strategy,
+			MinReadySeconds:         minReadySeconds(modelapi.Spec.MinReadySeconds),
+			ProgressDeadlineSeconds: progressDeadlineSeconds(modelapi.Spec.ProgressDeadlineSeconds),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
-					Annotations: map[string]string{
-						util.PodSpecHashAnnotation: podSpecHash,
-					},
+					Labels:      labels,
+					Annotations: annotations,
 				},
 				Spec: finalPodSpec,
 			},
 		},
 	}
 
-	return deployment, nil
+	return deployment, overridden, nil
+}
+
+// ollamaModelsDir is where Ollama stores pulled models, backed by the ollama-data volume
+const ollamaModelsDir = "/root/.ollama"
+
+// ollamaPullCommand returns the init container script that pulls model. With a persistent
+// cache the volume may be shared by several pods, so the pull is skipped when the model's
+// manifest already exists (Ollama writes it only after all layers are downloaded) and is
+// otherwise serialized with a file lock on the volume, re-checking once the lock is held
+// in case another pod pulled the model in the meantime.
+func ollamaPullCommand(model string, cached bool) string {
+	pull := fmt.Sprintf("ollama serve & OLLAMA_PID=$! && sleep 5 && ollama pull %s && kill $OLLAMA_PID", model)
+	if !cached {
+		return pull
+	}
+
+	check := fmt.Sprintf(`if [ -f "%s" ]; then echo "model %s already cached, skipping pull"; exit 0; fi`,
+		ollamaManifestPath(model), model)
+	return fmt.Sprintf("%s; exec flock %s/.kaos-pull.lock /bin/sh -c '%s; %s'", check, ollamaModelsDir, check, pull)
 }
 
-// constructContainer creates the container spec based on ModelAPI mode
-func (r *ModelAPIReconciler) constructContainer(modelapi *kaosv1alpha1.ModelAPI) (corev1.Container, error) {
+// ollamaManifestPath returns the manifest file Ollama writes for model, e.g.
+// smollm2:135m -> <models dir>/models/manifests/registry.ollama.ai/library/smollm2/135m
+func ollamaManifestPath(model string) string {
+	name, tag := model, "latest"
+	if i := strings.LastIndex(model, ":"); i > strings.LastIndex(model, "/") {
+		name, tag = model[:i], model[i+1:]
+	}
+
+	// Names are [host/][namespace/]model, defaulting to registry.ollama.ai/library
+	parts := strings.Split(name, "/")
+	switch len(parts) {
+	case 1:
+		parts = []string{"registry.ollama.ai", "library", parts[0]}
+	case 2:
+		parts = append([]string{"registry.ollama.ai"}, parts...)
+	}
+
+	return fmt.Sprintf("%s/models/manifests/%s/%s", ollamaModelsDir, strings.Join(parts, "/"), tag)
+}
+
+// modelCacheClaimName returns the PVC backing the Ollama models directory, or "" when
+// persistModels is not enabled
+func modelCacheClaimName(modelapi *kaosv1alpha1.ModelAPI) string {
+	if modelapi.Spec.Mode != kaosv1alpha1.ModelAPIModeHosted || modelapi.Spec.HostedConfig == nil ||
+		modelapi.Spec.HostedConfig.PersistModels == nil {
+		return ""
+	}
+	if modelapi.Spec.HostedConfig.PersistModels.ClaimName != "" {
+		return modelapi.Spec.HostedConfig.PersistModels.ClaimName
+	}
+	return fmt.Sprintf("modelapi-%s-models", modelapi.Name)
+}
+
+// modelCacheReadWriteOnce reports whether the model cache PVC can only be mounted by one node
+func modelCacheReadWriteOnce(modelapi *kaosv1alpha1.ModelAPI) bool {
+	if modelCacheClaimName(modelapi) == "" {
+		return false
+	}
+	return modelapi.Spec.HostedConfig.PersistModels.AccessMode != corev1.ReadWriteMany
+}
+
+// reconcileModelCacheClaim creates the operator-owned model cache PVC if persistModels is
+// enabled without a claimName. Existing claims are left untouched since most PVC fields
+// are immutable; referenced claims are managed by the user.
+func (r *ModelAPIReconciler) reconcileModelCacheClaim(ctx context.Context, modelapi *kaosv1alpha1.ModelAPI) error {
+	log := log.FromContext(ctx)
+
+	claimName := modelCacheClaimName(modelapi)
+	if claimName == "" || modelapi.Spec.HostedConfig.PersistModels.ClaimName != "" {
+		return nil
+	}
+
+	claim := &corev1.PersistentVolumeClaim{}
+	err := r.Get(ctx, types.NamespacedName{Name: claimName, Namespace: modelapi.Namespace}, claim)
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	claim = constructModelCacheClaim(modelapi)
+	if err := util.SetControllerReference(modelapi, claim, r.Scheme); err != nil {
+		return err
+	}
+	log.Info("Creating model cache PersistentVolumeClaim", "name", claim.Name)
+	return r.Create(ctx, claim)
+}
+
+// constructModelCacheClaim builds the operator-owned PVC for the Ollama models directory
+func constructModelCacheClaim(modelapi *kaosv1alpha1.ModelAPI) *corev1.PersistentVolumeClaim {
+	persist := modelapi.Spec.HostedConfig.PersistModels
+
+	size := defaultModelCacheSize
+	if persist.Size != nil {
+		size = *persist.Size
+	}
+	accessMode := persist.AccessMode
+	if accessMode == "" {
+		accessMode = corev1.ReadWriteOnce
+	}
+
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      modelCacheClaimName(modelapi),
+			Namespace: modelapi.Namespace,
+			Labels: map[string]string{
+				"app":      "modelapi",
+				"modelapi": modelapi.Name,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{accessMode},
+			StorageClassName: persist.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+		},
+	}
+}
+
+// modelAPIHostedModels returns the Ollama models pulled in Hosted mode: hostedConfig.model
+// followed by hostedConfig.models, without duplicates
+func modelAPIHostedModels(modelapi *kaosv1alpha1.ModelAPI) []string {
+	if modelapi.Spec.Mode != kaosv1alpha1.ModelAPIModeHosted || modelapi.Spec.HostedConfig == nil {
+		return nil
+	}
+	candidates := append([]string{modelapi.Spec.HostedConfig.Model}, modelapi.Spec.HostedConfig.Models...)
+	seen := make(map[string]bool, len(candidates))
+	models := []string{}
+	for _, model := range candidates {
+		if model == "" || seen[model] {
+			continue
+		}
+		seen[model] = true
+		models = append(models, model)
+	}
+	return models
+}
+
+// modelAPISupportedModels returns the models an Agent may request from the ModelAPI
+func modelAPISupportedModels(modelapi *kaosv1alpha1.ModelAPI) []string {
+	switch modelapi.Spec.Mode {
+	case kaosv1alpha1.ModelAPIModeProxy:
+		if proxyConfig := modelapi.Spec.ProxyConfig; proxyConfig != nil {
+			if len(proxyConfig.Aliases) == 0 {
+				return proxyConfig.Models
+			}
+			// Aliases are served as model names of their own
+			return append(slices.Clone(proxyConfig.Models), sortedKeys(proxyConfig.Aliases)...)
+		}
+	case kaosv1alpha1.ModelAPIModeHosted:
+		return modelAPIHostedModels(modelapi)
+	case kaosv1alpha1.ModelAPIModeExternal:
+		if modelapi.Spec.ExternalConfig != nil {
+			return modelapi.Spec.ExternalConfig.Models
+		}
+	}
+	return nil
+}
+
+// modelAPIHealthPath returns the HTTP health path served by the ModelAPI container
+func modelAPIHealthPath(modelapi *kaosv1alpha1.ModelAPI) string {
+	if modelapi.Spec.Mode == kaosv1alpha1.ModelAPIModeExternal {
+		// External endpoints only have a health path if one is configured
+		if modelapi.Spec.ExternalConfig == nil {
+			return ""
+		}
+		return modelapi.Spec.ExternalConfig.HealthPath
+	}
+	if modelapi.Spec.Mode == kaosv1alpha1.ModelAPIModeProxy {
+		// Use /health/liveliness for faster probe responses
+		// /health does a full backend check which can timeout
+		return "/health/liveliness"
+	}
+	// Ollama serves its health check on the root path
+	return "/"
+}
+
+// constructContainer creates the container spec based on ModelAPI mode, and returns the
+// generated env vars container.env overrides
+func (r *ModelAPIReconciler) constructContainer(modelapi *kaosv1alpha1.ModelAPI) (corev1.Container, []string, error) {
 	var image string
 	var args []string
 	var env []corev1.EnvVar
@@ -424,12 +943,10 @@ func (r *ModelAPIReconciler) constructContainer(modelapi *kaosv1alpha1.ModelAPI)
 		// LiteLLM Proxy mode - always uses config file
 		image = os.Getenv("DEFAULT_LITELLM_IMAGE")
 		if image == "" {
-			return corev1.Container{}, fmt.Errorf("DEFAULT_LITELLM_IMAGE environment variable is required but not set")
+			return corev1.Container{}, nil, &util.MissingEnvError{Name: "DEFAULT_LITELLM_IMAGE"}
 		}
 		port = 8000
-		// Use /health/liveliness for faster probe responses
-		// /health does a full backend check which can timeout
-		healthPath = "/health/liveliness"
+		healthPath = modelAPIHealthPath(modelapi)
 
 		// Always use config file mode for consistency:
 		// - User provides configYaml → use their config directly
@@ -445,32 +962,38 @@ func (r *ModelAPIReconciler) constructContainer(modelapi *kaosv1alpha1.ModelAPI)
 		}
 
 		// Add PROXY_API_KEY env var if apiKey is configured
-		if modelapi.Spec.ProxyConfig != nil && modelapi.Spec.ProxyConfig.APIKey != nil {
-			apiKey := modelapi.Spec.ProxyConfig.APIKey
-			if apiKey.Value != "" {
-				env = append(env, corev1.EnvVar{
-					Name:  "PROXY_API_KEY",
-					Value: apiKey.Value,
-				})
-			} else if apiKey.ValueFrom != nil {
-				if apiKey.ValueFrom.SecretKeyRef != nil {
-					env = append(env, corev1.EnvVar{
-						Name: "PROXY_API_KEY",
-						ValueFrom: &corev1.EnvVarSource{
-							SecretKeyRef: apiKey.ValueFrom.SecretKeyRef,
-						},
-					})
-				} else if apiKey.ValueFrom.ConfigMapKeyRef != nil {
-					env = append(env, corev1.EnvVar{
-						Name: "PROXY_API_KEY",
-						ValueFrom: &corev1.EnvVarSource{
-							ConfigMapKeyRef: apiKey.ValueFrom.ConfigMapKeyRef,
-						},
-					})
+		if modelapi.Spec.ProxyConfig != nil {
+			if e, ok := apiKeyEnvVar("PROXY_API_KEY", modelapi.Spec.ProxyConfig.APIKey); ok {
+				env = append(env, e)
+			}
+		}
+
+		// Add the API keys of the configured guardrails
+		if modelapi.Spec.ProxyConfig != nil {
+			for _, guardrail := range modelapi.Spec.ProxyConfig.Guardrails {
+				if e, ok := apiKeyEnvVar(guardrailAPIKeyEnvName(guardrail.Name), guardrail.APIKey); ok {
+					env = append(env, e)
 				}
 			}
 		}
 
+		// Add the database connection and master key when a database is configured. The
+		// schema is migrated by the migrate-db init container, so the proxy skips it.
+		if database := proxyDatabase(modelapi); database != nil {
+			env = append(env, databaseURLEnvVar(database), corev1.EnvVar{
+				Name:  "DISABLE_SCHEMA_UPDATE",
+				Value: "true",
+			})
+			if e, ok := apiKeyEnvVar("LITELLM_MASTER_KEY", database.MasterKey); ok {
+				env = append(env, e)
+			}
+		}
+
+		// Add env vars read by the configured callbacks (API keys, hosts)
+		if modelapi.Spec.ProxyConfig != nil {
+			env = append(env, modelapi.Spec.ProxyConfig.CallbackEnv...)
+		}
+
 		// Add user-provided env vars from container
 		if modelapi.Spec.Container != nil {
 			env = append(env, modelapi.Spec.Container.Env...)
@@ -531,11 +1054,11 @@ func (r *ModelAPIReconciler) constructContainer(modelapi *kaosv1alpha1.ModelAPI)
 		// Ollama Hosted mode
 		image = os.Getenv("DEFAULT_OLLAMA_IMAGE")
 		if image == "" {
-			return corev1.Container{}, fmt.Errorf("DEFAULT_OLLAMA_IMAGE environment variable is required but not set")
+			return corev1.Container{}, nil, &util.MissingEnvError{Name: "DEFAULT_OLLAMA_IMAGE"}
 		}
 		args = []string{}
 		port = 11434
-		healthPath = "/"
+		healthPath = modelAPIHealthPath(modelapi)
 
 		// Add user-provided env vars from container
 		if modelapi.Spec.Container != nil {
@@ -570,6 +1093,20 @@ func (r *ModelAPIReconciler) constructContainer(modelapi *kaosv1alpha1.ModelAPI)
 		}
 	}
 
+	// Trust the spec.caBundle certificates for outbound TLS
+	env = append(env, caBundleEnv(modelapi.Spec.CABundle)...)
+
+	// Route outbound calls through the egress proxy, if any
+	env = append(env, util.BuildEgressProxyEnvVars(util.MergeEgressProxy(modelapi.Spec.EgressProxy))...)
+
+	// Generated entries come first and user entries (including valueFrom) last, so the
+	// user wins; the result has one entry per name
+	var userEnv []corev1.EnvVar
+	if modelapi.Spec.Container != nil {
+		userEnv = modelapi.Spec.Container.Env
+	}
+	env, overridden := finalizeEnv(env, userEnv)
+
 	// Build volume mounts - add litellm-config for Proxy mode (always uses config file)
 	volumeMounts := []corev1.VolumeMount{}
 	if modelapi.Spec.Mode == kaosv1alpha1.ModelAPIModeProxy && modelapi.Spec.ProxyConfig != nil {
@@ -579,12 +1116,15 @@ func (r *ModelAPIReconciler) constructContainer(modelapi *kaosv1alpha1.ModelAPI)
 		})
 	}
 	// Add ollama-data volume mount for Hosted mode
-	if modelapi.Spec.Mode == kaosv1alpha1.ModelAPIModeHosted && modelapi.Spec.HostedConfig != nil && modelapi.Spec.HostedConfig.Model != "" {
+	if len(modelAPIHostedModels(modelapi)) > 0 {
 		volumeMounts = append(volumeMounts, corev1.VolumeMount{
 			Name:      "ollama-data",
 			MountPath: "/root/.ollama",
 		})
 	}
+	if constructCABundleVolume(modelapi.Spec.CABundle) != nil {
+		volumeMounts = append(volumeMounts, caBundleVolumeMount())
+	}
 
 	container := corev1.Container{
 		Name:            "model-api",
@@ -628,7 +1168,67 @@ func (r *ModelAPIReconciler) constructContainer(modelapi *kaosv1alpha1.ModelAPI)
 		},
 	}
 
-	return container, nil
+	return container, overridden, nil
+}
+
+// proxyDatabase returns the LiteLLM database config of a Proxy ModelAPI, or nil
+func proxyDatabase(modelapi *kaosv1alpha1.ModelAPI) *kaosv1alpha1.ProxyDatabaseConfig {
+	if modelapi.Spec.Mode != kaosv1alpha1.ModelAPIModeProxy || modelapi.Spec.ProxyConfig == nil {
+		return nil
+	}
+	return modelapi.Spec.ProxyConfig.Database
+}
+
+// databaseURLEnvVar returns DATABASE_URL read from the database's Secret
+func databaseURLEnvVar(database *kaosv1alpha1.ProxyDatabaseConfig) corev1.EnvVar {
+	urlRef := database.URLSecretRef
+	return corev1.EnvVar{
+		Name:      "DATABASE_URL",
+		ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &urlRef},
+	}
+}
+
+// litellmMigrationContainer returns the init container applying LiteLLM's database
+// migrations with image, so the proxy never starts against an unmigrated schema. Prisma
+// holds a lock while migrating, so replicas starting together apply them once.
+func litellmMigrationContainer(database *kaosv1alpha1.ProxyDatabaseConfig, image string) corev1.Container {
+	return corev1.Container{
+		Name:            "migrate-db",
+		Image:           image,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"python", "litellm/proxy/prisma_migration.py"},
+		Env:             []corev1.EnvVar{databaseURLEnvVar(database)},
+	}
+}
+
+// apiKeyEnvVar returns the env var named name holding apiKey, either as a direct value
+// or a Secret/ConfigMap reference. Returns false when apiKey sets neither.
+func apiKeyEnvVar(name string, apiKey *kaosv1alpha1.ApiKeySource) (corev1.EnvVar, bool) {
+	if apiKey == nil {
+		return corev1.EnvVar{}, false
+	}
+	if apiKey.Value != "" {
+		return corev1.EnvVar{Name: name, Value: apiKey.Value}, true
+	}
+	if apiKey.ValueFrom != nil {
+		if apiKey.ValueFrom.SecretKeyRef != nil {
+			return corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: apiKey.ValueFrom.SecretKeyRef,
+			}}, true
+		}
+		if apiKey.ValueFrom.ConfigMapKeyRef != nil {
+			return corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{
+				ConfigMapKeyRef: apiKey.ValueFrom.ConfigMapKeyRef,
+			}}, true
+		}
+	}
+	return corev1.EnvVar{}, false
+}
+
+// guardrailAPIKeyEnvName returns the env var holding the API key of the named guardrail,
+// e.g. GUARDRAIL_LAKERA_PRE_API_KEY for "lakera-pre"
+func guardrailAPIKeyEnvName(name string) string {
+	return fmt.Sprintf("GUARDRAIL_%s_API_KEY", strings.ToUpper(strings.ReplaceAll(name, "-", "_")))
 }
 
 // constructService creates a Service for the ModelAPI
@@ -656,7 +1256,6 @@ func (r *ModelAPIReconciler) constructService(modelapi *kaosv1alpha1.ModelAPI) *
 			Type: corev1.ServiceTypeClusterIP,
 			Ports: []corev1.ServicePort{
 				{
-					Name:       "http",
 					Port:       port,
 					TargetPort: intstr.FromInt(int(targetPort)),
 					Protocol:   corev1.ProtocolTCP,
@@ -666,6 +1265,8 @@ func (r *ModelAPIReconciler) constructService(modelapi *kaosv1alpha1.ModelAPI) *
 		},
 	}
 
+	applyServicePortConfig(&service.Spec.Ports[0], kaosv1alpha1.ServiceProtocolHTTP, modelapi.Spec.ServicePort)
+
 	return service
 }
 
@@ -712,40 +1313,22 @@ func (r *ModelAPIReconciler) constructConfigMap(modelapi *kaosv1alpha1.ModelAPI)
 // - models: ["*"] with provider: "nebius" → model_name: "*" → model: "nebius/*"
 // - models: ["*"] without provider → model_name: "*" → model: "*"
 // When telemetry is enabled, adds OTel callback for traces/metrics.
+// Models are rendered sorted and de-duplicated so the output is byte-identical
+// for equivalent specs and the ConfigMap only changes when the spec does.
 func (r *ModelAPIReconciler) generateLiteLLMConfig(proxyConfig *kaosv1alpha1.ProxyConfig, telemetry *kaosv1alpha1.TelemetryConfig) string {
 	var sb strings.Builder
 
 	sb.WriteString("# Auto-generated LiteLLM config\n")
 	sb.WriteString("model_list:\n")
 
-	provider := proxyConfig.Provider
-
-	// Generate model_list entries for each model
-	for _, model := range proxyConfig.Models {
-		// model_name is what clients request (e.g., "gpt-4o" or "*")
-		sb.WriteString(fmt.Sprintf("  - model_name: \"%s\"\n", model))
-		sb.WriteString("    litellm_params:\n")
-
-		// model is what LiteLLM uses internally (with provider prefix if set)
-		var litellmModel string
-		if provider != "" {
-			// Prepend provider prefix: "gpt-4o" → "nebius/gpt-4o"
-			litellmModel = fmt.Sprintf("%s/%s", provider, model)
-		} else {
-			// Use model as-is
-			litellmModel = model
-		}
-		sb.WriteString(fmt.Sprintf("      model: \"%s\"\n", litellmModel))
-
-		// Add api_base if configured
-		if proxyConfig.APIBase != "" {
-			sb.WriteString("      api_base: \"os.environ/PROXY_API_BASE\"\n")
-		}
+	// Generate model_list entries for each model (stable order)
+	for _, model := range sortedUniqueModels(proxyConfig.Models) {
+		writeLiteLLMModel(&sb, proxyConfig, model, model)
+	}
 
-		// Add api_key if configured
-		if proxyConfig.APIKey != nil {
-			sb.WriteString("      api_key: \"os.environ/PROXY_API_KEY\"\n")
-		}
+	// Aliases are routed exactly like the model they point to
+	for _, alias := range sortedKeys(proxyConfig.Aliases) {
+		writeLiteLLMModel(&sb, proxyConfig, alias, proxyConfig.Aliases[alias])
 	}
 
 	sb.WriteString("\nlitellm_settings:\n")
@@ -757,77 +1340,200 @@ func (r *ModelAPIReconciler) generateLiteLLMConfig(proxyConfig *kaosv1alpha1.Pro
 		sb.WriteString("  failure_callback: [\"otel\"]\n")
 	}
 
+	// Add user-configured callbacks (validated against the allowed set)
+	if len(proxyConfig.Callbacks) > 0 {
+		quoted := make([]string, 0, len(proxyConfig.Callbacks))
+		for _, callback := range proxyConfig.Callbacks {
+			quoted = append(quoted, fmt.Sprintf("\"%s\"", callback))
+		}
+		sb.WriteString(fmt.Sprintf("  callbacks: [%s]\n", strings.Join(quoted, ", ")))
+	}
+
+	// Retry failed requests on the configured fallback models, in order
+	if len(proxyConfig.Fallbacks) > 0 {
+		sb.WriteString("\nrouter_settings:\n")
+		sb.WriteString("  fallbacks:\n")
+		for _, model := range slices.Sorted(maps.Keys(proxyConfig.Fallbacks)) {
+			quoted := make([]string, 0, len(proxyConfig.Fallbacks[model]))
+			for _, fallback := range proxyConfig.Fallbacks[model] {
+				quoted = append(quoted, fmt.Sprintf("\"%s\"", fallback))
+			}
+			sb.WriteString(fmt.Sprintf("    - \"%s\": [%s]\n", model, strings.Join(quoted, ", ")))
+		}
+	}
+
+	// Store keys, budgets and spend in the database when one is configured
+	if database := proxyConfig.Database; database != nil {
+		sb.WriteString("\ngeneral_settings:\n")
+		sb.WriteString("  database_url: \"os.environ/DATABASE_URL\"\n")
+		if _, ok := apiKeyEnvVar("", database.MasterKey); ok {
+			sb.WriteString("  master_key: \"os.environ/LITELLM_MASTER_KEY\"\n")
+		}
+	}
+
+	// Add guardrails in the order listed
+	if len(proxyConfig.Guardrails) > 0 {
+		sb.WriteString("\nguardrails:\n")
+		for _, guardrail := range proxyConfig.Guardrails {
+			sb.WriteString(fmt.Sprintf("  - guardrail_name: %s\n", yamlQuote(guardrail.Name)))
+			sb.WriteString("    litellm_params:\n")
+			sb.WriteString(fmt.Sprintf("      guardrail: %s\n", yamlQuote(guardrail.Provider)))
+			sb.WriteString(fmt.Sprintf("      mode: %s\n", yamlQuote(guardrail.Mode)))
+			sb.WriteString(fmt.Sprintf("      default_on: %t\n", guardrail.DefaultOn == nil || *guardrail.DefaultOn))
+			if _, ok := apiKeyEnvVar("", guardrail.APIKey); ok {
+				sb.WriteString(fmt.Sprintf("      api_key: \"os.environ/%s\"\n", guardrailAPIKeyEnvName(guardrail.Name)))
+			}
+			if guardrail.APIBase != "" {
+				sb.WriteString(fmt.Sprintf("      api_base: %s\n", yamlQuote(guardrail.APIBase)))
+			}
+			if len(guardrail.Thresholds) > 0 {
+				categories := make([]string, 0, len(guardrail.Thresholds))
+				for category := range guardrail.Thresholds {
+					categories = append(categories, category)
+				}
+				sort.Strings(categories)
+				sb.WriteString("      category_thresholds:\n")
+				for _, category := range categories {
+					sb.WriteString(fmt.Sprintf("        %s: %s\n", category, guardrail.Thresholds[category]))
+				}
+			}
+		}
+	}
+
 	return sb.String()
 }
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *ModelAPIReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&kaosv1alpha1.ModelAPI{}).
-		Owns(&appsv1.Deployment{}).
-		Owns(&corev1.Service{}).
-		Owns(&corev1.ConfigMap{})
+// writeLiteLLMModel renders a model_list entry that serves modelName by routing to model
+func writeLiteLLMModel(sb *strings.Builder, proxyConfig *kaosv1alpha1.ProxyConfig, modelName, model string) {
+	// model_name is what clients request (e.g., "gpt-4o" or "*")
+	sb.WriteString(fmt.Sprintf("  - model_name: \"%s\"\n", modelName))
+	sb.WriteString("    litellm_params:\n")
+
+	// model is what LiteLLM uses internally (with provider prefix if set)
+	var litellmModel string
+	if proxyConfig.Provider != "" {
+		// Prepend provider prefix: "gpt-4o" → "nebius/gpt-4o"
+		litellmModel = fmt.Sprintf("%s/%s", proxyConfig.Provider, model)
+	} else {
+		// Use model as-is
+		litellmModel = model
+	}
+	sb.WriteString(fmt.Sprintf("      model: \"%s\"\n", litellmModel))
 
-	if gateway.GetConfig().Enabled {
-		builder = builder.Owns(&gatewayv1.HTTPRoute{})
+	// Add api_base if configured
+	if proxyConfig.APIBase != "" {
+		sb.WriteString("      api_base: \"os.environ/PROXY_API_BASE\"\n")
 	}
 
-	return builder.Complete(r)
-}
+	// Add api_key if configured
+	if proxyConfig.APIKey != nil {
+		sb.WriteString("      api_key: \"os.environ/PROXY_API_KEY\"\n")
+	}
 
-// liteLLMConfig represents the structure of LiteLLM config for validation
-type liteLLMConfig struct {
-	ModelList []struct {
-		ModelName string `yaml:"model_name"`
-	} `yaml:"model_list"`
+	// Add extra litellm_params in a stable order
+	for _, key := range sortedKeys(proxyConfig.ExtraParams) {
+		sb.WriteString(fmt.Sprintf("      %s: %s\n", key, yamlScalar(proxyConfig.ExtraParams[key])))
+	}
 }
 
-// validateConfigYamlModels validates that model_names in configYaml match the models list
-func (r *ModelAPIReconciler) validateConfigYamlModels(proxyConfig *kaosv1alpha1.ProxyConfig) error {
-	if proxyConfig.ConfigYaml == nil || proxyConfig.ConfigYaml.FromString == "" {
-		return nil
-	}
+var (
+	// plainYAMLNumber matches numbers that LiteLLM's YAML parser reads as numbers
+	plainYAMLNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?$`)
+	// plainYAMLString matches strings that LiteLLM's YAML parser reads back unchanged
+	plainYAMLString = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_./-]*$`)
+	// yamlReservedWords are read as booleans or null by YAML 1.1 parsers such as PyYAML
+	yamlReservedWords = map[string]bool{"y": true, "n": true, "yes": true, "no": true, "on": true, "off": true, "true": true, "false": true, "null": true}
+)
 
-	// Parse the configYaml
-	var config liteLLMConfig
-	if err := yaml.Unmarshal([]byte(proxyConfig.ConfigYaml.FromString), &config); err != nil {
-		return fmt.Errorf("failed to parse configYaml: %w", err)
+// yamlScalar renders value verbatim when it is a number, true/false or a plain identifier
+// (e.g. 0.2, true, us-east-1), so numbers and booleans keep their type. Anything else is
+// double-quoted, so values such as "a: b", dates or multi-line strings stay strings and
+// cannot change the structure of the config.
+func yamlScalar(value string) string {
+	if plainYAMLNumber.MatchString(value) || value == "true" || value == "false" ||
+		(plainYAMLString.MatchString(value) && !yamlReservedWords[strings.ToLower(value)]) {
+		return value
 	}
+	return yamlQuote(value)
+}
+
+// yamlQuote renders value as a double-quoted YAML string. JSON string escaping is valid
+// in YAML double-quoted scalars, so quotes, backslashes and newlines in value cannot end
+// the string early or change the structure of the config.
+func yamlQuote(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
 
-	// Build a set of allowed models from the models list
-	allowedModels := make(map[string]bool)
-	for _, model := range proxyConfig.Models {
-		allowedModels[model] = true
+// sortedUniqueModels returns a sorted copy of models with duplicates removed.
+// LiteLLM resolves exact model_names before wildcard patterns, so list order
+// does not affect routing.
+func sortedUniqueModels(models []string) []string {
+	result := make([]string, 0, len(models))
+	seen := make(map[string]bool, len(models))
+	for _, model := range models {
+		if seen[model] {
+			continue
+		}
+		seen[model] = true
+		result = append(result, model)
 	}
+	sort.Strings(result)
+	return result
+}
 
-	// Check each model_name in configYaml against the models list
-	for _, entry := range config.ModelList {
-		if !r.modelMatchesPatterns(entry.ModelName, proxyConfig.Models) {
-			return fmt.Errorf("model_name %q in configYaml not found in models list %v", entry.ModelName, proxyConfig.Models)
+// blockDeletion keeps a deleting ModelAPI in the Terminating phase with the names of the
+// Agents that still reference it
+func (r *ModelAPIReconciler) blockDeletion(ctx context.Context, modelapi *kaosv1alpha1.ModelAPI, agents []string) error {
+	log := log.FromContext(ctx)
+
+	message := fmt.Sprintf("Deletion blocked: referenced by Agents %s; remove or repoint them, or set the %s annotation to \"true\"",
+		strings.Join(agents, ", "), modelAPIForceDeleteAnnotation)
+	if modelapi.Status.Phase != "Terminating" {
+		log.Info("WARNING: ModelAPI deletion blocked by referencing Agents", "modelapi", modelapi.Name, "agents", agents)
+		if r.Recorder != nil {
+			r.Recorder.Event(modelapi, corev1.EventTypeWarning, "DeletionBlocked", message)
 		}
 	}
 
-	return nil
+	modelapi.Status.Phase = "Terminating"
+	modelapi.Status.Message = message
+	modelapi.Status.Dependents = agents
+	return patchStatus(ctx, r.Client, modelapi)
 }
 
-// modelMatchesPatterns checks if a model matches any pattern in the list
-func (r *ModelAPIReconciler) modelMatchesPatterns(model string, patterns []string) bool {
-	for _, pattern := range patterns {
-		// Full wildcard
-		if pattern == "*" {
-			return true
-		}
-		// Exact match
-		if pattern == model {
-			return true
-		}
-		// Provider wildcard: "openai/*" matches "openai/gpt-4"
-		if strings.HasSuffix(pattern, "/*") {
-			prefix := strings.TrimSuffix(pattern, "*")
-			if strings.HasPrefix(model, prefix) {
-				return true
-			}
+// SetupWithManager sets up the controller with the Manager.
+func (r *ModelAPIReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Map Agent changes to the ModelAPIs they reference (before and after an update), so
+	// status.dependents stays current and a blocked deletion is released once its Agents
+	// are removed or repointed
+	mapAgentToModelAPIs := handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []ctrl.Request {
+		agent := obj.(*kaosv1alpha1.Agent)
+		requests := []ctrl.Request{}
+		for _, name := range agentModelAPINames(agent) {
+			requests = append(requests, ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: name, Namespace: agent.Namespace},
+			})
 		}
+		return requests
+	})
+
+	builder := ctrl.NewControllerManagedBy(mgr).
+		For(&kaosv1alpha1.ModelAPI{}).
+		Owns(&appsv1.Deployment{}).
+		Owns(&corev1.Service{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.PersistentVolumeClaim{}).
+		Watches(&kaosv1alpha1.Agent{}, mapAgentToModelAPIs,
+			ctrlbuilder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.ConfigMap{}, mapReferencedObjectToOwners(r.Client, "ModelAPI", "ConfigMap"),
+			ctrlbuilder.OnlyMetadata).
+		Watches(&corev1.Secret{}, mapReferencedObjectToOwners(r.Client, "ModelAPI", "Secret"),
+			ctrlbuilder.OnlyMetadata)
+
+	if gateway.GetConfig().Enabled {
+		builder = builder.Owns(&gatewayv1.HTTPRoute{})
 	}
-	return false
+
+	return builder.Complete(r)
 }
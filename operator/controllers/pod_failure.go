@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+const (
+	// maxPodFailureLength bounds the pod failure detail added to status.message, in characters
+	maxPodFailureLength = 400
+)
+
+// podFailureMessage describes the first failing pod of deployment for status.message,
+// e.g. `container agent in pod agent-x-1 is ImagePullBackOff: Back-off pulling image
+// "agent:bad"`. Only the reason and message of the container state are reported; the
+// container logs are never read, since they may hold secrets. Pods are read with the
+// clientset, bypassing the cache, so the operator does not watch every pod in the
+// cluster. It returns "" when no pod is failing, the clientset is not set or the pods
+// cannot be read.
+func podFailureMessage(ctx context.Context, clientset kubernetes.Interface, deployment *appsv1.Deployment) string {
+	if clientset == nil || deployment.Spec.Selector == nil {
+		return ""
+	}
+	log := log.FromContext(ctx)
+
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return ""
+	}
+	pods, err := clientset.CoreV1().Pods(deployment.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		log.Info("Failed to list pods for the status message", "deployment", deployment.Name, "error", err.Error())
+		return ""
+	}
+	failure := util.FindPodFailure(pods.Items)
+	if failure == nil {
+		return ""
+	}
+
+	var message string
+	if failure.Container != "" {
+		message = fmt.Sprintf("container %s in pod %s is %s", failure.Container, failure.Pod, failure.Reason)
+	} else {
+		message = fmt.Sprintf("pod %s is %s", failure.Pod, failure.Reason)
+	}
+	if detail := strings.TrimSpace(failure.Message); detail != "" {
+		message += ": " + detail
+	}
+	return truncateRunes(message, maxPodFailureLength)
+}
+
+// truncateRunes shortens s to at most max characters, marking the cut with "..."
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-3]) + "..."
+}
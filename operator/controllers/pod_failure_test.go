@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = ginkgo.Describe("pod failures in status.message", func() {
+	ginkgo.It("bounds the message", func() {
+		ctx := context.Background()
+		labels := map[string]string{"app": "agent", "agent": "agent"}
+		clientset := kubefake.NewClientset(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent-agent-1", Namespace: "default", Labels: labels},
+			Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "agent",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+					Reason: "CreateContainerConfigError", Message: strings.Repeat("x", 2000),
+				}},
+			}}},
+		})
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent-agent", Namespace: "default"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: labels}},
+		}
+
+		message := podFailureMessage(ctx, clientset, deployment)
+		gomega.Expect([]rune(message)).To(gomega.HaveLen(maxPodFailureLength))
+		gomega.Expect(message).To(gomega.HaveSuffix("..."))
+	})
+})
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+// readyTimeoutExceeded reports whether the current rollout has waited longer than
+// readyTimeout for its first ready replica. Otherwise it returns how long until the
+// timeout, or zero when no timeout applies, so the caller can requeue for it.
+func readyTimeoutExceeded(readyTimeout string, status *kaosv1alpha1.DeploymentStatus) (time.Duration, bool) {
+	if readyTimeout == "" || status == nil || status.PendingSince == nil {
+		return 0, false
+	}
+	timeout, err := time.ParseDuration(readyTimeout)
+	if err != nil || timeout <= 0 {
+		return 0, false
+	}
+	remaining := timeout - time.Since(status.PendingSince.Time)
+	if remaining <= 0 {
+		return 0, true
+	}
+	return remaining, false
+}
+
+// readyTimeoutMessage is the status message of a resource whose rollout exceeded its
+// readyTimeout, naming the Deployment's latest failing condition when there is one
+func readyTimeoutMessage(readyTimeout string, deployment *appsv1.Deployment) string {
+	message := fmt.Sprintf("No replica became ready within readyTimeout %s", readyTimeout)
+	if failure := util.DeploymentFailureMessage(deployment); failure != "" {
+		message += ": " + failure
+	}
+	return message
+}
+
+// earliestRequeue returns the shorter of two requeue delays, ignoring zero (no requeue)
+func earliestRequeue(a, b time.Duration) time.Duration {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
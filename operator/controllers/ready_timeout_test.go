@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("readyTimeout", func() {
+	var (
+		ctx context.Context
+		c   client.Client
+		r   *AgentReconciler
+		key types.NamespacedName
+	)
+
+	// unschedulable is the status of a Deployment whose only pod cannot be scheduled
+	unschedulable := appsv1.DeploymentStatus{
+		Replicas:            1,
+		UpdatedReplicas:     1,
+		UnavailableReplicas: 1,
+		Conditions: []appsv1.DeploymentCondition{
+			{
+				Type: appsv1.DeploymentProgressing, Status: corev1.ConditionTrue, Reason: "ReplicaSetUpdated",
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+			},
+			{
+				Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse, Reason: "MinimumReplicasUnavailable",
+				Message:            "Deployment does not have minimum availability.",
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+			},
+		},
+	}
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		setDefaultAgentImage()
+
+		scheme := runtime.NewScheme()
+		gomega.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+		gomega.Expect(kaosv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+
+		modelapi := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:        kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"mock-model"}},
+			},
+			Status: kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "mock-model", ReadyTimeout: "5m"},
+		}
+		key = types.NamespacedName{Name: agent.Name, Namespace: agent.Namespace}
+
+		c = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(modelapi, agent).
+			WithStatusSubresource(&kaosv1alpha1.ModelAPI{}, &kaosv1alpha1.Agent{}).
+			Build()
+		r = &AgentReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+	})
+
+	reconcile := func() (ctrl.Result, *kaosv1alpha1.Agent) {
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		agent := &kaosv1alpha1.Agent{}
+		gomega.Expect(c.Get(ctx, key, agent)).To(gomega.Succeed())
+		return result, agent
+	}
+
+	// setDeploymentStatus replaces the status of the agent's Deployment
+	setDeploymentStatus := func(status appsv1.DeploymentStatus) {
+		deployment := &appsv1.Deployment{}
+		gomega.Expect(c.Get(ctx, types.NamespacedName{Name: "agent-agent", Namespace: "default"}, deployment)).To(gomega.Succeed())
+		deployment.Status = status
+		gomega.Expect(c.Status().Update(ctx, deployment)).To(gomega.Succeed())
+	}
+
+	// backdatePendingSince moves the start of the current wait into the past
+	backdatePendingSince := func(agent *kaosv1alpha1.Agent, ago time.Duration) {
+		since := metav1.NewTime(time.Now().Add(-ago))
+		agent.Status.Deployment.PendingSince = &since
+		gomega.Expect(c.Status().Update(ctx, agent)).To(gomega.Succeed())
+	}
+
+	ginkgo.It("stays Pending and requeues for the timeout while within it", func() {
+		reconcile()
+		setDeploymentStatus(unschedulable)
+
+		result, agent := reconcile()
+		gomega.Expect(agent.Status.Phase).To(gomega.Equal("Pending"))
+		gomega.Expect(agent.Status.Deployment.PendingSince).NotTo(gomega.BeNil())
+		gomega.Expect(result.RequeueAfter).To(gomega.BeNumerically("~", 5*time.Minute, 10*time.Second))
+	})
+
+	ginkgo.It("marks an unschedulable agent Failed after the timeout with the Deployment condition", func() {
+		_, agent := reconcile()
+		setDeploymentStatus(unschedulable)
+		backdatePendingSince(agent, 6*time.Minute)
+
+		_, agent = reconcile()
+		gomega.Expect(agent.Status.Phase).To(gomega.Equal("Failed"))
+		gomega.Expect(agent.Status.Ready).To(gomega.BeFalse())
+		gomega.Expect(agent.Status.Message).To(gomega.Equal(
+			"No replica became ready within readyTimeout 5m: Available=False (MinimumReplicasUnavailable): Deployment does not have minimum availability."))
+	})
+
+	ginkgo.It("restarts the timer when a new rollout starts", func() {
+		_, agent := reconcile()
+		setDeploymentStatus(unschedulable)
+		backdatePendingSince(agent, 6*time.Minute)
+		_, agent = reconcile()
+		gomega.Expect(agent.Status.Phase).To(gomega.Equal("Failed"))
+
+		deployment := &appsv1.Deployment{}
+		gomega.Expect(c.Get(ctx, types.NamespacedName{Name: "agent-agent", Namespace: "default"}, deployment)).To(gomega.Succeed())
+		deployment.Generation++
+		gomega.Expect(c.Update(ctx, deployment)).To(gomega.Succeed())
+
+		_, agent = reconcile()
+		gomega.Expect(agent.Status.Phase).To(gomega.Equal("Pending"))
+		gomega.Expect(agent.Status.Deployment.ObservedGeneration).To(gomega.Equal(deployment.Generation))
+	})
+
+	ginkgo.It("does not time out a rollout that already had a ready replica", func() {
+		reconcile()
+		setDeploymentStatus(appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1, AvailableReplicas: 1})
+		_, agent := reconcile()
+		gomega.Expect(agent.Status.Phase).To(gomega.Equal("Ready"))
+		gomega.Expect(agent.Status.Deployment.PendingSince).To(gomega.BeNil())
+
+		setDeploymentStatus(unschedulable)
+		result, agent := reconcile()
+		gomega.Expect(agent.Status.Phase).To(gomega.Equal("Pending"))
+		gomega.Expect(agent.Status.Deployment.PendingSince).To(gomega.BeNil())
+		gomega.Expect(result.RequeueAfter).To(gomega.BeZero())
+	})
+})
@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/gateway"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+// ConditionTypeReady is the status condition mirroring the resource's Ready flag
+const ConditionTypeReady = "Ready"
+
+// ConditionTypeDegraded is the status condition reporting that only some replicas are ready
+const ConditionTypeDegraded = "Degraded"
+
+// statusFields points at the status fields shared by all KAOS resources
+type statusFields struct {
+	phase      *string
+	ready      *bool
+	message    *string
+	conditions *[]metav1.Condition
+}
+
+// statusFieldsFor returns the common status fields of a KAOS resource
+func statusFieldsFor(obj client.Object) (statusFields, bool) {
+	switch o := obj.(type) {
+	case *kaosv1alpha1.Agent:
+		return statusFields{&o.Status.Phase, &o.Status.Ready, &o.Status.Message, &o.Status.Conditions}, true
+	case *kaosv1alpha1.ModelAPI:
+		return statusFields{&o.Status.Phase, &o.Status.Ready, &o.Status.Message, &o.Status.Conditions}, true
+	case *kaosv1alpha1.MCPServer:
+		return statusFields{&o.Status.Phase, &o.Status.Ready, &o.Status.Message, &o.Status.Conditions}, true
+	}
+	return statusFields{}, false
+}
+
+// setReadyCondition sets the Ready condition from the ready flag, using reason
+// (typically the phase) and message to describe the current state.
+func setReadyCondition(conditions *[]metav1.Condition, generation int64, ready bool, reason, message string) {
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               ConditionTypeReady,
+		Status:             status,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// setDegradedCondition sets the Degraded condition, which is True while some but not all
+// of the desired replicas are ready. Ready stays True in that case, so this condition is
+// what surfaces a partial outage.
+func setDegradedCondition(conditions *[]metav1.Condition, generation int64, desired, ready int32) {
+	condition := metav1.Condition{
+		Type:               ConditionTypeDegraded,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: generation,
+		Reason:             "AllReplicasReady",
+		Message:            fmt.Sprintf("%d/%d replicas ready", ready, desired),
+	}
+	if ready == 0 || desired == 0 {
+		condition.Reason = "NoReplicasReady"
+	} else if ready < desired {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "ReplicasUnavailable"
+	}
+	meta.SetStatusCondition(conditions, condition)
+}
+
+// reconcileError records a reconcile failure on obj in one call: it logs the error,
+// sets the phase, message and Ready=False condition, emits a Warning event with the
+// given reason and persists the status.
+//
+// If err is non-nil the message is suffixed with it and the wrapped error is returned
+// so the request is requeued. A recoverable err keeps the resource Progressing until it
+// has persisted for the failure grace period, after which it is marked Failed. A nil
+// err marks a terminal failure (e.g. invalid spec) that is Failed immediately and not
+// retried; only a status update error is returned in that case.
+func reconcileError(ctx context.Context, c client.Client, recorder record.EventRecorder, obj client.Object, reason string, err error, message string) error {
+	log := log.FromContext(ctx)
+	log.Error(err, message, "reason", reason)
+
+	fullMessage := message
+	if err != nil {
+		fullMessage = fmt.Sprintf("%s: %v", message, err)
+	}
+
+	if fields, ok := statusFieldsFor(obj); ok {
+		phase := "Failed"
+		if recoverableError(err) {
+			since := failingSince(*fields.conditions, reason)
+			if deadline := since.Add(util.GetFailureGracePeriod()); time.Now().Before(deadline) {
+				phase = "Progressing"
+				fullMessage = fmt.Sprintf("%s (retrying, marked Failed at %s)", fullMessage, deadline.UTC().Format(time.RFC3339))
+			}
+			// Keep the time the error first occurred when the reason is unchanged
+			meta.RemoveStatusCondition(fields.conditions, ConditionTypeReady)
+			meta.SetStatusCondition(fields.conditions, metav1.Condition{
+				Type:               ConditionTypeReady,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: obj.GetGeneration(),
+				LastTransitionTime: since,
+				Reason:             reason,
+				Message:            fullMessage,
+			})
+		} else {
+			setReadyCondition(fields.conditions, obj.GetGeneration(), false, reason, fullMessage)
+		}
+		*fields.phase = phase
+		*fields.ready = false
+		*fields.message = fullMessage
+	}
+
+	if recorder != nil {
+		recorder.Event(obj, corev1.EventTypeWarning, reason, fullMessage)
+	}
+
+	updateErr := patchStatus(ctx, c, obj)
+	if updateErr != nil {
+		log.Error(updateErr, "failed to update status")
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", message, err)
+	}
+	return updateErr
+}
+
+// constructError records a failure to build the desired Deployment. An invalid podSpec
+// override is a terminal InvalidPodSpec failure that waits for the spec to be fixed;
+// anything else is retried as DeploymentConstructFailed.
+func constructError(ctx context.Context, c client.Client, recorder record.EventRecorder, obj client.Object, err error) error {
+	var patchErr *util.PodSpecPatchError
+	if errors.As(err, &patchErr) {
+		return reconcileError(ctx, c, recorder, obj, "InvalidPodSpec", nil, "Invalid podSpec: "+patchErr.Error())
+	}
+	return reconcileError(ctx, c, recorder, obj, "DeploymentConstructFailed", err, "Failed to construct Deployment")
+}
+
+// routeError records a failure to reconcile the resource's HTTPRoute. Invalid route
+// settings, including a path prefix held by another route, are a terminal
+// InvalidGatewayRoute failure that waits for the spec to be fixed; anything else is
+// retried as HTTPRouteFailed.
+func routeError(ctx context.Context, c client.Client, recorder record.EventRecorder, obj client.Object, err error) error {
+	var invalid *gateway.InvalidRouteError
+	if errors.As(err, &invalid) {
+		return reconcileError(ctx, c, recorder, obj, "InvalidGatewayRoute", nil, "Invalid gatewayRoute: "+invalid.Error())
+	}
+	return reconcileError(ctx, c, recorder, obj, "HTTPRouteFailed", err, "Failed to reconcile HTTPRoute")
+}
+
+// recoverableError reports whether err may resolve on its own when retried, such as an
+// API server outage or conflict. Requests the API server rejected as invalid fail the
+// same way on every retry, as do missing operator configuration, invalid podSpec or
+// route settings and terminal failures (nil err).
+func recoverableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var (
+		missingEnv *util.MissingEnvError
+		patchErr   *util.PodSpecPatchError
+		invalid    *gateway.InvalidRouteError
+	)
+	if errors.As(err, &missingEnv) || errors.As(err, &patchErr) || errors.As(err, &invalid) {
+		return false
+	}
+	return !apierrors.IsInvalid(err) && !apierrors.IsBadRequest(err)
+}
+
+// failingSince returns when the recoverable error with the given reason started, which
+// is the Ready condition's lastTransitionTime while it still reports that reason, or now
+// for a new error
+func failingSince(conditions []metav1.Condition, reason string) metav1.Time {
+	cond := meta.FindStatusCondition(conditions, ConditionTypeReady)
+	if cond != nil && cond.Status == metav1.ConditionFalse && cond.Reason == reason {
+		return cond.LastTransitionTime
+	}
+	return metav1.Now()
+}
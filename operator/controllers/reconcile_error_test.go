@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+var _ = ginkgo.Describe("reconcileError", func() {
+	var (
+		ctx      context.Context
+		c        client.Client
+		recorder *record.FakeRecorder
+		agent    *kaosv1alpha1.Agent
+	)
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		gomega.Expect(kaosv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default", Generation: 3},
+			Status:     kaosv1alpha1.AgentStatus{Phase: "Ready", Ready: true},
+		}
+		c = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(agent).
+			WithStatusSubresource(agent).
+			Build()
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	getAgent := func() *kaosv1alpha1.Agent {
+		updated := &kaosv1alpha1.Agent{}
+		gomega.Expect(c.Get(ctx, types.NamespacedName{Name: "agent", Namespace: "default"}, updated)).To(gomega.Succeed())
+		return updated
+	}
+
+	ginkgo.It("sets Failed status, condition and event and wraps the error", func() {
+		setEnv("FAILURE_GRACE_PERIOD", "0")
+
+		cause := errors.New("boom")
+		err := reconcileError(ctx, c, recorder, agent, "DeploymentCreateFailed", cause, "Failed to create Deployment")
+
+		gomega.Expect(err).To(gomega.MatchError(cause))
+		gomega.Expect(err.Error()).To(gomega.Equal("Failed to create Deployment: boom"))
+
+		updated := getAgent()
+		gomega.Expect(updated.Status.Phase).To(gomega.Equal("Failed"))
+		gomega.Expect(updated.Status.Ready).To(gomega.BeFalse())
+		gomega.Expect(updated.Status.Message).To(gomega.Equal("Failed to create Deployment: boom"))
+
+		cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionTypeReady)
+		gomega.Expect(cond).NotTo(gomega.BeNil())
+		gomega.Expect(cond.Status).To(gomega.Equal(metav1.ConditionFalse))
+		gomega.Expect(cond.Reason).To(gomega.Equal("DeploymentCreateFailed"))
+		gomega.Expect(cond.ObservedGeneration).To(gomega.Equal(int64(3)))
+
+		gomega.Expect(recorder.Events).To(gomega.Receive(gomega.Equal(
+			"Warning DeploymentCreateFailed Failed to create Deployment: boom")))
+	})
+
+	ginkgo.It("keeps a recoverable error Progressing until the grace period elapses", func() {
+		cause := errors.New("connection refused")
+		err := reconcileError(ctx, c, recorder, agent, "DeploymentCreateFailed", cause, "Failed to create Deployment")
+		gomega.Expect(err).To(gomega.MatchError(cause))
+
+		updated := getAgent()
+		gomega.Expect(updated.Status.Phase).To(gomega.Equal("Progressing"))
+		gomega.Expect(updated.Status.Ready).To(gomega.BeFalse())
+		cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionTypeReady)
+		gomega.Expect(cond.Reason).To(gomega.Equal("DeploymentCreateFailed"))
+		deadline := cond.LastTransitionTime.Add(util.DefaultFailureGracePeriod).UTC().Format(time.RFC3339)
+		gomega.Expect(updated.Status.Message).To(gomega.Equal(
+			"Failed to create Deployment: connection refused (retrying, marked Failed at " + deadline + ")"))
+
+		// The same error persisting past the grace period marks the agent Failed
+		cond.LastTransitionTime = metav1.NewTime(time.Now().Add(-util.DefaultFailureGracePeriod - time.Second))
+		gomega.Expect(reconcileError(ctx, c, recorder, updated, "DeploymentCreateFailed", cause, "Failed to create Deployment")).To(gomega.HaveOccurred())
+		updated = getAgent()
+		gomega.Expect(updated.Status.Phase).To(gomega.Equal("Failed"))
+		gomega.Expect(updated.Status.Message).To(gomega.Equal("Failed to create Deployment: connection refused"))
+	})
+
+	ginkgo.It("restarts the grace period for a different error", func() {
+		agent.Status.Conditions = []metav1.Condition{{
+			Type:               ConditionTypeReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             "ServiceCreateFailed",
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+		}}
+		gomega.Expect(reconcileError(ctx, c, recorder, agent, "DeploymentCreateFailed", errors.New("timeout"), "Failed to create Deployment")).To(gomega.HaveOccurred())
+		gomega.Expect(getAgent().Status.Phase).To(gomega.Equal("Progressing"))
+	})
+
+	ginkgo.It("fails immediately when the API server rejects the request as invalid", func() {
+		cause := apierrors.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "agent-agent", nil)
+		gomega.Expect(reconcileError(ctx, c, recorder, agent, "DeploymentCreateFailed", cause, "Failed to create Deployment")).To(gomega.HaveOccurred())
+		gomega.Expect(getAgent().Status.Phase).To(gomega.Equal("Failed"))
+	})
+
+	ginkgo.It("fails immediately when the operator is missing required configuration", func() {
+		cause := &util.MissingEnvError{Name: "DEFAULT_AGENT_IMAGE"}
+		err := reconcileError(ctx, c, recorder, agent, "DeploymentConstructFailed", cause, "Failed to construct Deployment")
+		gomega.Expect(err).To(gomega.MatchError(cause))
+
+		updated := getAgent()
+		gomega.Expect(updated.Status.Phase).To(gomega.Equal("Failed"))
+		gomega.Expect(updated.Status.Message).To(gomega.Equal(
+			"Failed to construct Deployment: DEFAULT_AGENT_IMAGE environment variable is required but not set"))
+	})
+
+	ginkgo.It("returns nil for terminal failures without a cause", func() {
+		err := reconcileError(ctx, c, recorder, agent, "ModelNotSupported", nil, "model \"x\" not supported")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		updated := getAgent()
+		gomega.Expect(updated.Status.Phase).To(gomega.Equal("Failed"))
+		gomega.Expect(updated.Status.Message).To(gomega.Equal("model \"x\" not supported"))
+
+		gomega.Expect(recorder.Events).To(gomega.Receive(gomega.Equal(
+			"Warning ModelNotSupported model \"x\" not supported")))
+	})
+
+	ginkgo.It("tolerates a nil recorder", func() {
+		err := reconcileError(ctx, c, nil, agent, "InvalidSchedule", nil, "invalid cron schedule")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(getAgent().Status.Phase).To(gomega.Equal("Failed"))
+	})
+
+	ginkgo.It("flips the Ready condition back when the resource recovers", func() {
+		gomega.Expect(reconcileError(ctx, c, recorder, agent, "ServiceCreateFailed", errors.New("boom"), "Failed to create Service")).To(gomega.HaveOccurred())
+
+		setReadyCondition(&agent.Status.Conditions, agent.Generation, true, "Ready", "Deployment ready replicas: 1/1")
+		cond := meta.FindStatusCondition(agent.Status.Conditions, ConditionTypeReady)
+		gomega.Expect(cond.Status).To(gomega.Equal(metav1.ConditionTrue))
+		gomega.Expect(cond.Reason).To(gomega.Equal("Ready"))
+		gomega.Expect(agent.Status.Conditions).To(gomega.HaveLen(1))
+	})
+})
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"context"
+	"slices"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+// referencedContentHashAnnotation records the hash of the ConfigMaps and Secrets the pod
+// template reads from
+const referencedContentHashAnnotation = "kaos.tools/referenced-content-hash"
+
+// applyReferencedContentHash records the content hash of the ConfigMaps and Secrets the
+// pod template references and folds it into the pod spec hash, so rotating a referenced
+// Secret or editing a referenced ConfigMap rolls the pods.
+func applyReferencedContentHash(ctx context.Context, c client.Reader, owner client.Object, template *corev1.PodTemplateSpec) error {
+	contentHash, err := util.ComputeReferencedContentHash(ctx, c, owner, template.Spec)
+	if err != nil || contentHash == "" {
+		return err
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[referencedContentHashAnnotation] = contentHash
+	template.Annotations[util.PodSpecHashAnnotation] = util.ComputeContentHash(
+		[]byte(template.Annotations[util.PodSpecHashAnnotation] + contentHash))
+	return nil
+}
+
+// contentReader returns the reader referenced ConfigMap and Secret content is read
+// through: the uncached reader when set, so the manager never caches Secret data, else c
+func contentReader(c client.Reader, reader client.Reader) client.Reader {
+	if reader != nil {
+		return reader
+	}
+	return c
+}
+
+// mapReferencedObjectToOwners maps ConfigMap or Secret events to the resources of
+// ownerKind whose Deployment or CronJob reads from the object. The objects are watched as metadata
+// only, so kind ("ConfigMap" or "Secret") names which of the two obj is.
+func mapReferencedObjectToOwners(c client.Reader, ownerKind, kind string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []ctrl.Request {
+		return referencingOwners(ctx, c, ownerKind, kind, obj)
+	})
+}
+
+// referencingOwners returns the resources of ownerKind whose Deployment or CronJob reads
+// from the ConfigMap or Secret obj
+func referencingOwners(ctx context.Context, c client.Reader, ownerKind, kind string, obj client.Object) []ctrl.Request {
+	deployments := &appsv1.DeploymentList{}
+	if err := c.List(ctx, deployments, client.InNamespace(obj.GetNamespace())); err != nil {
+		return []ctrl.Request{}
+	}
+	cronJobs := &batchv1.CronJobList{}
+	if err := c.List(ctx, cronJobs, client.InNamespace(obj.GetNamespace())); err != nil {
+		return []ctrl.Request{}
+	}
+
+	requests := []ctrl.Request{}
+	addOwner := func(workload client.Object, spec corev1.PodSpec) {
+		owner := metav1.GetControllerOf(workload)
+		if owner == nil || owner.Kind != ownerKind || owner.APIVersion != kaosv1alpha1.GroupVersion.String() {
+			return
+		}
+		configMaps, secrets := util.PodSpecReferences(spec)
+		names := configMaps
+		if kind == "Secret" {
+			names = secrets
+		}
+		if slices.Contains(names, obj.GetName()) {
+			requests = append(requests, ctrl.Request{
+				NamespacedName: types.NamespacedName{Name: owner.Name, Namespace: workload.GetNamespace()},
+			})
+		}
+	}
+	for i := range deployments.Items {
+		addOwner(&deployments.Items[i], deployments.Items[i].Spec.Template.Spec)
+	}
+	for i := range cronJobs.Items {
+		addOwner(&cronJobs.Items[i], cronJobs.Items[i].Spec.JobTemplate.Spec.Template.Spec)
+	}
+	return requests
+}
@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("referenced ConfigMap and Secret content", func() {
+	var (
+		ctx     context.Context
+		c       client.Client
+		r       *AgentReconciler
+		key     types.NamespacedName
+		prompts *corev1.ConfigMap
+		keys    *corev1.Secret
+	)
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		setDefaultAgentImage()
+
+		scheme := runtime.NewScheme()
+		gomega.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+		gomega.Expect(kaosv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+
+		modelapi := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:        kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"mock-model"}},
+			},
+			Status: kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}
+		prompts = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "prompts", Namespace: "default"},
+			Data:       map[string]string{"system.md": "Be brief."},
+		}
+		keys = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "tool-keys", Namespace: "default"},
+			Data:       map[string][]byte{"SEARCH_API_KEY": []byte("key-1")},
+		}
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI: "llm",
+				Model:    "mock-model",
+				Config: &kaosv1alpha1.AgentConfig{
+					InstructionsFrom: &kaosv1alpha1.InstructionsSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "prompts"},
+							Key:                  "system.md",
+						},
+					},
+				},
+				PodSpec: &corev1.PodSpec{Containers: []corev1.Container{{
+					Name: "agent",
+					EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "tool-keys"},
+					}}},
+				}}},
+			},
+		}
+		key = types.NamespacedName{Name: "agent", Namespace: "default"}
+		c = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(modelapi, prompts, keys, agent).
+			WithStatusSubresource(&kaosv1alpha1.ModelAPI{}, &kaosv1alpha1.Agent{}).
+			Build()
+		r = &AgentReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+	})
+
+	reconcile := func() {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	}
+
+	ginkgo.It("maps a referenced Secret to the owning Agent", func() {
+		reconcile()
+
+		// Watch events carry only the object metadata
+		metadata := func(obj client.Object) *metav1.PartialObjectMetadata {
+			return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: obj.GetName(), Namespace: obj.GetNamespace()}}
+		}
+		gomega.Expect(referencingOwners(ctx, c, "Agent", "Secret", metadata(keys))).To(gomega.ConsistOf(ctrl.Request{NamespacedName: key}))
+		gomega.Expect(referencingOwners(ctx, c, "Agent", "ConfigMap", metadata(prompts))).To(gomega.ConsistOf(ctrl.Request{NamespacedName: key}))
+		gomega.Expect(referencingOwners(ctx, c, "Agent", "ConfigMap", metadata(keys))).To(gomega.BeEmpty())
+		gomega.Expect(referencingOwners(ctx, c, "ModelAPI", "Secret", metadata(keys))).To(gomega.BeEmpty())
+
+		unrelated := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"}}
+		gomega.Expect(referencingOwners(ctx, c, "Agent", "Secret", metadata(unrelated))).To(gomega.BeEmpty())
+	})
+})
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("gatewayRoute.requestHeaders", func() {
+	var (
+		ctx context.Context
+		c   client.Client
+		r   *ModelAPIReconciler
+		key types.NamespacedName
+	)
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		for name, value := range map[string]string{
+			"DEFAULT_LITELLM_IMAGE": "litellm/litellm:test",
+			"GATEWAY_API_ENABLED":   "true",
+			"GATEWAY_NAME":          "kaos-gateway",
+			"GATEWAY_NAMESPACE":     "kaos-system",
+			"GATEWAY_HOST":          "kaos.example.com",
+		} {
+			setEnv(name, value)
+		}
+
+		scheme := runtime.NewScheme()
+		gomega.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+		gomega.Expect(kaosv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+		gomega.Expect(gatewayv1.Install(scheme)).To(gomega.Succeed())
+
+		modelapi := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "team-a"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:         kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig:  &kaosv1alpha1.ProxyConfig{Models: []string{"mock-model"}},
+				GatewayRoute: &kaosv1alpha1.GatewayRoute{RequestHeaders: map[string]string{"x-tenant-id": "acme"}},
+			},
+		}
+		key = types.NamespacedName{Name: modelapi.Name, Namespace: modelapi.Namespace}
+
+		c = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(modelapi).
+			WithStatusSubresource(&kaosv1alpha1.ModelAPI{}).
+			Build()
+		r = &ModelAPIReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+	})
+
+	reconcile := func() *kaosv1alpha1.ModelAPI {
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		stored := &kaosv1alpha1.ModelAPI{}
+		gomega.Expect(c.Get(ctx, key, stored)).To(gomega.Succeed())
+		return stored
+	}
+
+	ginkgo.It("fails with InvalidGatewayRoute and drops the gateway endpoints for an invalid header name", func() {
+		gomega.Expect(reconcile().Status.GatewayEndpoints).NotTo(gomega.BeEmpty())
+
+		modelapi := &kaosv1alpha1.ModelAPI{}
+		gomega.Expect(c.Get(ctx, key, modelapi)).To(gomega.Succeed())
+		modelapi.Spec.GatewayRoute.RequestHeaders = map[string]string{"x tenant": "acme"}
+		gomega.Expect(c.Update(ctx, modelapi)).To(gomega.Succeed())
+
+		stored := reconcile()
+		gomega.Expect(stored.Status.Phase).To(gomega.Equal("Failed"))
+		gomega.Expect(stored.Status.Message).To(gomega.ContainSubstring(`invalid request header name "x tenant"`))
+		gomega.Expect(meta.FindStatusCondition(stored.Status.Conditions, ConditionTypeReady).Reason).To(gomega.Equal("InvalidGatewayRoute"))
+		gomega.Expect(stored.Status.GatewayEndpoints).To(gomega.BeEmpty())
+		gomega.Expect(r.Recorder.(*record.FakeRecorder).Events).To(gomega.Receive(gomega.ContainSubstring("InvalidGatewayRoute")))
+	})
+})
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/gateway"
+)
+
+var _ = ginkgo.Describe("agent requestTimeout", func() {
+	var (
+		ctx       context.Context
+		scheme    *runtime.Scheme
+		agent     *kaosv1alpha1.Agent
+		modelapis []*kaosv1alpha1.ModelAPI
+	)
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		setDefaultAgentImage()
+		for key, value := range map[string]string{
+			"GATEWAY_API_ENABLED": "true",
+			"GATEWAY_NAME":        "kaos-gateway",
+			"GATEWAY_NAMESPACE":   "kaos-system",
+		} {
+			setEnv(key, value)
+		}
+
+		scheme = runtime.NewScheme()
+		gomega.Expect(kaosv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+		gomega.Expect(gatewayv1.Install(scheme)).To(gomega.Succeed())
+
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default", UID: "agent-uid"},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI:       "llm",
+				Model:          "mock-model",
+				RequestTimeout: "90s",
+			},
+		}
+		modelapis = []*kaosv1alpha1.ModelAPI{{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Status:     kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}}
+	})
+
+	// routeTimeout reconciles the agent's HTTPRoute with a fake client and returns its request timeout
+	routeTimeout := func() string {
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		params, err := agentHTTPRouteParams(agent, "agent-agent")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(gateway.ReconcileHTTPRoute(ctx, c, scheme, agent, params, logr.Discard())).To(gomega.Succeed())
+
+		route := &gatewayv1.HTTPRoute{}
+		gomega.Expect(c.Get(ctx, types.NamespacedName{Name: "agent-agent", Namespace: "default"}, route)).To(gomega.Succeed())
+		gomega.Expect(route.Spec.Rules).To(gomega.HaveLen(1))
+		gomega.Expect(route.Spec.Rules[0].Timeouts).NotTo(gomega.BeNil())
+		return string(*route.Spec.Rules[0].Timeouts.Request)
+	}
+
+	ginkgo.It("uses the configured value for both the route timeout and AGENT_REQUEST_TIMEOUT", func() {
+		gomega.Expect(routeTimeout()).To(gomega.Equal("90s"))
+		gomega.Expect(agentEnv(agent, modelapis...)).To(gomega.HaveKeyWithValue("AGENT_REQUEST_TIMEOUT", "90s"))
+	})
+
+	ginkgo.It("lets an explicit gatewayRoute.timeout override the route timeout", func() {
+		agent.Spec.GatewayRoute = &kaosv1alpha1.GatewayRoute{Timeout: "5m"}
+		gomega.Expect(routeTimeout()).To(gomega.Equal("5m"))
+		gomega.Expect(agentEnv(agent, modelapis...)).To(gomega.HaveKeyWithValue("AGENT_REQUEST_TIMEOUT", "90s"))
+	})
+
+	ginkgo.It("falls back to the gateway default and sets no env var when unset", func() {
+		agent.Spec.RequestTimeout = ""
+		gomega.Expect(routeTimeout()).To(gomega.Equal(gateway.DefaultTimeout(gateway.ResourceTypeAgent)))
+		gomega.Expect(agentEnv(agent, modelapis...)).NotTo(gomega.HaveKey("AGENT_REQUEST_TIMEOUT"))
+	})
+})
@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcpruntime "github.com/axsaucedo/kaos/operator/pkg/runtime"
+)
+
+// missingRequiredEnv returns the required env var names that container does not set,
+// either directly or through an envFrom ConfigMap/Secret in namespace. An env var read
+// from a ConfigMap or Secret key only counts as set when the key exists.
+func missingRequiredEnv(ctx context.Context, c client.Reader, namespace string, container corev1.Container, required []string) ([]string, error) {
+	if len(required) == 0 {
+		return nil, nil
+	}
+
+	provided := map[string]bool{}
+	for _, env := range container.Env {
+		present, err := envValuePresent(ctx, c, namespace, env)
+		if err != nil {
+			return nil, err
+		}
+		provided[env.Name] = present
+	}
+	for _, source := range container.EnvFrom {
+		keys, err := envFromKeys(ctx, c, namespace, source)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			provided[source.Prefix+key] = true
+		}
+	}
+
+	var missing []string
+	for _, name := range required {
+		if !provided[name] {
+			missing = append(missing, name)
+		}
+	}
+	return missing, nil
+}
+
+// envFromKeys returns the keys an envFrom source exposes; a source that does not exist
+// exposes none
+func envFromKeys(ctx context.Context, c client.Reader, namespace string, source corev1.EnvFromSource) ([]string, error) {
+	var keys []string
+	switch {
+	case source.ConfigMapRef != nil:
+		cm := &corev1.ConfigMap{}
+		if err := c.Get(ctx, types.NamespacedName{Name: source.ConfigMapRef.Name, Namespace: namespace}, cm); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		for key := range cm.Data {
+			keys = append(keys, key)
+		}
+	case source.SecretRef != nil:
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Name: source.SecretRef.Name, Namespace: namespace}, secret); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+// envValuePresent reports whether env has a value: a literal value, or a ConfigMap or
+// Secret key that exists. Other valueFrom sources (fieldRef, resourceFieldRef) always do.
+func envValuePresent(ctx context.Context, c client.Reader, namespace string, env corev1.EnvVar) (bool, error) {
+	if env.ValueFrom == nil {
+		return true, nil
+	}
+	switch {
+	case env.ValueFrom.SecretKeyRef != nil:
+		ref := env.ValueFrom.SecretKeyRef
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		_, ok := secret.Data[ref.Key]
+		return ok, nil
+	case env.ValueFrom.ConfigMapKeyRef != nil:
+		ref := env.ValueFrom.ConfigMapKeyRef
+		cm := &corev1.ConfigMap{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, cm); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		_, inData := cm.Data[ref.Key]
+		_, inBinaryData := cm.BinaryData[ref.Key]
+		return inData || inBinaryData, nil
+	}
+	return true, nil
+}
+
+// runtimeSecretEnv returns the runtime's secretEnv vars read from the keys of the same
+// name in secretRef. Vars that are not in requiredEnv are optional, so a Secret without
+// them still starts the pod; required ones are checked by missingRequiredEnv.
+func runtimeSecretEnv(secretRef *corev1.LocalObjectReference, config mcpruntime.Config) []corev1.EnvVar {
+	if secretRef == nil {
+		return nil
+	}
+	env := make([]corev1.EnvVar, 0, len(config.SecretEnv))
+	for _, name := range config.SecretEnv {
+		selector := &corev1.SecretKeySelector{LocalObjectReference: *secretRef, Key: name}
+		if !slices.Contains(config.RequiredEnv, name) {
+			optional := true
+			selector.Optional = &optional
+		}
+		env = append(env, corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: selector}})
+	}
+	return env
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+// disableAutoRolloutAnnotation opts a resource out of operator-triggered rollouts, for
+// clusters where an external tool (e.g. Argo Rollouts) decides when pods restart. The
+// hash annotations are kept off the pod template and recorded on the Deployment instead,
+// so the Deployment spec is still updated but only real template changes roll the pods.
+const disableAutoRolloutAnnotation = "kaos.tools/disable-auto-rollout"
+
+// podTemplateHashAnnotations are the pod template annotations that only exist to roll the
+// pods when their value changes
+var podTemplateHashAnnotations = []string{
+	util.PodSpecHashAnnotation,
+	referencedContentHashAnnotation,
+	litellmConfigHashAnnotation,
+}
+
+// autoRolloutDisabled reports whether obj has the disable-auto-rollout annotation set to "true"
+func autoRolloutDisabled(obj client.Object) bool {
+	return obj.GetAnnotations()[disableAutoRolloutAnnotation] == "true"
+}
+
+// applyAutoRollout moves the pod spec hash of the desired Deployment from the pod
+// template to the Deployment's own annotations when obj has automatic rollouts disabled,
+// and drops the other hash annotations from the pod template.
+func applyAutoRollout(obj client.Object, deployment *appsv1.Deployment) {
+	if !autoRolloutDisabled(obj) {
+		return
+	}
+	hash := deployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]
+	for _, annotation := range podTemplateHashAnnotations {
+		delete(deployment.Spec.Template.Annotations, annotation)
+	}
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	deployment.Annotations[util.PodSpecHashAnnotation] = hash
+}
+
+// deploymentHash returns the pod spec hash of a Deployment, read from the pod template or,
+// when automatic rollouts are disabled, from the Deployment's annotations
+func deploymentHash(deployment *appsv1.Deployment) string {
+	if hash, ok := deployment.Spec.Template.Annotations[util.PodSpecHashAnnotation]; ok {
+		return hash
+	}
+	return deployment.Annotations[util.PodSpecHashAnnotation]
+}
+
+// setDeploymentHash copies the Deployment-level pod spec hash of desired onto current,
+// removing it when desired keeps the hash on the pod template
+func setDeploymentHash(current, desired *appsv1.Deployment) {
+	hash, ok := desired.Annotations[util.PodSpecHashAnnotation]
+	if !ok {
+		delete(current.Annotations, util.PodSpecHashAnnotation)
+		return
+	}
+	if current.Annotations == nil {
+		current.Annotations = map[string]string{}
+	}
+	current.Annotations[util.PodSpecHashAnnotation] = hash
+}
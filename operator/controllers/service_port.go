@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+const (
+	// defaultServicePortName is the name of the generated Service port unless
+	// spec.servicePort sets another
+	defaultServicePortName = "http"
+	// grpcServicePortName is the default Service port name in gRPC mode
+	grpcServicePortName = "grpc"
+	// grpcGatewayPort is the extra Service port the HTTPRoute targets in gRPC mode. It
+	// carries appProtocol kubernetes.io/h2c, which Gateway API implementations read to
+	// speak cleartext HTTP/2 to the backend.
+	grpcGatewayPort = 8001
+	// h2cAppProtocol is the Gateway API appProtocol for cleartext HTTP/2 backends
+	h2cAppProtocol = "kubernetes.io/h2c"
+)
+
+// isGRPC reports whether the runtime speaks gRPC
+func isGRPC(protocol kaosv1alpha1.ServiceProtocol) bool {
+	return protocol == kaosv1alpha1.ServiceProtocolGRPC
+}
+
+// applyServicePortConfig sets the name and appProtocol of the generated Service port from
+// spec.servicePort. Unset fields default to "http" with no appProtocol, or to "grpc" for
+// both in gRPC mode.
+func applyServicePortConfig(port *corev1.ServicePort, protocol kaosv1alpha1.ServiceProtocol, config *kaosv1alpha1.ServicePortConfig) {
+	port.Name = defaultServicePortName
+	if isGRPC(protocol) {
+		port.Name = grpcServicePortName
+		appProtocol := string(kaosv1alpha1.ServiceProtocolGRPC)
+		port.AppProtocol = &appProtocol
+	}
+	if config == nil {
+		return
+	}
+	if config.Name != "" {
+		port.Name = config.Name
+	}
+	if config.AppProtocol != "" {
+		appProtocol := config.AppProtocol
+		port.AppProtocol = &appProtocol
+	}
+}
+
+// grpcGatewayServicePort returns the h2c Service port forwarding grpcGatewayPort to the
+// runtime's targetPort, used as the Gateway backend in gRPC mode
+func grpcGatewayServicePort(targetPort int) corev1.ServicePort {
+	appProtocol := h2cAppProtocol
+	return corev1.ServicePort{
+		Name:        "h2c",
+		Port:        grpcGatewayPort,
+		TargetPort:  intstr.FromInt(targetPort),
+		Protocol:    corev1.ProtocolTCP,
+		AppProtocol: &appProtocol,
+	}
+}
+
+// gatewayServicePort returns the Service port the HTTPRoute forwards to
+func gatewayServicePort(protocol kaosv1alpha1.ServiceProtocol) int32 {
+	if isGRPC(protocol) {
+		return grpcGatewayPort
+	}
+	return 8000
+}
+
+// probeHandler returns a gRPC health check on port in gRPC mode, and otherwise the given
+// HTTP handler
+func probeHandler(protocol kaosv1alpha1.ServiceProtocol, port int32, handler corev1.ProbeHandler) corev1.ProbeHandler {
+	if isGRPC(protocol) {
+		return corev1.ProbeHandler{GRPC: &corev1.GRPCAction{Port: port}}
+	}
+	return handler
+}
+
+// servicePortsChanged reports whether the ports of the current Service differ from the
+// desired ones in a field the operator sets
+func servicePortsChanged(current, desired *corev1.Service) bool {
+	return !slices.EqualFunc(current.Spec.Ports, desired.Spec.Ports, func(a, b corev1.ServicePort) bool {
+		return a.Name == b.Name && a.Port == b.Port && a.TargetPort == b.TargetPort &&
+			a.Protocol == b.Protocol && equalStringPtr(a.AppProtocol, b.AppProtocol)
+	})
+}
+
+// equalStringPtr reports whether two optional strings are both unset or equal
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
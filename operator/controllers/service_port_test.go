@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	mcpruntime "github.com/axsaucedo/kaos/operator/pkg/runtime"
+)
+
+var _ = ginkgo.Describe("service port name and appProtocol", func() {
+	ginkgo.It("defaults the port name to http without an appProtocol", func() {
+		service := (&MCPServerReconciler{}).constructService(&kaosv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "tools", Namespace: "default"},
+		})
+		gomega.Expect(service.Spec.Ports[0].Name).To(gomega.Equal("http"))
+		gomega.Expect(service.Spec.Ports[0].AppProtocol).To(gomega.BeNil())
+	})
+
+	ginkgo.It("sets a custom name and appProtocol on the ModelAPI port", func() {
+		modelapi := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:        kaosv1alpha1.ModelAPIModeProxy,
+				ServicePort: &kaosv1alpha1.ServicePortConfig{Name: "http2-llm", AppProtocol: "kubernetes.io/h2c"},
+			},
+		}
+		port := (&ModelAPIReconciler{}).constructService(modelapi).Spec.Ports[0]
+		gomega.Expect(port.Name).To(gomega.Equal("http2-llm"))
+		gomega.Expect(port.AppProtocol).To(gomega.HaveValue(gomega.Equal("kubernetes.io/h2c")))
+		gomega.Expect(port.Port).To(gomega.Equal(int32(8000)))
+	})
+
+	ginkgo.It("uses gRPC probes and appProtocol for a gRPC agent", func() {
+		setDefaultAgentImage()
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI: "llm",
+				Model:    "mock-model",
+				Protocol: kaosv1alpha1.ServiceProtocolGRPC,
+			},
+		}
+
+		ports := (&AgentReconciler{}).constructService(agent).Spec.Ports
+		gomega.Expect(ports).To(gomega.HaveLen(2))
+		gomega.Expect(ports[0].Name).To(gomega.Equal("grpc"))
+		gomega.Expect(ports[0].AppProtocol).To(gomega.HaveValue(gomega.Equal("grpc")))
+		gomega.Expect(ports[1].Port).To(gomega.Equal(int32(grpcGatewayPort)))
+		gomega.Expect(ports[1].AppProtocol).To(gomega.HaveValue(gomega.Equal("kubernetes.io/h2c")))
+
+		modelapis := []*kaosv1alpha1.ModelAPI{{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Status:     kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}}
+		podSpec, _, err := (&AgentReconciler{}).constructPodSpec(agent, modelapis, nil, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		container := podSpec.Containers[0]
+		gomega.Expect(container.LivenessProbe.GRPC).To(gomega.Equal(&corev1.GRPCAction{Port: 8000}))
+		gomega.Expect(container.ReadinessProbe.GRPC).To(gomega.Equal(&corev1.GRPCAction{Port: 8000}))
+		gomega.Expect(container.LivenessProbe.HTTPGet).To(gomega.BeNil())
+
+		params, err := agentHTTPRouteParams(agent, "agent-agent")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(params.ServicePort).To(gomega.Equal(int32(grpcGatewayPort)))
+	})
+
+	ginkgo.It("uses gRPC probes and appProtocol for a gRPC MCPServer", func() {
+		mcpserver := &kaosv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "tools", Namespace: "default"},
+			Spec: kaosv1alpha1.MCPServerSpec{
+				Runtime:   mcpruntime.CustomRuntime,
+				Container: &kaosv1alpha1.ContainerOverride{Image: "tools:test"},
+				Protocol:  kaosv1alpha1.ServiceProtocolGRPC,
+			},
+		}
+
+		port := (&MCPServerReconciler{}).constructService(mcpserver).Spec.Ports[0]
+		gomega.Expect(port.AppProtocol).To(gomega.HaveValue(gomega.Equal("grpc")))
+
+		container, _, err := (&MCPServerReconciler{}).constructContainerFromRuntime(context.Background(), mcpserver)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(container.LivenessProbe.GRPC).To(gomega.Equal(&corev1.GRPCAction{Port: 8000}))
+		gomega.Expect(container.ReadinessProbe.GRPC).To(gomega.Equal(&corev1.GRPCAction{Port: 8000}))
+		gomega.Expect(container.ReadinessProbe.TCPSocket).To(gomega.BeNil())
+	})
+})
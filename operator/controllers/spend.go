@@ -0,0 +1,178 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// defaultSpendInterval is how often spend is read when spendTracking.intervalSeconds is unset
+const defaultSpendInterval = 5 * time.Minute
+
+// spendInterval returns how often spend is read from the proxy
+func spendInterval(tracking *kaosv1alpha1.SpendTrackingConfig) time.Duration {
+	if tracking.IntervalSeconds != nil {
+		return time.Duration(*tracking.IntervalSeconds) * time.Second
+	}
+	return defaultSpendInterval
+}
+
+// reconcileSpend refreshes modelapi.Status.Spend from the LiteLLM spend endpoints once
+// the interval has elapsed, and returns when spend should be read again (zero when spend
+// tracking is off or the proxy is not ready). Failures, such as a proxy without a spend
+// database, are reported in status.spend.message and never fail the reconcile.
+func (r *ModelAPIReconciler) reconcileSpend(ctx context.Context, modelapi *kaosv1alpha1.ModelAPI) time.Duration {
+	log := log.FromContext(ctx)
+
+	if modelapi.Spec.Mode != kaosv1alpha1.ModelAPIModeProxy || modelapi.Spec.ProxyConfig == nil ||
+		modelapi.Spec.ProxyConfig.SpendTracking == nil {
+		modelapi.Status.Spend = nil
+		return 0
+	}
+	if !modelapi.Status.Ready {
+		return 0
+	}
+
+	tracking := modelapi.Spec.ProxyConfig.SpendTracking
+	interval := spendInterval(tracking)
+	spend := modelapi.Status.Spend
+	if spend != nil && spend.LastUpdated != nil {
+		if elapsed := time.Since(spend.LastUpdated.Time); elapsed < interval {
+			return interval - elapsed
+		}
+	}
+
+	updated := &kaosv1alpha1.ModelAPISpendStatus{}
+	if spend != nil {
+		updated.Total, updated.Today = spend.Total, spend.Today
+	}
+	now := metav1.Now()
+	updated.LastUpdated = &now
+
+	total, today, err := r.fetchSpend(ctx, modelapi, tracking)
+	if err != nil {
+		log.Info("Failed to read ModelAPI spend", "modelapi", modelapi.Name, "error", err.Error())
+		updated.Message = fmt.Sprintf("Spend unavailable: %v", err)
+	} else {
+		updated.Total = formatSpend(total)
+		updated.Today = formatSpend(today)
+	}
+	modelapi.Status.Spend = updated
+	return interval
+}
+
+// fetchSpend returns the total spend and the spend of the current UTC day from the proxy
+func (r *ModelAPIReconciler) fetchSpend(ctx context.Context, modelapi *kaosv1alpha1.ModelAPI, tracking *kaosv1alpha1.SpendTrackingConfig) (float64, float64, error) {
+	masterKey, err := resolveAPIKey(ctx, contentReader(r.Client, r.APIReader), modelapi.Namespace, &tracking.MasterKey)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to resolve master key: %w", err)
+	}
+
+	var total struct {
+		Spend float64 `json:"spend"`
+	}
+	if err := r.getSpendJSON(ctx, modelapi.Status.Endpoint, "/global/spend", masterKey, &total); err != nil {
+		return 0, 0, err
+	}
+
+	day := time.Now().UTC().Format("2006-01-02")
+	var report []struct {
+		TotalSpend float64 `json:"total_spend"`
+	}
+	path := fmt.Sprintf("/global/spend/report?start_date=%s&end_date=%s", day, day)
+	if err := r.getSpendJSON(ctx, modelapi.Status.Endpoint, path, masterKey, &report); err != nil {
+		return 0, 0, err
+	}
+	today := 0.0
+	for _, entry := range report {
+		today += entry.TotalSpend
+	}
+	return total.Spend, today, nil
+}
+
+// getSpendJSON performs an authenticated GET of endpoint+path and decodes the JSON response into out.
+// LiteLLM answers with an error status when it has no spend database, which is returned
+// together with the start of the response body.
+func (r *ModelAPIReconciler) getSpendJSON(ctx context.Context, endpoint, path, masterKey string, out interface{}) error {
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 5 * time.Second}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+masterKey)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail := strings.TrimSpace(string(body))
+		if len(detail) > 200 {
+			detail = detail[:200]
+		}
+		return fmt.Errorf("GET %s returned %d: %s", path, resp.StatusCode, detail)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("GET %s returned an unexpected response: %w", path, err)
+	}
+	return nil
+}
+
+// resolveAPIKey returns the value of apiKey, reading the referenced Secret or ConfigMap key
+// in namespace
+func resolveAPIKey(ctx context.Context, c client.Reader, namespace string, apiKey *kaosv1alpha1.ApiKeySource) (string, error) {
+	if apiKey.Value != "" {
+		return apiKey.Value, nil
+	}
+	if apiKey.ValueFrom != nil {
+		if ref := apiKey.ValueFrom.SecretKeyRef; ref != nil {
+			secret := &corev1.Secret{}
+			if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+				return "", err
+			}
+			value, ok := secret.Data[ref.Key]
+			if !ok {
+				return "", fmt.Errorf("key %q not found in Secret %s", ref.Key, ref.Name)
+			}
+			return string(value), nil
+		}
+		if ref := apiKey.ValueFrom.ConfigMapKeyRef; ref != nil {
+			configMap := &corev1.ConfigMap{}
+			if err := c.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, configMap); err != nil {
+				return "", err
+			}
+			value, ok := configMap.Data[ref.Key]
+			if !ok {
+				return "", fmt.Errorf("key %q not found in ConfigMap %s", ref.Key, ref.Name)
+			}
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("no value or valueFrom set")
+}
+
+// formatSpend renders a USD amount for the status
+func formatSpend(amount float64) string {
+	return fmt.Sprintf("%.4f", amount)
+}
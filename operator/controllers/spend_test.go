@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// redirectTransport sends every request to target, standing in for the in-cluster Service
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+var _ = ginkgo.Describe("ModelAPI spend status", func() {
+	var (
+		ctx      context.Context
+		c        client.Client
+		r        *ModelAPIReconciler
+		key      types.NamespacedName
+		noDB     bool
+		authSeen []string
+	)
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		noDB = false
+		authSeen = nil
+		setEnv("DEFAULT_LITELLM_IMAGE", "litellm:test")
+
+		stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			authSeen = append(authSeen, req.Header.Get("Authorization"))
+			if noDB {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(`{"error": "No DB Connected"}`))
+				return
+			}
+			switch req.URL.Path {
+			case "/global/spend":
+				_, _ = w.Write([]byte(`{"spend": 12.5, "max_budget": 100}`))
+			case "/global/spend/report":
+				_, _ = w.Write([]byte(`[{"group_by_day": "2026-10-15", "total_spend": 0.75}, {"group_by_day": "2026-10-15", "total_spend": 0.5}]`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		ginkgo.DeferCleanup(stub.Close)
+		target, err := url.Parse(stub.URL)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		scheme := runtime.NewScheme()
+		gomega.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+		gomega.Expect(kaosv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+
+		modelapi := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode: kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{
+					Models: []string{"gpt-4o"},
+					Database: &kaosv1alpha1.ProxyDatabaseConfig{
+						URLSecretRef: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "litellm"},
+							Key:                  "database-url",
+						},
+					},
+					SpendTracking: &kaosv1alpha1.SpendTrackingConfig{
+						MasterKey: kaosv1alpha1.ApiKeySource{ValueFrom: &kaosv1alpha1.ApiKeyValueFrom{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "litellm"},
+								Key:                  "master-key",
+							},
+						}},
+					},
+				},
+			},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "litellm", Namespace: "default"},
+			Data:       map[string][]byte{"master-key": []byte("sk-master")},
+		}
+		key = types.NamespacedName{Name: modelapi.Name, Namespace: modelapi.Namespace}
+
+		c = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(modelapi, secret).
+			WithStatusSubresource(&kaosv1alpha1.ModelAPI{}).
+			Build()
+		r = &ModelAPIReconciler{
+			Client:     c,
+			Scheme:     scheme,
+			Recorder:   record.NewFakeRecorder(100),
+			HTTPClient: &http.Client{Transport: redirectTransport{target: target}},
+		}
+	})
+
+	reconcile := func() (ctrl.Result, *kaosv1alpha1.ModelAPI) {
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		modelapi := &kaosv1alpha1.ModelAPI{}
+		gomega.Expect(c.Get(ctx, key, modelapi)).To(gomega.Succeed())
+		return result, modelapi
+	}
+
+	// markReady reconciles until the Deployment exists and then reports a ready replica
+	markReady := func() {
+		reconcile()
+		deployment := &appsv1.Deployment{}
+		gomega.Expect(c.Get(ctx, types.NamespacedName{Name: "modelapi-llm", Namespace: "default"}, deployment)).To(gomega.Succeed())
+		deployment.Status.Replicas = 1
+		deployment.Status.ReadyReplicas = 1
+		gomega.Expect(c.Status().Update(ctx, deployment)).To(gomega.Succeed())
+	}
+
+	ginkgo.It("does not read spend before the proxy is ready", func() {
+		_, modelapi := reconcile()
+		gomega.Expect(modelapi.Status.Ready).To(gomega.BeFalse())
+		gomega.Expect(modelapi.Status.Spend).To(gomega.BeNil())
+		gomega.Expect(authSeen).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("populates status.spend from the spend endpoints", func() {
+		markReady()
+		result, modelapi := reconcile()
+
+		gomega.Expect(modelapi.Status.Spend).NotTo(gomega.BeNil())
+		gomega.Expect(modelapi.Status.Spend.Total).To(gomega.Equal("12.5000"))
+		gomega.Expect(modelapi.Status.Spend.Today).To(gomega.Equal("1.2500"))
+		gomega.Expect(modelapi.Status.Spend.Message).To(gomega.BeEmpty())
+		gomega.Expect(modelapi.Status.Spend.LastUpdated).NotTo(gomega.BeNil())
+		gomega.Expect(authSeen).To(gomega.ConsistOf("Bearer sk-master", "Bearer sk-master"))
+		gomega.Expect(result.RequeueAfter).To(gomega.Equal(defaultSpendInterval))
+
+		// Within the interval the proxy is not queried again
+		result, _ = reconcile()
+		gomega.Expect(authSeen).To(gomega.HaveLen(2))
+		gomega.Expect(result.RequeueAfter).To(gomega.BeNumerically(">", 0))
+		gomega.Expect(result.RequeueAfter).To(gomega.BeNumerically("<=", defaultSpendInterval))
+	})
+
+	ginkgo.It("reports a proxy without a spend database in the message and stays ready", func() {
+		noDB = true
+		markReady()
+		_, modelapi := reconcile()
+
+		gomega.Expect(modelapi.Status.Ready).To(gomega.BeTrue())
+		gomega.Expect(modelapi.Status.Phase).To(gomega.Equal("Ready"))
+		gomega.Expect(modelapi.Status.Spend.Total).To(gomega.BeEmpty())
+		gomega.Expect(modelapi.Status.Spend.Message).To(gomega.ContainSubstring("returned 500"))
+		gomega.Expect(modelapi.Status.Spend.Message).To(gomega.ContainSubstring("No DB Connected"))
+	})
+
+	ginkgo.It("clears status.spend when spend tracking is removed", func() {
+		markReady()
+		_, modelapi := reconcile()
+		gomega.Expect(modelapi.Status.Spend).NotTo(gomega.BeNil())
+
+		modelapi.Spec.ProxyConfig.SpendTracking = nil
+		gomega.Expect(c.Update(ctx, modelapi)).To(gomega.Succeed())
+		result, modelapi := reconcile()
+		gomega.Expect(modelapi.Status.Spend).To(gomega.BeNil())
+		gomega.Expect(result.RequeueAfter).To(gomega.BeZero())
+	})
+})
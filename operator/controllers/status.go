@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+// statusReader reads objects around the informer cache. patchStatus uses it to fetch the
+// latest object after a conflict, since the cache rarely catches up within the retry
+// interval. When unset the reconciler's client is used.
+var statusReader client.Reader
+
+// SetStatusReader configures the uncached reader patchStatus uses after a conflict,
+// typically the manager's APIReader
+func SetStatusReader(reader client.Reader) {
+	statusReader = reader
+}
+
+// patchStatus persists the status of obj with a status merge patch, retrying on conflict.
+// Each attempt reads the latest object and patches only the status fields that differ
+// from it, with its resourceVersion as an optimistic lock, so a concurrent writer
+// (another reconcile, the scale subresource, kubectl) is never silently overwritten:
+// its write makes the patch conflict and the next attempt starts from it. Writes use the
+// operator's field manager. On success obj holds the latest stored object.
+//
+// Reconcilers build the status in memory and call patchStatus once per reconcile. The
+// write is skipped when the status read in the attempt already matches, so a
+// steady-state reconcile issues no status writes at all.
+func patchStatus(ctx context.Context, c client.Client, obj client.Object) error {
+	desired := obj.DeepCopyObject().(client.Object)
+	var reader client.Reader = c
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := reader.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return err
+		}
+		if statusEqual(obj, desired) {
+			return nil
+		}
+		patch := client.MergeFromWithOptions(obj.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+		copyStatus(obj, desired)
+		err := c.Status().Patch(ctx, obj, patch, client.FieldOwner(util.GetFieldManager()))
+		if apierrors.IsConflict(err) && statusReader != nil {
+			reader = statusReader
+		}
+		return err
+	})
+}
+
+// copyStatus copies the status of src onto dst for KAOS resources of the same kind
+func copyStatus(dst, src client.Object) {
+	switch d := dst.(type) {
+	case *kaosv1alpha1.Agent:
+		d.Status = *src.(*kaosv1alpha1.Agent).Status.DeepCopy()
+	case *kaosv1alpha1.ModelAPI:
+		d.Status = *src.(*kaosv1alpha1.ModelAPI).Status.DeepCopy()
+	case *kaosv1alpha1.MCPServer:
+		d.Status = *src.(*kaosv1alpha1.MCPServer).Status.DeepCopy()
+	}
+}
+
+// statusEqual reports whether a and b are KAOS resources of the same kind with
+// semantically equal status
+func statusEqual(a, b client.Object) bool {
+	switch a := a.(type) {
+	case *kaosv1alpha1.Agent:
+		b, ok := b.(*kaosv1alpha1.Agent)
+		return ok && equality.Semantic.DeepEqual(a.Status, b.Status)
+	case *kaosv1alpha1.ModelAPI:
+		b, ok := b.(*kaosv1alpha1.ModelAPI)
+		return ok && equality.Semantic.DeepEqual(a.Status, b.Status)
+	case *kaosv1alpha1.MCPServer:
+		b, ok := b.(*kaosv1alpha1.MCPServer)
+		return ok && equality.Semantic.DeepEqual(a.Status, b.Status)
+	}
+	return false
+}
@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("patchStatus", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		agent  *kaosv1alpha1.Agent
+	)
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		gomega.Expect(kaosv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Status:     kaosv1alpha1.AgentStatus{Phase: "Pending"},
+		}
+	})
+
+	// conflictingClient simulates another writer: before each of the first
+	// `conflicts` status patches it modifies the stored object, so the patch
+	// is sent with a stale resourceVersion and fails with a conflict.
+	conflictingClient := func(conflicts int) (client.Client, *int) {
+		attempts := 0
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(agent).
+			WithStatusSubresource(agent).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					attempts++
+					if attempts <= conflicts {
+						latest := &kaosv1alpha1.Agent{}
+						if err := c.Get(ctx, client.ObjectKeyFromObject(obj), latest); err != nil {
+							return err
+						}
+						if latest.Annotations == nil {
+							latest.Annotations = map[string]string{}
+						}
+						latest.Annotations["other-writer"] = "true"
+						if err := c.Update(ctx, latest); err != nil {
+							return err
+						}
+					}
+					return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+				},
+			}).
+			Build()
+		return c, &attempts
+	}
+
+	ginkgo.It("retries on conflict until the status converges", func() {
+		c, attempts := conflictingClient(2)
+
+		current := &kaosv1alpha1.Agent{}
+		gomega.Expect(c.Get(ctx, client.ObjectKeyFromObject(agent), current)).To(gomega.Succeed())
+		current.Status.Phase = "Ready"
+		current.Status.Ready = true
+		current.Status.Message = "all good"
+
+		gomega.Expect(patchStatus(ctx, c, current)).To(gomega.Succeed())
+		gomega.Expect(*attempts).To(gomega.Equal(3))
+
+		stored := &kaosv1alpha1.Agent{}
+		gomega.Expect(c.Get(ctx, client.ObjectKeyFromObject(agent), stored)).To(gomega.Succeed())
+		gomega.Expect(stored.Status.Phase).To(gomega.Equal("Ready"))
+		gomega.Expect(stored.Status.Ready).To(gomega.BeTrue())
+		gomega.Expect(stored.Status.Message).To(gomega.Equal("all good"))
+		// The concurrent writer's change is kept rather than overwritten
+		gomega.Expect(stored.Annotations).To(gomega.HaveKeyWithValue("other-writer", "true"))
+		gomega.Expect(current.ResourceVersion).To(gomega.Equal(stored.ResourceVersion))
+	})
+
+	ginkgo.It("returns the conflict once retries are exhausted", func() {
+		c, _ := conflictingClient(100)
+
+		current := &kaosv1alpha1.Agent{}
+		gomega.Expect(c.Get(ctx, client.ObjectKeyFromObject(agent), current)).To(gomega.Succeed())
+		current.Status.Phase = "Ready"
+
+		err := patchStatus(ctx, c, current)
+		gomega.Expect(apierrors.IsConflict(err)).To(gomega.BeTrue())
+	})
+
+	ginkgo.It("does not retry non-conflict errors", func() {
+		attempts := 0
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(agent).
+			WithStatusSubresource(agent).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					attempts++
+					return apierrors.NewForbidden(schema.GroupResource{Resource: "agents"}, obj.GetName(), nil)
+				},
+			}).
+			Build()
+
+		current := agent.DeepCopy()
+		current.Status.Phase = "Ready"
+		err := patchStatus(ctx, c, current)
+		gomega.Expect(apierrors.IsForbidden(err)).To(gomega.BeTrue())
+		gomega.Expect(attempts).To(gomega.Equal(1))
+	})
+
+	ginkgo.It("skips the write when the stored status already matches", func() {
+		attempts := 0
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(agent).
+			WithStatusSubresource(agent).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					attempts++
+					return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+				},
+			}).
+			Build()
+
+		current := &kaosv1alpha1.Agent{}
+		gomega.Expect(c.Get(ctx, client.ObjectKeyFromObject(agent), current)).To(gomega.Succeed())
+		gomega.Expect(patchStatus(ctx, c, current)).To(gomega.Succeed())
+		gomega.Expect(attempts).To(gomega.Equal(0))
+
+		current.Status.Phase = "Ready"
+		gomega.Expect(patchStatus(ctx, c, current)).To(gomega.Succeed())
+		gomega.Expect(attempts).To(gomega.Equal(1))
+	})
+
+	ginkgo.It("skips the retry when a concurrent writer already stored the desired status", func() {
+		attempts := 0
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(agent).
+			WithStatusSubresource(agent).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					attempts++
+					if attempts == 1 {
+						latest := &kaosv1alpha1.Agent{}
+						if err := c.Get(ctx, client.ObjectKeyFromObject(obj), latest); err != nil {
+							return err
+						}
+						latest.Status.Phase = "Ready"
+						if err := c.Status().Update(ctx, latest); err != nil {
+							return err
+						}
+					}
+					return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+				},
+			}).
+			Build()
+
+		current := &kaosv1alpha1.Agent{}
+		gomega.Expect(c.Get(ctx, client.ObjectKeyFromObject(agent), current)).To(gomega.Succeed())
+		current.Status.Phase = "Ready"
+		gomega.Expect(patchStatus(ctx, c, current)).To(gomega.Succeed())
+		gomega.Expect(attempts).To(gomega.Equal(1))
+		gomega.Expect(current.Status.Phase).To(gomega.Equal("Ready"))
+	})
+
+	ginkgo.It("reads the latest object through the status reader after a conflict", func() {
+		c, attempts := conflictingClient(1)
+
+		// The informer cache keeps serving the object as it was before the conflict
+		stale := &kaosv1alpha1.Agent{}
+		gomega.Expect(c.Get(ctx, client.ObjectKeyFromObject(agent), stale)).To(gomega.Succeed())
+		cached := interceptor.NewClient(c.(client.WithWatch), interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				stale.DeepCopyInto(obj.(*kaosv1alpha1.Agent))
+				return nil
+			},
+		})
+		SetStatusReader(c)
+		ginkgo.DeferCleanup(func() { SetStatusReader(nil) })
+
+		current := stale.DeepCopy()
+		current.Status.Phase = "Ready"
+		gomega.Expect(patchStatus(ctx, cached, current)).To(gomega.Succeed())
+		gomega.Expect(*attempts).To(gomega.Equal(2))
+
+		stored := &kaosv1alpha1.Agent{}
+		gomega.Expect(c.Get(ctx, client.ObjectKeyFromObject(agent), stored)).To(gomega.Succeed())
+		gomega.Expect(stored.Status.Phase).To(gomega.Equal("Ready"))
+	})
+})
@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("status writes per reconcile", func() {
+	var (
+		ctx          context.Context
+		scheme       *runtime.Scheme
+		statusWrites int
+	)
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		setDefaultAgentImage()
+		for name, value := range map[string]string{
+			"DEFAULT_LITELLM_IMAGE": "litellm:test",
+		} {
+			setEnv(name, value)
+		}
+
+		scheme = runtime.NewScheme()
+		gomega.Expect(clientgoscheme.AddToScheme(scheme)).To(gomega.Succeed())
+		gomega.Expect(kaosv1alpha1.AddToScheme(scheme)).To(gomega.Succeed())
+		statusWrites = 0
+	})
+
+	// countingClient returns a fake client holding objs that counts status patches
+	countingClient := func(objs ...client.Object) client.Client {
+		return fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&kaosv1alpha1.Agent{}, &kaosv1alpha1.ModelAPI{}, &kaosv1alpha1.MCPServer{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					if subResource == "status" {
+						statusWrites++
+					}
+					return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+				},
+			}).
+			Build()
+	}
+
+	ginkgo.It("writes the Agent status at most once, and not again when nothing changed", func() {
+		modelapi := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:        kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"mock-model"}},
+			},
+			Status: kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "mock-model"},
+		}
+		c := countingClient(modelapi, agent)
+		r := &AgentReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "agent", Namespace: "default"}}
+
+		_, err := r.Reconcile(ctx, req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(statusWrites).To(gomega.BeNumerically("<=", 1))
+
+		stored := &kaosv1alpha1.Agent{}
+		gomega.Expect(c.Get(ctx, req.NamespacedName, stored)).To(gomega.Succeed())
+		gomega.Expect(stored.Status.Phase).To(gomega.Equal("Pending"))
+
+		statusWrites = 0
+		_, err = r.Reconcile(ctx, req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(statusWrites).To(gomega.Equal(0))
+	})
+
+	ginkgo.It("writes the ModelAPI status at most once", func() {
+		modelapi := &kaosv1alpha1.ModelAPI{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec: kaosv1alpha1.ModelAPISpec{
+				Mode:        kaosv1alpha1.ModelAPIModeProxy,
+				ProxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"mock-model"}},
+			},
+		}
+		c := countingClient(modelapi)
+		r := &ModelAPIReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "llm", Namespace: "default"}}
+
+		_, err := r.Reconcile(ctx, req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(statusWrites).To(gomega.BeNumerically("<=", 1))
+
+		statusWrites = 0
+		_, err = r.Reconcile(ctx, req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(statusWrites).To(gomega.Equal(0))
+	})
+
+	ginkgo.It("still persists the status on an early-return error", func() {
+		agent := &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "missing", Model: "mock-model"},
+		}
+		c := countingClient(agent)
+		r := &AgentReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(100)}
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "agent", Namespace: "default"}}
+
+		_, err := r.Reconcile(ctx, req)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(statusWrites).To(gomega.Equal(1))
+
+		stored := &kaosv1alpha1.Agent{}
+		gomega.Expect(c.Get(ctx, req.NamespacedName, stored)).To(gomega.Succeed())
+		gomega.Expect(stored.Status.Phase).To(gomega.Equal("Failed"))
+	})
+})
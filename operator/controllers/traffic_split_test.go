@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/features"
+	"github.com/axsaucedo/kaos/operator/pkg/gateway"
+)
+
+var _ = ginkgo.Describe("agent trafficSplit", func() {
+	var agent *kaosv1alpha1.Agent
+
+	ginkgo.BeforeEach(func() {
+		enableFeatureGate(features.AgentTrafficSplit)
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "support", Namespace: "default"},
+			Spec: kaosv1alpha1.AgentSpec{
+				ModelAPI: "llm",
+				Model:    "mock-model",
+			},
+		}
+	})
+
+	ginkgo.It("routes only to the agent's Service without a split", func() {
+		params, err := agentHTTPRouteParams(agent, "agent-support")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(params.Backends).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("ignores the split while the AgentTrafficSplit feature gate is off", func() {
+		gomega.Expect(features.DefaultGate.Set("AgentTrafficSplit=false")).To(gomega.Succeed())
+		agent.Spec.TrafficSplit = &kaosv1alpha1.AgentTrafficSplit{
+			Backends: []kaosv1alpha1.AgentTrafficSplitBackend{{Agent: "support-v2", Weight: 10}},
+		}
+
+		params, err := agentHTTPRouteParams(agent, "agent-support")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(params.Backends).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("gives the agent the weight not assigned to backends", func() {
+		agent.Spec.TrafficSplit = &kaosv1alpha1.AgentTrafficSplit{
+			Backends: []kaosv1alpha1.AgentTrafficSplitBackend{{Agent: "support-v2", Weight: 10}},
+		}
+
+		params, err := agentHTTPRouteParams(agent, "agent-support")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(params.Backends).To(gomega.Equal([]gateway.WeightedBackend{
+			{ServiceName: "agent-support", ServicePort: 8000, Weight: 90},
+			{ServiceName: "agent-support-v2", ServicePort: 8000, Weight: 10},
+		}))
+	})
+
+	ginkgo.It("rejects weights adding up to more than 100", func() {
+		agent.Spec.TrafficSplit = &kaosv1alpha1.AgentTrafficSplit{
+			Backends: []kaosv1alpha1.AgentTrafficSplitBackend{
+				{Agent: "support-v2", Weight: 60},
+				{Agent: "support-v3", Weight: 50},
+			},
+		}
+
+		_, err := agentHTTPRouteParams(agent, "agent-support")
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("add up to 110")))
+	})
+
+	ginkgo.It("handles backend weights of 0 and 100", func() {
+		agent.Spec.TrafficSplit = &kaosv1alpha1.AgentTrafficSplit{
+			Backends: []kaosv1alpha1.AgentTrafficSplitBackend{{Agent: "support-v2", Weight: 0}},
+		}
+		params, err := agentHTTPRouteParams(agent, "agent-support")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(params.Backends[0].Weight).To(gomega.Equal(int32(100)))
+
+		agent.Spec.TrafficSplit.Backends[0].Weight = 100
+		params, err = agentHTTPRouteParams(agent, "agent-support")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(params.Backends[0].Weight).To(gomega.Equal(int32(0)))
+	})
+
+	ginkgo.It("rejects a backend referencing the agent itself", func() {
+		agent.Spec.TrafficSplit = &kaosv1alpha1.AgentTrafficSplit{
+			Backends: []kaosv1alpha1.AgentTrafficSplitBackend{{Agent: "support", Weight: 10}},
+		}
+
+		_, err := agentHTTPRouteParams(agent, "agent-support")
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("must not reference the agent itself")))
+	})
+})
@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/features"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+// warmStandbyLabel selects the pods of the standby Deployment. Standby pods do not carry
+// the modelapi label of the main pods, so neither the main Deployment's selector nor the
+// Service's default selector match them; the Service is pointed at them during a swap.
+const warmStandbyLabel = "kaos.tools/warm-standby"
+
+// warmStandbyRequested reports whether a Hosted ModelAPI has hostedConfig.warmStandby set
+func warmStandbyRequested(modelapi *kaosv1alpha1.ModelAPI) bool {
+	return modelapi.Spec.Mode == kaosv1alpha1.ModelAPIModeHosted && modelapi.Spec.HostedConfig != nil &&
+		modelapi.Spec.HostedConfig.WarmStandby != nil && *modelapi.Spec.HostedConfig.WarmStandby
+}
+
+// warmStandbyEnabled reports whether warmStandby is requested and the WarmStandby
+// feature gate is enabled
+func warmStandbyEnabled(modelapi *kaosv1alpha1.ModelAPI) bool {
+	return warmStandbyRequested(modelapi) && features.Enabled(features.WarmStandby)
+}
+
+// warmStandbyName returns the name of the standby Deployment of a ModelAPI
+func warmStandbyName(modelapi *kaosv1alpha1.ModelAPI) string {
+	return fmt.Sprintf("modelapi-%s-standby", modelapi.Name)
+}
+
+// warmStandbyLabels returns the labels and selector of the standby Deployment's pods
+func warmStandbyLabels(modelapi *kaosv1alpha1.ModelAPI) map[string]string {
+	return map[string]string{
+		"app":            "modelapi",
+		warmStandbyLabel: modelapi.Name,
+	}
+}
+
+// constructWarmStandby returns the standby Deployment for the desired main Deployment:
+// the same pod template and replicas, with the standby labels as its selector
+func constructWarmStandby(modelapi *kaosv1alpha1.ModelAPI, desired *appsv1.Deployment) *appsv1.Deployment {
+	standby := desired.DeepCopy()
+	standby.Name = warmStandbyName(modelapi)
+	standby.Labels = warmStandbyLabels(modelapi)
+	standby.Spec.Selector = &metav1.LabelSelector{MatchLabels: warmStandbyLabels(modelapi)}
+	standby.Spec.Template.Labels = warmStandbyLabels(modelapi)
+	return standby
+}
+
+// warmStandbyReady reports whether every replica of the standby runs its current pod
+// template and is ready to serve
+func warmStandbyReady(standby *appsv1.Deployment) bool {
+	if _, rolledOut := util.DeploymentRolloutComplete(standby); !rolledOut {
+		return false
+	}
+	replicas := int32(1)
+	if standby.Spec.Replicas != nil {
+		replicas = *standby.Spec.Replicas
+	}
+	return standby.Status.ReadyReplicas >= replicas
+}
+
+// reconcileWarmStandby creates or updates the standby Deployment with the desired pod
+// template, points the Service at it once it is ready, and reports whether the main
+// Deployment can now be updated without leaving the ModelAPI without a ready pod.
+func (r *ModelAPIReconciler) reconcileWarmStandby(ctx context.Context, modelapi *kaosv1alpha1.ModelAPI, desired *appsv1.Deployment) (bool, error) {
+	log := log.FromContext(ctx)
+	desiredStandby := constructWarmStandby(modelapi, desired)
+
+	standby := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: desiredStandby.Name, Namespace: modelapi.Namespace}, standby)
+	if apierrors.IsNotFound(err) {
+		if err := util.SetControllerReference(modelapi, desiredStandby, r.Scheme); err != nil {
+			return false, err
+		}
+		log.Info("Creating warm standby Deployment", "name", desiredStandby.Name)
+		if err := r.Create(ctx, desiredStandby); err != nil {
+			return false, err
+		}
+		if r.Recorder != nil {
+			r.Recorder.Event(modelapi, corev1.EventTypeNormal, "WarmStandbyCreated",
+				fmt.Sprintf("Started %s to load the new pod template before updating the Deployment", desiredStandby.Name))
+		}
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	if !metav1.IsControlledBy(standby, modelapi) {
+		return false, fmt.Errorf("deployment %s exists and is not owned by ModelAPI %s", standby.Name, modelapi.Name)
+	}
+
+	// The spec changed again during the swap; the standby restarts with the latest template
+	if deploymentHash(standby) != deploymentHash(desiredStandby) || deploymentRolloutChanged(standby, desiredStandby) {
+		log.Info("Updating warm standby Deployment", "name", standby.Name)
+		standby.Spec.Replicas = desiredStandby.Spec.Replicas
+		standby.Spec.Template = desiredStandby.Spec.Template
+		setDeploymentRollout(standby, desiredStandby)
+		setDeploymentHash(standby, desiredStandby)
+		return false, r.Update(ctx, standby)
+	}
+	if !warmStandbyReady(standby) {
+		return false, nil
+	}
+
+	// Move traffic to the standby before the main pods are replaced
+	return r.selectServicePods(ctx, modelapi, standby.Spec.Selector.MatchLabels)
+}
+
+// selectServicePods points the ModelAPI Service at the pods matching selector and
+// reports whether it already did, so callers act on the swap in the next reconcile
+// (triggered by the Service update) once endpoints follow the new selector
+func (r *ModelAPIReconciler) selectServicePods(ctx context.Context, modelapi *kaosv1alpha1.ModelAPI, selector map[string]string) (bool, error) {
+	service := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Name: fmt.Sprintf("modelapi-%s", modelapi.Name), Namespace: modelapi.Namespace}, service); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	if equality.Semantic.DeepEqual(service.Spec.Selector, selector) {
+		return true, nil
+	}
+
+	log.FromContext(ctx).Info("Pointing Service at new pods", "name", service.Name, "selector", selector)
+	service.Spec.Selector = selector
+	if err := r.Update(ctx, service); err != nil {
+		return false, err
+	}
+	if r.Recorder != nil {
+		r.Recorder.Event(modelapi, corev1.EventTypeNormal, "WarmStandbySwapped",
+			fmt.Sprintf("Service %s now selects %v", service.Name, selector))
+	}
+	return false, nil
+}
+
+// retireWarmStandby points the Service back at the main Deployment and then deletes the
+// standby, once the main Deployment has rolled out the standby's pod template or right
+// away when warmStandby is turned off. It returns the standby while it is kept, so its
+// ready pods count towards readiness.
+func (r *ModelAPIReconciler) retireWarmStandby(ctx context.Context, modelapi *kaosv1alpha1.ModelAPI, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	standby := &appsv1.Deployment{}
+	err := r.Get(ctx, types.NamespacedName{Name: warmStandbyName(modelapi), Namespace: modelapi.Namespace}, standby)
+	if err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(standby, modelapi) {
+		return nil, nil
+	}
+
+	if warmStandbyEnabled(modelapi) {
+		if deploymentHash(deployment) != deploymentHash(standby) {
+			return standby, nil
+		}
+		if _, rolledOut := util.DeploymentRolloutComplete(deployment); !rolledOut || deployment.Status.ReadyReplicas < *deployment.Spec.Replicas {
+			return standby, nil
+		}
+	}
+
+	// Move traffic back to the main pods before the standby goes away
+	if selected, err := r.selectServicePods(ctx, modelapi, deployment.Spec.Selector.MatchLabels); err != nil || !selected {
+		return standby, err
+	}
+
+	log.FromContext(ctx).Info("Deleting warm standby Deployment", "name", standby.Name)
+	if err := r.Delete(ctx, standby); err != nil && !apierrors.IsNotFound(err) {
+		return standby, err
+	}
+	if r.Recorder != nil && warmStandbyEnabled(modelapi) {
+		r.Recorder.Event(modelapi, corev1.EventTypeNormal, "WarmStandbyRetired",
+			fmt.Sprintf("Deployment %s rolled out; removed %s", deployment.Name, standby.Name))
+	}
+	return nil, nil
+}
@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("agent warm-up hook", func() {
+	var (
+		agent     *kaosv1alpha1.Agent
+		modelapis []*kaosv1alpha1.ModelAPI
+	)
+
+	ginkgo.BeforeEach(func() {
+		setDefaultAgentImage()
+
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "mock-model"},
+		}
+		modelapis = []*kaosv1alpha1.ModelAPI{{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Spec:       kaosv1alpha1.ModelAPISpec{Mode: kaosv1alpha1.ModelAPIModeProxy},
+			Status:     kaosv1alpha1.ModelAPIStatus{Endpoint: "http://modelapi-llm.default:8000", Ready: true},
+		}}
+	})
+
+	lifecycle := func() *corev1.Lifecycle {
+		podSpec, _, err := (&AgentReconciler{}).constructPodSpec(agent, modelapis, nil, nil)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		return podSpec.Containers[0].Lifecycle
+	}
+
+	ginkgo.It("adds no hook by default", func() {
+		gomega.Expect(lifecycle()).To(gomega.BeNil())
+	})
+
+	ginkgo.It("pings the ModelAPI health path with an HTTP postStart hook", func() {
+		agent.Spec.WarmUp = &kaosv1alpha1.AgentWarmUp{Handler: kaosv1alpha1.AgentWarmUpHandlerHTTPGet}
+
+		hook := lifecycle()
+		gomega.Expect(hook).NotTo(gomega.BeNil())
+		gomega.Expect(hook.PostStart.HTTPGet).To(gomega.Equal(&corev1.HTTPGetAction{
+			Host:   "modelapi-llm.default",
+			Port:   intstr.FromInt(8000),
+			Path:   "/health/liveliness",
+			Scheme: corev1.URISchemeHTTP,
+		}))
+	})
+
+	ginkgo.It("requests the ModelAPI URL from an exec postStart hook", func() {
+		agent.Spec.WarmUp = &kaosv1alpha1.AgentWarmUp{Handler: kaosv1alpha1.AgentWarmUpHandlerExec, Path: "/v1/models"}
+
+		hook := lifecycle()
+		gomega.Expect(hook).NotTo(gomega.BeNil())
+		gomega.Expect(hook.PostStart.Exec).NotTo(gomega.BeNil())
+		gomega.Expect(hook.PostStart.Exec.Command).To(gomega.Equal([]string{
+			"sh", "-c", `python -c "$0" "$1" || true`, warmUpScript, "http://modelapi-llm.default:8000/v1/models",
+		}))
+	})
+
+	ginkgo.It("uses the failure-tolerant exec hook by default", func() {
+		agent.Spec.WarmUp = &kaosv1alpha1.AgentWarmUp{}
+
+		hook := lifecycle()
+		gomega.Expect(hook.PostStart.HTTPGet).To(gomega.BeNil())
+		gomega.Expect(hook.PostStart.Exec.Command).To(gomega.HaveExactElements(
+			"sh", "-c", `python -c "$0" "$1" || true`, warmUpScript, "http://modelapi-llm.default:8000/health/liveliness",
+		))
+	})
+
+	ginkgo.It("uses the HTTPS default port for external endpoints", func() {
+		agent.Spec.WarmUp = &kaosv1alpha1.AgentWarmUp{Handler: kaosv1alpha1.AgentWarmUpHandlerHTTPGet}
+		modelapis[0].Spec.Mode = kaosv1alpha1.ModelAPIModeExternal
+		modelapis[0].Status.Endpoint = "https://api.example.com/openai"
+
+		hook := lifecycle()
+		gomega.Expect(hook.PostStart.HTTPGet.Host).To(gomega.Equal("api.example.com"))
+		gomega.Expect(hook.PostStart.HTTPGet.Port).To(gomega.Equal(intstr.FromInt(443)))
+		gomega.Expect(hook.PostStart.HTTPGet.Scheme).To(gomega.Equal(corev1.URISchemeHTTPS))
+		gomega.Expect(hook.PostStart.HTTPGet.Path).To(gomega.Equal("/openai/"))
+	})
+})
@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+var _ = ginkgo.Describe("agent workers", func() {
+	var (
+		agent     *kaosv1alpha1.Agent
+		modelapis []*kaosv1alpha1.ModelAPI
+	)
+
+	ginkgo.BeforeEach(func() {
+		setDefaultAgentImage()
+
+		agent = &kaosv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+			Spec:       kaosv1alpha1.AgentSpec{ModelAPI: "llm", Model: "mock-model"},
+		}
+		modelapis = []*kaosv1alpha1.ModelAPI{{
+			ObjectMeta: metav1.ObjectMeta{Name: "llm", Namespace: "default"},
+			Status:     kaosv1alpha1.ModelAPIStatus{Endpoint: "http://llm:8000", Ready: true},
+		}}
+	})
+
+	ginkgo.It("leaves the worker count to the runtime by default", func() {
+		gomega.Expect(agentEnv(agent, modelapis...)).NotTo(gomega.HaveKey("WEB_CONCURRENCY"))
+	})
+
+	ginkgo.It("passes workers as WEB_CONCURRENCY and rolls the pods when it changes", func() {
+		before := agentPodSpecHash(agent, modelapis...)
+
+		workers := int32(4)
+		agent.Spec.Workers = &workers
+		gomega.Expect(agentEnv(agent, modelapis...)).To(gomega.HaveKeyWithValue("WEB_CONCURRENCY", "4"))
+		gomega.Expect(agentPodSpecHash(agent, modelapis...)).NotTo(gomega.Equal(before))
+	})
+
+	ginkgo.It("warns that inmemory sessions are not shared between workers or replicas", func() {
+		gomega.Expect(memoryNotSharedMessage(agent)).To(gomega.BeEmpty())
+
+		workers, replicas := int32(4), int32(2)
+		agent.Spec.Workers = &workers
+		gomega.Expect(memoryNotSharedMessage(agent)).To(gomega.HavePrefix("Agent has more than one worker but"))
+
+		agent.Spec.Replicas = &replicas
+		gomega.Expect(memoryNotSharedMessage(agent)).To(gomega.HavePrefix("Agent has more than one replica and more than one worker but"))
+
+		agent.Spec.Config = &kaosv1alpha1.AgentConfig{Memory: &kaosv1alpha1.MemoryConfig{Backend: kaosv1alpha1.MemoryBackendRedis}}
+		gomega.Expect(memoryNotSharedMessage(agent)).To(gomega.BeEmpty())
+	})
+})
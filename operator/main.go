@@ -1,22 +1,34 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"os"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	kaosv1beta1 "github.com/axsaucedo/kaos/operator/api/v1beta1"
 	"github.com/axsaucedo/kaos/operator/controllers"
+	"github.com/axsaucedo/kaos/operator/pkg/features"
+	"github.com/axsaucedo/kaos/operator/pkg/gateway"
+	"github.com/axsaucedo/kaos/operator/pkg/tracing"
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+	"github.com/axsaucedo/kaos/operator/pkg/validation"
 )
 
 var (
@@ -34,19 +46,58 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(kaosv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(kaosv1beta1.AddToScheme(scheme))
 	utilruntime.Must(gatewayv1.Install(scheme))
 }
 
 func main() {
 	var metricsAddr string
+	var metricsTokenFile string
 	var enableLeaderElection bool
 	var probeAddr string
+	var enableTracing bool
+	var logFormat string
+	var enableConversionWebhook bool
+	var enableValidationWebhook bool
+	var blockOwnerDeletion bool
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	flag.StringVar(&metricsTokenFile, "metrics-token-file", util.GetMetricsTokenFile(),
+		"File holding a bearer token that requests to the metric endpoint must present. "+
+			"Empty leaves the endpoint open.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&enableTracing, "enable-tracing", tracing.IsEnabled(),
+		"Enable OpenTelemetry tracing of reconcile loops. "+
+			"Spans are exported to DEFAULT_TELEMETRY_ENDPOINT and require DEFAULT_TELEMETRY_ENABLED=true.")
+
+	flag.BoolVar(&enableConversionWebhook, "enable-conversion-webhook", os.Getenv("ENABLE_CONVERSION_WEBHOOK") == "true",
+		"Serve the Agent conversion webhook (v1alpha1 <-> v1beta1). "+
+			"Requires serving certificates and a CRD conversion strategy of Webhook.")
+	flag.BoolVar(&enableValidationWebhook, "enable-validation-webhook", os.Getenv("ENABLE_VALIDATION_WEBHOOK") == "true",
+		"Serve the ModelAPI and Agent validating webhooks so invalid specs are rejected at admission. "+
+			"Requires serving certificates and the ValidatingWebhookConfiguration in config/webhook.")
+	flag.BoolVar(&blockOwnerDeletion, "block-owner-deletion", util.GetBlockOwnerDeletion(),
+		"Set blockOwnerDeletion on owner references of created resources. "+
+			"Disable so foreground deletion of an Agent, ModelAPI or MCPServer does not wait for its children.")
+	flag.StringVar(&logFormat, "log-format", util.GetLogFormat(),
+		"Log encoding: 'console' for human-readable development logs or 'json' for structured production logs. "+
+			"The level defaults to DEFAULT_LOG_LEVEL unless --zap-log-level is set.")
+
+	// FEATURE_GATES is applied first so --feature-gates can override individual gates
+	if err := features.DefaultGate.Set(features.GetFeatureGates()); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid FEATURE_GATES: %v\n", err)
+		os.Exit(1)
+	}
+	knownGates := "none"
+	if known := features.DefaultGate.Known(); len(known) > 0 {
+		knownGates = strings.Join(known, ", ")
+	}
+	flag.Var(features.DefaultGate, "feature-gates",
+		"Comma-separated Feature=true|false pairs that toggle experimental features, applied on top of FEATURE_GATES. "+
+			"Known gates: "+knownGates+".")
 
 	opts := zap.Options{
 		Development: true,
@@ -54,10 +105,57 @@ func main() {
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	if err := util.ApplyLogOptions(&opts, logFormat, util.GetDefaultLogLevel()); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid logging configuration: %v\n", err)
+		os.Exit(1)
+	}
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if gates := features.DefaultGate.String(); gates != "" {
+		setupLog.Info("feature gates set", "featureGates", gates)
+	}
+
+	if err := gateway.GetConfig().Validate(); err != nil {
+		setupLog.Error(err, "invalid Gateway API configuration")
+		os.Exit(1)
+	}
+
+	if _, err := util.GetImageDigests(); err != nil {
+		setupLog.Error(err, "invalid image digest configuration")
+		os.Exit(1)
+	}
+
+	if _, err := util.GetDefaultDebugMemoryEndpoints(); err != nil {
+		setupLog.Error(err, "invalid agent defaults")
+		os.Exit(1)
+	}
+
+	util.SetBlockOwnerDeletion(blockOwnerDeletion)
+
+	systemNamespace := getEnvWithDefault("SYSTEM_NAMESPACE", "kaos")
+
+	// Restrict the cache to WATCH_NAMESPACES and CACHE_LABEL_SELECTOR if set
+	cacheOpts, err := util.BuildCacheOptions(systemNamespace)
+	if err != nil {
+		setupLog.Error(err, "unable to build cache options")
+		os.Exit(1)
+	}
+	if watchNamespaces := util.GetWatchNamespaces(); len(watchNamespaces) > 0 {
+		setupLog.Info("watching namespaces", "namespaces", watchNamespaces, "systemNamespace", systemNamespace)
+	} else {
+		setupLog.Info("watching all namespaces")
+	}
+
+	metricsOpts := metricsserver.Options{BindAddress: metricsAddr}
+	if metricsTokenFile != "" {
+		metricsOpts.FilterProvider = util.MetricsBearerTokenFilter(metricsTokenFile)
+		setupLog.Info("metrics endpoint requires a bearer token", "tokenFile", metricsTokenFile)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOpts,
+		Metrics:                metricsOpts,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "kaos-operator.kaos.tools",
@@ -67,37 +165,98 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Record all operator writes under a single field manager (OPERATOR_FIELD_MANAGER)
+	k8sClient := client.WithFieldOwner(mgr.GetClient(), util.GetFieldManager())
+
+	// Status patches that conflict re-read the object directly instead of from the cache
+	controllers.SetStatusReader(mgr.GetAPIReader())
+
+	// Trace reconciles and client calls when enabled, using the data plane's collector
+	if enableTracing {
+		telemetry := util.GetDefaultTelemetryConfig()
+		if telemetry == nil || telemetry.Endpoint == "" {
+			setupLog.Info("WARNING: --enable-tracing requires DEFAULT_TELEMETRY_ENABLED=true and DEFAULT_TELEMETRY_ENDPOINT; tracing disabled")
+		} else {
+			shutdown, err := tracing.Setup(context.Background(), telemetry.Endpoint)
+			if err != nil {
+				setupLog.Error(err, "unable to set up tracing")
+				os.Exit(1)
+			}
+			defer func() {
+				if err := shutdown(context.Background()); err != nil {
+					setupLog.Error(err, "failed to shut down tracing")
+				}
+			}()
+			k8sClient = tracing.WrapClient(k8sClient)
+			setupLog.Info("operator tracing enabled", "endpoint", telemetry.Endpoint)
+		}
+	}
+
+	// Pods and their logs are read directly, without caching every pod in the cluster
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create clientset")
+		os.Exit(1)
+	}
+
 	// Setup controllers
 	if err = (&controllers.ModelAPIReconciler{
-		Client: mgr.GetClient(),
-		Log:    setupLog,
-		Scheme: mgr.GetScheme(),
+		Client:    k8sClient,
+		Log:       setupLog,
+		Scheme:    mgr.GetScheme(),
+		Recorder:  mgr.GetEventRecorderFor("modelapi-controller"),
+		Clientset: clientset,
+		APIReader: mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ModelAPI")
 		os.Exit(1)
 	}
 
 	if err = (&controllers.MCPServerReconciler{
-		Client:          mgr.GetClient(),
+		Client:          k8sClient,
 		Log:             setupLog,
 		Scheme:          mgr.GetScheme(),
-		SystemNamespace: getEnvWithDefault("SYSTEM_NAMESPACE", "kaos"),
+		Recorder:        mgr.GetEventRecorderFor("mcpserver-controller"),
+		SystemNamespace: systemNamespace,
+		Clientset:       clientset,
+		APIReader:       mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MCPServer")
 		os.Exit(1)
 	}
 
 	if err = (&controllers.AgentReconciler{
-		Client: mgr.GetClient(),
-		Log:    setupLog,
-		Scheme: mgr.GetScheme(),
+		Client:    k8sClient,
+		Log:       setupLog,
+		Scheme:    mgr.GetScheme(),
+		Recorder:  mgr.GetEventRecorderFor("agent-controller"),
+		Clientset: clientset,
+		APIReader: mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Agent")
 		os.Exit(1)
 	}
 
-	// Webhooks not implemented yet in this version
-	// TODO: Add webhook setup when webhooks are needed
+	// Conversion webhook for Agent. v1alpha1 is the hub and storage version;
+	// v1beta1 is unserved, so this is only needed once the CRD enables it.
+	if enableConversionWebhook {
+		if err = ctrl.NewWebhookManagedBy(mgr).For(&kaosv1alpha1.Agent{}).Complete(); err != nil {
+			setupLog.Error(err, "unable to create conversion webhook", "webhook", "Agent")
+			os.Exit(1)
+		}
+	}
+
+	// Validating webhooks for ModelAPI and Agent; the reconcilers run the same checks as a fallback
+	if enableValidationWebhook {
+		if err = validation.SetupModelAPIWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create validating webhook", "webhook", "ModelAPI")
+			os.Exit(1)
+		}
+		if err = validation.SetupAgentWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create validating webhook", "webhook", "Agent")
+			os.Exit(1)
+		}
+	}
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
@@ -107,6 +266,12 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	// Report not-ready until the KAOS CRDs are installed and served
+	if err := mgr.AddReadyzCheck("crds", util.NewCRDReadyCheck(mgr.GetAPIReader(),
+		&kaosv1alpha1.AgentList{}, &kaosv1alpha1.ModelAPIList{}, &kaosv1alpha1.MCPServerList{})); err != nil {
+		setupLog.Error(err, "unable to set up CRD ready check")
+		os.Exit(1)
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
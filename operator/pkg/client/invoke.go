@@ -0,0 +1,260 @@
+// Package client invokes agents from outside the cluster, for use by the kaos CLI.
+// It resolves an Agent's base URL, either through the Gateway or a port-forward to
+// its Service, and sends OpenAI-style chat completions, streaming the response.
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// agentPort is the port the agent Service listens on
+const agentPort = 8000
+
+// PortForwardFunc forwards localPort to remotePort of a Service and returns a function
+// that stops the forward
+type PortForwardFunc func(ctx context.Context, namespace, service string, localPort, remotePort int) (stop func(), err error)
+
+// ResolveOptions controls how an agent endpoint is resolved
+type ResolveOptions struct {
+	// ViaGateway uses the agent's Gateway endpoint instead of a port-forward
+	ViaGateway bool
+	// LocalPort is the local port to forward; 0 picks a free port
+	LocalPort int
+	// PortForward overrides the port-forward implementation; defaults to KubectlPortForward
+	PortForward PortForwardFunc
+}
+
+// Endpoint is a resolved agent base URL
+type Endpoint struct {
+	URL  string
+	stop func()
+}
+
+// Close stops the port-forward backing the endpoint, if any
+func (e *Endpoint) Close() {
+	if e.stop != nil {
+		e.stop()
+		e.stop = nil
+	}
+}
+
+// ResolveEndpoint returns the base URL to invoke the named Agent. The caller must
+// Close the endpoint once done.
+func ResolveEndpoint(ctx context.Context, c ctrlclient.Reader, namespace, name string, opts ResolveOptions) (*Endpoint, error) {
+	agent := &kaosv1alpha1.Agent{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, agent); err != nil {
+		return nil, fmt.Errorf("failed to get Agent %s/%s: %w", namespace, name, err)
+	}
+
+	if opts.ViaGateway {
+		url := agent.Status.Endpoints["gateway"]
+		if url == "" {
+			return nil, fmt.Errorf("agent %s/%s has no gateway endpoint; is it exposed through the Gateway?", namespace, name)
+		}
+		return &Endpoint{URL: strings.TrimSuffix(url, "/")}, nil
+	}
+
+	if agent.Status.Endpoint == "" {
+		return nil, fmt.Errorf("agent %s/%s has no Service; is spec.agentNetwork.expose disabled?", namespace, name)
+	}
+
+	return ForwardService(ctx, namespace, fmt.Sprintf("agent-%s", name), agentPort, opts)
+}
+
+// ForwardService port-forwards a local port to port of the Service and returns the
+// local endpoint. The caller must Close the endpoint once done.
+func ForwardService(ctx context.Context, namespace, service string, port int, opts ResolveOptions) (*Endpoint, error) {
+	localPort := opts.LocalPort
+	if localPort == 0 {
+		free, err := freePort()
+		if err != nil {
+			return nil, err
+		}
+		localPort = free
+	}
+	portForward := opts.PortForward
+	if portForward == nil {
+		portForward = KubectlPortForward
+	}
+	stop, err := portForward(ctx, namespace, service, localPort, port)
+	if err != nil {
+		return nil, err
+	}
+	return &Endpoint{URL: fmt.Sprintf("http://127.0.0.1:%d", localPort), stop: stop}, nil
+}
+
+// KubectlPortForward runs `kubectl port-forward` to the Service and waits until the
+// local port accepts connections
+func KubectlPortForward(ctx context.Context, namespace, service string, localPort, remotePort int) (func(), error) {
+	cmd := exec.Command("kubectl", "port-forward", "-n", namespace,
+		"svc/"+service, fmt.Sprintf("%d:%d", localPort, remotePort))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start kubectl port-forward: %w", err)
+	}
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+	stop := func() {
+		_ = cmd.Process.Kill()
+		<-exited
+	}
+
+	address := fmt.Sprintf("127.0.0.1:%d", localPort)
+	deadline := time.After(10 * time.Second)
+	for {
+		if conn, err := net.DialTimeout("tcp", address, time.Second); err == nil {
+			_ = conn.Close()
+			return stop, nil
+		}
+		select {
+		case <-exited:
+			return nil, fmt.Errorf("kubectl port-forward exited: %s", strings.TrimSpace(stderr.String()))
+		case <-deadline:
+			stop()
+			return nil, fmt.Errorf("timed out waiting for port-forward to svc/%s", service)
+		case <-ctx.Done():
+			stop()
+			return nil, ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
+
+// freePort returns a local TCP port that is currently unused
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free local port: %w", err)
+	}
+	defer listener.Close()
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// Message is a chat completion message
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// CompletionRequest is an OpenAI-style chat completion request
+type CompletionRequest struct {
+	Messages []Message `json:"messages"`
+	Stream   bool      `json:"stream"`
+}
+
+// InvokeOptions configures a completion call
+type InvokeOptions struct {
+	// Token is sent as a bearer token, for agents that require authentication
+	Token string
+	// HTTPClient defaults to a client with a 120s timeout
+	HTTPClient *http.Client
+}
+
+type completionChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+}
+
+// Invoke POSTs a streaming chat completion to the agent at baseURL, calling onChunk
+// with each content delta as it arrives, and returns the full response content
+func Invoke(ctx context.Context, baseURL string, messages []Message, opts InvokeOptions, onChunk func(string)) (string, error) {
+	body, err := json.Marshal(CompletionRequest{Messages: messages, Stream: true})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(baseURL, "/")+"/v1/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	if opts.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+opts.Token)
+	}
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 120 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to invoke agent: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+			return "", fmt.Errorf("agent rejected the request (HTTP %d); check the auth token: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+		}
+		return "", fmt.Errorf("agent returned HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+
+	// Agents that do not stream answer with a single completion object
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		var completion completionChunk
+		if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+			return "", fmt.Errorf("failed to decode completion: %w", err)
+		}
+		if len(completion.Choices) == 0 {
+			return "", nil
+		}
+		content := completion.Choices[0].Message.Content
+		if onChunk != nil {
+			onChunk(content)
+		}
+		return content, nil
+	}
+
+	var content strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		if data == "[DONE]" {
+			break
+		}
+		var chunk completionChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil || len(chunk.Choices) == 0 {
+			continue
+		}
+		if delta := chunk.Choices[0].Delta.Content; delta != "" {
+			content.WriteString(delta)
+			if onChunk != nil {
+				onChunk(delta)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return content.String(), fmt.Errorf("failed to read response stream: %w", err)
+	}
+	return content.String(), nil
+}
@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// agentServer emulates an agent's /v1/chat/completions endpoint, streaming chunks
+// when the request asks for it and requiring token when non-empty
+func agentServer(t *testing.T, token string, chunks ...string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		if token != "" && r.Header.Get("Authorization") != "Bearer "+token {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		var req CompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Messages) == 0 {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if !req.Stream {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `{"choices":[{"message":{"role":"assistant","content":%q}}]}`, strings.Join(chunks, ""))
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, chunk := range chunks {
+			fmt.Fprintf(w, "data: {\"choices\":[{\"delta\":{\"content\":%q}}]}\n\n", chunk)
+			w.(http.Flusher).Flush()
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+}
+
+func TestInvokeStreams(t *testing.T) {
+	server := agentServer(t, "", "Hello", ", ", "world")
+	defer server.Close()
+
+	var received []string
+	content, err := Invoke(context.Background(), server.URL, []Message{{Role: "user", Content: "hi"}}, InvokeOptions{},
+		func(delta string) { received = append(received, delta) })
+	if err != nil {
+		t.Fatalf("Invoke() error = %v", err)
+	}
+	if content != "Hello, world" {
+		t.Errorf("expected the full content, got %q", content)
+	}
+	if len(received) != 3 || received[0] != "Hello" {
+		t.Errorf("expected each delta to be streamed, got %q", received)
+	}
+}
+
+func TestInvokeNonStreamingResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"choices":[{"message":{"role":"assistant","content":"done"}}]}`)
+	}))
+	defer server.Close()
+
+	content, err := Invoke(context.Background(), server.URL, []Message{{Role: "user", Content: "hi"}}, InvokeOptions{}, nil)
+	if err != nil || content != "done" {
+		t.Errorf("expected content done, got %q, err = %v", content, err)
+	}
+}
+
+func TestInvokeAuthToken(t *testing.T) {
+	server := agentServer(t, "secret", "ok")
+	defer server.Close()
+	messages := []Message{{Role: "user", Content: "hi"}}
+
+	_, err := Invoke(context.Background(), server.URL, messages, InvokeOptions{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "HTTP 401") {
+		t.Errorf("expected an HTTP 401 error without a token, got %v", err)
+	}
+
+	content, err := Invoke(context.Background(), server.URL, messages, InvokeOptions{Token: "secret"}, nil)
+	if err != nil || content != "ok" {
+		t.Errorf("expected the token to be accepted, got %q, err = %v", content, err)
+	}
+}
+
+func TestInvokeHTTPError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "model unavailable", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	_, err := Invoke(context.Background(), server.URL, []Message{{Role: "user", Content: "hi"}}, InvokeOptions{}, nil)
+	if err == nil || !strings.Contains(err.Error(), "HTTP 502: model unavailable") {
+		t.Errorf("expected the HTTP error to be reported, got %v", err)
+	}
+}
+
+func resolveClient(t *testing.T, status kaosv1alpha1.AgentStatus) *fake.ClientBuilder {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := kaosv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	agent := &kaosv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "support", Namespace: "prod"},
+		Status:     status,
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(agent)
+}
+
+func TestResolveEndpointViaGateway(t *testing.T) {
+	c := resolveClient(t, kaosv1alpha1.AgentStatus{
+		Endpoints: map[string]string{"gateway": "http://kaos.example.com/prod/agent/support/"},
+	}).Build()
+
+	endpoint, err := ResolveEndpoint(context.Background(), c, "prod", "support", ResolveOptions{ViaGateway: true})
+	if err != nil {
+		t.Fatalf("ResolveEndpoint() error = %v", err)
+	}
+	defer endpoint.Close()
+	if endpoint.URL != "http://kaos.example.com/prod/agent/support" {
+		t.Errorf("expected the gateway endpoint, got %s", endpoint.URL)
+	}
+
+	c = resolveClient(t, kaosv1alpha1.AgentStatus{}).Build()
+	if _, err := ResolveEndpoint(context.Background(), c, "prod", "support", ResolveOptions{ViaGateway: true}); err == nil {
+		t.Error("expected an error for an agent without a gateway endpoint")
+	}
+}
+
+func TestResolveEndpointPortForward(t *testing.T) {
+	c := resolveClient(t, kaosv1alpha1.AgentStatus{Endpoint: "http://agent-support.prod.svc.cluster.local:8000"}).Build()
+
+	var forwarded string
+	stopped := false
+	endpoint, err := ResolveEndpoint(context.Background(), c, "prod", "support", ResolveOptions{
+		LocalPort: 18080,
+		PortForward: func(ctx context.Context, namespace, service string, localPort, remotePort int) (func(), error) {
+			forwarded = fmt.Sprintf("%s/%s %d:%d", namespace, service, localPort, remotePort)
+			return func() { stopped = true }, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("ResolveEndpoint() error = %v", err)
+	}
+	if forwarded != "prod/agent-support 18080:8000" {
+		t.Errorf("unexpected port-forward %q", forwarded)
+	}
+	if endpoint.URL != "http://127.0.0.1:18080" {
+		t.Errorf("expected the local endpoint, got %s", endpoint.URL)
+	}
+	endpoint.Close()
+	if !stopped {
+		t.Error("expected Close to stop the port-forward")
+	}
+}
+
+func TestResolveEndpointMissingAgent(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kaosv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	_, err := ResolveEndpoint(context.Background(), c, "prod", "missing", ResolveOptions{})
+	if err == nil || !strings.Contains(err.Error(), "prod/missing") {
+		t.Errorf("expected a not found error naming the agent, got %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+// Package debug provides helpers to attach a debug ephemeral container to the pods of
+// KAOS resources for live troubleshooting
+package debug
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Annotation opts a resource into debugging. "true" uses the default debug image;
+	// any other value except "false" is used as the image.
+	Annotation = "kaos.tools/debug"
+
+	// ContainerName is the name of the debug ephemeral container
+	ContainerName = "kaos-debug"
+
+	// DefaultImage bundles common network and process tools (curl, dig, tcpdump, ps)
+	DefaultImage = "nicolaka/netshoot:v0.13"
+)
+
+// GetImage returns the debug image from the DEFAULT_DEBUG_IMAGE env var.
+// Falls back to DefaultImage if not set.
+func GetImage() string {
+	if image := os.Getenv("DEFAULT_DEBUG_IMAGE"); image != "" {
+		return image
+	}
+	return DefaultImage
+}
+
+// Requested returns the debug image requested by the Annotation in annotations, and
+// false when debugging is not requested
+func Requested(annotations map[string]string) (string, bool) {
+	value := strings.TrimSpace(annotations[Annotation])
+	switch strings.ToLower(value) {
+	case "", "false":
+		return "", false
+	case "true":
+		return GetImage(), true
+	default:
+		return value, true
+	}
+}
+
+// EphemeralContainer builds the debug container for pod. It targets the pod's first
+// container so the process namespace is shared with it, and keeps stdin and a TTY open
+// for `kubectl attach -it`.
+func EphemeralContainer(pod *corev1.Pod, image string) corev1.EphemeralContainer {
+	container := corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:                     ContainerName,
+			Image:                    image,
+			ImagePullPolicy:          corev1.PullIfNotPresent,
+			Stdin:                    true,
+			TTY:                      true,
+			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		},
+	}
+	if len(pod.Spec.Containers) > 0 {
+		container.TargetContainerName = pod.Spec.Containers[0].Name
+	}
+	return container
+}
+
+// Attach adds the debug ephemeral container to pod through the ephemeralcontainers
+// subresource. Ephemeral containers cannot be removed or changed, so it returns false
+// without an update if the pod already has one.
+func Attach(ctx context.Context, c client.Client, pod *corev1.Pod, image string) (bool, error) {
+	for _, existing := range pod.Spec.EphemeralContainers {
+		if existing.Name == ContainerName {
+			return false, nil
+		}
+	}
+
+	pod.Spec.EphemeralContainers = append(pod.Spec.EphemeralContainers, EphemeralContainer(pod, image))
+	if err := c.SubResource("ephemeralcontainers").Update(ctx, pod); err != nil {
+		return false, fmt.Errorf("failed to attach debug container to pod %s: %w", pod.Name, err)
+	}
+	return true, nil
+}
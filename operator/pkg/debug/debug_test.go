@@ -0,0 +1,62 @@
+package debug
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRequested(t *testing.T) {
+	t.Setenv("DEFAULT_DEBUG_IMAGE", "")
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		expectedImage string
+		expectedOK    bool
+	}{
+		{"no annotations", nil, "", false},
+		{"false", map[string]string{Annotation: "false"}, "", false},
+		{"true uses the default image", map[string]string{Annotation: "true"}, DefaultImage, true},
+		{"custom image", map[string]string{Annotation: "busybox:1.36"}, "busybox:1.36", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image, ok := Requested(tt.annotations)
+			if image != tt.expectedImage || ok != tt.expectedOK {
+				t.Errorf("Requested() = (%q, %v), want (%q, %v)", image, ok, tt.expectedImage, tt.expectedOK)
+			}
+		})
+	}
+}
+
+func TestGetImageOverride(t *testing.T) {
+	t.Setenv("DEFAULT_DEBUG_IMAGE", "registry.local/debug:1")
+	if image, _ := Requested(map[string]string{Annotation: "true"}); image != "registry.local/debug:1" {
+		t.Errorf("expected DEFAULT_DEBUG_IMAGE to be used, got %s", image)
+	}
+}
+
+func TestEphemeralContainer(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent-agent-abc", Namespace: "default"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "agent", Image: "axsauze/kaos-agent:latest"},
+			{Name: "sidecar", Image: "sidecar:latest"},
+		}},
+	}
+
+	container := EphemeralContainer(pod, "busybox:1.36")
+	if container.Name != ContainerName || container.Image != "busybox:1.36" {
+		t.Errorf("unexpected container %s with image %s", container.Name, container.Image)
+	}
+	if container.TargetContainerName != "agent" {
+		t.Errorf("expected the first container to be targeted, got %q", container.TargetContainerName)
+	}
+	if !container.Stdin || !container.TTY {
+		t.Errorf("expected stdin and a TTY for an interactive session")
+	}
+	if len(container.Ports) != 0 || container.Resources.Limits != nil || container.LivenessProbe != nil {
+		t.Errorf("ephemeral containers must not set ports, resources or probes")
+	}
+}
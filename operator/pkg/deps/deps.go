@@ -0,0 +1,138 @@
+// Package deps resolves the ModelAPI, MCPServer and peer Agent resources an agent
+// depends on, returning their endpoints and readiness for use by controllers.
+package deps
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/tracing"
+)
+
+// ModelAPI is a resolved ModelAPI dependency
+type ModelAPI struct {
+	Name string
+	// Object is nil when the ModelAPI does not exist
+	Object   *kaosv1alpha1.ModelAPI
+	Endpoint string
+	Ready    bool
+}
+
+// Found reports whether the referenced ModelAPI exists
+func (m *ModelAPI) Found() bool {
+	return m.Object != nil
+}
+
+// MCPServer is a resolved MCPServer dependency
+type MCPServer struct {
+	Name     string
+	Object   *kaosv1alpha1.MCPServer
+	Endpoint string
+	Ready    bool
+}
+
+// Peer is a resolved peer Agent dependency
+type Peer struct {
+	Name     string
+	Found    bool
+	Endpoint string
+	Ready    bool
+}
+
+// ResolveModelAPI looks up a ModelAPI by name. A missing ModelAPI is not an error;
+// it is reported through Found() so callers can decide how to handle it.
+func ResolveModelAPI(ctx context.Context, c client.Reader, namespace, name string) (*ModelAPI, error) {
+	ctx, span := tracing.Start(ctx, "ResolveModelAPI", attribute.String("kaos.name", name), attribute.String("kaos.namespace", namespace))
+	defer span.End()
+
+	resolved := &ModelAPI{Name: name}
+
+	modelapi := &kaosv1alpha1.ModelAPI{}
+	err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, modelapi)
+	if apierrors.IsNotFound(err) {
+		return resolved, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get ModelAPI %s: %w", name, err)
+	}
+
+	resolved.Object = modelapi
+	resolved.Endpoint = modelapi.Status.Endpoint
+	resolved.Ready = modelapi.Status.Ready
+	return resolved, nil
+}
+
+// ResolveMCPServers looks up each MCPServer in order. Every referenced MCPServer
+// must exist; a missing one is returned as an error.
+func ResolveMCPServers(ctx context.Context, c client.Reader, namespace string, names []string) ([]MCPServer, error) {
+	ctx, span := tracing.Start(ctx, "ResolveMCPServers", attribute.StringSlice("kaos.names", names), attribute.String("kaos.namespace", namespace))
+	defer span.End()
+
+	resolved := make([]MCPServer, 0, len(names))
+	for _, name := range names {
+		mcp := &kaosv1alpha1.MCPServer{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, mcp); err != nil {
+			return nil, fmt.Errorf("failed to get MCPServer %s: %w", name, err)
+		}
+		resolved = append(resolved, MCPServer{
+			Name:     name,
+			Object:   mcp,
+			Endpoint: mcp.Status.Endpoint,
+			Ready:    mcp.Status.Ready,
+		})
+	}
+	return resolved, nil
+}
+
+// ResolvePeers looks up each peer Agent in order. Peers that do not exist yet are
+// returned with Found=false rather than as an error, since agents in a network
+// may be created in any order.
+func ResolvePeers(ctx context.Context, c client.Reader, namespace string, names []string) ([]Peer, error) {
+	ctx, span := tracing.Start(ctx, "ResolvePeers", attribute.StringSlice("kaos.names", names), attribute.String("kaos.namespace", namespace))
+	defer span.End()
+
+	resolved := make([]Peer, 0, len(names))
+	for _, name := range names {
+		peer := &kaosv1alpha1.Agent{}
+		err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, peer)
+		if apierrors.IsNotFound(err) {
+			resolved = append(resolved, Peer{Name: name})
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get peer Agent %s: %w", name, err)
+		}
+		resolved = append(resolved, Peer{
+			Name:     name,
+			Found:    true,
+			Endpoint: peer.Status.Endpoint,
+			Ready:    peer.Status.Ready,
+		})
+	}
+	return resolved, nil
+}
+
+// MCPServerEndpoints returns the endpoints of the resolved MCPServers keyed by name
+func MCPServerEndpoints(servers []MCPServer) map[string]string {
+	endpoints := make(map[string]string, len(servers))
+	for _, server := range servers {
+		endpoints[server.Name] = server.Endpoint
+	}
+	return endpoints
+}
+
+// PeerEndpoints returns the endpoints of the resolved peers keyed by name,
+// skipping peers that are missing or have not published an endpoint yet
+func PeerEndpoints(peers []Peer) map[string]string {
+	endpoints := make(map[string]string, len(peers))
+	for _, peer := range peers {
+		if peer.Found && peer.Endpoint != "" {
+			endpoints[peer.Name] = peer.Endpoint
+		}
+	}
+	return endpoints
+}
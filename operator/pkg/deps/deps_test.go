@@ -0,0 +1,162 @@
+package deps
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+const testNamespace = "default"
+
+func newFakeClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := kaosv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func testMeta(name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: testNamespace}
+}
+
+func TestResolveModelAPI(t *testing.T) {
+	c := newFakeClient(t,
+		&kaosv1alpha1.ModelAPI{
+			ObjectMeta: testMeta("ready"),
+			Status:     kaosv1alpha1.ModelAPIStatus{Ready: true, Endpoint: "http://modelapi-ready:8000"},
+		},
+		&kaosv1alpha1.ModelAPI{
+			ObjectMeta: testMeta("unready"),
+			Status:     kaosv1alpha1.ModelAPIStatus{Ready: false, Endpoint: "http://modelapi-unready:8000"},
+		},
+	)
+
+	tests := []struct {
+		name         string
+		expectFound  bool
+		expectReady  bool
+		expectTarget string
+	}{
+		{name: "ready", expectFound: true, expectReady: true, expectTarget: "http://modelapi-ready:8000"},
+		{name: "unready", expectFound: true, expectReady: false, expectTarget: "http://modelapi-unready:8000"},
+		{name: "missing", expectFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := ResolveModelAPI(context.Background(), c, testNamespace, tt.name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resolved.Name != tt.name {
+				t.Errorf("expected name %q, got %q", tt.name, resolved.Name)
+			}
+			if resolved.Found() != tt.expectFound {
+				t.Errorf("expected found=%v, got %v", tt.expectFound, resolved.Found())
+			}
+			if resolved.Ready != tt.expectReady {
+				t.Errorf("expected ready=%v, got %v", tt.expectReady, resolved.Ready)
+			}
+			if resolved.Endpoint != tt.expectTarget {
+				t.Errorf("expected endpoint %q, got %q", tt.expectTarget, resolved.Endpoint)
+			}
+		})
+	}
+}
+
+func TestResolveMCPServers(t *testing.T) {
+	c := newFakeClient(t,
+		&kaosv1alpha1.MCPServer{
+			ObjectMeta: testMeta("tools"),
+			Status:     kaosv1alpha1.MCPServerStatus{Ready: true, Endpoint: "http://mcpserver-tools:8000"},
+		},
+		&kaosv1alpha1.MCPServer{
+			ObjectMeta: testMeta("search"),
+			Status:     kaosv1alpha1.MCPServerStatus{Ready: false},
+		},
+	)
+
+	t.Run("ready and unready servers are returned in order", func(t *testing.T) {
+		resolved, err := ResolveMCPServers(context.Background(), c, testNamespace, []string{"tools", "search"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resolved) != 2 {
+			t.Fatalf("expected 2 servers, got %d", len(resolved))
+		}
+		if resolved[0].Name != "tools" || !resolved[0].Ready || resolved[0].Endpoint != "http://mcpserver-tools:8000" {
+			t.Errorf("unexpected first server: %+v", resolved[0])
+		}
+		if resolved[1].Name != "search" || resolved[1].Ready {
+			t.Errorf("unexpected second server: %+v", resolved[1])
+		}
+
+		endpoints := MCPServerEndpoints(resolved)
+		if endpoints["tools"] != "http://mcpserver-tools:8000" {
+			t.Errorf("expected tools endpoint, got %q", endpoints["tools"])
+		}
+		if _, ok := endpoints["search"]; !ok {
+			t.Error("expected search to be present in endpoints")
+		}
+	})
+
+	t.Run("missing server is an error", func(t *testing.T) {
+		_, err := ResolveMCPServers(context.Background(), c, testNamespace, []string{"tools", "missing"})
+		if err == nil {
+			t.Fatal("expected error for missing MCPServer")
+		}
+	})
+
+	t.Run("no references", func(t *testing.T) {
+		resolved, err := ResolveMCPServers(context.Background(), c, testNamespace, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(resolved) != 0 {
+			t.Errorf("expected no servers, got %d", len(resolved))
+		}
+	})
+}
+
+func TestResolvePeers(t *testing.T) {
+	c := newFakeClient(t,
+		&kaosv1alpha1.Agent{
+			ObjectMeta: testMeta("worker-1"),
+			Status:     kaosv1alpha1.AgentStatus{Ready: true, Endpoint: "http://agent-worker-1:8000"},
+		},
+		&kaosv1alpha1.Agent{
+			ObjectMeta: testMeta("worker-2"),
+			Status:     kaosv1alpha1.AgentStatus{Ready: false},
+		},
+	)
+
+	peers, err := ResolvePeers(context.Background(), c, testNamespace, []string{"worker-1", "worker-2", "worker-3"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(peers) != 3 {
+		t.Fatalf("expected 3 peers, got %d", len(peers))
+	}
+	if !peers[0].Found || !peers[0].Ready {
+		t.Errorf("expected worker-1 to be found and ready: %+v", peers[0])
+	}
+	if !peers[1].Found || peers[1].Ready {
+		t.Errorf("expected worker-2 to be found and not ready: %+v", peers[1])
+	}
+	if peers[2].Found {
+		t.Errorf("expected worker-3 to be missing: %+v", peers[2])
+	}
+
+	endpoints := PeerEndpoints(peers)
+	if len(endpoints) != 1 || endpoints["worker-1"] != "http://agent-worker-1:8000" {
+		t.Errorf("expected only worker-1 endpoint, got %v", endpoints)
+	}
+}
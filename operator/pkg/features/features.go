@@ -0,0 +1,163 @@
+// Package features provides feature gates that turn experimental operator behaviour on
+// or off at startup, e.g. --feature-gates=SomeFeature=true,OtherFeature=false
+package features
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Feature is the name of a feature gate
+type Feature string
+
+const (
+	// WarmStandby rolls Hosted ModelAPIs with hostedConfig.warmStandby through a standby
+	// Deployment; when off, the field is ignored and the Deployment is updated in place
+	WarmStandby Feature = "WarmStandby"
+
+	// AgentTrafficSplit weights an Agent's HTTPRoute across the Services in its
+	// spec.trafficSplit; when off, the route only targets the Agent's own Service
+	AgentTrafficSplit Feature = "AgentTrafficSplit"
+
+	// NormalizedPodSpecHash hashes pod specs with defaulted fields cleared and
+	// order-insensitive lists sorted; enabling it rolls every Deployment once, as the
+	// stored hashes change
+	NormalizedPodSpecHash Feature = "NormalizedPodSpecHash"
+)
+
+// defaultFeatures lists the features known to the operator with their default value.
+// New experimental features are added here disabled and checked with Enabled.
+var defaultFeatures = map[Feature]bool{
+	WarmStandby:           false,
+	AgentTrafficSplit:     false,
+	NormalizedPodSpecHash: false,
+}
+
+// DefaultGate is the operator's feature gate, set from FEATURE_GATES and --feature-gates
+var DefaultGate = newDefaultGate()
+
+// Enabled reports whether feature is enabled on DefaultGate
+func Enabled(feature Feature) bool {
+	return DefaultGate.Enabled(feature)
+}
+
+// GetFeatureGates returns the feature gates from the FEATURE_GATES env var, in
+// --feature-gates format. Empty if not set.
+func GetFeatureGates() string {
+	return os.Getenv("FEATURE_GATES")
+}
+
+// Gate holds the registered features and the values they were set to. It implements
+// flag.Value so it can be bound to --feature-gates directly.
+type Gate struct {
+	mu       sync.RWMutex
+	defaults map[Feature]bool
+	values   map[Feature]bool
+}
+
+// NewGate returns a Gate with no registered features
+func NewGate() *Gate {
+	return &Gate{defaults: map[Feature]bool{}, values: map[Feature]bool{}}
+}
+
+// newDefaultGate returns a Gate with defaultFeatures registered
+func newDefaultGate() *Gate {
+	gate := NewGate()
+	for feature, enabled := range defaultFeatures {
+		if err := gate.Register(feature, enabled); err != nil {
+			panic(err)
+		}
+	}
+	return gate
+}
+
+// Register adds feature to the gate with its default value. New features should
+// default to false until they are stable.
+func (g *Gate) Register(feature Feature, defaultEnabled bool) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.defaults[feature]; ok {
+		return fmt.Errorf("feature gate %s is already registered", feature)
+	}
+	g.defaults[feature] = defaultEnabled
+	return nil
+}
+
+// Enabled reports whether feature is enabled: its set value, else its default. Unknown
+// features are disabled.
+func (g *Gate) Enabled(feature Feature) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if enabled, ok := g.values[feature]; ok {
+		return enabled
+	}
+	return g.defaults[feature]
+}
+
+// Set parses a comma-separated list of Feature=bool pairs and applies them on top of
+// the values already set. Unknown features and invalid values are errors, in which
+// case nothing is applied.
+func (g *Gate) Set(value string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	parsed := map[Feature]bool{}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, raw, found := strings.Cut(pair, "=")
+		if !found {
+			return fmt.Errorf("feature gate %q must be in the form Feature=true|false", pair)
+		}
+		feature := Feature(strings.TrimSpace(name))
+		if _, ok := g.defaults[feature]; !ok {
+			return fmt.Errorf("unknown feature gate %s, known gates are: %s", feature, strings.Join(g.knownLocked(), ", "))
+		}
+		enabled, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("invalid value %q for feature gate %s: must be true or false", raw, feature)
+		}
+		parsed[feature] = enabled
+	}
+
+	for feature, enabled := range parsed {
+		g.values[feature] = enabled
+	}
+	return nil
+}
+
+// String returns the features that were set, sorted by name, in --feature-gates format
+func (g *Gate) String() string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	pairs := make([]string, 0, len(g.values))
+	for feature, enabled := range g.values {
+		pairs = append(pairs, fmt.Sprintf("%s=%t", feature, enabled))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Known returns the registered features with their defaults, sorted by name, for help
+// text, e.g. "SomeFeature=true|false (default false)"
+func (g *Gate) Known() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.knownLocked()
+}
+
+// knownLocked is Known for callers that already hold the lock
+func (g *Gate) knownLocked() []string {
+	known := make([]string, 0, len(g.defaults))
+	for feature, enabled := range g.defaults {
+		known = append(known, fmt.Sprintf("%s=true|false (default %t)", feature, enabled))
+	}
+	sort.Strings(known)
+	return known
+}
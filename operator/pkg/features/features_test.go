@@ -0,0 +1,130 @@
+package features
+
+import "testing"
+
+const (
+	alpha Feature = "Alpha"
+	beta  Feature = "Beta"
+)
+
+// newTestGate returns a gate with alpha off and beta on by default
+func newTestGate(t *testing.T) *Gate {
+	t.Helper()
+	gate := NewGate()
+	if err := gate.Register(alpha, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := gate.Register(beta, true); err != nil {
+		t.Fatal(err)
+	}
+	return gate
+}
+
+func TestGateDefaults(t *testing.T) {
+	gate := newTestGate(t)
+	if gate.Enabled(alpha) {
+		t.Error("expected Alpha to default to off")
+	}
+	if !gate.Enabled(beta) {
+		t.Error("expected Beta to default to on")
+	}
+	if gate.Enabled("Unknown") {
+		t.Error("expected an unknown feature to be disabled")
+	}
+	if got := gate.String(); got != "" {
+		t.Errorf("expected no features set, got %q", got)
+	}
+}
+
+func TestGateSet(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		wantErr   bool
+		wantAlpha bool
+		wantBeta  bool
+	}{
+		{name: "empty", value: "", wantBeta: true},
+		{name: "enable", value: "Alpha=true", wantAlpha: true, wantBeta: true},
+		{name: "both", value: "Alpha=true,Beta=false", wantAlpha: true},
+		{name: "spaces and trailing comma", value: " Alpha = 1 , Beta=false,", wantAlpha: true},
+		{name: "last value wins", value: "Alpha=true,Alpha=false", wantBeta: true},
+		{name: "unknown feature", value: "Alpha=true,Gamma=true", wantErr: true, wantBeta: true},
+		{name: "invalid value", value: "Alpha=yes", wantErr: true, wantBeta: true},
+		{name: "missing value", value: "Alpha", wantErr: true, wantBeta: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gate := newTestGate(t)
+			err := gate.Set(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Set(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got := gate.Enabled(alpha); got != tt.wantAlpha {
+				t.Errorf("Enabled(Alpha) = %v, want %v", got, tt.wantAlpha)
+			}
+			if got := gate.Enabled(beta); got != tt.wantBeta {
+				t.Errorf("Enabled(Beta) = %v, want %v", got, tt.wantBeta)
+			}
+		})
+	}
+}
+
+func TestGateSetMerges(t *testing.T) {
+	gate := newTestGate(t)
+	if err := gate.Set("Alpha=true"); err != nil {
+		t.Fatal(err)
+	}
+	if err := gate.Set("Beta=false"); err != nil {
+		t.Fatal(err)
+	}
+	if got := gate.String(); got != "Alpha=true,Beta=false" {
+		t.Errorf("String() = %q, want both features set", got)
+	}
+}
+
+func TestGateRegisterDuplicate(t *testing.T) {
+	gate := newTestGate(t)
+	if err := gate.Register(alpha, true); err == nil {
+		t.Error("expected an error registering Alpha twice")
+	}
+	if gate.Enabled(alpha) {
+		t.Error("expected the original default to be kept")
+	}
+}
+
+func TestGateKnown(t *testing.T) {
+	known := newTestGate(t).Known()
+	expected := []string{"Alpha=true|false (default false)", "Beta=true|false (default true)"}
+	if len(known) != len(expected) {
+		t.Fatalf("Known() = %v, want %v", known, expected)
+	}
+	for i := range expected {
+		if known[i] != expected[i] {
+			t.Errorf("Known()[%d] = %q, want %q", i, known[i], expected[i])
+		}
+	}
+}
+
+func TestGetFeatureGates(t *testing.T) {
+	t.Setenv("FEATURE_GATES", "Alpha=true")
+	if got := GetFeatureGates(); got != "Alpha=true" {
+		t.Errorf("GetFeatureGates() = %q, want Alpha=true", got)
+	}
+}
+
+func TestDefaultGateRegistersExperimentalFeaturesOff(t *testing.T) {
+	gate := newDefaultGate()
+	for _, feature := range []Feature{WarmStandby, AgentTrafficSplit, NormalizedPodSpecHash} {
+		if gate.Enabled(feature) {
+			t.Errorf("expected %s to default to off", feature)
+		}
+		if err := gate.Set(string(feature) + "=true"); err != nil {
+			t.Fatalf("expected %s to be registered: %v", feature, err)
+		}
+		if !gate.Enabled(feature) {
+			t.Errorf("expected %s to be enabled once set", feature)
+		}
+	}
+}
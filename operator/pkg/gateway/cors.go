@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"strings"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// CORSConfig holds the CORS response headers for an HTTPRoute
+type CORSConfig struct {
+	// AllowOrigins lists allowed origins, or a single "*" for any origin
+	AllowOrigins []string
+	// AllowMethods defaults to GET, POST and OPTIONS when empty
+	AllowMethods []string
+	// AllowHeaders defaults to Content-Type and Authorization when empty
+	AllowHeaders []string
+}
+
+var (
+	defaultCORSMethods = []string{"GET", "POST", "OPTIONS"}
+	defaultCORSHeaders = []string{"Content-Type", "Authorization"}
+)
+
+// corsRules returns the HTTPRoute rules for rule with CORS headers applied. The
+// standard ResponseHeaderModifier filter is used rather than the experimental CORS
+// filter, so routes are accepted by Gateways installed from the standard channel.
+//
+// Access-Control-Allow-Origin holds a single origin, so each allowed origin gets its
+// own copy of rule matching on the Origin request header, alongside the unmodified
+// rule for same-origin and non-browser clients. A "*" origin applies to every request.
+func corsRules(rule gatewayv1.HTTPRouteRule, cors *CORSConfig) []gatewayv1.HTTPRouteRule {
+	if cors == nil || len(cors.AllowOrigins) == 0 {
+		return []gatewayv1.HTTPRouteRule{rule}
+	}
+
+	if len(cors.AllowOrigins) == 1 && cors.AllowOrigins[0] == "*" {
+		return []gatewayv1.HTTPRouteRule{withCORSHeaders(rule, "*", cors)}
+	}
+
+	exact := gatewayv1.HeaderMatchExact
+	rules := make([]gatewayv1.HTTPRouteRule, 0, len(cors.AllowOrigins)+1)
+	for _, origin := range cors.AllowOrigins {
+		originRule := withCORSHeaders(rule, origin, cors)
+		originRule.Matches = make([]gatewayv1.HTTPRouteMatch, len(rule.Matches))
+		for i, match := range rule.Matches {
+			match.Headers = append(append([]gatewayv1.HTTPHeaderMatch{}, match.Headers...), gatewayv1.HTTPHeaderMatch{
+				Type:  &exact,
+				Name:  "Origin",
+				Value: origin,
+			})
+			originRule.Matches[i] = match
+		}
+		rules = append(rules, originRule)
+	}
+	return append(rules, rule)
+}
+
+// withCORSHeaders returns a copy of rule with a ResponseHeaderModifier filter setting
+// the CORS headers for origin
+func withCORSHeaders(rule gatewayv1.HTTPRouteRule, origin string, cors *CORSConfig) gatewayv1.HTTPRouteRule {
+	methods := cors.AllowMethods
+	if len(methods) == 0 {
+		methods = defaultCORSMethods
+	}
+	allowHeaders := cors.AllowHeaders
+	if len(allowHeaders) == 0 {
+		allowHeaders = defaultCORSHeaders
+	}
+
+	headers := []gatewayv1.HTTPHeader{
+		{Name: "Access-Control-Allow-Origin", Value: origin},
+		{Name: "Access-Control-Allow-Methods", Value: strings.Join(methods, ", ")},
+		{Name: "Access-Control-Allow-Headers", Value: strings.Join(allowHeaders, ", ")},
+	}
+	if origin != "*" {
+		// Responses differ per origin, so caches must key on it
+		headers = append(headers, gatewayv1.HTTPHeader{Name: "Vary", Value: "Origin"})
+	}
+
+	rule.Filters = append(append([]gatewayv1.HTTPRouteFilter{}, rule.Filters...), gatewayv1.HTTPRouteFilter{
+		Type:                   gatewayv1.HTTPRouteFilterResponseHeaderModifier,
+		ResponseHeaderModifier: &gatewayv1.HTTPHeaderFilter{Set: headers},
+	})
+	return rule
+}
@@ -0,0 +1,114 @@
+package gateway
+
+import (
+	"testing"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func corsRoute(cors *CORSConfig) *gatewayv1.HTTPRoute {
+	return constructHTTPRoute(HTTPRouteParams{
+		ResourceType: ResourceTypeAgent,
+		ResourceName: "agent",
+		Namespace:    "default",
+		ServiceName:  "agent-agent",
+		ServicePort:  8000,
+		CORS:         cors,
+	}, Config{GatewayName: "kaos-gateway", GatewayNamespace: "kaos-system"})
+}
+
+// corsHeaders returns the headers set by the rule's ResponseHeaderModifier filter
+func corsHeaders(t *testing.T, rule gatewayv1.HTTPRouteRule) map[string]string {
+	t.Helper()
+	for _, filter := range rule.Filters {
+		if filter.Type == gatewayv1.HTTPRouteFilterResponseHeaderModifier {
+			headers := map[string]string{}
+			for _, header := range filter.ResponseHeaderModifier.Set {
+				headers[string(header.Name)] = header.Value
+			}
+			return headers
+		}
+	}
+	return nil
+}
+
+func TestCORSDisabledByDefault(t *testing.T) {
+	route := corsRoute(nil)
+	if len(route.Spec.Rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(route.Spec.Rules))
+	}
+	if headers := corsHeaders(t, route.Spec.Rules[0]); headers != nil {
+		t.Errorf("expected no CORS headers, got %v", headers)
+	}
+}
+
+func TestCORSAnyOrigin(t *testing.T) {
+	route := corsRoute(&CORSConfig{AllowOrigins: []string{"*"}})
+	if len(route.Spec.Rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(route.Spec.Rules))
+	}
+	rule := route.Spec.Rules[0]
+	if len(rule.Matches[0].Headers) != 0 {
+		t.Errorf("expected no Origin match for *, got %+v", rule.Matches[0].Headers)
+	}
+
+	headers := corsHeaders(t, rule)
+	expected := map[string]string{
+		"Access-Control-Allow-Origin":  "*",
+		"Access-Control-Allow-Methods": "GET, POST, OPTIONS",
+		"Access-Control-Allow-Headers": "Content-Type, Authorization",
+	}
+	for name, value := range expected {
+		if headers[name] != value {
+			t.Errorf("expected %s: %q, got %q", name, value, headers[name])
+		}
+	}
+	if _, ok := headers["Vary"]; ok {
+		t.Errorf("expected no Vary header for *")
+	}
+	// The path rewrite is kept alongside the CORS filter
+	if rule.Filters[0].Type != gatewayv1.HTTPRouteFilterURLRewrite {
+		t.Errorf("expected the URL rewrite filter first, got %s", rule.Filters[0].Type)
+	}
+}
+
+func TestCORSPerOriginRules(t *testing.T) {
+	route := corsRoute(&CORSConfig{
+		AllowOrigins: []string{"https://app.example.com", "http://localhost:3000"},
+		AllowMethods: []string{"POST"},
+		AllowHeaders: []string{"Content-Type", "X-Session-Id"},
+	})
+
+	rules := route.Spec.Rules
+	if len(rules) != 3 {
+		t.Fatalf("expected a rule per origin plus the default rule, got %d", len(rules))
+	}
+	for i, origin := range []string{"https://app.example.com", "http://localhost:3000"} {
+		match := rules[i].Matches[0]
+		if len(match.Headers) != 1 || match.Headers[0].Name != "Origin" || match.Headers[0].Value != origin {
+			t.Errorf("rule %d: expected an Origin match for %s, got %+v", i, origin, match.Headers)
+		}
+		if *match.Path.Value != "/default/agent/agent" {
+			t.Errorf("rule %d: expected the route path to be kept, got %s", i, *match.Path.Value)
+		}
+
+		headers := corsHeaders(t, rules[i])
+		if headers["Access-Control-Allow-Origin"] != origin {
+			t.Errorf("rule %d: expected Access-Control-Allow-Origin %s, got %s", i, origin, headers["Access-Control-Allow-Origin"])
+		}
+		if headers["Access-Control-Allow-Methods"] != "POST" || headers["Access-Control-Allow-Headers"] != "Content-Type, X-Session-Id" {
+			t.Errorf("rule %d: unexpected headers %v", i, headers)
+		}
+		if headers["Vary"] != "Origin" {
+			t.Errorf("rule %d: expected Vary: Origin, got %q", i, headers["Vary"])
+		}
+		if len(rules[i].BackendRefs) != 1 || rules[i].BackendRefs[0].Name != "agent-agent" {
+			t.Errorf("rule %d: expected the route backend, got %+v", i, rules[i].BackendRefs)
+		}
+	}
+
+	// Requests from other origins (or without one) use the rule without CORS headers
+	if len(rules[2].Matches[0].Headers) != 0 || corsHeaders(t, rules[2]) != nil {
+		t.Errorf("expected an unmodified default rule, got %+v", rules[2])
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -12,8 +15,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/axsaucedo/kaos/operator/pkg/util"
 )
 
 // Config holds Gateway API configuration from environment
@@ -21,6 +25,14 @@ type Config struct {
 	Enabled          bool
 	GatewayName      string
 	GatewayNamespace string
+	// Scheme of external Gateway endpoints: "http", or "https" for TLS-terminating gateways
+	Scheme string
+	// Host clients outside the cluster use to reach the Gateway (e.g. "kaos.example.com");
+	// empty when unknown, in which case no external endpoints are reported
+	Host string
+	// SectionName binds routes to a single Gateway listener; empty binds to all
+	// compatible listeners
+	SectionName string
 	// Default timeouts for each resource type (Gateway API Duration format)
 	DefaultAgentTimeout    string
 	DefaultModelAPITimeout string
@@ -34,18 +46,32 @@ const (
 	defaultMCPTimeout      = "30s"  // Tool calls are typically fast
 )
 
+// defaultScheme is used for Gateway endpoints when GATEWAY_SCHEME is not set
+const defaultScheme = "http"
+
 // GetConfig reads Gateway API configuration from environment variables
 func GetConfig() Config {
 	return Config{
 		Enabled:                os.Getenv("GATEWAY_API_ENABLED") == "true",
 		GatewayName:            os.Getenv("GATEWAY_NAME"),
 		GatewayNamespace:       os.Getenv("GATEWAY_NAMESPACE"),
+		Scheme:                 strings.ToLower(getEnvOrDefault("GATEWAY_SCHEME", defaultScheme)),
+		Host:                   os.Getenv("GATEWAY_HOST"),
+		SectionName:            os.Getenv("GATEWAY_SECTION_NAME"),
 		DefaultAgentTimeout:    getEnvOrDefault("GATEWAY_DEFAULT_AGENT_TIMEOUT", defaultAgentTimeout),
 		DefaultModelAPITimeout: getEnvOrDefault("GATEWAY_DEFAULT_MODELAPI_TIMEOUT", defaultModelAPITimeout),
 		DefaultMCPTimeout:      getEnvOrDefault("GATEWAY_DEFAULT_MCP_TIMEOUT", defaultMCPTimeout),
 	}
 }
 
+// Validate checks the configuration values that cannot be enforced by the CRD schema
+func (c Config) Validate() error {
+	if c.Scheme != "http" && c.Scheme != "https" {
+		return fmt.Errorf("GATEWAY_SCHEME must be http or https, got %q", c.Scheme)
+	}
+	return nil
+}
+
 // getEnvOrDefault returns the value of an environment variable or a default value
 func getEnvOrDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -74,9 +100,60 @@ func HTTPRoutePath(namespace string, resourceType ResourceType, resourceName str
 	return fmt.Sprintf("/%s/%s/%s", namespace, resourceType, resourceName)
 }
 
-// GatewayEndpoint returns the external endpoint URL for a resource via Gateway
+// routePath returns the path prefix matched by the HTTPRoute of params
+func routePath(params HTTPRouteParams) string {
+	if params.PathPrefix != "" {
+		return params.PathPrefix
+	}
+	return HTTPRoutePath(params.Namespace, params.ResourceType, params.ResourceName)
+}
+
+// GatewayEndpoint returns the external endpoint URL for a resource via Gateway,
+// using the configured scheme (see GATEWAY_SCHEME)
 func GatewayEndpoint(gatewayHost string, namespace string, resourceType ResourceType, resourceName string) string {
-	return fmt.Sprintf("http://%s/%s/%s/%s", gatewayHost, namespace, resourceType, resourceName)
+	return fmt.Sprintf("%s://%s/%s/%s/%s", GetConfig().Scheme, gatewayHost, namespace, resourceType, resourceName)
+}
+
+// RouteEndpoint returns the external URL of the HTTPRoute for params through its first
+// Gateway with a known host, honouring a custom path prefix, or "" when Gateway API is
+// disabled or no host is known
+func RouteEndpoint(params HTTPRouteParams) string {
+	config := GetConfig()
+	if !config.Enabled {
+		return ""
+	}
+	for _, target := range gatewayTargets(params, config) {
+		if target.Host != "" {
+			return routeURL(config, target.Host, params)
+		}
+	}
+	return ""
+}
+
+// RouteEndpoints returns the external URL of the HTTPRoute for params through each of
+// its Gateways with a known host, keyed by Gateway name, or nil when Gateway API is
+// disabled or no host is known
+func RouteEndpoints(params HTTPRouteParams) map[string]string {
+	config := GetConfig()
+	if !config.Enabled {
+		return nil
+	}
+	var endpoints map[string]string
+	for _, target := range gatewayTargets(params, config) {
+		if target.Host == "" {
+			continue
+		}
+		if endpoints == nil {
+			endpoints = map[string]string{}
+		}
+		endpoints[target.Name] = routeURL(config, target.Host, params)
+	}
+	return endpoints
+}
+
+// routeURL returns the URL of the HTTPRoute for params on a Gateway reached at host
+func routeURL(config Config, host string, params HTTPRouteParams) string {
+	return fmt.Sprintf("%s://%s%s", config.Scheme, host, strings.TrimSuffix(routePath(params), "/"))
 }
 
 // HTTPRouteParams holds parameters for creating an HTTPRoute
@@ -87,9 +164,142 @@ type HTTPRouteParams struct {
 	ServiceName  string
 	ServicePort  int32
 	Labels       map[string]string
+	// PathPrefix overrides the path computed by HTTPRoutePath when set (e.g. "/chat")
+	PathPrefix string
+	// SectionName overrides the Gateway listener from Config.SectionName when set
+	SectionName string
+	// Gateways attaches the route to each of these Gateways instead of the configured one
+	Gateways []GatewayTarget
 	// Timeout is the request timeout for the HTTPRoute (Gateway API Duration format, e.g., "30s", "1m")
 	// If empty, a default timeout is applied based on resource type.
 	Timeout string
+	// Backends splits traffic by weight across Services (e.g. for canaries).
+	// If empty, all traffic goes to ServiceName/ServicePort.
+	Backends []WeightedBackend
+	// CORS adds CORS response headers to the route when set
+	CORS *CORSConfig
+	// RequestHeaders are set on every request before it is forwarded (e.g. x-tenant-id)
+	RequestHeaders map[string]string
+}
+
+// GatewayTarget is a Gateway an HTTPRoute attaches to
+type GatewayTarget struct {
+	Name string
+	// Namespace defaults to Config.GatewayNamespace when empty
+	Namespace string
+	// SectionName binds the route to a single listener; empty binds to all compatible ones
+	SectionName string
+	// Host clients use to reach the Gateway; defaults to Config.Host for the configured
+	// Gateway, and no endpoint is reported for the Gateway when empty
+	Host string
+}
+
+// gatewayTargets returns the Gateways the HTTPRoute for params attaches to, with
+// defaults from config applied: params.Gateways when set, otherwise the configured
+// Gateway
+func gatewayTargets(params HTTPRouteParams, config Config) []GatewayTarget {
+	if len(params.Gateways) == 0 {
+		sectionName := params.SectionName
+		if sectionName == "" {
+			sectionName = config.SectionName
+		}
+		return []GatewayTarget{{
+			Name:        config.GatewayName,
+			Namespace:   config.GatewayNamespace,
+			SectionName: sectionName,
+			Host:        config.Host,
+		}}
+	}
+
+	targets := make([]GatewayTarget, 0, len(params.Gateways))
+	for _, target := range params.Gateways {
+		if target.Namespace == "" {
+			target.Namespace = config.GatewayNamespace
+		}
+		if target.Host == "" && target.Name == config.GatewayName && target.Namespace == config.GatewayNamespace {
+			target.Host = config.Host
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// ValidateGateways checks that every Gateway target is named and that names are unique,
+// since endpoints are reported by Gateway name
+func ValidateGateways(targets []GatewayTarget) error {
+	seen := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		if target.Name == "" {
+			return fmt.Errorf("gateway name must not be empty")
+		}
+		if seen[target.Name] {
+			return fmt.Errorf("gateway %s is listed more than once", target.Name)
+		}
+		seen[target.Name] = true
+	}
+	return nil
+}
+
+// WeightedBackend is a Service receiving a weighted share of an HTTPRoute's traffic
+type WeightedBackend struct {
+	ServiceName string
+	ServicePort int32
+	Weight      int32
+}
+
+// maxBackendWeight is the largest weight the Gateway API accepts for a backendRef
+const maxBackendWeight = 1000000
+
+// ValidateWeights checks that backend weights are within the Gateway API range and that
+// at least one backend receives traffic (a route whose weights are all zero returns 500s)
+func ValidateWeights(backends []WeightedBackend) error {
+	var total int64
+	for _, backend := range backends {
+		if backend.Weight < 0 || backend.Weight > maxBackendWeight {
+			return fmt.Errorf("weight %d for backend %s must be between 0 and %d", backend.Weight, backend.ServiceName, maxBackendWeight)
+		}
+		total += int64(backend.Weight)
+	}
+	if len(backends) > 0 && total == 0 {
+		return fmt.Errorf("at least one backend must have a weight greater than 0")
+	}
+	return nil
+}
+
+// maxRequestHeaders is the most headers a Gateway API header filter can set
+const maxRequestHeaders = 16
+
+// headerNamePattern matches the request header names accepted on GatewayRoute
+var headerNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateRequestHeaders checks that request header names are valid HTTP header names
+// and that there are no more than the Gateway API allows in one filter
+func ValidateRequestHeaders(headers map[string]string) error {
+	if len(headers) > maxRequestHeaders {
+		return fmt.Errorf("at most %d request headers can be set, got %d", maxRequestHeaders, len(headers))
+	}
+	for name := range headers {
+		if !headerNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid request header name %q", name)
+		}
+	}
+	return nil
+}
+
+// requestHeaders returns headers as Gateway API headers, sorted by name so the
+// generated route is stable across reconciles
+func requestHeaders(headers map[string]string) []gatewayv1.HTTPHeader {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]gatewayv1.HTTPHeader, 0, len(names))
+	for _, name := range names {
+		result = append(result, gatewayv1.HTTPHeader{Name: gatewayv1.HTTPHeaderName(name), Value: headers[name]})
+	}
+	return result
 }
 
 // DefaultTimeout returns the default timeout for a resource type from config
@@ -110,9 +320,7 @@ func DefaultTimeout(resourceType ResourceType) string {
 // constructHTTPRoute creates an HTTPRoute for a resource (internal helper)
 func constructHTTPRoute(params HTTPRouteParams, config Config) *gatewayv1.HTTPRoute {
 	pathPrefix := gatewayv1.PathMatchPathPrefix
-	pathValue := HTTPRoutePath(params.Namespace, params.ResourceType, params.ResourceName)
-	port := gatewayv1.PortNumber(params.ServicePort)
-	gwNamespace := gatewayv1.Namespace(config.GatewayNamespace)
+	pathValue := routePath(params)
 
 	// URL rewrite to strip the path prefix
 	rewritePath := "/"
@@ -133,28 +341,25 @@ func constructHTTPRoute(params HTTPRouteParams, config Config) *gatewayv1.HTTPRo
 				},
 			},
 		},
-		Filters: []gatewayv1.HTTPRouteFilter{
-			{
-				Type: gatewayv1.HTTPRouteFilterURLRewrite,
-				URLRewrite: &gatewayv1.HTTPURLRewriteFilter{
-					Path: &gatewayv1.HTTPPathModifier{
-						Type:               gatewayv1.PrefixMatchHTTPPathModifier,
-						ReplacePrefixMatch: &rewritePath,
-					},
-				},
-			},
-		},
-		BackendRefs: []gatewayv1.HTTPBackendRef{
-			{
-				BackendRef: gatewayv1.BackendRef{
-					BackendObjectReference: gatewayv1.BackendObjectReference{
-						Name: gatewayv1.ObjectName(params.ServiceName),
-						Port: &port,
-					},
-				},
+		BackendRefs: constructBackendRefs(params),
+	}
+
+	// Stamp configured request headers, then strip the path prefix
+	if len(params.RequestHeaders) > 0 {
+		rule.Filters = append(rule.Filters, gatewayv1.HTTPRouteFilter{
+			Type:                  gatewayv1.HTTPRouteFilterRequestHeaderModifier,
+			RequestHeaderModifier: &gatewayv1.HTTPHeaderFilter{Set: requestHeaders(params.RequestHeaders)},
+		})
+	}
+	rule.Filters = append(rule.Filters, gatewayv1.HTTPRouteFilter{
+		Type: gatewayv1.HTTPRouteFilterURLRewrite,
+		URLRewrite: &gatewayv1.HTTPURLRewriteFilter{
+			Path: &gatewayv1.HTTPPathModifier{
+				Type:               gatewayv1.PrefixMatchHTTPPathModifier,
+				ReplacePrefixMatch: &rewritePath,
 			},
 		},
-	}
+	})
 
 	// Add timeout if not "0s" (which means use gateway default)
 	if timeout != "0s" && timeout != "" {
@@ -172,20 +377,84 @@ func constructHTTPRoute(params HTTPRouteParams, config Config) *gatewayv1.HTTPRo
 		},
 		Spec: gatewayv1.HTTPRouteSpec{
 			CommonRouteSpec: gatewayv1.CommonRouteSpec{
-				ParentRefs: []gatewayv1.ParentReference{
-					{
-						Name:      gatewayv1.ObjectName(config.GatewayName),
-						Namespace: &gwNamespace,
+				ParentRefs: constructParentRefs(params, config),
+			},
+			Rules: corsRules(rule, params.CORS),
+		},
+	}
+}
+
+// constructParentRefs returns a parentRef for each Gateway the route for params attaches to
+func constructParentRefs(params HTTPRouteParams, config Config) []gatewayv1.ParentReference {
+	targets := gatewayTargets(params, config)
+	refs := make([]gatewayv1.ParentReference, 0, len(targets))
+	for _, target := range targets {
+		ref := gatewayv1.ParentReference{Name: gatewayv1.ObjectName(target.Name)}
+		if target.Namespace != "" {
+			namespace := gatewayv1.Namespace(target.Namespace)
+			ref.Namespace = &namespace
+		}
+		if target.SectionName != "" {
+			section := gatewayv1.SectionName(target.SectionName)
+			ref.SectionName = &section
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// constructBackendRefs returns the weighted backendRefs for params.Backends, or a single
+// unweighted backendRef to ServiceName when no split is configured
+func constructBackendRefs(params HTTPRouteParams) []gatewayv1.HTTPBackendRef {
+	if len(params.Backends) == 0 {
+		port := gatewayv1.PortNumber(params.ServicePort)
+		return []gatewayv1.HTTPBackendRef{
+			{
+				BackendRef: gatewayv1.BackendRef{
+					BackendObjectReference: gatewayv1.BackendObjectReference{
+						Name: gatewayv1.ObjectName(params.ServiceName),
+						Port: &port,
 					},
 				},
 			},
-			Rules: []gatewayv1.HTTPRouteRule{rule},
-		},
+		}
 	}
+
+	refs := make([]gatewayv1.HTTPBackendRef, 0, len(params.Backends))
+	for _, backend := range params.Backends {
+		port := gatewayv1.PortNumber(backend.ServicePort)
+		weight := backend.Weight
+		refs = append(refs, gatewayv1.HTTPBackendRef{
+			BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: gatewayv1.BackendObjectReference{
+					Name: gatewayv1.ObjectName(backend.ServiceName),
+					Port: &port,
+				},
+				Weight: &weight,
+			},
+		})
+	}
+	return refs
+}
+
+// InvalidRouteError reports HTTPRoute parameters that fail validation (backend weights,
+// request headers or Gateway targets) or a path prefix another route already uses, which
+// fail the same way until the spec is fixed
+type InvalidRouteError struct {
+	Err error
+}
+
+func (e *InvalidRouteError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidRouteError) Unwrap() error {
+	return e.Err
 }
 
 // ReconcileHTTPRoute creates or updates an HTTPRoute for a resource.
 // This consolidates the common reconciliation logic used by all controllers.
+// Invalid parameters are returned as an InvalidRouteError.
 func ReconcileHTTPRoute(
 	ctx context.Context,
 	c client.Client,
@@ -199,13 +468,29 @@ func ReconcileHTTPRoute(
 		return nil
 	}
 
+	if err := ValidateWeights(params.Backends); err != nil {
+		return &InvalidRouteError{Err: err}
+	}
+	if err := ValidateRequestHeaders(params.RequestHeaders); err != nil {
+		return &InvalidRouteError{Err: err}
+	}
+	if err := ValidateGateways(params.Gateways); err != nil {
+		return &InvalidRouteError{Err: err}
+	}
+
 	httpRoute := constructHTTPRoute(params, config)
 
+	if params.PathPrefix != "" {
+		if err := checkPathPrefixConflict(ctx, c, httpRoute, params.PathPrefix); err != nil {
+			return err
+		}
+	}
+
 	existing := &gatewayv1.HTTPRoute{}
 	err := c.Get(ctx, types.NamespacedName{Name: httpRoute.Name, Namespace: httpRoute.Namespace}, existing)
 
 	if err != nil && apierrors.IsNotFound(err) {
-		if err := controllerutil.SetControllerReference(owner, httpRoute, scheme); err != nil {
+		if err := util.SetControllerReference(owner, httpRoute, scheme); err != nil {
 			return err
 		}
 		log.Info("Creating HTTPRoute", "name", httpRoute.Name)
@@ -217,3 +502,32 @@ func ReconcileHTTPRoute(
 	existing.Spec = httpRoute.Spec
 	return c.Update(ctx, existing)
 }
+
+// DeleteHTTPRoute deletes the HTTPRoute of a resource that no longer routes through the
+// Gateway. Only a route controlled by owner is deleted, so routes created by users or
+// other controllers under the same name are left in place.
+func DeleteHTTPRoute(
+	ctx context.Context,
+	c client.Client,
+	owner client.Object,
+	resourceType ResourceType,
+	resourceName string,
+	namespace string,
+	log logr.Logger,
+) error {
+	if !GetConfig().Enabled {
+		return nil
+	}
+
+	existing := &gatewayv1.HTTPRoute{}
+	err := c.Get(ctx, types.NamespacedName{Name: HTTPRouteName(resourceType, resourceName), Namespace: namespace}, existing)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(existing, owner) {
+		return nil
+	}
+
+	log.Info("Deleting HTTPRoute", "name", existing.Name)
+	return client.IgnoreNotFound(c.Delete(ctx, existing))
+}
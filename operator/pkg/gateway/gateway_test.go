@@ -0,0 +1,330 @@
+package gateway
+
+import (
+	"fmt"
+	"testing"
+
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestConstructHTTPRouteSingleBackend(t *testing.T) {
+	route := constructHTTPRoute(HTTPRouteParams{
+		ResourceType: ResourceTypeAgent,
+		ResourceName: "agent",
+		Namespace:    "default",
+		ServiceName:  "agent-agent",
+		ServicePort:  8000,
+	}, Config{GatewayName: "kaos-gateway", GatewayNamespace: "kaos-system"})
+
+	refs := route.Spec.Rules[0].BackendRefs
+	if len(refs) != 1 {
+		t.Fatalf("expected 1 backendRef, got %d", len(refs))
+	}
+	if refs[0].Name != "agent-agent" || *refs[0].Port != 8000 {
+		t.Errorf("unexpected backendRef %s:%d", refs[0].Name, *refs[0].Port)
+	}
+	if refs[0].Weight != nil {
+		t.Errorf("expected no weight without a split, got %d", *refs[0].Weight)
+	}
+}
+
+func TestConstructHTTPRouteWeightedSplit(t *testing.T) {
+	route := constructHTTPRoute(HTTPRouteParams{
+		ResourceType: ResourceTypeAgent,
+		ResourceName: "agent",
+		Namespace:    "default",
+		ServiceName:  "agent-agent",
+		ServicePort:  8000,
+		Backends: []WeightedBackend{
+			{ServiceName: "agent-agent", ServicePort: 8000, Weight: 90},
+			{ServiceName: "agent-agent-canary", ServicePort: 8000, Weight: 10},
+		},
+	}, Config{GatewayName: "kaos-gateway", GatewayNamespace: "kaos-system"})
+
+	if len(route.Spec.Rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(route.Spec.Rules))
+	}
+	refs := route.Spec.Rules[0].BackendRefs
+	if len(refs) != 2 {
+		t.Fatalf("expected 2 backendRefs, got %d", len(refs))
+	}
+
+	expected := map[string]int32{"agent-agent": 90, "agent-agent-canary": 10}
+	for _, ref := range refs {
+		if ref.Weight == nil {
+			t.Fatalf("expected weight on backendRef %s", ref.Name)
+		}
+		if *ref.Weight != expected[string(ref.Name)] {
+			t.Errorf("expected weight %d for %s, got %d", expected[string(ref.Name)], ref.Name, *ref.Weight)
+		}
+		if *ref.Port != 8000 {
+			t.Errorf("expected port 8000 for %s, got %d", ref.Name, *ref.Port)
+		}
+	}
+}
+
+func TestValidateWeights(t *testing.T) {
+	tests := []struct {
+		name     string
+		backends []WeightedBackend
+		wantErr  bool
+	}{
+		{name: "no split", backends: nil},
+		{name: "90/10", backends: []WeightedBackend{{ServiceName: "a", Weight: 90}, {ServiceName: "b", Weight: 10}}},
+		{name: "all traffic to one backend", backends: []WeightedBackend{{ServiceName: "a", Weight: 0}, {ServiceName: "b", Weight: 100}}},
+		{name: "all zero", backends: []WeightedBackend{{ServiceName: "a", Weight: 0}, {ServiceName: "b", Weight: 0}}, wantErr: true},
+		{name: "negative", backends: []WeightedBackend{{ServiceName: "a", Weight: 110}, {ServiceName: "b", Weight: -10}}, wantErr: true},
+		{name: "above gateway maximum", backends: []WeightedBackend{{ServiceName: "a", Weight: 1000001}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWeights(tt.backends)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWeights() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGatewayEndpointScheme(t *testing.T) {
+	t.Setenv("GATEWAY_SCHEME", "")
+	if got := GatewayEndpoint("gw.example.com", "prod", ResourceTypeAgent, "coordinator"); got != "http://gw.example.com/prod/agent/coordinator" {
+		t.Errorf("expected http endpoint by default, got %s", got)
+	}
+
+	t.Setenv("GATEWAY_SCHEME", "HTTPS")
+	if got := GatewayEndpoint("gw.example.com", "prod", ResourceTypeAgent, "coordinator"); got != "https://gw.example.com/prod/agent/coordinator" {
+		t.Errorf("expected https endpoint, got %s", got)
+	}
+}
+
+func TestRouteEndpoint(t *testing.T) {
+	params := HTTPRouteParams{ResourceType: ResourceTypeAgent, ResourceName: "coordinator", Namespace: "prod"}
+
+	t.Setenv("GATEWAY_API_ENABLED", "true")
+	t.Setenv("GATEWAY_HOST", "")
+	if got := RouteEndpoint(params); got != "" {
+		t.Errorf("expected no endpoint without GATEWAY_HOST, got %s", got)
+	}
+
+	t.Setenv("GATEWAY_HOST", "kaos.example.com")
+	t.Setenv("GATEWAY_SCHEME", "https")
+	if got := RouteEndpoint(params); got != "https://kaos.example.com/prod/agent/coordinator" {
+		t.Errorf("expected the computed route path, got %s", got)
+	}
+
+	params.PathPrefix = "/chat/"
+	if got := RouteEndpoint(params); got != "https://kaos.example.com/chat" {
+		t.Errorf("expected the custom path prefix, got %s", got)
+	}
+
+	t.Setenv("GATEWAY_API_ENABLED", "false")
+	if got := RouteEndpoint(params); got != "" {
+		t.Errorf("expected no endpoint with Gateway API disabled, got %s", got)
+	}
+}
+
+func TestConfigValidateScheme(t *testing.T) {
+	for scheme, wantErr := range map[string]bool{"http": false, "https": false, "ftp": true, "https://": true} {
+		err := Config{Scheme: scheme}.Validate()
+		if (err != nil) != wantErr {
+			t.Errorf("Validate() with scheme %q error = %v, wantErr %v", scheme, err, wantErr)
+		}
+	}
+}
+
+func TestConstructHTTPRouteRequestHeaders(t *testing.T) {
+	route := constructHTTPRoute(HTTPRouteParams{
+		ResourceType:   ResourceTypeAgent,
+		ResourceName:   "agent",
+		Namespace:      "default",
+		ServiceName:    "agent-agent",
+		ServicePort:    8000,
+		RequestHeaders: map[string]string{"x-tenant-id": "acme", "x-env": "prod"},
+	}, Config{GatewayName: "kaos-gateway", GatewayNamespace: "kaos-system"})
+
+	filters := route.Spec.Rules[0].Filters
+	if len(filters) != 2 {
+		t.Fatalf("expected 2 filters, got %d", len(filters))
+	}
+	if filters[0].Type != gatewayv1.HTTPRouteFilterRequestHeaderModifier || filters[1].Type != gatewayv1.HTTPRouteFilterURLRewrite {
+		t.Fatalf("expected the header filter before the URL rewrite, got %s, %s", filters[0].Type, filters[1].Type)
+	}
+
+	set := filters[0].RequestHeaderModifier.Set
+	expected := []gatewayv1.HTTPHeader{{Name: "x-env", Value: "prod"}, {Name: "x-tenant-id", Value: "acme"}}
+	if len(set) != len(expected) {
+		t.Fatalf("expected %d headers, got %d", len(expected), len(set))
+	}
+	for i := range expected {
+		if set[i] != expected[i] {
+			t.Errorf("expected header %d to be %s: %s, got %s: %s", i, expected[i].Name, expected[i].Value, set[i].Name, set[i].Value)
+		}
+	}
+}
+
+func TestConstructHTTPRouteWithoutRequestHeaders(t *testing.T) {
+	route := constructHTTPRoute(HTTPRouteParams{
+		ResourceType: ResourceTypeAgent,
+		ResourceName: "agent",
+		Namespace:    "default",
+		ServiceName:  "agent-agent",
+		ServicePort:  8000,
+	}, Config{GatewayName: "kaos-gateway", GatewayNamespace: "kaos-system"})
+
+	filters := route.Spec.Rules[0].Filters
+	if len(filters) != 1 || filters[0].Type != gatewayv1.HTTPRouteFilterURLRewrite {
+		t.Errorf("expected only the URL rewrite filter, got %+v", filters)
+	}
+}
+
+func TestValidateRequestHeaders(t *testing.T) {
+	tooMany := map[string]string{}
+	for i := 0; i < 17; i++ {
+		tooMany[fmt.Sprintf("x-header-%d", i)] = "v"
+	}
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		wantErr bool
+	}{
+		{name: "none"},
+		{name: "tenant", headers: map[string]string{"x-tenant-id": "acme", "X_Env": "prod"}},
+		{name: "space in name", headers: map[string]string{"x tenant": "acme"}, wantErr: true},
+		{name: "colon in name", headers: map[string]string{"x-tenant:": "acme"}, wantErr: true},
+		{name: "empty name", headers: map[string]string{"": "acme"}, wantErr: true},
+		{name: "too many", headers: tooMany, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRequestHeaders(tt.headers)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRequestHeaders() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConstructHTTPRouteSectionName(t *testing.T) {
+	params := HTTPRouteParams{
+		ResourceType: ResourceTypeAgent,
+		ResourceName: "agent",
+		Namespace:    "default",
+		ServiceName:  "agent-agent",
+		ServicePort:  8000,
+	}
+	config := Config{GatewayName: "kaos-gateway", GatewayNamespace: "kaos-system"}
+
+	// Unset binds to all compatible listeners
+	if ref := constructHTTPRoute(params, config).Spec.ParentRefs[0]; ref.SectionName != nil {
+		t.Errorf("expected no sectionName by default, got %s", *ref.SectionName)
+	}
+
+	config.SectionName = "https"
+	ref := constructHTTPRoute(params, config).Spec.ParentRefs[0]
+	if ref.SectionName == nil || *ref.SectionName != "https" {
+		t.Fatalf("expected sectionName https from config, got %v", ref.SectionName)
+	}
+	if ref.Name != "kaos-gateway" || *ref.Namespace != "kaos-system" {
+		t.Errorf("unexpected parentRef %s/%s", *ref.Namespace, ref.Name)
+	}
+
+	params.SectionName = "internal"
+	ref = constructHTTPRoute(params, config).Spec.ParentRefs[0]
+	if ref.SectionName == nil || *ref.SectionName != "internal" {
+		t.Errorf("expected the resource sectionName to override config, got %v", ref.SectionName)
+	}
+}
+
+func TestGetConfigSectionName(t *testing.T) {
+	t.Setenv("GATEWAY_SECTION_NAME", "https")
+	if got := GetConfig().SectionName; got != "https" {
+		t.Errorf("expected sectionName https, got %q", got)
+	}
+}
+
+func TestConstructHTTPRouteMultipleGateways(t *testing.T) {
+	params := HTTPRouteParams{
+		ResourceType: ResourceTypeAgent,
+		ResourceName: "agent",
+		Namespace:    "default",
+		ServiceName:  "agent-agent",
+		ServicePort:  8000,
+		Gateways: []GatewayTarget{
+			{Name: "internal"},
+			{Name: "external", Namespace: "edge", SectionName: "https"},
+		},
+	}
+	config := Config{GatewayName: "kaos-gateway", GatewayNamespace: "kaos-system", SectionName: "http"}
+
+	refs := constructHTTPRoute(params, config).Spec.ParentRefs
+	if len(refs) != 2 {
+		t.Fatalf("expected a parentRef per gateway, got %d", len(refs))
+	}
+	if refs[0].Name != "internal" || *refs[0].Namespace != "kaos-system" || refs[0].SectionName != nil {
+		t.Errorf("expected internal gateway in the default namespace on all listeners, got %+v", refs[0])
+	}
+	if refs[1].Name != "external" || *refs[1].Namespace != "edge" || refs[1].SectionName == nil || *refs[1].SectionName != "https" {
+		t.Errorf("expected external gateway on its https listener, got %+v", refs[1])
+	}
+}
+
+func TestRouteEndpoints(t *testing.T) {
+	t.Setenv("GATEWAY_API_ENABLED", "true")
+	t.Setenv("GATEWAY_NAME", "kaos-gateway")
+	t.Setenv("GATEWAY_NAMESPACE", "kaos-system")
+	t.Setenv("GATEWAY_HOST", "kaos.example.com")
+	t.Setenv("GATEWAY_SCHEME", "https")
+	params := HTTPRouteParams{ResourceType: ResourceTypeAgent, ResourceName: "coordinator", Namespace: "prod"}
+
+	expected := map[string]string{"kaos-gateway": "https://kaos.example.com/prod/agent/coordinator"}
+	if got := RouteEndpoints(params); len(got) != 1 || got["kaos-gateway"] != expected["kaos-gateway"] {
+		t.Errorf("expected the configured gateway endpoint, got %v", got)
+	}
+
+	params.Gateways = []GatewayTarget{
+		{Name: "kaos-gateway"},
+		{Name: "internal", Host: "kaos.internal"},
+		{Name: "unreachable"},
+	}
+	got := RouteEndpoints(params)
+	expected["internal"] = "https://kaos.internal/prod/agent/coordinator"
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d endpoints, got %v", len(expected), got)
+	}
+	for name, endpoint := range expected {
+		if got[name] != endpoint {
+			t.Errorf("expected endpoint %s for %s, got %s", endpoint, name, got[name])
+		}
+	}
+
+	params.Gateways = []GatewayTarget{{Name: "unreachable"}, {Name: "internal", Host: "kaos.internal"}}
+	if got := RouteEndpoint(params); got != "https://kaos.internal/prod/agent/coordinator" {
+		t.Errorf("expected the first gateway with a host, got %s", got)
+	}
+}
+
+func TestValidateGateways(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []GatewayTarget
+		wantErr bool
+	}{
+		{name: "none"},
+		{name: "internal and external", targets: []GatewayTarget{{Name: "internal"}, {Name: "external", Namespace: "edge"}}},
+		{name: "empty name", targets: []GatewayTarget{{Namespace: "edge"}}, wantErr: true},
+		{name: "duplicate name", targets: []GatewayTarget{{Name: "gw"}, {Name: "gw", Namespace: "edge"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGateways(tt.targets)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateGateways() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
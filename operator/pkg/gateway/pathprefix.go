@@ -0,0 +1,85 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// operatorRouteSelector matches the HTTPRoutes created by the operator, which carry the
+// app label of the resource they serve
+func operatorRouteSelector() labels.Selector {
+	requirement, _ := labels.NewRequirement("app", selection.In, []string{"agent", "modelapi", "mcpserver"})
+	return labels.NewSelector().Add(*requirement)
+}
+
+// checkPathPrefixConflict returns an InvalidRouteError if another operator-created
+// HTTPRoute attached to the same Gateway as route already matches pathPrefix. Routes
+// created by users or other controllers are not checked. The check is best-effort:
+// routes outside the operator's cache are not seen, and two resources reconciled at the
+// same time can both claim a prefix.
+func checkPathPrefixConflict(ctx context.Context, c client.Client, route *gatewayv1.HTTPRoute, pathPrefix string) error {
+	routes := &gatewayv1.HTTPRouteList{}
+	if err := c.List(ctx, routes, client.MatchingLabelsSelector{Selector: operatorRouteSelector()}); err != nil {
+		return err
+	}
+
+	for i := range routes.Items {
+		other := &routes.Items[i]
+		if other.Namespace == route.Namespace && other.Name == route.Name {
+			continue
+		}
+		if !sharesParent(route, other) {
+			continue
+		}
+		if matchesPathPrefix(other, pathPrefix) {
+			return &InvalidRouteError{Err: fmt.Errorf("path prefix %q is already used by HTTPRoute %s/%s", pathPrefix, other.Namespace, other.Name)}
+		}
+	}
+	return nil
+}
+
+// sharesParent reports whether both routes attach to a common Gateway
+func sharesParent(route, other *gatewayv1.HTTPRoute) bool {
+	for _, a := range route.Spec.ParentRefs {
+		for _, b := range other.Spec.ParentRefs {
+			if a.Name == b.Name && parentNamespace(a, route.Namespace) == parentNamespace(b, other.Namespace) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parentNamespace returns the namespace of a parentRef, which defaults to the route's own
+func parentNamespace(ref gatewayv1.ParentReference, routeNamespace string) string {
+	if ref.Namespace != nil {
+		return string(*ref.Namespace)
+	}
+	return routeNamespace
+}
+
+// matchesPathPrefix reports whether any rule of route matches the same path prefix,
+// ignoring a trailing slash
+func matchesPathPrefix(route *gatewayv1.HTTPRoute, pathPrefix string) bool {
+	want := strings.TrimSuffix(pathPrefix, "/")
+	for _, rule := range route.Spec.Rules {
+		for _, match := range rule.Matches {
+			if match.Path == nil || match.Path.Value == nil {
+				continue
+			}
+			if match.Path.Type != nil && *match.Path.Type != gatewayv1.PathMatchPathPrefix {
+				continue
+			}
+			if strings.TrimSuffix(*match.Path.Value, "/") == want {
+				return true
+			}
+		}
+	}
+	return false
+}
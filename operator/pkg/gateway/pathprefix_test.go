@@ -0,0 +1,122 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+func TestConstructHTTPRouteCustomPathPrefix(t *testing.T) {
+	route := constructHTTPRoute(HTTPRouteParams{
+		ResourceType: ResourceTypeAgent,
+		ResourceName: "support",
+		Namespace:    "prod",
+		ServiceName:  "agent-support",
+		ServicePort:  8000,
+		PathPrefix:   "/chat",
+	}, Config{GatewayName: "kaos-gateway", GatewayNamespace: "kaos-system"})
+
+	rule := route.Spec.Rules[0]
+	if got := *rule.Matches[0].Path.Value; got != "/chat" {
+		t.Errorf("expected path match /chat, got %s", got)
+	}
+	if *rule.Matches[0].Path.Type != gatewayv1.PathMatchPathPrefix {
+		t.Errorf("expected a PathPrefix match, got %s", *rule.Matches[0].Path.Type)
+	}
+
+	// The rewrite still strips whatever prefix matched
+	if len(rule.Filters) != 1 || rule.Filters[0].URLRewrite == nil {
+		t.Fatalf("expected a URL rewrite filter, got %+v", rule.Filters)
+	}
+	path := rule.Filters[0].URLRewrite.Path
+	if path.Type != gatewayv1.PrefixMatchHTTPPathModifier || *path.ReplacePrefixMatch != "/" {
+		t.Errorf("expected the matched prefix to be replaced with /, got %+v", path)
+	}
+}
+
+func TestConstructHTTPRouteDefaultPath(t *testing.T) {
+	route := constructHTTPRoute(HTTPRouteParams{
+		ResourceType: ResourceTypeAgent,
+		ResourceName: "support",
+		Namespace:    "prod",
+		ServiceName:  "agent-support",
+		ServicePort:  8000,
+	}, Config{GatewayName: "kaos-gateway", GatewayNamespace: "kaos-system"})
+
+	if got := *route.Spec.Rules[0].Matches[0].Path.Value; got != "/prod/agent/support" {
+		t.Errorf("expected computed path /prod/agent/support, got %s", got)
+	}
+}
+
+func TestReconcileHTTPRoutePathPrefixConflict(t *testing.T) {
+	t.Setenv("GATEWAY_API_ENABLED", "true")
+	t.Setenv("GATEWAY_NAME", "kaos-gateway")
+	t.Setenv("GATEWAY_NAMESPACE", "kaos-system")
+
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{corev1.AddToScheme, gatewayv1.Install} {
+		if err := add(scheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := context.Background()
+
+	reconcile := func(namespace, name, pathPrefix string) error {
+		owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID("uid-" + name)}}
+		return ReconcileHTTPRoute(ctx, c, scheme, owner, HTTPRouteParams{
+			ResourceType: ResourceTypeAgent,
+			ResourceName: name,
+			Namespace:    namespace,
+			ServiceName:  "agent-" + name,
+			ServicePort:  8000,
+			PathPrefix:   pathPrefix,
+			Labels:       map[string]string{"app": "agent", "agent": name},
+		}, logr.Discard())
+	}
+
+	if err := reconcile("team-a", "support", "/chat"); err != nil {
+		t.Fatalf("first route: %v", err)
+	}
+	// Reconciling the same resource again is not a conflict with itself
+	if err := reconcile("team-a", "support", "/chat"); err != nil {
+		t.Fatalf("re-reconcile: %v", err)
+	}
+
+	err := reconcile("team-b", "helpdesk", "/chat/")
+	var invalid *InvalidRouteError
+	if !errors.As(err, &invalid) || !strings.Contains(err.Error(), "team-a/agent-support") {
+		t.Errorf("expected an InvalidRouteError for a conflict with team-a/agent-support, got %v", err)
+	}
+
+	// Routes the operator did not create are not checked
+	pathType := gatewayv1.PathMatchPathPrefix
+	path := "/docs"
+	gatewayNamespace := gatewayv1.Namespace("kaos-system")
+	userRoute := &gatewayv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "docs", Namespace: "team-c"},
+		Spec: gatewayv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayv1.CommonRouteSpec{ParentRefs: []gatewayv1.ParentReference{{Name: "kaos-gateway", Namespace: &gatewayNamespace}}},
+			Rules:           []gatewayv1.HTTPRouteRule{{Matches: []gatewayv1.HTTPRouteMatch{{Path: &gatewayv1.HTTPPathMatch{Type: &pathType, Value: &path}}}}},
+		},
+	}
+	if err := c.Create(ctx, userRoute); err != nil {
+		t.Fatal(err)
+	}
+	if err := reconcile("team-b", "docs", "/docs"); err != nil {
+		t.Errorf("expected a prefix held by a user route to be accepted, got %v", err)
+	}
+
+	if err := reconcile("team-b", "helpdesk", "/help"); err != nil {
+		t.Errorf("expected a distinct prefix to be accepted, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package mcpclient
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// event is a server-sent event
+type event struct {
+	name string
+	data string
+}
+
+// eventReader reads server-sent events from a stream
+type eventReader struct {
+	scanner *bufio.Scanner
+}
+
+func newEventReader(r io.Reader) *eventReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	return &eventReader{scanner: scanner}
+}
+
+// next returns the next event with data, or io.EOF once the stream ends
+func (r *eventReader) next() (event, error) {
+	var current event
+	var data []string
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+		if line == "" {
+			if len(data) > 0 {
+				current.data = strings.Join(data, "\n")
+				return current, nil
+			}
+			current = event{}
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			current.name = value
+		case "data":
+			data = append(data, value)
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return event{}, err
+	}
+	// A final event without a trailing blank line still counts
+	if len(data) > 0 {
+		current.data = strings.Join(data, "\n")
+		return current, nil
+	}
+	return event{}, io.EOF
+}
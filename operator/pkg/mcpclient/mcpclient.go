@@ -0,0 +1,366 @@
+// Package mcpclient is a minimal MCP client for calling tools on an MCPServer, used by
+// the kaos CLI and by readiness checks. It supports the streamable HTTP transport
+// (POST /mcp) and the legacy SSE transport (GET /sse plus a POST message endpoint).
+package mcpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/client"
+)
+
+// Transport is the MCP transport spoken by a server
+type Transport string
+
+const (
+	// TransportStreamableHTTP posts JSON-RPC messages to /mcp
+	TransportStreamableHTTP Transport = "streamable-http"
+	// TransportSSE reads responses from the /sse event stream
+	TransportSSE Transport = "sse"
+)
+
+// protocolVersion is the MCP protocol version requested in the handshake
+const protocolVersion = "2025-03-26"
+
+// mcpServerPort is the port the MCPServer Service listens on
+const mcpServerPort = 8000
+
+// Options configures a Session
+type Options struct {
+	// Transport defaults to TransportStreamableHTTP
+	Transport Transport
+	// HTTPClient defaults to a client with a 60s timeout for streamable HTTP, and
+	// without a timeout for SSE since the event stream stays open
+	HTTPClient *http.Client
+}
+
+// Tool is a tool advertised by a server
+type Tool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	InputSchema json.RawMessage `json:"inputSchema,omitempty"`
+}
+
+// Content is an item of a tool result
+type Content struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+}
+
+// ToolResult is the result of a tool call
+type ToolResult struct {
+	Content           []Content       `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent,omitempty"`
+	IsError           bool            `json:"isError,omitempty"`
+}
+
+// Text joins the text content of the result
+func (r *ToolResult) Text() string {
+	var parts []string
+	for _, content := range r.Content {
+		if content.Type == "text" {
+			parts = append(parts, content.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      *int   `json:"id,omitempty"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	ID     *int            `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// Session is an initialized connection to an MCP server. It is not safe for
+// concurrent use.
+type Session struct {
+	transport  Transport
+	httpClient *http.Client
+	nextID     int
+
+	// streamable HTTP
+	url       string
+	sessionID string
+	version   string
+
+	// SSE
+	messageURL string
+	stream     io.ReadCloser
+	events     *eventReader
+}
+
+// ResolveEndpoint returns the base URL of the named MCPServer. In-cluster callers get
+// the Service endpoint; otherwise the Service is port-forwarded and the caller must
+// Close the endpoint once done.
+func ResolveEndpoint(ctx context.Context, c ctrlclient.Reader, namespace, name string, inCluster bool, opts client.ResolveOptions) (*client.Endpoint, error) {
+	mcpserver := &kaosv1alpha1.MCPServer{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, mcpserver); err != nil {
+		return nil, fmt.Errorf("failed to get MCPServer %s/%s: %w", namespace, name, err)
+	}
+	if mcpserver.Status.Endpoint == "" {
+		return nil, fmt.Errorf("MCPServer %s/%s has no endpoint yet", namespace, name)
+	}
+	if inCluster {
+		return &client.Endpoint{URL: mcpserver.Status.Endpoint}, nil
+	}
+	return client.ForwardService(ctx, namespace, fmt.Sprintf("mcpserver-%s", name), mcpServerPort, opts)
+}
+
+// Connect opens a session to the MCP server at baseURL and performs the initialize
+// handshake
+func Connect(ctx context.Context, baseURL string, opts Options) (*Session, error) {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	s := &Session{transport: opts.Transport, httpClient: opts.HTTPClient}
+	if s.transport == "" {
+		s.transport = TransportStreamableHTTP
+	}
+
+	switch s.transport {
+	case TransportStreamableHTTP:
+		if s.httpClient == nil {
+			s.httpClient = &http.Client{Timeout: 60 * time.Second}
+		}
+		s.url = baseURL
+		if !strings.HasSuffix(s.url, "/mcp") {
+			s.url += "/mcp"
+		}
+	case TransportSSE:
+		if s.httpClient == nil {
+			s.httpClient = &http.Client{}
+		}
+		if err := s.openStream(ctx, baseURL); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported MCP transport %q", s.transport)
+	}
+
+	result, err := s.call(ctx, "initialize", map[string]any{
+		"protocolVersion": protocolVersion,
+		"capabilities":    map[string]any{},
+		"clientInfo":      map[string]any{"name": "kaos", "version": "1.0.0"},
+	})
+	if err != nil {
+		s.Close()
+		return nil, fmt.Errorf("MCP initialize failed: %w", err)
+	}
+	var initialized struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if err := json.Unmarshal(result, &initialized); err == nil {
+		s.version = initialized.ProtocolVersion
+	}
+	if err := s.notify(ctx, "notifications/initialized"); err != nil {
+		s.Close()
+		return nil, fmt.Errorf("MCP initialize failed: %w", err)
+	}
+	return s, nil
+}
+
+// ListTools returns the tools advertised by the server
+func (s *Session) ListTools(ctx context.Context) ([]Tool, error) {
+	result, err := s.call(ctx, "tools/list", map[string]any{})
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Tools []Tool `json:"tools"`
+	}
+	if err := json.Unmarshal(result, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode tools/list result: %w", err)
+	}
+	return list.Tools, nil
+}
+
+// CallTool calls the named tool with args, a JSON object. A tool that fails reports it
+// through ToolResult.IsError rather than an error.
+func (s *Session) CallTool(ctx context.Context, name string, args json.RawMessage) (*ToolResult, error) {
+	if len(args) == 0 {
+		args = json.RawMessage("{}")
+	}
+	result, err := s.call(ctx, "tools/call", map[string]any{"name": name, "arguments": args})
+	if err != nil {
+		return nil, err
+	}
+	toolResult := &ToolResult{}
+	if err := json.Unmarshal(result, toolResult); err != nil {
+		return nil, fmt.Errorf("failed to decode tools/call result: %w", err)
+	}
+	return toolResult, nil
+}
+
+// Close ends the session
+func (s *Session) Close() {
+	if s.stream != nil {
+		_ = s.stream.Close()
+		s.stream = nil
+	}
+	if s.sessionID != "" {
+		req, err := http.NewRequest(http.MethodDelete, s.url, nil)
+		if err == nil {
+			req.Header.Set("Mcp-Session-Id", s.sessionID)
+			if resp, err := s.httpClient.Do(req); err == nil {
+				_ = resp.Body.Close()
+			}
+		}
+		s.sessionID = ""
+	}
+}
+
+// call sends a JSON-RPC request and returns its result
+func (s *Session) call(ctx context.Context, method string, params any) (json.RawMessage, error) {
+	s.nextID++
+	id := s.nextID
+	resp, err := s.send(ctx, rpcRequest{JSONRPC: "2.0", ID: &id, Method: method, Params: params})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("%s: MCP error %d: %s", method, resp.Error.Code, resp.Error.Message)
+	}
+	return resp.Result, nil
+}
+
+// notify sends a JSON-RPC notification, which has no response
+func (s *Session) notify(ctx context.Context, method string) error {
+	_, err := s.send(ctx, rpcRequest{JSONRPC: "2.0", Method: method})
+	return err
+}
+
+// send posts req and, for requests, waits for the response with the matching id
+func (s *Session) send(ctx context.Context, req rpcRequest) (*rpcResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	target := s.url
+	if s.transport == TransportSSE {
+		target = s.messageURL
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json, text/event-stream")
+	if s.sessionID != "" {
+		httpReq.Header.Set("Mcp-Session-Id", s.sessionID)
+	}
+	if s.version != "" {
+		httpReq.Header.Set("MCP-Protocol-Version", s.version)
+	}
+
+	httpResp, err := s.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", req.Method, err)
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(httpResp.Body, 4096))
+		return nil, fmt.Errorf("%s: HTTP %d: %s", req.Method, httpResp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	if id := httpResp.Header.Get("Mcp-Session-Id"); id != "" {
+		s.sessionID = id
+	}
+	if req.ID == nil {
+		return nil, nil
+	}
+
+	if s.transport == TransportSSE {
+		// The POST is only acknowledged; the response arrives on the event stream
+		return s.awaitResponse(*req.ID, s.events)
+	}
+	if strings.HasPrefix(httpResp.Header.Get("Content-Type"), "text/event-stream") {
+		return s.awaitResponse(*req.ID, newEventReader(httpResp.Body))
+	}
+	resp := &rpcResponse{}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, fmt.Errorf("%s: failed to decode response: %w", req.Method, err)
+	}
+	return resp, nil
+}
+
+// awaitResponse reads message events until the response with id, skipping server
+// notifications and requests
+func (s *Session) awaitResponse(id int, events *eventReader) (*rpcResponse, error) {
+	for {
+		event, err := events.next()
+		if err != nil {
+			return nil, fmt.Errorf("MCP event stream ended before response %d: %w", id, err)
+		}
+		if event.name != "" && event.name != "message" {
+			continue
+		}
+		resp := &rpcResponse{}
+		if err := json.Unmarshal([]byte(event.data), resp); err != nil || resp.ID == nil || *resp.ID != id {
+			continue
+		}
+		return resp, nil
+	}
+}
+
+// openStream opens the SSE stream and waits for the endpoint event naming the URL
+// messages are posted to
+func (s *Session) openStream(ctx context.Context, baseURL string) error {
+	streamURL := baseURL
+	if !strings.HasSuffix(streamURL, "/sse") {
+		streamURL += "/sse"
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, streamURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to open MCP event stream: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return fmt.Errorf("failed to open MCP event stream: HTTP %d", resp.StatusCode)
+	}
+	s.stream = resp.Body
+	s.events = newEventReader(resp.Body)
+
+	for {
+		event, err := s.events.next()
+		if err != nil {
+			s.Close()
+			return fmt.Errorf("MCP event stream ended before the endpoint event: %w", err)
+		}
+		if event.name != "endpoint" {
+			continue
+		}
+		endpoint, err := url.Parse(strings.TrimSpace(event.data))
+		if err != nil {
+			s.Close()
+			return fmt.Errorf("invalid MCP message endpoint %q: %w", event.data, err)
+		}
+		s.messageURL = resp.Request.URL.ResolveReference(endpoint).String()
+		return nil
+	}
+}
@@ -0,0 +1,212 @@
+package mcpclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+	"github.com/axsaucedo/kaos/operator/pkg/client"
+)
+
+// handle answers a JSON-RPC request the way an MCP server with an echo tool would;
+// it returns nil for notifications
+func handle(t *testing.T, body []byte) map[string]any {
+	t.Helper()
+	var req struct {
+		ID     *int   `json:"id"`
+		Method string `json:"method"`
+		Params struct {
+			Name      string         `json:"name"`
+			Arguments map[string]any `json:"arguments"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		t.Errorf("invalid JSON-RPC request %s: %v", body, err)
+		return nil
+	}
+	if req.ID == nil {
+		return nil
+	}
+	response := map[string]any{"jsonrpc": "2.0", "id": *req.ID}
+	switch req.Method {
+	case "initialize":
+		response["result"] = map[string]any{"protocolVersion": protocolVersion, "capabilities": map[string]any{"tools": map[string]any{}}}
+	case "tools/list":
+		response["result"] = map[string]any{"tools": []map[string]any{{"name": "echo", "description": "Echoes text"}}}
+	case "tools/call":
+		if req.Params.Name != "echo" {
+			response["error"] = map[string]any{"code": -32602, "message": "unknown tool " + req.Params.Name}
+			break
+		}
+		response["result"] = map[string]any{"content": []map[string]any{{"type": "text", "text": fmt.Sprint(req.Params.Arguments["text"])}}}
+	default:
+		response["error"] = map[string]any{"code": -32601, "message": "method not found"}
+	}
+	return response
+}
+
+// streamableServer is a stub MCP server on the streamable HTTP transport. Tool calls
+// are answered as an event stream, everything else as JSON.
+func streamableServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mcp" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			return
+		}
+		var body json.RawMessage
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		response := handle(t, body)
+		if strings.Contains(string(body), `"initialize"`) {
+			w.Header().Set("Mcp-Session-Id", "session-1")
+		} else if r.Header.Get("Mcp-Session-Id") != "session-1" {
+			http.Error(w, "missing session", http.StatusBadRequest)
+			return
+		}
+		if response == nil {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		encoded, _ := json.Marshal(response)
+		if strings.Contains(string(body), `"tools/call"`) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprintf(w, "event: message\ndata: {\"jsonrpc\":\"2.0\",\"method\":\"notifications/progress\"}\n\n")
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", encoded)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(encoded)
+	}))
+}
+
+// sseServer is a stub MCP server on the legacy SSE transport
+func sseServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	responses := make(chan []byte, 10)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/sse":
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "event: endpoint\ndata: /messages?session_id=abc\n\n")
+			w.(http.Flusher).Flush()
+			for {
+				select {
+				case encoded := <-responses:
+					fmt.Fprintf(w, "event: message\ndata: %s\n\n", encoded)
+					w.(http.Flusher).Flush()
+				case <-r.Context().Done():
+					return
+				}
+			}
+		case r.Method == http.MethodPost && r.URL.Path == "/messages":
+			if r.URL.Query().Get("session_id") != "abc" {
+				http.Error(w, "unknown session", http.StatusNotFound)
+				return
+			}
+			var body json.RawMessage
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if response := handle(t, body); response != nil {
+				encoded, _ := json.Marshal(response)
+				responses <- encoded
+			}
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestCallToolTransports(t *testing.T) {
+	for _, tc := range []struct {
+		transport Transport
+		server    func(*testing.T) *httptest.Server
+	}{
+		{TransportStreamableHTTP, streamableServer},
+		{TransportSSE, sseServer},
+	} {
+		t.Run(string(tc.transport), func(t *testing.T) {
+			server := tc.server(t)
+			defer server.Close()
+			ctx := context.Background()
+
+			session, err := Connect(ctx, server.URL, Options{Transport: tc.transport})
+			if err != nil {
+				t.Fatalf("Connect() error = %v", err)
+			}
+			defer session.Close()
+
+			tools, err := session.ListTools(ctx)
+			if err != nil {
+				t.Fatalf("ListTools() error = %v", err)
+			}
+			if len(tools) != 1 || tools[0].Name != "echo" {
+				t.Errorf("expected the echo tool, got %+v", tools)
+			}
+
+			result, err := session.CallTool(ctx, "echo", json.RawMessage(`{"text":"hello"}`))
+			if err != nil {
+				t.Fatalf("CallTool() error = %v", err)
+			}
+			if result.IsError || result.Text() != "hello" {
+				t.Errorf("expected the echoed text, got %+v", result)
+			}
+
+			_, err = session.CallTool(ctx, "missing", nil)
+			if err == nil || !strings.Contains(err.Error(), "unknown tool missing") {
+				t.Errorf("expected the JSON-RPC error to be returned, got %v", err)
+			}
+		})
+	}
+}
+
+func TestConnectUnsupportedTransport(t *testing.T) {
+	if _, err := Connect(context.Background(), "http://localhost", Options{Transport: "stdio"}); err == nil {
+		t.Error("expected an error for an unsupported transport")
+	}
+}
+
+func TestResolveEndpoint(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kaosv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	mcpserver := &kaosv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "tools", Namespace: "prod"},
+		Status:     kaosv1alpha1.MCPServerStatus{Endpoint: "http://mcpserver-tools.prod.svc.cluster.local:8000"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcpserver).Build()
+	ctx := context.Background()
+
+	endpoint, err := ResolveEndpoint(ctx, c, "prod", "tools", true, client.ResolveOptions{})
+	if err != nil || endpoint.URL != mcpserver.Status.Endpoint {
+		t.Errorf("expected the Service endpoint in-cluster, got %+v, err = %v", endpoint, err)
+	}
+
+	var forwarded string
+	endpoint, err = ResolveEndpoint(ctx, c, "prod", "tools", false, client.ResolveOptions{
+		LocalPort: 18081,
+		PortForward: func(ctx context.Context, namespace, service string, localPort, remotePort int) (func(), error) {
+			forwarded = fmt.Sprintf("%s/%s %d:%d", namespace, service, localPort, remotePort)
+			return func() {}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("ResolveEndpoint() error = %v", err)
+	}
+	defer endpoint.Close()
+	if forwarded != "prod/mcpserver-tools 18081:8000" || endpoint.URL != "http://127.0.0.1:18081" {
+		t.Errorf("unexpected port-forward %q to %s", forwarded, endpoint.URL)
+	}
+}
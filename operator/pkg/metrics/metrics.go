@@ -0,0 +1,48 @@
+// Package metrics defines the operator's Prometheus metrics, served on the
+// controller-runtime metrics endpoint.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// ReconcileDuration tracks how long each Reconcile takes, by CR kind
+	ReconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kaos_reconcile_duration_seconds",
+			Help:    "Duration of KAOS reconcile loops in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"kind"},
+	)
+
+	// ReconcileRequeues counts reconciles that asked to be requeued (explicitly or by returning an error)
+	ReconcileRequeues = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kaos_reconcile_requeues_total",
+			Help: "Number of KAOS reconciles that resulted in a requeue",
+		},
+		[]string{"kind"},
+	)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(ReconcileDuration, ReconcileRequeues)
+}
+
+// ObserveReconcile runs a reconcile function, recording its duration and whether it
+// requeued under the given CR kind.
+func ObserveReconcile(kind string, reconcile func() (ctrl.Result, error)) (ctrl.Result, error) {
+	start := time.Now()
+	result, err := reconcile()
+	ReconcileDuration.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+	if err != nil || result.Requeue || result.RequeueAfter > 0 {
+		ReconcileRequeues.WithLabelValues(kind).Inc()
+	}
+	return result, err
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// sampleCount returns the number of observations recorded by the histogram for kind
+func sampleCount(t *testing.T, kind string) uint64 {
+	t.Helper()
+	metric := &dto.Metric{}
+	if err := ReconcileDuration.WithLabelValues(kind).(prometheus.Metric).Write(metric); err != nil {
+		t.Fatalf("failed to read histogram: %v", err)
+	}
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestObserveReconcile(t *testing.T) {
+	tests := []struct {
+		name          string
+		kind          string
+		result        ctrl.Result
+		err           error
+		expectRequeue float64
+	}{
+		{name: "successful reconcile", kind: "TestDone", expectRequeue: 0},
+		{name: "requeue after", kind: "TestRequeueAfter", result: ctrl.Result{RequeueAfter: time.Second}, expectRequeue: 1},
+		{name: "error requeues", kind: "TestError", err: errors.New("boom"), expectRequeue: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := sampleCount(t, tt.kind)
+
+			// Fake reconcile loop: run the wrapped reconcile once
+			result, err := ObserveReconcile(tt.kind, func() (ctrl.Result, error) {
+				return tt.result, tt.err
+			})
+
+			if result != tt.result || !errors.Is(err, tt.err) {
+				t.Errorf("expected result and error to be passed through, got %v, %v", result, err)
+			}
+			if got := sampleCount(t, tt.kind) - before; got != 1 {
+				t.Errorf("expected 1 histogram sample, got %d", got)
+			}
+			if got := testutil.ToFloat64(ReconcileRequeues.WithLabelValues(tt.kind)); got != tt.expectRequeue {
+				t.Errorf("expected %v requeues, got %v", tt.expectRequeue, got)
+			}
+		})
+	}
+}
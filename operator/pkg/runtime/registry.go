@@ -0,0 +1,132 @@
+// Package runtime loads the MCP runtime registry, the kaos-mcp-runtimes ConfigMap that
+// maps MCPServer spec.runtime names to container images, for the MCPServer controller
+// and the CLI's `system runtimes` command.
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/axsaucedo/kaos/operator/pkg/util"
+)
+
+const (
+	// ConfigMapName is the name of the registry ConfigMap in the shared resources namespace
+	ConfigMapName = "kaos-mcp-runtimes"
+	// RegistryKey is the ConfigMap data key holding the registry YAML
+	RegistryKey = "runtimes.yaml"
+	// CustomRuntime is the reserved runtime name for user-supplied images
+	CustomRuntime = "custom"
+)
+
+// envVarName matches a valid container environment variable name
+var envVarName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Config is a runtime definition from the registry
+type Config struct {
+	Type         string   `yaml:"type"`
+	Image        string   `yaml:"image"`
+	Description  string   `yaml:"description,omitempty"`
+	Command      []string `yaml:"command,omitempty"`
+	Args         []string `yaml:"args,omitempty"`
+	ParamsEnvVar string   `yaml:"paramsEnvVar,omitempty"`
+	Transport    string   `yaml:"transport,omitempty"`
+	RequiredEnv  []string `yaml:"requiredEnv,omitempty"`
+	// SecretEnv lists the env vars that hold secrets. An MCPServer's secretRef provides
+	// them from the Secret keys of the same name.
+	SecretEnv []string `yaml:"secretEnv,omitempty"`
+}
+
+// Registry is the parsed runtime registry
+type Registry struct {
+	Runtimes map[string]Config `yaml:"runtimes"`
+}
+
+// Runtime is a named registry entry
+type Runtime struct {
+	Name string
+	Config
+}
+
+// List returns the registry entries sorted by name
+func (r *Registry) List() []Runtime {
+	runtimes := make([]Runtime, 0, len(r.Runtimes))
+	for name, config := range r.Runtimes {
+		runtimes = append(runtimes, Runtime{Name: name, Config: config})
+	}
+	sort.Slice(runtimes, func(i, j int) bool { return runtimes[i].Name < runtimes[j].Name })
+	return runtimes
+}
+
+// Parse parses and validates the registry YAML
+func Parse(data string) (*Registry, error) {
+	var registry Registry
+	if err := yaml.Unmarshal([]byte(data), &registry); err != nil {
+		return nil, fmt.Errorf("failed to parse runtime registry: %w", err)
+	}
+	if err := registry.Validate(); err != nil {
+		return nil, err
+	}
+	return &registry, nil
+}
+
+// Validate checks that every runtime has an image and valid env var names
+func (r *Registry) Validate() error {
+	if len(r.Runtimes) == 0 {
+		return fmt.Errorf("runtime registry defines no runtimes")
+	}
+
+	var problems []string
+	for _, runtime := range r.List() {
+		if runtime.Name == CustomRuntime {
+			problems = append(problems, fmt.Sprintf("%s: the name is reserved for user-supplied images", runtime.Name))
+			continue
+		}
+		if strings.TrimSpace(runtime.Image) == "" {
+			problems = append(problems, fmt.Sprintf("%s: image is required", runtime.Name))
+		}
+		if runtime.ParamsEnvVar != "" && !envVarName.MatchString(runtime.ParamsEnvVar) {
+			problems = append(problems, fmt.Sprintf("%s: paramsEnvVar %q is not a valid env var name", runtime.Name, runtime.ParamsEnvVar))
+		}
+		for _, env := range runtime.RequiredEnv {
+			if !envVarName.MatchString(env) {
+				problems = append(problems, fmt.Sprintf("%s: requiredEnv %q is not a valid env var name", runtime.Name, env))
+			}
+		}
+		for _, env := range runtime.SecretEnv {
+			if !envVarName.MatchString(env) {
+				problems = append(problems, fmt.Sprintf("%s: secretEnv %q is not a valid env var name", runtime.Name, env))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid runtime registry: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// FromConfigMap parses the registry held by cm
+func FromConfigMap(cm *corev1.ConfigMap) (*Registry, error) {
+	data, ok := cm.Data[RegistryKey]
+	if !ok {
+		return nil, fmt.Errorf("%s key not found in ConfigMap %s/%s", RegistryKey, cm.Namespace, cm.Name)
+	}
+	return Parse(data)
+}
+
+// Load fetches and parses the registry ConfigMap from sharedNamespace, falling back to
+// namespace when the registry does not exist there
+func Load(ctx context.Context, c client.Reader, sharedNamespace, namespace string) (*Registry, error) {
+	cm, err := util.GetSharedConfigMap(ctx, c, sharedNamespace, namespace, ConfigMapName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get runtime registry ConfigMap: %w", err)
+	}
+	return FromConfigMap(cm)
+}
@@ -0,0 +1,111 @@
+package runtime
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const sampleRegistry = `
+runtimes:
+  python-string:
+    type: python
+    image: axsauze/kaos-mcp-python-string:test
+    description: "Execute Python code strings as MCP tools"
+    paramsEnvVar: MCP_TOOLS_STRING
+    transport: http
+  slack:
+    type: nodejs
+    image: zencoderai/slack-mcp:latest
+    command: ["--transport", "http", "--port", "8000"]
+    transport: http
+    requiredEnv:
+    - SLACK_BOT_TOKEN
+    - SLACK_TEAM_ID
+  kubernetes:
+    type: go
+    image: ghcr.io/manusa/kubernetes-mcp-server:latest
+    args: ["--port", "8000"]
+`
+
+func TestLoadSampleRegistry(t *testing.T) {
+	scheme := k8sruntime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: "kaos-system"},
+		Data:       map[string]string{RegistryKey: sampleRegistry},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()
+
+	registry, err := Load(context.Background(), c, "kaos-system", "default")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	runtimes := registry.List()
+	names := make([]string, len(runtimes))
+	for i, runtime := range runtimes {
+		names[i] = runtime.Name
+	}
+	if strings.Join(names, ",") != "kubernetes,python-string,slack" {
+		t.Errorf("expected runtimes sorted by name, got %v", names)
+	}
+	slack := runtimes[2]
+	if slack.Image != "zencoderai/slack-mcp:latest" || strings.Join(slack.RequiredEnv, ",") != "SLACK_BOT_TOKEN,SLACK_TEAM_ID" {
+		t.Errorf("unexpected slack runtime %+v", slack)
+	}
+	if runtimes[1].ParamsEnvVar != "MCP_TOOLS_STRING" || runtimes[1].Description == "" {
+		t.Errorf("unexpected python-string runtime %+v", runtimes[1])
+	}
+
+	if _, err := Load(context.Background(), c, "other", "default"); err == nil {
+		t.Error("expected an error when the ConfigMap is missing")
+	}
+}
+
+func TestParseInvalidRegistry(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"malformed YAML", "runtimes: [", "failed to parse runtime registry"},
+		{"wrong shape", "runtimes:\n  - python-string", "failed to parse runtime registry"},
+		{"empty", "runtimes: {}", "defines no runtimes"},
+		{"missing image", "runtimes:\n  broken:\n    type: python", "broken: image is required"},
+		{"reserved name", "runtimes:\n  custom:\n    image: foo", "custom: the name is reserved"},
+		{"invalid env", "runtimes:\n  slack:\n    image: foo\n    requiredEnv: [SLACK-TOKEN]", `requiredEnv "SLACK-TOKEN"`},
+		{"invalid secret env", "runtimes:\n  slack:\n    image: foo\n    secretEnv: [slack.token]", `secretEnv "slack.token"`},
+		{"invalid params env", "runtimes:\n  py:\n    image: foo\n    paramsEnvVar: 1TOOLS", `paramsEnvVar "1TOOLS"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(tc.data)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("expected an error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestParseReportsAllProblems(t *testing.T) {
+	_, err := Parse("runtimes:\n  a:\n    type: python\n  b:\n    type: go\n")
+	if err == nil || !strings.Contains(err.Error(), "a: image is required; b: image is required") {
+		t.Errorf("expected every invalid runtime to be reported, got %v", err)
+	}
+}
+
+func TestFromConfigMapMissingKey(t *testing.T) {
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName, Namespace: "kaos-system"}}
+	_, err := FromConfigMap(cm)
+	if err == nil || !strings.Contains(err.Error(), "runtimes.yaml key not found") {
+		t.Errorf("expected a missing key error, got %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+// Package tracing provides optional OpenTelemetry tracing for the operator's own
+// reconcile loops, exported to the same collector as the data plane.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+const (
+	tracerName  = "github.com/axsaucedo/kaos/operator"
+	serviceName = "kaos-operator"
+)
+
+// IsEnabled reports whether operator tracing was requested via the
+// OPERATOR_TRACING_ENABLED env var (used as the --enable-tracing flag default).
+func IsEnabled() bool {
+	return os.Getenv("OPERATOR_TRACING_ENABLED") == "true"
+}
+
+// Setup installs a global tracer provider exporting spans over OTLP gRPC to endpoint
+// (e.g. "http://otel-collector.observability:4317"). The returned function flushes
+// and shuts down the provider.
+func Setup(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start starts a span using the global tracer provider. Spans are no-ops unless
+// Setup has been called.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartReconcile starts the root span for a reconcile of the given CR kind
+func StartReconcile(ctx context.Context, kind string, name types.NamespacedName) (context.Context, trace.Span) {
+	return Start(ctx, "Reconcile "+kind,
+		attribute.String("kaos.kind", kind),
+		attribute.String("kaos.name", name.Name),
+		attribute.String("kaos.namespace", name.Namespace),
+	)
+}
+
+// End records err on the span (if any) and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Client wraps a client.Client, tracing reads and writes of Kubernetes objects
+type Client struct {
+	client.Client
+}
+
+// WrapClient returns c with a span around each Get, List, Create, Update, Patch and Delete
+func WrapClient(c client.Client) client.Client {
+	return &Client{Client: c}
+}
+
+// startSpan starts a span named after the operation and the object's kind
+func (c *Client) startSpan(ctx context.Context, operation string, obj runtime.Object, name, namespace string) (context.Context, trace.Span) {
+	kind := fmt.Sprintf("%T", obj)
+	if gvk, err := apiutil.GVKForObject(obj, c.Scheme()); err == nil {
+		kind = gvk.Kind
+	}
+	attrs := []attribute.KeyValue{attribute.String("kaos.kind", kind)}
+	if name != "" {
+		attrs = append(attrs, attribute.String("kaos.name", name))
+	}
+	if namespace != "" {
+		attrs = append(attrs, attribute.String("kaos.namespace", namespace))
+	}
+	return Start(ctx, operation+" "+kind, attrs...)
+}
+
+// Get traces client.Client.Get. NotFound is not recorded as a span error, since
+// controllers use it to detect objects that still need to be created.
+func (c *Client) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	ctx, span := c.startSpan(ctx, "Get", obj, key.Name, key.Namespace)
+	err := c.Client.Get(ctx, key, obj, opts...)
+	End(span, client.IgnoreNotFound(err))
+	return err
+}
+
+// List traces client.Client.List
+func (c *Client) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	ctx, span := c.startSpan(ctx, "List", list, "", "")
+	err := c.Client.List(ctx, list, opts...)
+	End(span, err)
+	return err
+}
+
+// Create traces client.Client.Create
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	ctx, span := c.startSpan(ctx, "Create", obj, obj.GetName(), obj.GetNamespace())
+	err := c.Client.Create(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
+
+// Update traces client.Client.Update
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ctx, span := c.startSpan(ctx, "Update", obj, obj.GetName(), obj.GetNamespace())
+	err := c.Client.Update(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
+
+// Patch traces client.Client.Patch
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, span := c.startSpan(ctx, "Patch", obj, obj.GetName(), obj.GetNamespace())
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	End(span, err)
+	return err
+}
+
+// Delete traces client.Client.Delete
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	ctx, span := c.startSpan(ctx, "Delete", obj, obj.GetName(), obj.GetNamespace())
+	err := c.Client.Delete(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
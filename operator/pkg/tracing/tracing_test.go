@@ -0,0 +1,104 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// setupRecorder installs an in-memory span exporter as the global tracer provider
+func setupRecorder(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = provider.Shutdown(context.Background())
+	})
+	return exporter
+}
+
+func attributeValue(attrs []attribute.KeyValue, key string) string {
+	for _, attr := range attrs {
+		if string(attr.Key) == key {
+			return attr.Value.AsString()
+		}
+	}
+	return ""
+}
+
+func TestStartReconcile(t *testing.T) {
+	exporter := setupRecorder(t)
+
+	_, span := StartReconcile(context.Background(), "Agent", types.NamespacedName{Name: "my-agent", Namespace: "team-a"})
+	End(span, errors.New("boom"))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	got := spans[0]
+	if got.Name != "Reconcile Agent" {
+		t.Errorf("expected span name %q, got %q", "Reconcile Agent", got.Name)
+	}
+	for key, want := range map[string]string{"kaos.kind": "Agent", "kaos.name": "my-agent", "kaos.namespace": "team-a"} {
+		if value := attributeValue(got.Attributes, key); value != want {
+			t.Errorf("expected attribute %s=%q, got %q", key, want, value)
+		}
+	}
+	if got.Status.Code != codes.Error {
+		t.Errorf("expected error status, got %v", got.Status.Code)
+	}
+}
+
+func TestWrapClient(t *testing.T) {
+	exporter := setupRecorder(t)
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	c := WrapClient(fake.NewClientBuilder().WithScheme(scheme).Build())
+
+	ctx, parent := StartReconcile(context.Background(), "Agent", types.NamespacedName{Name: "my-agent", Namespace: "default"})
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "agent-my-agent", Namespace: "default"}}
+	if err := c.Create(ctx, service); err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+	// NotFound from Get is expected by controllers and should not mark the span as failed
+	missing := &corev1.Service{}
+	_ = c.Get(ctx, types.NamespacedName{Name: "missing", Namespace: "default"}, missing)
+	End(parent, nil)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+
+	create, get, root := spans[0], spans[1], spans[2]
+	if create.Name != "Create Service" {
+		t.Errorf("expected span name %q, got %q", "Create Service", create.Name)
+	}
+	if value := attributeValue(create.Attributes, "kaos.name"); value != "agent-my-agent" {
+		t.Errorf("expected kaos.name attribute, got %q", value)
+	}
+	if create.Parent.SpanID() != root.SpanContext.SpanID() {
+		t.Error("expected client span to be a child of the reconcile span")
+	}
+	if get.Name != "Get Service" || get.Status.Code == codes.Error {
+		t.Errorf("expected successful Get Service span, got %q with status %v", get.Name, get.Status.Code)
+	}
+}
@@ -0,0 +1,94 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetWatchNamespaces returns the namespaces the operator should watch from the
+// WATCH_NAMESPACES env var (comma-separated). Returns nil when unset, which means
+// all namespaces are watched.
+func GetWatchNamespaces() []string {
+	value := os.Getenv("WATCH_NAMESPACES")
+	if value == "" {
+		return nil
+	}
+
+	var namespaces []string
+	seen := make(map[string]bool)
+	for _, ns := range strings.Split(value, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "" || seen[ns] {
+			continue
+		}
+		seen[ns] = true
+		namespaces = append(namespaces, ns)
+	}
+	return namespaces
+}
+
+// GetCacheLabelSelector parses the CACHE_LABEL_SELECTOR env var
+// (e.g. "app in (agent,modelapi,mcpserver)"). Returns nil when unset.
+func GetCacheLabelSelector() (labels.Selector, error) {
+	value := strings.TrimSpace(os.Getenv("CACHE_LABEL_SELECTOR"))
+	if value == "" {
+		return nil, nil
+	}
+	selector, err := labels.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CACHE_LABEL_SELECTOR %q: %w", value, err)
+	}
+	return selector, nil
+}
+
+// BuildCacheOptions returns the manager cache options derived from environment.
+// When WATCH_NAMESPACES is set, the cache is restricted to those namespaces.
+// The system and shared resources namespaces are always included so the operator can
+// still read its own resources (e.g. the MCP runtime registry ConfigMap).
+// When CACHE_LABEL_SELECTOR is set, Deployments, Jobs, CronJobs, Services and
+// PersistentVolumeClaims are only cached if they match the selector. KAOS CRs, ConfigMaps
+// and Secrets are not filtered, since they are not labelled by the operator (CRs) or may
+// be user-provided (ConfigMaps, Secrets).
+func BuildCacheOptions(systemNamespace string) (cache.Options, error) {
+	opts := cache.Options{}
+
+	selector, err := GetCacheLabelSelector()
+	if err != nil {
+		return opts, err
+	}
+	if selector != nil {
+		opts.ByObject = map[client.Object]cache.ByObject{
+			&appsv1.Deployment{}:            {Label: selector},
+			&batchv1.Job{}:                  {Label: selector},
+			&batchv1.CronJob{}:              {Label: selector},
+			&corev1.Service{}:               {Label: selector},
+			&corev1.PersistentVolumeClaim{}: {Label: selector},
+		}
+	}
+
+	namespaces := GetWatchNamespaces()
+	if len(namespaces) == 0 {
+		return opts, nil
+	}
+
+	opts.DefaultNamespaces = make(map[string]cache.Config, len(namespaces)+2)
+	for _, ns := range namespaces {
+		opts.DefaultNamespaces[ns] = cache.Config{}
+	}
+	if systemNamespace != "" {
+		opts.DefaultNamespaces[systemNamespace] = cache.Config{}
+	}
+	if shared := GetSharedResourcesNamespace(systemNamespace); shared != "" {
+		opts.DefaultNamespaces[shared] = cache.Config{}
+	}
+
+	return opts, nil
+}
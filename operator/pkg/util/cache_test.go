@@ -0,0 +1,165 @@
+package util
+
+import (
+	"os"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestGetWatchNamespaces(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    string
+		expect []string
+	}{
+		{
+			name:   "unset watches all namespaces",
+			env:    "",
+			expect: nil,
+		},
+		{
+			name:   "single namespace",
+			env:    "team-a",
+			expect: []string{"team-a"},
+		},
+		{
+			name:   "trims whitespace and drops empty and duplicate entries",
+			env:    " team-a, ,team-b,team-a ",
+			expect: []string{"team-a", "team-b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Unsetenv("WATCH_NAMESPACES")
+			if tt.env != "" {
+				os.Setenv("WATCH_NAMESPACES", tt.env)
+			}
+			defer os.Unsetenv("WATCH_NAMESPACES")
+
+			result := GetWatchNamespaces()
+			if len(result) != len(tt.expect) {
+				t.Fatalf("expected %v, got %v", tt.expect, result)
+			}
+			for i := range tt.expect {
+				if result[i] != tt.expect[i] {
+					t.Errorf("expected %v, got %v", tt.expect, result)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildCacheOptions(t *testing.T) {
+	t.Run("all namespaces when unset", func(t *testing.T) {
+		os.Unsetenv("WATCH_NAMESPACES")
+
+		opts, err := BuildCacheOptions("kaos-system")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if opts.DefaultNamespaces != nil {
+			t.Errorf("expected no namespace restriction, got %v", opts.DefaultNamespaces)
+		}
+	})
+
+	t.Run("restricts to watched namespaces plus system namespace", func(t *testing.T) {
+		os.Setenv("WATCH_NAMESPACES", "team-a,team-b")
+		defer os.Unsetenv("WATCH_NAMESPACES")
+
+		opts, err := BuildCacheOptions("kaos-system")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, ns := range []string{"team-a", "team-b", "kaos-system"} {
+			if _, ok := opts.DefaultNamespaces[ns]; !ok {
+				t.Errorf("expected namespace %s to be watched", ns)
+			}
+		}
+		if _, ok := opts.DefaultNamespaces["team-c"]; ok {
+			t.Error("expected namespace team-c not to be watched")
+		}
+		if len(opts.DefaultNamespaces) != 3 {
+			t.Errorf("expected 3 watched namespaces, got %d", len(opts.DefaultNamespaces))
+		}
+	})
+
+	t.Run("includes the shared resources namespace", func(t *testing.T) {
+		t.Setenv("WATCH_NAMESPACES", "team-a")
+		t.Setenv("SHARED_RESOURCES_NAMESPACE", "kaos-shared")
+
+		opts, err := BuildCacheOptions("kaos-system")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, ns := range []string{"team-a", "kaos-system", "kaos-shared"} {
+			if _, ok := opts.DefaultNamespaces[ns]; !ok {
+				t.Errorf("expected namespace %s to be watched", ns)
+			}
+		}
+	})
+}
+
+func TestBuildCacheOptionsLabelSelector(t *testing.T) {
+	t.Run("no selector when unset", func(t *testing.T) {
+		os.Unsetenv("CACHE_LABEL_SELECTOR")
+
+		opts, err := BuildCacheOptions("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(opts.ByObject) != 0 {
+			t.Errorf("expected no per-object cache config, got %v", opts.ByObject)
+		}
+	})
+
+	t.Run("invalid selector returns error", func(t *testing.T) {
+		os.Setenv("CACHE_LABEL_SELECTOR", "app in (agent")
+		defer os.Unsetenv("CACHE_LABEL_SELECTOR")
+
+		if _, err := BuildCacheOptions(""); err == nil {
+			t.Error("expected error for invalid selector")
+		}
+	})
+
+	t.Run("selector excludes unrelated deployments, jobs, cronjobs, services and pvcs", func(t *testing.T) {
+		os.Setenv("CACHE_LABEL_SELECTOR", "app in (agent,modelapi,mcpserver)")
+		defer os.Unsetenv("CACHE_LABEL_SELECTOR")
+
+		opts, err := BuildCacheOptions("")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		foundTypes := 0
+		for obj, byObject := range opts.ByObject {
+			switch obj.(type) {
+			case *appsv1.Deployment, *batchv1.Job, *batchv1.CronJob, *corev1.Service, *corev1.PersistentVolumeClaim:
+				foundTypes++
+			default:
+				t.Errorf("unexpected filtered object type %T", obj)
+			}
+			if byObject.Label == nil {
+				t.Fatalf("expected label selector for %T", obj)
+			}
+			for _, app := range []string{"agent", "modelapi", "mcpserver"} {
+				if !byObject.Label.Matches(labels.Set{"app": app}) {
+					t.Errorf("expected %T with app=%s to be cached", obj, app)
+				}
+			}
+			if byObject.Label.Matches(labels.Set{"app": "nginx"}) {
+				t.Errorf("expected unrelated %T to be excluded from cache", obj)
+			}
+			if byObject.Label.Matches(labels.Set{}) {
+				t.Errorf("expected unlabelled %T to be excluded from cache", obj)
+			}
+		}
+		if foundTypes != 5 {
+			t.Errorf("expected Deployment, Job, CronJob, Service and PersistentVolumeClaim to be filtered, got %d types", foundTypes)
+		}
+	})
+}
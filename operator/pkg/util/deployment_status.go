@@ -1,7 +1,10 @@
 package util
 
 import (
+	"fmt"
+
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
@@ -15,10 +18,11 @@ func CopyDeploymentStatus(deployment *appsv1.Deployment) *kaosv1alpha1.Deploymen
 	}
 
 	status := &kaosv1alpha1.DeploymentStatus{
-		Replicas:          deployment.Status.Replicas,
-		ReadyReplicas:     deployment.Status.ReadyReplicas,
-		AvailableReplicas: deployment.Status.AvailableReplicas,
-		UpdatedReplicas:   deployment.Status.UpdatedReplicas,
+		Replicas:           deployment.Status.Replicas,
+		ReadyReplicas:      deployment.Status.ReadyReplicas,
+		AvailableReplicas:  deployment.Status.AvailableReplicas,
+		UpdatedReplicas:    deployment.Status.UpdatedReplicas,
+		ObservedGeneration: deployment.Generation,
 	}
 
 	// Convert deployment conditions to metav1.Condition format
@@ -34,3 +38,72 @@ func CopyDeploymentStatus(deployment *appsv1.Deployment) *kaosv1alpha1.Deploymen
 
 	return status
 }
+
+// DeploymentProgressDeadlineExceeded reports whether the Deployment's rollout has made no
+// progress for progressDeadlineSeconds, returning the Progressing condition message
+func DeploymentProgressDeadlineExceeded(deployment *appsv1.Deployment) (string, bool) {
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Status == corev1.ConditionFalse && cond.Reason == "ProgressDeadlineExceeded" {
+			return cond.Message, true
+		}
+	}
+	return "", false
+}
+
+// DeploymentRolloutComplete reports whether the Deployment controller has observed the
+// latest Deployment spec and every replica runs the current pod template, the same checks
+// `kubectl rollout status` waits on. Otherwise it returns a message naming what the
+// rollout is still waiting for.
+func DeploymentRolloutComplete(deployment *appsv1.Deployment) (string, bool) {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return "waiting for the Deployment controller to observe the latest spec", false
+	}
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	if deployment.Status.UpdatedReplicas < desired {
+		return fmt.Sprintf("%d/%d replicas updated", deployment.Status.UpdatedReplicas, desired), false
+	}
+	if old := deployment.Status.Replicas - deployment.Status.UpdatedReplicas; old > 0 {
+		return fmt.Sprintf("%d old replicas pending termination", old), false
+	}
+	return "", true
+}
+
+// TrackPendingSince sets status.PendingSince for the rollout status was copied from,
+// carrying it over from previous while the Deployment generation is unchanged. A new
+// rollout without ready replicas starts waiting at now, and the wait ends for good
+// once a replica of the rollout is ready.
+func TrackPendingSince(status, previous *kaosv1alpha1.DeploymentStatus, now metav1.Time) {
+	if status == nil {
+		return
+	}
+	switch {
+	case status.ReadyReplicas > 0:
+		status.PendingSince = nil
+	case previous != nil && previous.ObservedGeneration == status.ObservedGeneration:
+		status.PendingSince = previous.PendingSince
+	default:
+		status.PendingSince = &now
+	}
+}
+
+// DeploymentFailureMessage describes the Deployment's most recent failing condition (a
+// ReplicaFailure, or an Available or Progressing condition that is False), for example
+// "Available=False (MinimumReplicasUnavailable): Deployment does not have minimum
+// availability.". It is empty when no condition is failing.
+func DeploymentFailureMessage(deployment *appsv1.Deployment) string {
+	var latest *appsv1.DeploymentCondition
+	for i, cond := range deployment.Status.Conditions {
+		failing := (cond.Type == appsv1.DeploymentReplicaFailure && cond.Status == corev1.ConditionTrue) ||
+			((cond.Type == appsv1.DeploymentAvailable || cond.Type == appsv1.DeploymentProgressing) && cond.Status == corev1.ConditionFalse)
+		if failing && (latest == nil || !cond.LastTransitionTime.Before(&latest.LastTransitionTime)) {
+			latest = &deployment.Status.Conditions[i]
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s=%s (%s): %s", latest.Type, latest.Status, latest.Reason, latest.Message)
+}
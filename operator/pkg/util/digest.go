@@ -0,0 +1,77 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// digestPattern matches an image digest as accepted in IMAGE_DIGESTS
+var digestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// ImageDigestPinningEnabled reports whether generated pods must reference images by
+// digest, from the IMAGE_DIGEST_PINNING env var
+func ImageDigestPinningEnabled() bool {
+	return os.Getenv("IMAGE_DIGEST_PINNING") == "true"
+}
+
+// GetImageDigests parses the IMAGE_DIGESTS env var, a JSON object mapping image
+// references as configured (e.g. "axsauze/kaos-agent:v0.1.0") to their digest
+// ("sha256:..."). Returns an empty map when unset.
+func GetImageDigests() (map[string]string, error) {
+	digests := map[string]string{}
+	value := strings.TrimSpace(os.Getenv("IMAGE_DIGESTS"))
+	if value == "" {
+		return digests, nil
+	}
+	if err := json.Unmarshal([]byte(value), &digests); err != nil {
+		return nil, fmt.Errorf("invalid IMAGE_DIGESTS: %w", err)
+	}
+	for image, digest := range digests {
+		if !digestPattern.MatchString(digest) {
+			return nil, fmt.Errorf("invalid IMAGE_DIGESTS: digest %q of image %q is not sha256:<64 hex characters>", digest, image)
+		}
+	}
+	return digests, nil
+}
+
+// PinImage returns image referenced by digest (image@sha256:...), using digests.
+// Images that already carry a digest are returned as-is. Fails when no digest is
+// known for image, so unpinned images never reach a pod.
+func PinImage(image string, digests map[string]string) (string, error) {
+	if strings.Contains(image, "@") {
+		return image, nil
+	}
+	digest, ok := digests[image]
+	if !ok {
+		return "", fmt.Errorf("image digest pinning is enabled but no digest is configured for image %q", image)
+	}
+	return image + "@" + digest, nil
+}
+
+// PinPodSpecImages replaces the images of all containers and init containers in spec
+// with their digest-pinned references when IMAGE_DIGEST_PINNING is enabled. It is a
+// no-op otherwise.
+func PinPodSpecImages(spec *corev1.PodSpec) error {
+	if !ImageDigestPinningEnabled() {
+		return nil
+	}
+	digests, err := GetImageDigests()
+	if err != nil {
+		return err
+	}
+	for _, containers := range [][]corev1.Container{spec.InitContainers, spec.Containers} {
+		for i := range containers {
+			pinned, err := PinImage(containers[i].Image, digests)
+			if err != nil {
+				return fmt.Errorf("container %s: %w", containers[i].Name, err)
+			}
+			containers[i].Image = pinned
+		}
+	}
+	return nil
+}
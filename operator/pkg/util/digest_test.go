@@ -0,0 +1,89 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	agentDigest   = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	litellmDigest = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+)
+
+func TestPinImage(t *testing.T) {
+	digests := map[string]string{"axsauze/kaos-agent:v0.1.0": agentDigest}
+
+	pinned, err := PinImage("axsauze/kaos-agent:v0.1.0", digests)
+	if err != nil || pinned != "axsauze/kaos-agent:v0.1.0@"+agentDigest {
+		t.Errorf("expected the digest to be appended, got %q (err %v)", pinned, err)
+	}
+
+	already := "busybox@" + litellmDigest
+	if pinned, err := PinImage(already, digests); err != nil || pinned != already {
+		t.Errorf("expected an already pinned image to be kept, got %q (err %v)", pinned, err)
+	}
+
+	if _, err := PinImage("axsauze/kaos-agent:latest", digests); err == nil {
+		t.Errorf("expected an error for an image without a configured digest")
+	}
+}
+
+func TestGetImageDigests(t *testing.T) {
+	t.Setenv("IMAGE_DIGESTS", "")
+	if digests, err := GetImageDigests(); err != nil || len(digests) != 0 {
+		t.Errorf("expected an empty map when unset, got %v (err %v)", digests, err)
+	}
+
+	t.Setenv("IMAGE_DIGESTS", `{"litellm:v1": "`+litellmDigest+`"}`)
+	digests, err := GetImageDigests()
+	if err != nil || digests["litellm:v1"] != litellmDigest {
+		t.Errorf("expected the parsed digest, got %v (err %v)", digests, err)
+	}
+
+	t.Setenv("IMAGE_DIGESTS", `{"litellm:v1": "v1"}`)
+	if _, err := GetImageDigests(); err == nil || !strings.Contains(err.Error(), "sha256") {
+		t.Errorf("expected an invalid digest error, got %v", err)
+	}
+
+	t.Setenv("IMAGE_DIGESTS", `not json`)
+	if _, err := GetImageDigests(); err == nil {
+		t.Errorf("expected a parse error")
+	}
+}
+
+func TestPinPodSpecImages(t *testing.T) {
+	spec := func() *corev1.PodSpec {
+		return &corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: "migrate-db", Image: "litellm:v1"}},
+			Containers:     []corev1.Container{{Name: "model-api", Image: "litellm:v1"}},
+		}
+	}
+
+	t.Setenv("IMAGE_DIGESTS", `{"litellm:v1": "`+litellmDigest+`"}`)
+	t.Setenv("IMAGE_DIGEST_PINNING", "false")
+	unpinned := spec()
+	if err := PinPodSpecImages(unpinned); err != nil || unpinned.Containers[0].Image != "litellm:v1" {
+		t.Errorf("expected images untouched when pinning is disabled, got %q (err %v)", unpinned.Containers[0].Image, err)
+	}
+
+	t.Setenv("IMAGE_DIGEST_PINNING", "true")
+	pinned := spec()
+	if err := PinPodSpecImages(pinned); err != nil {
+		t.Fatalf("PinPodSpecImages() error = %v", err)
+	}
+	for _, c := range append(pinned.InitContainers, pinned.Containers...) {
+		if c.Image != "litellm:v1@"+litellmDigest {
+			t.Errorf("container %s: expected a pinned image, got %q", c.Name, c.Image)
+		}
+	}
+
+	// Fails closed when any image has no digest
+	missing := spec()
+	missing.Containers = append(missing.Containers, corev1.Container{Name: "sidecar", Image: "envoy:v1"})
+	err := PinPodSpecImages(missing)
+	if err == nil || !strings.Contains(err.Error(), `container sidecar`) || !strings.Contains(err.Error(), `"envoy:v1"`) {
+		t.Errorf("expected an error naming the unpinned container, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package util
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// DefaultClusterDomain is the cluster DNS domain used when KUBERNETES_CLUSTER_DOMAIN is unset
+const DefaultClusterDomain = "cluster.local"
+
+// GetClusterDomain returns the cluster DNS domain from the KUBERNETES_CLUSTER_DOMAIN env var,
+// falling back to DefaultClusterDomain
+func GetClusterDomain() string {
+	if domain := strings.Trim(strings.TrimSpace(os.Getenv("KUBERNETES_CLUSTER_DOMAIN")), "."); domain != "" {
+		return domain
+	}
+	return DefaultClusterDomain
+}
+
+// GetDefaultEgressProxy returns the operator-wide proxy settings from the
+// DEFAULT_HTTP_PROXY, DEFAULT_HTTPS_PROXY and DEFAULT_NO_PROXY (comma-separated) env vars.
+// Returns nil when none are set.
+func GetDefaultEgressProxy() *kaosv1alpha1.EgressProxyConfig {
+	proxy := &kaosv1alpha1.EgressProxyConfig{
+		HTTPProxy:  strings.TrimSpace(os.Getenv("DEFAULT_HTTP_PROXY")),
+		HTTPSProxy: strings.TrimSpace(os.Getenv("DEFAULT_HTTPS_PROXY")),
+		NoProxy:    splitNoProxy(os.Getenv("DEFAULT_NO_PROXY")),
+	}
+	if proxy.HTTPProxy == "" && proxy.HTTPSProxy == "" && len(proxy.NoProxy) == 0 {
+		return nil
+	}
+	return proxy
+}
+
+// MergeEgressProxy performs a field-wise merge of component-level proxy settings with the
+// operator-wide defaults. Component proxy URLs take precedence when set, and component
+// noProxy entries are added to the default ones.
+func MergeEgressProxy(componentConfig *kaosv1alpha1.EgressProxyConfig) *kaosv1alpha1.EgressProxyConfig {
+	merged := GetDefaultEgressProxy()
+	if componentConfig == nil {
+		return merged
+	}
+	if merged == nil {
+		merged = &kaosv1alpha1.EgressProxyConfig{}
+	}
+	if componentConfig.HTTPProxy != "" {
+		merged.HTTPProxy = componentConfig.HTTPProxy
+	}
+	if componentConfig.HTTPSProxy != "" {
+		merged.HTTPSProxy = componentConfig.HTTPSProxy
+	}
+	merged.NoProxy = append(merged.NoProxy, componentConfig.NoProxy...)
+	return merged
+}
+
+// BuildEgressProxyEnvVars creates the HTTP_PROXY, HTTPS_PROXY and NO_PROXY env vars for
+// proxy, or returns nil when no proxy URL is set. NO_PROXY always starts with loopback,
+// the cluster service domain and the API server address, so in-cluster calls between
+// KAOS components (which use .svc.<cluster domain> endpoints) bypass the proxy.
+func BuildEgressProxyEnvVars(proxy *kaosv1alpha1.EgressProxyConfig) []corev1.EnvVar {
+	if proxy == nil || (proxy.HTTPProxy == "" && proxy.HTTPSProxy == "") {
+		return nil
+	}
+
+	noProxy := []string{"localhost", "127.0.0.1", ".svc", GetClusterDomain()}
+	if host := os.Getenv("KUBERNETES_SERVICE_HOST"); host != "" {
+		noProxy = append(noProxy, host)
+	}
+	for _, entry := range proxy.NoProxy {
+		if entry = strings.TrimSpace(entry); entry != "" && !slices.Contains(noProxy, entry) {
+			noProxy = append(noProxy, entry)
+		}
+	}
+
+	var envVars []corev1.EnvVar
+	if proxy.HTTPProxy != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "HTTP_PROXY", Value: proxy.HTTPProxy})
+	}
+	if proxy.HTTPSProxy != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: "HTTPS_PROXY", Value: proxy.HTTPSProxy})
+	}
+	envVars = append(envVars, corev1.EnvVar{Name: "NO_PROXY", Value: strings.Join(noProxy, ",")})
+	return envVars
+}
+
+// splitNoProxy splits a comma-separated NO_PROXY value, dropping empty entries
+func splitNoProxy(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
@@ -0,0 +1,80 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// envValue returns the value of the named env var, or "" when absent
+func envValue(env []corev1.EnvVar, name string) string {
+	for _, e := range env {
+		if e.Name == name {
+			return e.Value
+		}
+	}
+	return ""
+}
+
+func TestMergeEgressProxy(t *testing.T) {
+	t.Setenv("DEFAULT_HTTP_PROXY", "")
+	t.Setenv("DEFAULT_HTTPS_PROXY", "")
+	t.Setenv("DEFAULT_NO_PROXY", "")
+	if got := MergeEgressProxy(nil); got != nil {
+		t.Errorf("expected nil without defaults, got %+v", got)
+	}
+
+	t.Setenv("DEFAULT_HTTP_PROXY", "http://proxy.corp:3128")
+	t.Setenv("DEFAULT_HTTPS_PROXY", "http://proxy.corp:3128")
+	t.Setenv("DEFAULT_NO_PROXY", "corp.internal, 10.0.0.0/8")
+
+	merged := MergeEgressProxy(&kaosv1alpha1.EgressProxyConfig{
+		HTTPSProxy: "http://secure-proxy.corp:3128",
+		NoProxy:    []string{"llm.partner.net"},
+	})
+	if merged.HTTPProxy != "http://proxy.corp:3128" {
+		t.Errorf("expected inherited HTTP proxy, got %q", merged.HTTPProxy)
+	}
+	if merged.HTTPSProxy != "http://secure-proxy.corp:3128" {
+		t.Errorf("expected component HTTPS proxy, got %q", merged.HTTPSProxy)
+	}
+	if want := []string{"corp.internal", "10.0.0.0/8", "llm.partner.net"}; strings.Join(merged.NoProxy, ",") != strings.Join(want, ",") {
+		t.Errorf("expected noProxy %v, got %v", want, merged.NoProxy)
+	}
+}
+
+func TestBuildEgressProxyEnvVars(t *testing.T) {
+	t.Setenv("KUBERNETES_CLUSTER_DOMAIN", "")
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.96.0.1")
+
+	if env := BuildEgressProxyEnvVars(&kaosv1alpha1.EgressProxyConfig{NoProxy: []string{"corp.internal"}}); env != nil {
+		t.Errorf("expected no env without a proxy URL, got %v", env)
+	}
+
+	env := BuildEgressProxyEnvVars(&kaosv1alpha1.EgressProxyConfig{
+		HTTPProxy:  "http://proxy.corp:3128",
+		HTTPSProxy: "http://proxy.corp:3128",
+		NoProxy:    []string{"corp.internal", "localhost"},
+	})
+	if got := envValue(env, "HTTP_PROXY"); got != "http://proxy.corp:3128" {
+		t.Errorf("expected HTTP_PROXY, got %q", got)
+	}
+	if got := envValue(env, "HTTPS_PROXY"); got != "http://proxy.corp:3128" {
+		t.Errorf("expected HTTPS_PROXY, got %q", got)
+	}
+	if got, want := envValue(env, "NO_PROXY"), "localhost,127.0.0.1,.svc,cluster.local,10.96.0.1,corp.internal"; got != want {
+		t.Errorf("expected NO_PROXY %q, got %q", want, got)
+	}
+
+	t.Setenv("KUBERNETES_CLUSTER_DOMAIN", "k8s.corp.")
+	env = BuildEgressProxyEnvVars(&kaosv1alpha1.EgressProxyConfig{HTTPSProxy: "http://proxy.corp:3128"})
+	if got := envValue(env, "NO_PROXY"); !strings.Contains(got, ",k8s.corp,") {
+		t.Errorf("expected the configured cluster domain in NO_PROXY, got %q", got)
+	}
+	if got := envValue(env, "HTTP_PROXY"); got != "" {
+		t.Errorf("expected no HTTP_PROXY, got %q", got)
+	}
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// PreserveUserEnv ensures user-provided env vars (spec.container.env) are never
+// clobbered by operator-generated ones. The precedence is generated first, user last:
+// any entry in env sharing a name with a user entry is dropped and the user entries
+// are appended last, unchanged, so both Value and ValueFrom (e.g. secretKeyRef)
+// entries reach the pod as written. Appending last also lets user values reference
+// generated vars via $(VAR). The result has one entry per name; when a name is
+// repeated within env or userEnv, its last entry wins.
+func PreserveUserEnv(env []corev1.EnvVar, userEnv []corev1.EnvVar) []corev1.EnvVar {
+	if len(userEnv) == 0 {
+		return dedupeEnv(env)
+	}
+
+	userNames := make(map[string]bool, len(userEnv))
+	for _, e := range userEnv {
+		userNames[e.Name] = true
+	}
+
+	result := make([]corev1.EnvVar, 0, len(env)+len(userEnv))
+	for _, e := range env {
+		if !userNames[e.Name] {
+			result = append(result, e)
+		}
+	}
+	for _, e := range userEnv {
+		result = append(result, *e.DeepCopy())
+	}
+	return dedupeEnv(result)
+}
+
+// dedupeEnv keeps only the last entry of each name, in the order of those entries
+func dedupeEnv(env []corev1.EnvVar) []corev1.EnvVar {
+	last := make(map[string]int, len(env))
+	for i, e := range env {
+		last[e.Name] = i
+	}
+	if len(last) == len(env) {
+		return env
+	}
+
+	result := make([]corev1.EnvVar, 0, len(last))
+	for i, e := range env {
+		if last[e.Name] == i {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// OverriddenEnvNames returns the sorted names of generated entries in env that a user
+// entry replaces with a different definition. env may already contain copies of the
+// user entries; those are not counted as generated.
+func OverriddenEnvNames(env []corev1.EnvVar, userEnv []corev1.EnvVar) []string {
+	if len(userEnv) == 0 {
+		return nil
+	}
+
+	userEntries := make(map[string][]corev1.EnvVar, len(userEnv))
+	for _, e := range userEnv {
+		userEntries[e.Name] = append(userEntries[e.Name], e)
+	}
+
+	overridden := map[string]bool{}
+	for _, e := range env {
+		entries, ok := userEntries[e.Name]
+		if !ok {
+			continue
+		}
+		copied := false
+		for _, user := range entries {
+			if equality.Semantic.DeepEqual(e, user) {
+				copied = true
+				break
+			}
+		}
+		if !copied {
+			overridden[e.Name] = true
+		}
+	}
+
+	names := make([]string, 0, len(overridden))
+	for name := range overridden {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,124 @@
+package util
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPreserveUserEnv(t *testing.T) {
+	secretRef := &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "otel-creds"},
+			Key:                  "endpoint",
+		},
+	}
+	userEnv := []corev1.EnvVar{
+		{Name: "CUSTOM_FLAG", Value: "on"},
+		{Name: "OPENAI_API_KEY", ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "llm-keys"},
+				Key:                  "openai",
+			},
+		}},
+		{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", ValueFrom: secretRef},
+		{Name: "LOG_LEVEL", Value: "DEBUG"},
+	}
+
+	// Generated env as built by the controllers: user entries appended mid-way,
+	// followed by telemetry and log level additions with conflicting names
+	env := []corev1.EnvVar{{Name: "AGENT_NAME", Value: "a"}}
+	env = append(env, userEnv...)
+	env = append(env,
+		corev1.EnvVar{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Value: "http://collector:4317"},
+		corev1.EnvVar{Name: "OTEL_SERVICE_NAME", Value: "a"},
+		corev1.EnvVar{Name: "LOG_LEVEL", Value: "INFO"},
+	)
+
+	got := PreserveUserEnv(env, userEnv)
+
+	counts := map[string]int{}
+	byName := map[string]corev1.EnvVar{}
+	for _, e := range got {
+		counts[e.Name]++
+		byName[e.Name] = e
+	}
+	for name, n := range counts {
+		if n != 1 {
+			t.Errorf("expected %s exactly once, got %d", name, n)
+		}
+	}
+
+	if byName["AGENT_NAME"].Value != "a" || byName["OTEL_SERVICE_NAME"].Value != "a" {
+		t.Error("expected generated vars without user overrides to be kept")
+	}
+	if byName["CUSTOM_FLAG"].Value != "on" || byName["LOG_LEVEL"].Value != "DEBUG" {
+		t.Error("expected user Value entries to survive")
+	}
+	if ref := byName["OPENAI_API_KEY"].ValueFrom; ref == nil || ref.SecretKeyRef == nil || ref.SecretKeyRef.Name != "llm-keys" {
+		t.Errorf("expected user valueFrom entry to survive, got %+v", byName["OPENAI_API_KEY"])
+	}
+	otel := byName["OTEL_EXPORTER_OTLP_ENDPOINT"]
+	if otel.Value != "" || otel.ValueFrom == nil || otel.ValueFrom.SecretKeyRef.Name != "otel-creds" {
+		t.Errorf("expected generated telemetry endpoint not to clobber user valueFrom, got %+v", otel)
+	}
+
+	if got[len(got)-1].Name != "LOG_LEVEL" {
+		t.Errorf("expected user entries last, got %s", got[len(got)-1].Name)
+	}
+}
+
+func TestPreserveUserEnvNoUserEnv(t *testing.T) {
+	env := []corev1.EnvVar{{Name: "A", Value: "1"}}
+	if got := PreserveUserEnv(env, nil); len(got) != 1 || got[0].Name != "A" {
+		t.Errorf("expected env unchanged, got %+v", got)
+	}
+}
+
+func TestPreserveUserEnvOneEntryPerName(t *testing.T) {
+	env := []corev1.EnvVar{
+		{Name: "MODEL_API_URL", Value: "http://llm:8000"},
+		{Name: "LOG_LEVEL", Value: "INFO"},
+		{Name: "LOG_LEVEL", Value: "DEBUG"},
+	}
+	userEnv := []corev1.EnvVar{
+		{Name: "MODEL_API_URL", Value: "http://first:8000"},
+		{Name: "MODEL_API_URL", Value: "http://second:8000"},
+	}
+
+	got := PreserveUserEnv(env, userEnv)
+	want := []corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "DEBUG"},
+		{Name: "MODEL_API_URL", Value: "http://second:8000"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	for i := range want {
+		if got[i].Name != want[i].Name || got[i].Value != want[i].Value {
+			t.Errorf("entry %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestOverriddenEnvNames(t *testing.T) {
+	userEnv := []corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "DEBUG"},
+		{Name: "MODEL_API_URL", Value: "http://override:8000"},
+		{Name: "CUSTOM", Value: "1"},
+	}
+	// LOG_LEVEL is only a copy of the user entry, as when generation skips set vars
+	env := []corev1.EnvVar{
+		{Name: "MODEL_API_URL", Value: "http://llm:8000"},
+		{Name: "LOG_LEVEL", Value: "DEBUG"},
+		{Name: "OTEL_SERVICE_NAME", Value: "agent"},
+	}
+
+	got := OverriddenEnvNames(env, userEnv)
+	if len(got) != 1 || got[0] != "MODEL_API_URL" {
+		t.Errorf("expected only MODEL_API_URL to be reported, got %v", got)
+	}
+	if got := OverriddenEnvNames(env, nil); got != nil {
+		t.Errorf("expected nothing without user env, got %v", got)
+	}
+}
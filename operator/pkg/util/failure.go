@@ -0,0 +1,36 @@
+package util
+
+import (
+	"os"
+	"time"
+)
+
+// DefaultFailureGracePeriod is how long a resource keeps retrying a recoverable reconcile
+// error before it is marked Failed
+const DefaultFailureGracePeriod = 2 * time.Minute
+
+// GetFailureGracePeriod returns the grace period from the FAILURE_GRACE_PERIOD env var (a
+// Go duration such as "90s"), falling back to DefaultFailureGracePeriod when unset or
+// invalid. Zero marks resources Failed on the first error.
+func GetFailureGracePeriod() time.Duration {
+	value := os.Getenv("FAILURE_GRACE_PERIOD")
+	if value == "" {
+		return DefaultFailureGracePeriod
+	}
+	period, err := time.ParseDuration(value)
+	if err != nil || period < 0 {
+		return DefaultFailureGracePeriod
+	}
+	return period
+}
+
+// MissingEnvError reports a required operator env var that is not set. It is a
+// configuration error that fails the same way on every retry until the operator is
+// redeployed with the variable set.
+type MissingEnvError struct {
+	Name string
+}
+
+func (e *MissingEnvError) Error() string {
+	return e.Name + " environment variable is required but not set"
+}
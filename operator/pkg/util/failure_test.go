@@ -0,0 +1,29 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetFailureGracePeriod(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		expect time.Duration
+	}{
+		{name: "unset uses default", value: "", expect: DefaultFailureGracePeriod},
+		{name: "valid value", value: "90s", expect: 90 * time.Second},
+		{name: "zero disables the grace period", value: "0", expect: 0},
+		{name: "invalid value uses default", value: "soon", expect: DefaultFailureGracePeriod},
+		{name: "negative value uses default", value: "-1m", expect: DefaultFailureGracePeriod},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("FAILURE_GRACE_PERIOD", tt.value)
+			if got := GetFailureGracePeriod(); got != tt.expect {
+				t.Errorf("expected %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
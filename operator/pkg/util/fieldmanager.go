@@ -0,0 +1,15 @@
+package util
+
+import "os"
+
+// DefaultFieldManager is the field manager recorded on objects written by the operator
+const DefaultFieldManager = "kaos-operator"
+
+// GetFieldManager returns the field manager name from the OPERATOR_FIELD_MANAGER env var.
+// Falls back to "kaos-operator" if not set.
+func GetFieldManager() string {
+	if manager := os.Getenv("OPERATOR_FIELD_MANAGER"); manager != "" {
+		return manager
+	}
+	return DefaultFieldManager
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// DefaultMaxInlineTextBytes is the default size limit for agent text passed inline as env vars.
+// The kernel caps a single env var at 128KiB (MAX_ARG_STRLEN) and all env vars share the
+// ARG_MAX budget, so larger values make pod creation fail with an opaque error.
+const DefaultMaxInlineTextBytes = 65536
+
+// GetMaxInlineTextBytes returns the inline text size limit from the MAX_INLINE_TEXT_BYTES
+// env var, falling back to DefaultMaxInlineTextBytes when unset or invalid.
+func GetMaxInlineTextBytes() int {
+	value := os.Getenv("MAX_INLINE_TEXT_BYTES")
+	if value == "" {
+		return DefaultMaxInlineTextBytes
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit <= 0 {
+		return DefaultMaxInlineTextBytes
+	}
+	return limit
+}
+
+// CheckInlineTextSize returns an error when value exceeds limit bytes. The hint is
+// appended to the error to suggest an alternative to inline text.
+func CheckInlineTextSize(field, value string, limit int, hint string) error {
+	if len(value) <= limit {
+		return nil
+	}
+	msg := fmt.Sprintf("%s is %d bytes, exceeding the %d byte limit for inline values", field, len(value), limit)
+	if hint != "" {
+		msg += "; " + hint
+	}
+	return errors.New(msg)
+}
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetMaxInlineTextBytes(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		expect int
+	}{
+		{name: "unset uses default", value: "", expect: DefaultMaxInlineTextBytes},
+		{name: "valid value", value: "1024", expect: 1024},
+		{name: "invalid value uses default", value: "lots", expect: DefaultMaxInlineTextBytes},
+		{name: "non-positive value uses default", value: "0", expect: DefaultMaxInlineTextBytes},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MAX_INLINE_TEXT_BYTES", tt.value)
+			if got := GetMaxInlineTextBytes(); got != tt.expect {
+				t.Errorf("expected %d, got %d", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestCheckInlineTextSize(t *testing.T) {
+	if err := CheckInlineTextSize("config.instructions", "short", 10, ""); err != nil {
+		t.Errorf("expected no error for value within limit, got %v", err)
+	}
+	if err := CheckInlineTextSize("config.instructions", strings.Repeat("a", 10), 10, ""); err != nil {
+		t.Errorf("expected no error for value at limit, got %v", err)
+	}
+
+	err := CheckInlineTextSize("config.instructions", strings.Repeat("a", 11), 10, "use config.instructionsFrom")
+	if err == nil {
+		t.Fatal("expected error for value over limit")
+	}
+	for _, want := range []string{"config.instructions is 11 bytes", "10 byte limit", "use config.instructionsFrom"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %q", want, err.Error())
+		}
+	}
+}
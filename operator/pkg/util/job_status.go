@@ -0,0 +1,93 @@
+package util
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// CopyJobStatus creates a JobStatus from a Kubernetes Job's status.
+// This mirrors key status fields to provide visibility into job completion.
+func CopyJobStatus(job *batchv1.Job) *kaosv1alpha1.JobStatus {
+	if job == nil {
+		return nil
+	}
+
+	status := &kaosv1alpha1.JobStatus{
+		Active:         job.Status.Active,
+		Succeeded:      job.Status.Succeeded,
+		Failed:         job.Status.Failed,
+		StartTime:      job.Status.StartTime,
+		CompletionTime: job.Status.CompletionTime,
+	}
+
+	// Convert job conditions to metav1.Condition format
+	for _, cond := range job.Status.Conditions {
+		status.Conditions = append(status.Conditions, metav1.Condition{
+			Type:               string(cond.Type),
+			Status:             metav1.ConditionStatus(cond.Status),
+			LastTransitionTime: cond.LastTransitionTime,
+			Reason:             cond.Reason,
+			Message:            cond.Message,
+		})
+	}
+
+	return status
+}
+
+// JobPhase returns the agent phase for a Job: Succeeded or Failed once the Job has
+// finished, Running while pods are active, and Pending otherwise.
+func JobPhase(job *batchv1.Job) string {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return "Succeeded"
+		case batchv1.JobFailed:
+			return "Failed"
+		}
+	}
+	if job.Status.Active > 0 {
+		return "Running"
+	}
+	return "Pending"
+}
+
+// ParseSchedule validates a standard 5-field cron expression.
+func ParseSchedule(schedule string) (cron.Schedule, error) {
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron schedule %q: %w", schedule, err)
+	}
+	return sched, nil
+}
+
+// CopyCronJobStatus creates a CronJobStatus from a Kubernetes CronJob's status.
+// The next schedule time is computed from the cron expression relative to now.
+func CopyCronJobStatus(cronJob *batchv1.CronJob, now time.Time) *kaosv1alpha1.CronJobStatus {
+	if cronJob == nil {
+		return nil
+	}
+
+	status := &kaosv1alpha1.CronJobStatus{
+		Schedule:           cronJob.Spec.Schedule,
+		Active:             int32(len(cronJob.Status.Active)),
+		LastScheduleTime:   cronJob.Status.LastScheduleTime,
+		LastSuccessfulTime: cronJob.Status.LastSuccessfulTime,
+	}
+
+	if sched, err := ParseSchedule(cronJob.Spec.Schedule); err == nil {
+		next := metav1.NewTime(sched.Next(now))
+		status.NextScheduleTime = &next
+	}
+
+	return status
+}
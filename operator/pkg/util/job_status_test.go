@@ -0,0 +1,96 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestJobPhase(t *testing.T) {
+	tests := []struct {
+		name   string
+		status batchv1.JobStatus
+		expect string
+	}{
+		{
+			name:   "no pods yet is pending",
+			status: batchv1.JobStatus{},
+			expect: "Pending",
+		},
+		{
+			name:   "active pods is running",
+			status: batchv1.JobStatus{Active: 1},
+			expect: "Running",
+		},
+		{
+			name: "complete condition is succeeded",
+			status: batchv1.JobStatus{
+				Succeeded:  1,
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			},
+			expect: "Succeeded",
+		},
+		{
+			name: "failed condition is failed",
+			status: batchv1.JobStatus{
+				Failed:     1,
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}},
+			},
+			expect: "Failed",
+		},
+		{
+			name: "false conditions are ignored",
+			status: batchv1.JobStatus{
+				Active:     1,
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionFalse}},
+			},
+			expect: "Running",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &batchv1.Job{Status: tt.status}
+			if got := JobPhase(job); got != tt.expect {
+				t.Errorf("expected %q, got %q", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestParseSchedule(t *testing.T) {
+	for _, schedule := range []string{"0 * * * *", "*/5 * * * *", "@daily"} {
+		if _, err := ParseSchedule(schedule); err != nil {
+			t.Errorf("expected %q to be valid, got %v", schedule, err)
+		}
+	}
+	for _, schedule := range []string{"", "not a cron", "* * *", "61 * * * *"} {
+		if _, err := ParseSchedule(schedule); err == nil {
+			t.Errorf("expected %q to be invalid", schedule)
+		}
+	}
+}
+
+func TestCopyCronJobStatus(t *testing.T) {
+	now := time.Date(2026, 1, 1, 10, 30, 0, 0, time.UTC)
+	cronJob := &batchv1.CronJob{
+		Spec: batchv1.CronJobSpec{Schedule: "0 * * * *"},
+		Status: batchv1.CronJobStatus{
+			Active: []corev1.ObjectReference{{Name: "run-1"}},
+		},
+	}
+
+	status := CopyCronJobStatus(cronJob, now)
+	if status.Schedule != "0 * * * *" {
+		t.Errorf("expected schedule to be copied, got %q", status.Schedule)
+	}
+	if status.Active != 1 {
+		t.Errorf("expected 1 active job, got %d", status.Active)
+	}
+	expectedNext := time.Date(2026, 1, 1, 11, 0, 0, 0, time.UTC)
+	if status.NextScheduleTime == nil || !status.NextScheduleTime.Time.Equal(expectedNext) {
+		t.Errorf("expected next schedule %v, got %v", expectedNext, status.NextScheduleTime)
+	}
+}
@@ -0,0 +1,71 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+const (
+	// LogFormatConsole is the human-readable development encoder (default)
+	LogFormatConsole = "console"
+	// LogFormatJSON is the structured production encoder
+	LogFormatJSON = "json"
+)
+
+// GetLogFormat returns the operator log format from the LOG_FORMAT env var.
+// Falls back to "console" if not set.
+func GetLogFormat() string {
+	format := os.Getenv("LOG_FORMAT")
+	if format == "" {
+		return LogFormatConsole
+	}
+	return format
+}
+
+// ApplyLogOptions configures zap options for the given log format and level.
+// "json" selects the production config (JSON encoder, no dev stack traces) and
+// "console" keeps the development config. The level uses the same names as
+// DEFAULT_LOG_LEVEL (TRACE, DEBUG, INFO, WARNING, ERROR) and is only applied
+// when --zap-log-level was not passed explicitly.
+func ApplyLogOptions(opts *zap.Options, format, level string) error {
+	switch strings.ToLower(format) {
+	case LogFormatJSON:
+		opts.Development = false
+	case LogFormatConsole:
+		opts.Development = true
+	default:
+		return fmt.Errorf("invalid log format %q: must be %q or %q", format, LogFormatJSON, LogFormatConsole)
+	}
+
+	if opts.Level == nil && level != "" {
+		zapLevel, err := parseLogLevel(level)
+		if err != nil {
+			return err
+		}
+		opts.Level = zapLevel
+	}
+	return nil
+}
+
+// parseLogLevel maps a DEFAULT_LOG_LEVEL value to a zap level.
+// TRACE maps to logr verbosity 2 so V(2) logs are emitted.
+func parseLogLevel(level string) (zapcore.Level, error) {
+	switch strings.ToUpper(level) {
+	case "TRACE":
+		return zapcore.Level(-2), nil
+	case "DEBUG":
+		return zapcore.DebugLevel, nil
+	case "INFO":
+		return zapcore.InfoLevel, nil
+	case "WARNING", "WARN":
+		return zapcore.WarnLevel, nil
+	case "ERROR":
+		return zapcore.ErrorLevel, nil
+	default:
+		return zapcore.InfoLevel, fmt.Errorf("invalid log level %q: must be one of TRACE, DEBUG, INFO, WARNING, ERROR", level)
+	}
+}
@@ -0,0 +1,89 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func logLine(t *testing.T, format, level string) string {
+	t.Helper()
+	opts := zap.Options{}
+	if err := ApplyLogOptions(&opts, format, level); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	opts.DestWriter = &buf
+	logger := zap.New(zap.UseFlagOptions(&opts))
+	logger.Info("hello", "key", "value")
+	return strings.TrimSpace(buf.String())
+}
+
+func TestApplyLogOptionsEncoderSelection(t *testing.T) {
+	out := logLine(t, "json", "INFO")
+	var entry map[string]interface{}
+	if err := json.Unmarshal([]byte(out), &entry); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", out, err)
+	}
+	if entry["msg"] != "hello" || entry["key"] != "value" {
+		t.Errorf("unexpected JSON entry: %v", entry)
+	}
+
+	out = logLine(t, "console", "INFO")
+	if strings.HasPrefix(out, "{") {
+		t.Errorf("expected console output, got %q", out)
+	}
+	if !strings.Contains(out, "hello") {
+		t.Errorf("expected message in console output, got %q", out)
+	}
+}
+
+func TestApplyLogOptionsLevel(t *testing.T) {
+	if out := logLine(t, "json", "ERROR"); out != "" {
+		t.Errorf("expected info log to be dropped at ERROR level, got %q", out)
+	}
+
+	opts := zap.Options{}
+	if err := ApplyLogOptions(&opts, "JSON", "trace"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Development {
+		t.Error("expected json format to disable development mode")
+	}
+	if opts.Level != zapcore.Level(-2) {
+		t.Errorf("expected TRACE to map to level -2, got %v", opts.Level)
+	}
+
+	// An explicit --zap-log-level takes precedence over DEFAULT_LOG_LEVEL
+	opts = zap.Options{Level: zapcore.WarnLevel}
+	if err := ApplyLogOptions(&opts, "console", "DEBUG"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Level != zapcore.WarnLevel {
+		t.Errorf("expected explicit level to be kept, got %v", opts.Level)
+	}
+}
+
+func TestApplyLogOptionsInvalid(t *testing.T) {
+	if err := ApplyLogOptions(&zap.Options{}, "xml", "INFO"); err == nil {
+		t.Error("expected error for invalid format")
+	}
+	if err := ApplyLogOptions(&zap.Options{}, "json", "VERBOSE"); err == nil {
+		t.Error("expected error for invalid level")
+	}
+}
+
+func TestGetLogFormat(t *testing.T) {
+	t.Setenv("LOG_FORMAT", "")
+	if got := GetLogFormat(); got != LogFormatConsole {
+		t.Errorf("expected default %q, got %q", LogFormatConsole, got)
+	}
+	t.Setenv("LOG_FORMAT", "json")
+	if got := GetLogFormat(); got != LogFormatJSON {
+		t.Errorf("expected %q, got %q", LogFormatJSON, got)
+	}
+}
@@ -0,0 +1,24 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// GetDefaultDebugMemoryEndpoints parses the DEFAULT_AGENT_DEBUG_MEMORY_ENDPOINTS env var,
+// whether agents expose their /memory/* debug endpoints when
+// spec.config.debugMemoryEndpoints is unset. Returns nil when unset, so the runtime
+// default (enabled) applies.
+func GetDefaultDebugMemoryEndpoints() (*bool, error) {
+	value := strings.TrimSpace(os.Getenv("DEFAULT_AGENT_DEBUG_MEMORY_ENDPOINTS"))
+	if value == "" {
+		return nil, nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DEFAULT_AGENT_DEBUG_MEMORY_ENDPOINTS %q: must be true or false", value)
+	}
+	return &enabled, nil
+}
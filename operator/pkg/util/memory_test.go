@@ -0,0 +1,29 @@
+package util
+
+import "testing"
+
+func TestGetDefaultDebugMemoryEndpoints(t *testing.T) {
+	enabled, disabled := true, false
+	tests := []struct {
+		value    string
+		expected *bool
+		wantErr  bool
+	}{
+		{"", nil, false},
+		{"true", &enabled, false},
+		{"false", &disabled, false},
+		{"invalid", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("DEFAULT_AGENT_DEBUG_MEMORY_ENDPOINTS", tt.value)
+			got, err := GetDefaultDebugMemoryEndpoints()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetDefaultDebugMemoryEndpoints() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if (got == nil) != (tt.expected == nil) || (got != nil && *got != *tt.expected) {
+				t.Errorf("GetDefaultDebugMemoryEndpoints() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
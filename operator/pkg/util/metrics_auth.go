@@ -0,0 +1,59 @@
+package util
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/rest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+// GetMetricsTokenFile returns the path of the bearer token required by the metrics
+// endpoint from the METRICS_TOKEN_FILE env var. Empty leaves the endpoint open.
+func GetMetricsTokenFile() string {
+	return strings.TrimSpace(os.Getenv("METRICS_TOKEN_FILE"))
+}
+
+// readMetricsToken reads the bearer token from tokenFile, rejecting an empty token
+func readMetricsToken(tokenFile string) (string, error) {
+	data, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read metrics token: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("metrics token file %s is empty", tokenFile)
+	}
+	return token, nil
+}
+
+// MetricsBearerTokenFilter returns a metrics server filter provider that rejects requests
+// without an "Authorization: Bearer" header matching the token in tokenFile. The file is
+// read on every request, so a rotated Secret is picked up without a restart.
+func MetricsBearerTokenFilter(tokenFile string) func(*rest.Config, *http.Client) (metricsserver.Filter, error) {
+	return func(_ *rest.Config, _ *http.Client) (metricsserver.Filter, error) {
+		if _, err := readMetricsToken(tokenFile); err != nil {
+			return nil, err
+		}
+		return func(log logr.Logger, handler http.Handler) (http.Handler, error) {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				token, err := readMetricsToken(tokenFile)
+				if err != nil {
+					log.Error(err, "rejecting metrics request")
+					http.Error(w, "metrics token unavailable", http.StatusInternalServerError)
+					return
+				}
+				provided, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+				if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+					http.Error(w, "Unauthorized", http.StatusUnauthorized)
+					return
+				}
+				handler.ServeHTTP(w, req)
+			}), nil
+		}, nil
+	}
+}
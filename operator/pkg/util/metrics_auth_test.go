@@ -0,0 +1,78 @@
+package util
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+func TestMetricsBearerTokenFilter(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	filter, err := MetricsBearerTokenFilter(tokenFile)(nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	handler, err := filter(logr.Discard(), http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		expect        int
+	}{
+		{name: "no token", expect: http.StatusUnauthorized},
+		{name: "wrong token", authorization: "Bearer nope", expect: http.StatusUnauthorized},
+		{name: "wrong scheme", authorization: "Basic s3cret", expect: http.StatusUnauthorized},
+		{name: "valid token", authorization: "Bearer s3cret", expect: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.expect {
+				t.Errorf("expected status %d, got %d", tt.expect, rec.Code)
+			}
+		})
+	}
+
+	// A rotated token is used without recreating the filter
+	if err := os.WriteFile(tokenFile, []byte("rotated"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer rotated")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected rotated token to be accepted, got %d", rec.Code)
+	}
+}
+
+func TestMetricsBearerTokenFilterRequiresToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if _, err := MetricsBearerTokenFilter(tokenFile)(nil, nil); err == nil {
+		t.Error("expected error for a missing token file")
+	}
+	if err := os.WriteFile(tokenFile, []byte("  \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := MetricsBearerTokenFilter(tokenFile)(nil, nil); err == nil {
+		t.Error("expected error for an empty token file")
+	}
+}
@@ -0,0 +1,39 @@
+package util
+
+import (
+	"os"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// blockOwnerDeletion is applied to every owner reference set through SetControllerReference
+var blockOwnerDeletion = true
+
+// GetBlockOwnerDeletion returns whether owner references set blockOwnerDeletion from the
+// OWNER_BLOCK_DELETION env var. Falls back to true (the controller-runtime default) if
+// not set or invalid.
+func GetBlockOwnerDeletion() bool {
+	block, err := strconv.ParseBool(os.Getenv("OWNER_BLOCK_DELETION"))
+	if err != nil {
+		return true
+	}
+	return block
+}
+
+// SetBlockOwnerDeletion configures blockOwnerDeletion for owner references set afterwards.
+// Disabling it lets foreground deletion of a resource finish without waiting for its
+// children, which GitOps tools that prune in dependency order rely on.
+func SetBlockOwnerDeletion(block bool) {
+	blockOwnerDeletion = block
+}
+
+// SetControllerReference sets owner as the controller of controlled, like
+// controllerutil.SetControllerReference, with the configured blockOwnerDeletion.
+// The reference is always a controller reference: Owns() watches and owned-object
+// cleanup depend on it.
+func SetControllerReference(owner, controlled metav1.Object, scheme *runtime.Scheme) error {
+	return controllerutil.SetControllerReference(owner, controlled, scheme, controllerutil.WithBlockOwnerDeletion(blockOwnerDeletion))
+}
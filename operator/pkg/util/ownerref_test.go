@@ -0,0 +1,58 @@
+package util
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestGetBlockOwnerDeletion(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"", true},
+		{"true", true},
+		{"false", false},
+		{"invalid", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("OWNER_BLOCK_DELETION", tt.value)
+			if got := GetBlockOwnerDeletion(); got != tt.expected {
+				t.Errorf("GetBlockOwnerDeletion() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSetControllerReference(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { SetBlockOwnerDeletion(true) })
+
+	for _, block := range []bool{true, false} {
+		SetBlockOwnerDeletion(block)
+		owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "default", UID: "owner-uid"}}
+		controlled := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "default"}}
+		if err := SetControllerReference(owner, controlled, scheme); err != nil {
+			t.Fatalf("SetControllerReference() error = %v", err)
+		}
+
+		refs := controlled.GetOwnerReferences()
+		if len(refs) != 1 {
+			t.Fatalf("expected 1 owner reference, got %d", len(refs))
+		}
+		ref := refs[0]
+		if ref.UID != "owner-uid" || ref.Controller == nil || !*ref.Controller {
+			t.Errorf("expected a controller reference to the owner, got %+v", ref)
+		}
+		if ref.BlockOwnerDeletion == nil || *ref.BlockOwnerDeletion != block {
+			t.Errorf("expected blockOwnerDeletion %v, got %v", block, ref.BlockOwnerDeletion)
+		}
+	}
+}
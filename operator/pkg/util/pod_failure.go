@@ -0,0 +1,53 @@
+package util
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PodFailure describes why a pod of a Deployment is not running
+type PodFailure struct {
+	// Pod is the name of the failing pod
+	Pod string
+	// Container is the name of the failing container, empty for pod-level failures
+	Container string
+	// Reason is the kubelet or scheduler reason, e.g. CrashLoopBackOff or ImagePullBackOff
+	Reason string
+	// Message is the detail reported with the reason, if any
+	Message string
+}
+
+// benignWaitingReasons are waiting reasons of containers that are still starting normally
+var benignWaitingReasons = map[string]bool{
+	"ContainerCreating": true,
+	"PodInitializing":   true,
+}
+
+// FindPodFailure returns the first failure among pods, taking pods by name and, within a
+// pod, init containers before containers. A container failing to start (e.g.
+// ImagePullBackOff) or crash-looping, a container that exited with an error and an
+// unschedulable pod count as failures. It returns nil when no pod is failing.
+func FindPodFailure(pods []corev1.Pod) *PodFailure {
+	sorted := make([]corev1.Pod, len(pods))
+	copy(sorted, pods)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	for _, pod := range sorted {
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "" && !benignWaitingReasons[waiting.Reason] {
+				return &PodFailure{Pod: pod.Name, Container: status.Name, Reason: waiting.Reason, Message: waiting.Message}
+			}
+			if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+				return &PodFailure{Pod: pod.Name, Container: status.Name, Reason: terminated.Reason, Message: terminated.Message}
+			}
+		}
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == corev1.PodScheduled && cond.Status == corev1.ConditionFalse && cond.Reason == corev1.PodReasonUnschedulable {
+				return &PodFailure{Pod: pod.Name, Reason: cond.Reason, Message: cond.Message}
+			}
+		}
+	}
+	return nil
+}
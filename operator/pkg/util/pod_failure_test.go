@@ -0,0 +1,76 @@
+package util
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFindPodFailure(t *testing.T) {
+	waiting := func(reason string) corev1.ContainerState {
+		return corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}}
+	}
+	pod := func(name string, status corev1.PodStatus) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: status}
+	}
+
+	tests := []struct {
+		name   string
+		pods   []corev1.Pod
+		expect *PodFailure
+	}{
+		{
+			name: "starting containers are not failures",
+			pods: []corev1.Pod{pod("a", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "app", State: waiting("ContainerCreating")}}})},
+		},
+		{
+			name:   "image pull back-off",
+			pods:   []corev1.Pod{pod("a", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "app", State: waiting("ImagePullBackOff")}}})},
+			expect: &PodFailure{Pod: "a", Container: "app", Reason: "ImagePullBackOff"},
+		},
+		{
+			name: "crash loop",
+			pods: []corev1.Pod{pod("a", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "app", State: waiting("CrashLoopBackOff"),
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1}},
+			}}})},
+			expect: &PodFailure{Pod: "a", Container: "app", Reason: "CrashLoopBackOff"},
+		},
+		{
+			name: "init containers come first",
+			pods: []corev1.Pod{pod("a", corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{{Name: "pull", State: corev1.ContainerState{
+					Terminated: &corev1.ContainerStateTerminated{ExitCode: 2, Reason: "Error"},
+				}}},
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "app", State: waiting("PodInitializing")}},
+			})},
+			expect: &PodFailure{Pod: "a", Container: "pull", Reason: "Error"},
+		},
+		{
+			name: "unschedulable pod",
+			pods: []corev1.Pod{pod("a", corev1.PodStatus{Conditions: []corev1.PodCondition{{
+				Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable,
+				Message: "0/3 nodes are available: 3 Insufficient cpu.",
+			}}})},
+			expect: &PodFailure{Pod: "a", Reason: "Unschedulable", Message: "0/3 nodes are available: 3 Insufficient cpu."},
+		},
+		{
+			name: "pods are taken by name",
+			pods: []corev1.Pod{
+				pod("b", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "app", State: waiting("ErrImagePull")}}}),
+				pod("a", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "app", State: waiting("ImagePullBackOff")}}}),
+			},
+			expect: &PodFailure{Pod: "a", Container: "app", Reason: "ImagePullBackOff"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindPodFailure(tt.pods)
+			if (got == nil) != (tt.expect == nil) || (got != nil && *got != *tt.expect) {
+				t.Errorf("expected %+v, got %+v", tt.expect, got)
+			}
+		})
+	}
+}
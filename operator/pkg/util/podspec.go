@@ -5,18 +5,43 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
+
+	"github.com/axsaucedo/kaos/operator/pkg/features"
 )
 
 // PodSpecHashAnnotation is the annotation key used to store the pod spec hash
 const PodSpecHashAnnotation = "kaos.tools/pod-spec-hash"
 
+// PodSpecPatchError reports a podSpec override that cannot be merged as intended
+type PodSpecPatchError struct {
+	Message string
+}
+
+func (e *PodSpecPatchError) Error() string {
+	return e.Message
+}
+
 // MergePodSpec merges a patch PodSpec into a base PodSpec using strategic merge patch.
 // This allows users to override specific fields (like resources, replicas via podSpec)
 // while preserving the base configuration.
+//
+// Containers are merged by name. A patch container without a name targets the first
+// base container. A patch container whose name matches no base container is added as a
+// sidecar, so it must set an image; without one it is most likely a misspelt override
+// and a PodSpecPatchError is returned instead of adding a broken container.
 func MergePodSpec(base, patch corev1.PodSpec) (corev1.PodSpec, error) {
+	patch, err := normalizePodSpecPatch(base, patch)
+	if err != nil {
+		return base, err
+	}
+
 	baseJSON, err := json.Marshal(base)
 	if err != nil {
 		return base, err
@@ -40,9 +65,58 @@ func MergePodSpec(base, patch corev1.PodSpec) (corev1.PodSpec, error) {
 	return merged, nil
 }
 
+// normalizePodSpecPatch names unnamed patch containers after the first base container
+// and rejects patch containers and init containers that match nothing and set no image
+func normalizePodSpecPatch(base, patch corev1.PodSpec) (corev1.PodSpec, error) {
+	if len(patch.Containers) > 0 && len(base.Containers) > 0 {
+		patch.Containers = slices.Clone(patch.Containers)
+		for i := range patch.Containers {
+			if patch.Containers[i].Name == "" {
+				patch.Containers[i].Name = base.Containers[0].Name
+			}
+		}
+	}
+
+	if err := checkPatchContainers("containers", base.Containers, patch.Containers); err != nil {
+		return patch, err
+	}
+	if err := checkPatchContainers("initContainers", base.InitContainers, patch.InitContainers); err != nil {
+		return patch, err
+	}
+	return patch, nil
+}
+
+// checkPatchContainers returns a PodSpecPatchError for the first patch container that
+// matches no base container by name and has no image
+func checkPatchContainers(field string, base, patch []corev1.Container) error {
+	names := make([]string, 0, len(base))
+	for _, container := range base {
+		names = append(names, container.Name)
+	}
+	for _, container := range patch {
+		if container.Image != "" || slices.Contains(names, container.Name) {
+			continue
+		}
+		generated := "none"
+		if len(names) > 0 {
+			generated = strings.Join(names, ", ")
+		}
+		return &PodSpecPatchError{Message: fmt.Sprintf(
+			"podSpec.%s entry %q does not match a generated container (%s); use the generated name to override it, or set image to add a sidecar",
+			field, container.Name, generated)}
+	}
+	return nil
+}
+
 // ComputePodSpecHash computes a SHA256 hash of the pod spec.
 // This is used to detect changes that should trigger a rolling update.
+// With the NormalizedPodSpecHash feature gate enabled, the spec is normalized with
+// NormalizePodSpecForHash first, so only changes that alter the running pods change
+// the hash.
 func ComputePodSpecHash(spec corev1.PodSpec) string {
+	if features.Enabled(features.NormalizedPodSpecHash) {
+		spec = NormalizePodSpecForHash(spec)
+	}
 	data, err := json.Marshal(spec)
 	if err != nil {
 		// Fallback to empty hash on error - will always trigger update
@@ -52,3 +126,80 @@ func ComputePodSpecHash(spec corev1.PodSpec) string {
 	// Use first 16 chars for brevity
 	return hex.EncodeToString(hash[:])[:16]
 }
+
+// NormalizePodSpecForHash returns a copy of spec with the fields that do not affect
+// the running pods normalized, so equivalent specs hash equally:
+//   - lists whose order is not significant (ports, volume mounts, volumes, image pull
+//     secrets) are sorted
+//   - fields set to the API server default (restart and DNS policy, scheduler, grace
+//     period, termination message settings, TCP protocol, empty security contexts)
+//     are cleared, as are deprecated aliases such as serviceAccount
+//
+// env, envFrom, containers and initContainers keep their order since it is meaningful:
+// $(VAR) references in env only expand variables defined earlier in the list.
+func NormalizePodSpecForHash(spec corev1.PodSpec) corev1.PodSpec {
+	spec = *spec.DeepCopy()
+
+	if spec.RestartPolicy == corev1.RestartPolicyAlways {
+		spec.RestartPolicy = ""
+	}
+	if spec.DNSPolicy == corev1.DNSClusterFirst {
+		spec.DNSPolicy = ""
+	}
+	if spec.SchedulerName == corev1.DefaultSchedulerName {
+		spec.SchedulerName = ""
+	}
+	if spec.TerminationGracePeriodSeconds != nil && *spec.TerminationGracePeriodSeconds == corev1.DefaultTerminationGracePeriodSeconds {
+		spec.TerminationGracePeriodSeconds = nil
+	}
+	if spec.ServiceAccountName == "" {
+		spec.ServiceAccountName = spec.DeprecatedServiceAccount
+	}
+	spec.DeprecatedServiceAccount = ""
+	if spec.SecurityContext != nil && equality.Semantic.DeepEqual(*spec.SecurityContext, corev1.PodSecurityContext{}) {
+		spec.SecurityContext = nil
+	}
+
+	slices.SortStableFunc(spec.Volumes, func(a, b corev1.Volume) int { return strings.Compare(a.Name, b.Name) })
+	slices.SortStableFunc(spec.ImagePullSecrets, func(a, b corev1.LocalObjectReference) int { return strings.Compare(a.Name, b.Name) })
+	for i := range spec.InitContainers {
+		normalizeContainerForHash(&spec.InitContainers[i])
+	}
+	for i := range spec.Containers {
+		normalizeContainerForHash(&spec.Containers[i])
+	}
+	return spec
+}
+
+// normalizeContainerForHash applies NormalizePodSpecForHash to a single container
+func normalizeContainerForHash(container *corev1.Container) {
+	if container.TerminationMessagePath == corev1.TerminationMessagePathDefault {
+		container.TerminationMessagePath = ""
+	}
+	if container.TerminationMessagePolicy == corev1.TerminationMessageReadFile {
+		container.TerminationMessagePolicy = ""
+	}
+	if container.SecurityContext != nil && equality.Semantic.DeepEqual(*container.SecurityContext, corev1.SecurityContext{}) {
+		container.SecurityContext = nil
+	}
+	for i := range container.Ports {
+		if container.Ports[i].Protocol == corev1.ProtocolTCP {
+			container.Ports[i].Protocol = ""
+		}
+	}
+
+	slices.SortStableFunc(container.Ports, func(a, b corev1.ContainerPort) int {
+		if a.ContainerPort != b.ContainerPort {
+			return int(a.ContainerPort - b.ContainerPort)
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	slices.SortStableFunc(container.VolumeMounts, func(a, b corev1.VolumeMount) int { return strings.Compare(a.MountPath, b.MountPath) })
+}
+
+// ComputeContentHash computes a SHA256 hash of arbitrary content, truncated like
+// ComputePodSpecHash. Used to roll pods when referenced configuration changes.
+func ComputeContentHash(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])[:16]
+}
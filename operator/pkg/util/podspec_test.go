@@ -0,0 +1,219 @@
+package util
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/axsaucedo/kaos/operator/pkg/features"
+)
+
+func podSpecBase() corev1.PodSpec {
+	return corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "wait-for-dependencies", Image: "busybox:1.36"}},
+		Containers: []corev1.Container{{
+			Name:  "agent",
+			Image: "axsauze/kaos-agent:test",
+			Env:   []corev1.EnvVar{{Name: "AGENT_NAME", Value: "a"}},
+		}},
+	}
+}
+
+func limits(memory string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memory)},
+	}
+}
+
+func TestMergePodSpecMatchingName(t *testing.T) {
+	patch := corev1.PodSpec{Containers: []corev1.Container{{Name: "agent", Resources: limits("1Gi")}}}
+
+	merged, err := MergePodSpec(podSpecBase(), patch)
+	if err != nil {
+		t.Fatalf("MergePodSpec() error = %v", err)
+	}
+	if len(merged.Containers) != 1 {
+		t.Fatalf("expected the override to merge into the generated container, got %d containers", len(merged.Containers))
+	}
+	container := merged.Containers[0]
+	if container.Image != "axsauze/kaos-agent:test" || len(container.Env) != 1 {
+		t.Errorf("expected generated fields to be preserved, got %+v", container)
+	}
+	if got := container.Resources.Limits.Memory().String(); got != "1Gi" {
+		t.Errorf("expected memory limit 1Gi, got %s", got)
+	}
+}
+
+func TestMergePodSpecUnnamedContainer(t *testing.T) {
+	patch := corev1.PodSpec{Containers: []corev1.Container{{Resources: limits("512Mi")}}}
+
+	merged, err := MergePodSpec(podSpecBase(), patch)
+	if err != nil {
+		t.Fatalf("MergePodSpec() error = %v", err)
+	}
+	if len(merged.Containers) != 1 || merged.Containers[0].Name != "agent" {
+		t.Fatalf("expected an unnamed container to target the generated one, got %+v", merged.Containers)
+	}
+	if got := merged.Containers[0].Resources.Limits.Memory().String(); got != "512Mi" {
+		t.Errorf("expected memory limit 512Mi, got %s", got)
+	}
+}
+
+func TestMergePodSpecMismatchedName(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch corev1.PodSpec
+		want  string
+	}{
+		{
+			"container",
+			corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Resources: limits("1Gi")}}},
+			`podSpec.containers entry "main" does not match a generated container (agent)`,
+		},
+		{
+			"init container",
+			corev1.PodSpec{InitContainers: []corev1.Container{{Name: "wait", Resources: limits("64Mi")}}},
+			`podSpec.initContainers entry "wait" does not match a generated container (wait-for-dependencies)`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			base := podSpecBase()
+			merged, err := MergePodSpec(base, tc.patch)
+			var patchErr *PodSpecPatchError
+			if !errors.As(err, &patchErr) || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected a PodSpecPatchError containing %q, got %v", tc.want, err)
+			}
+			if len(merged.Containers) != 1 || len(merged.InitContainers) != 1 {
+				t.Errorf("expected the base to be returned unchanged, got %+v", merged)
+			}
+		})
+	}
+}
+
+func TestMergePodSpecSidecar(t *testing.T) {
+	patch := corev1.PodSpec{Containers: []corev1.Container{{Name: "proxy", Image: "envoyproxy/envoy:v1.31"}}}
+
+	merged, err := MergePodSpec(podSpecBase(), patch)
+	if err != nil {
+		t.Fatalf("MergePodSpec() error = %v", err)
+	}
+	names := []string{}
+	for _, container := range merged.Containers {
+		names = append(names, container.Name)
+	}
+	if strings.Join(names, ",") != "agent,proxy" && strings.Join(names, ",") != "proxy,agent" {
+		t.Errorf("expected the sidecar to be added next to the generated container, got %v", names)
+	}
+}
+
+// enableNormalizedPodSpecHash enables the NormalizedPodSpecHash feature gate for the test
+func enableNormalizedPodSpecHash(t *testing.T) {
+	t.Helper()
+	if err := features.DefaultGate.Set("NormalizedPodSpecHash=true"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = features.DefaultGate.Set("NormalizedPodSpecHash=false") })
+}
+
+func TestComputePodSpecHashIgnoresOrdering(t *testing.T) {
+	enableNormalizedPodSpecHash(t)
+	a := corev1.PodSpec{
+		Volumes: []corev1.Volume{{Name: "config"}, {Name: "cache"}},
+		Containers: []corev1.Container{{
+			Name:         "agent",
+			Image:        "axsauze/kaos-agent:test",
+			Env:          []corev1.EnvVar{{Name: "AGENT_NAME", Value: "a"}, {Name: "LOG_LEVEL", Value: "INFO"}},
+			Ports:        []corev1.ContainerPort{{Name: "http", ContainerPort: 8000}, {Name: "metrics", ContainerPort: 9090}},
+			VolumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: "/etc/kaos"}, {Name: "cache", MountPath: "/cache"}},
+		}},
+	}
+	b := *a.DeepCopy()
+	slices.Reverse(b.Volumes)
+	slices.Reverse(b.Containers[0].Ports)
+	slices.Reverse(b.Containers[0].VolumeMounts)
+
+	if ComputePodSpecHash(a) != ComputePodSpecHash(b) {
+		t.Error("expected reordered but equivalent specs to hash equally")
+	}
+	if b.Containers[0].Ports[0].Name != "metrics" {
+		t.Error("expected the input spec not to be modified")
+	}
+}
+
+func TestComputePodSpecHashIgnoresDefaults(t *testing.T) {
+	enableNormalizedPodSpecHash(t)
+	a := corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:  "agent",
+			Image: "axsauze/kaos-agent:test",
+			Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8000}},
+		}},
+	}
+	grace := int64(corev1.DefaultTerminationGracePeriodSeconds)
+	b := *a.DeepCopy()
+	b.RestartPolicy = corev1.RestartPolicyAlways
+	b.DNSPolicy = corev1.DNSClusterFirst
+	b.SchedulerName = corev1.DefaultSchedulerName
+	b.TerminationGracePeriodSeconds = &grace
+	b.SecurityContext = &corev1.PodSecurityContext{}
+	b.Containers[0].TerminationMessagePath = corev1.TerminationMessagePathDefault
+	b.Containers[0].TerminationMessagePolicy = corev1.TerminationMessageReadFile
+	b.Containers[0].Ports[0].Protocol = corev1.ProtocolTCP
+
+	if ComputePodSpecHash(a) != ComputePodSpecHash(b) {
+		t.Error("expected explicitly defaulted fields not to change the hash")
+	}
+
+	a.ServiceAccountName = "tools"
+	b.DeprecatedServiceAccount = "tools"
+	if ComputePodSpecHash(a) != ComputePodSpecHash(b) {
+		t.Error("expected serviceAccount to hash like serviceAccountName")
+	}
+}
+
+func TestComputePodSpecHashDetectsChanges(t *testing.T) {
+	enableNormalizedPodSpecHash(t)
+	base := corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:  "agent",
+			Image: "axsauze/kaos-agent:test",
+			Env: []corev1.EnvVar{
+				{Name: "LOG_LEVEL", Value: "INFO"},
+				{Name: "AGENT_LOG_LEVEL", Value: "$(LOG_LEVEL)"},
+			},
+			EnvFrom: []corev1.EnvFromSource{{Prefix: "A_"}, {Prefix: "B_"}},
+		}},
+	}
+	hash := ComputePodSpecHash(base)
+
+	tests := map[string]func(*corev1.PodSpec){
+		"env value":      func(s *corev1.PodSpec) { s.Containers[0].Env[0].Value = "DEBUG" },
+		"env order":      func(s *corev1.PodSpec) { slices.Reverse(s.Containers[0].Env) },
+		"image":          func(s *corev1.PodSpec) { s.Containers[0].Image = "axsauze/kaos-agent:next" },
+		"envFrom order":  func(s *corev1.PodSpec) { slices.Reverse(s.Containers[0].EnvFrom) },
+		"restart policy": func(s *corev1.PodSpec) { s.RestartPolicy = corev1.RestartPolicyOnFailure },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec := *base.DeepCopy()
+			mutate(&spec)
+			if ComputePodSpecHash(spec) == hash {
+				t.Errorf("expected a %s change to change the hash", name)
+			}
+		})
+	}
+}
+
+func TestComputePodSpecHashNormalizationGated(t *testing.T) {
+	a := corev1.PodSpec{Containers: []corev1.Container{{Name: "agent", Image: "axsauze/kaos-agent:test"}}}
+	b := *a.DeepCopy()
+	b.RestartPolicy = corev1.RestartPolicyAlways
+	if ComputePodSpecHash(a) == ComputePodSpecHash(b) {
+		t.Error("expected the raw spec to be hashed while the NormalizedPodSpecHash feature gate is off")
+	}
+}
@@ -0,0 +1,43 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// NewCRDReadyCheck returns a readiness checker that lists each of the given kinds
+// once (limit 1) and reports not-ready until every list succeeds. Without the
+// CRDs installed the operator would run but silently reconcile nothing.
+// Once all kinds have been listed successfully the result is cached, so the
+// API server is not queried on every probe.
+// The reader should bypass the cache (e.g. mgr.GetAPIReader()).
+func NewCRDReadyCheck(reader client.Reader, lists ...client.ObjectList) healthz.Checker {
+	var mu sync.Mutex
+	ready := false
+
+	return func(req *http.Request) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if ready {
+			return nil
+		}
+
+		ctx := context.Background()
+		if req != nil {
+			ctx = req.Context()
+		}
+		for _, list := range lists {
+			obj := list.DeepCopyObject().(client.ObjectList)
+			if err := reader.List(ctx, obj, client.Limit(1)); err != nil {
+				return fmt.Errorf("CRD for %T not available: %w", list, err)
+			}
+		}
+		ready = true
+		return nil
+	}
+}
@@ -0,0 +1,34 @@
+package util
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+func TestCRDReadyCheck(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := kaosv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to build scheme: %v", err)
+	}
+	present := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	check := NewCRDReadyCheck(present,
+		&kaosv1alpha1.AgentList{}, &kaosv1alpha1.ModelAPIList{}, &kaosv1alpha1.MCPServerList{})
+	if err := check(nil); err != nil {
+		t.Errorf("expected nil when all kinds are present, got %v", err)
+	}
+}
+
+func TestCRDReadyCheckMissingKind(t *testing.T) {
+	// A client whose scheme lacks the KAOS kinds behaves like a cluster without the CRDs
+	missing := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+
+	check := NewCRDReadyCheck(missing, &kaosv1alpha1.AgentList{})
+	if err := check(nil); err == nil {
+		t.Error("expected error when a kind is missing")
+	}
+}
@@ -0,0 +1,188 @@
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// referencedKeys records the keys read from each referenced object; a nil entry means
+// every key is read (envFrom, or a volume without items)
+type referencedKeys map[string][]string
+
+// add records that key of name is read, or the whole object when key is ""
+func (r referencedKeys) add(name, key string) {
+	if name == "" {
+		return
+	}
+	keys, seen := r[name]
+	switch {
+	case seen && keys == nil:
+		// Every key is already read
+	case key == "":
+		r[name] = nil
+	case !slices.Contains(keys, key):
+		r[name] = append(keys, key)
+	}
+}
+
+// addItems records the keys projected by a volume, or the whole object without items
+func (r referencedKeys) addItems(name string, items []corev1.KeyToPath) {
+	if len(items) == 0 {
+		r.add(name, "")
+	}
+	for _, item := range items {
+		r.add(name, item.Key)
+	}
+}
+
+// names returns the sorted referenced object names
+func (r referencedKeys) names() []string {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// podSpecReferencedKeys collects the ConfigMap and Secret keys a pod spec reads
+func podSpecReferencedKeys(spec corev1.PodSpec) (configMaps, secrets referencedKeys) {
+	configMaps, secrets = referencedKeys{}, referencedKeys{}
+
+	for _, container := range slices.Concat(spec.InitContainers, spec.Containers) {
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+				configMaps.add(ref.Name, ref.Key)
+			}
+			if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+				secrets.add(ref.Name, ref.Key)
+			}
+		}
+		for _, source := range container.EnvFrom {
+			if source.ConfigMapRef != nil {
+				configMaps.add(source.ConfigMapRef.Name, "")
+			}
+			if source.SecretRef != nil {
+				secrets.add(source.SecretRef.Name, "")
+			}
+		}
+	}
+
+	for _, volume := range spec.Volumes {
+		if volume.ConfigMap != nil {
+			configMaps.addItems(volume.ConfigMap.Name, volume.ConfigMap.Items)
+		}
+		if volume.Secret != nil {
+			secrets.addItems(volume.Secret.SecretName, volume.Secret.Items)
+		}
+		if volume.Projected == nil {
+			continue
+		}
+		for _, source := range volume.Projected.Sources {
+			if source.ConfigMap != nil {
+				configMaps.addItems(source.ConfigMap.Name, source.ConfigMap.Items)
+			}
+			if source.Secret != nil {
+				secrets.addItems(source.Secret.Name, source.Secret.Items)
+			}
+		}
+	}
+	return configMaps, secrets
+}
+
+// PodSpecReferences returns the sorted names of the ConfigMaps and Secrets a pod spec
+// reads from, through env valueFrom, envFrom and volumes (including projected volumes)
+func PodSpecReferences(spec corev1.PodSpec) (configMaps, secrets []string) {
+	configMapKeys, secretKeys := podSpecReferencedKeys(spec)
+	return configMapKeys.names(), secretKeys.names()
+}
+
+// referencedContent is the hashed form of a referenced ConfigMap or Secret
+type referencedContent struct {
+	Kind       string            `json:"kind"`
+	Name       string            `json:"name"`
+	Missing    bool              `json:"missing,omitempty"`
+	Data       map[string]string `json:"data,omitempty"`
+	BinaryData map[string][]byte `json:"binaryData,omitempty"`
+}
+
+// ComputeReferencedContentHash hashes the data of the ConfigMaps and Secrets that spec
+// references in the namespace of owner, so a change to their content can roll the pods.
+// Only the keys the pods read are hashed, so editing an unrelated key does not roll them.
+// Objects controlled by owner are skipped since the controller hashes those itself.
+// A missing object is hashed as missing, so creating it later also changes the hash.
+// Returns "" when spec references nothing.
+func ComputeReferencedContentHash(ctx context.Context, c client.Reader, owner client.Object, spec corev1.PodSpec) (string, error) {
+	configMaps, secrets := podSpecReferencedKeys(spec)
+	if len(configMaps) == 0 && len(secrets) == 0 {
+		return "", nil
+	}
+
+	var contents []referencedContent
+	fetch := func(kind, name string, keys []string, obj client.Object) error {
+		err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: owner.GetNamespace()}, obj)
+		if apierrors.IsNotFound(err) {
+			contents = append(contents, referencedContent{Kind: kind, Name: name, Missing: true})
+			return nil
+		}
+		if err != nil || metav1.IsControlledBy(obj, owner) {
+			return err
+		}
+		content := referencedContent{Kind: kind, Name: name}
+		switch o := obj.(type) {
+		case *corev1.ConfigMap:
+			content.Data, content.BinaryData = selectKeys(o.Data, keys), selectKeys(o.BinaryData, keys)
+		case *corev1.Secret:
+			content.BinaryData = selectKeys(o.Data, keys)
+		}
+		contents = append(contents, content)
+		return nil
+	}
+	for _, name := range configMaps.names() {
+		if err := fetch("ConfigMap", name, configMaps[name], &corev1.ConfigMap{}); err != nil {
+			return "", err
+		}
+	}
+	for _, name := range secrets.names() {
+		if err := fetch("Secret", name, secrets[name], &corev1.Secret{}); err != nil {
+			return "", err
+		}
+	}
+	if len(contents) == 0 {
+		return "", nil
+	}
+
+	// json.Marshal sorts map keys, so the hash is stable
+	data, err := json.Marshal(contents)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])[:16], nil
+}
+
+// selectKeys returns the entries of data for keys, or all of data when keys is nil
+func selectKeys[V any](data map[string]V, keys []string) map[string]V {
+	if keys == nil {
+		return data
+	}
+	selected := map[string]V{}
+	for _, key := range keys {
+		if value, ok := data[key]; ok {
+			selected[key] = value
+		}
+	}
+	return selected
+}
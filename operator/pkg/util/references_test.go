@@ -0,0 +1,147 @@
+package util
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func referencingPodSpec() corev1.PodSpec {
+	return corev1.PodSpec{
+		Volumes: []corev1.Volume{
+			{Name: "instructions", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "prompts"},
+				Items:                []corev1.KeyToPath{{Key: "system.md", Path: "instructions"}},
+			}}},
+			{Name: "tls", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{{Secret: &corev1.SecretProjection{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "certs"},
+				}}},
+			}}},
+		},
+		Containers: []corev1.Container{{
+			Name: "agent",
+			Env: []corev1.EnvVar{{Name: "OPENAI_API_KEY", ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "llm-keys"}, Key: "openai"},
+			}}},
+			EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "settings"},
+			}}},
+		}},
+	}
+}
+
+func TestPodSpecReferences(t *testing.T) {
+	configMaps, secrets := PodSpecReferences(referencingPodSpec())
+	if !slices.Equal(configMaps, []string{"prompts", "settings"}) {
+		t.Errorf("unexpected ConfigMaps %v", configMaps)
+	}
+	if !slices.Equal(secrets, []string{"certs", "llm-keys"}) {
+		t.Errorf("unexpected Secrets %v", secrets)
+	}
+}
+
+func TestComputeReferencedContentHash(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "default", UID: "owner-uid"}}
+	prompts := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "prompts", Namespace: "default"},
+		Data:       map[string]string{"system.md": "Be brief.", "notes.md": "unused"},
+	}
+	keys := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "llm-keys", Namespace: "default"},
+		Data:       map[string][]byte{"openai": []byte("sk-1"), "anthropic": []byte("unused")},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(prompts, keys).Build()
+	ctx := context.Background()
+	spec := referencingPodSpec()
+
+	hash := func() string {
+		t.Helper()
+		h, err := ComputeReferencedContentHash(ctx, c, owner, spec)
+		if err != nil {
+			t.Fatalf("ComputeReferencedContentHash() error = %v", err)
+		}
+		return h
+	}
+	update := func(obj client.Object) {
+		t.Helper()
+		if err := c.Update(ctx, obj); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	initial := hash()
+	if initial == "" || hash() != initial {
+		t.Fatalf("expected a stable non-empty hash, got %q", initial)
+	}
+
+	// Keys the pod does not read do not change the hash
+	prompts.Data["notes.md"] = "still unused"
+	keys.Data["anthropic"] = []byte("still unused")
+	update(prompts)
+	update(keys)
+	if hash() != initial {
+		t.Error("expected edits to unreferenced keys not to change the hash")
+	}
+
+	prompts.Data["system.md"] = "Be thorough."
+	update(prompts)
+	edited := hash()
+	if edited == initial {
+		t.Error("expected editing a referenced ConfigMap key to change the hash")
+	}
+
+	keys.Data["openai"] = []byte("sk-2")
+	update(keys)
+	if hash() == edited {
+		t.Error("expected rotating a referenced Secret key to change the hash")
+	}
+
+	// Creating a previously missing object changes the hash
+	before := hash()
+	settings := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default"}, Data: map[string]string{"A": "1"}}
+	if err := c.Create(ctx, settings); err != nil {
+		t.Fatal(err)
+	}
+	if hash() == before {
+		t.Error("expected creating a referenced ConfigMap to change the hash")
+	}
+
+	if h, err := ComputeReferencedContentHash(ctx, c, owner, corev1.PodSpec{}); err != nil || h != "" {
+		t.Errorf("expected an empty hash without references, got %q, %v", h, err)
+	}
+}
+
+func TestComputeReferencedContentHashSkipsOwnedObjects(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	owner := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "default", UID: "owner-uid"}}
+	owned := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: "default", OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "v1", Kind: "ConfigMap", Name: "owner", UID: "owner-uid", Controller: &[]bool{true}[0],
+		}}},
+		Data: map[string]string{"A": "1"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(owned).Build()
+	spec := corev1.PodSpec{Containers: []corev1.Container{{
+		Name:    "model-api",
+		EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}}},
+	}}}
+
+	h, err := ComputeReferencedContentHash(context.Background(), c, owner, spec)
+	if err != nil || h != "" {
+		t.Errorf("expected ConfigMaps controlled by the owner to be skipped, got %q, %v", h, err)
+	}
+}
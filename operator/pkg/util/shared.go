@@ -0,0 +1,40 @@
+package util
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetSharedResourcesNamespace returns the central namespace operator-managed shared
+// resources (e.g. the MCP runtime registry ConfigMap) are read from, set by the
+// SHARED_RESOURCES_NAMESPACE env var. Defaults to systemNamespace when unset.
+func GetSharedResourcesNamespace(systemNamespace string) string {
+	if value := strings.TrimSpace(os.Getenv("SHARED_RESOURCES_NAMESPACE")); value != "" {
+		return value
+	}
+	return systemNamespace
+}
+
+// GetSharedConfigMap fetches the named shared ConfigMap from the central namespace,
+// falling back to namespace (the namespace of the resource being reconciled) when it
+// does not exist there
+func GetSharedConfigMap(ctx context.Context, c client.Reader, sharedNamespace, namespace, name string) (*corev1.ConfigMap, error) {
+	cm := &corev1.ConfigMap{}
+	err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: sharedNamespace}, cm)
+	if err == nil {
+		return cm, nil
+	}
+	if !apierrors.IsNotFound(err) || namespace == "" || namespace == sharedNamespace {
+		return nil, err
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, cm); err != nil {
+		return nil, err
+	}
+	return cm, nil
+}
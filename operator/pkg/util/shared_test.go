@@ -0,0 +1,63 @@
+package util
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestGetSharedResourcesNamespace(t *testing.T) {
+	if got := GetSharedResourcesNamespace("kaos-system"); got != "kaos-system" {
+		t.Errorf("expected the system namespace by default, got %s", got)
+	}
+	t.Setenv("SHARED_RESOURCES_NAMESPACE", "kaos-shared")
+	if got := GetSharedResourcesNamespace("kaos-system"); got != "kaos-shared" {
+		t.Errorf("expected SHARED_RESOURCES_NAMESPACE, got %s", got)
+	}
+}
+
+func TestGetSharedConfigMap(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	configMap := func(namespace string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: namespace},
+			Data:       map[string]string{"from": namespace},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap("kaos-shared"), configMap("team-b")).Build()
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		shared    string
+		namespace string
+		want      string
+	}{
+		{"central namespace wins", "kaos-shared", "team-b", "kaos-shared"},
+		{"falls back to the resource namespace", "kaos-system", "team-b", "team-b"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cm, err := GetSharedConfigMap(ctx, c, tc.shared, tc.namespace, "shared")
+			if err != nil {
+				t.Fatalf("GetSharedConfigMap() error = %v", err)
+			}
+			if cm.Data["from"] != tc.want {
+				t.Errorf("expected the ConfigMap from %s, got %s", tc.want, cm.Data["from"])
+			}
+		})
+	}
+
+	_, err := GetSharedConfigMap(ctx, c, "kaos-system", "team-a", "shared")
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected not found when neither namespace has it, got %v", err)
+	}
+}
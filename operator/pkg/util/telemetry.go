@@ -64,8 +64,8 @@ func IsTelemetryConfigValid(tel *kaosv1alpha1.TelemetryConfig) bool {
 // Uses standard OTEL_* env vars so the SDK auto-configures.
 // serviceName is used as OTEL_SERVICE_NAME (typically the CR name).
 // namespace is added to OTEL_RESOURCE_ATTRIBUTES as KAOS-specific attributes.
-// Note: If user sets OTEL_RESOURCE_ATTRIBUTES in spec.config.env, both will be present
-// and the user value takes precedence when they appear later in the env list.
+// Note: If user sets any of these in spec.container.env, the controllers drop the
+// generated entry (see PreserveUserEnv) so the user value or valueFrom wins.
 func BuildTelemetryEnvVars(tel *kaosv1alpha1.TelemetryConfig, serviceName, namespace string) []corev1.EnvVar {
 	if tel == nil || !tel.Enabled {
 		return nil
@@ -91,7 +91,7 @@ func BuildTelemetryEnvVars(tel *kaosv1alpha1.TelemetryConfig, serviceName, names
 
 	// Add KAOS-specific resource attributes
 	// These are added as a baseline; if user also sets OTEL_RESOURCE_ATTRIBUTES
-	// in spec.config.env, the container runtime merges them (later values win)
+	// in spec.container.env, the user value replaces this one
 	kaosAttrs := "service.namespace=" + namespace + ",kaos.resource.name=" + serviceName
 	envVars = append(envVars, corev1.EnvVar{
 		Name:  "OTEL_RESOURCE_ATTRIBUTES",
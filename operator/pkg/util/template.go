@@ -0,0 +1,125 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// InstructionsTemplateData is the context available to templated agent instructions and
+// descriptions. Only these fields may be referenced, so templates cannot read arbitrary
+// parts of the Agent spec.
+type InstructionsTemplateData struct {
+	// Name is the Agent name
+	Name string
+	// Namespace is the Agent namespace
+	Namespace string
+	// Peers are the names of the agents in agentNetwork.access
+	Peers []string
+	// Model is the model the agent uses
+	Model string
+}
+
+// instructionsTemplateFields lists the InstructionsTemplateData fields templates may reference
+var instructionsTemplateFields = []string{"Name", "Namespace", "Peers", "Model"}
+
+// RenderInstructionsTemplate renders text as a Go template against data. Text without
+// template actions is returned unchanged. An error is returned when the template does not
+// parse, references a field outside InstructionsTemplateData, or fails to execute; field
+// names the spec field in the message.
+func RenderInstructionsTemplate(field, text string, data InstructionsTemplateData) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New(field).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%s is not a valid template: %w", field, err)
+	}
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil {
+			continue
+		}
+		if name := disallowedTemplateField(t.Tree.Root, true); name != "" {
+			return "", fmt.Errorf("%s references .%s; templates may only use .%s",
+				field, name, strings.Join(instructionsTemplateFields, ", ."))
+		}
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", field, err)
+	}
+	return out.String(), nil
+}
+
+// disallowedTemplateField returns the first field referenced on the template data in node
+// that is not allowlisted, or "". dotIsData reports whether dot still refers to the data
+// at node; inside range and with blocks dot is rebound, so only $-rooted fields are checked.
+func disallowedTemplateField(node parse.Node, dotIsData bool) string {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return ""
+		}
+		for _, child := range n.Nodes {
+			if name := disallowedTemplateField(child, dotIsData); name != "" {
+				return name
+			}
+		}
+	case *parse.ActionNode:
+		return disallowedTemplateField(n.Pipe, dotIsData)
+	case *parse.TemplateNode:
+		return disallowedTemplateField(n.Pipe, dotIsData)
+	case *parse.PipeNode:
+		if n == nil {
+			return ""
+		}
+		for _, cmd := range n.Cmds {
+			for _, arg := range cmd.Args {
+				if name := disallowedTemplateField(arg, dotIsData); name != "" {
+					return name
+				}
+			}
+		}
+	case *parse.ChainNode:
+		return disallowedTemplateField(n.Node, dotIsData)
+	case *parse.FieldNode:
+		if dotIsData && !allowedTemplateField(n.Ident[0]) {
+			return n.Ident[0]
+		}
+	case *parse.VariableNode:
+		if n.Ident[0] == "$" && len(n.Ident) > 1 && !allowedTemplateField(n.Ident[1]) {
+			return n.Ident[1]
+		}
+	case *parse.IfNode:
+		return disallowedBranchField(&n.BranchNode, dotIsData, dotIsData)
+	case *parse.RangeNode:
+		return disallowedBranchField(&n.BranchNode, dotIsData, false)
+	case *parse.WithNode:
+		return disallowedBranchField(&n.BranchNode, dotIsData, false)
+	}
+	return ""
+}
+
+// disallowedBranchField checks the pipeline of an if, range or with block against the
+// current dot, its body against bodyDotIsData, and its else branch against the current dot
+func disallowedBranchField(n *parse.BranchNode, dotIsData, bodyDotIsData bool) string {
+	if name := disallowedTemplateField(n.Pipe, dotIsData); name != "" {
+		return name
+	}
+	if name := disallowedTemplateField(n.List, bodyDotIsData); name != "" {
+		return name
+	}
+	return disallowedTemplateField(n.ElseList, dotIsData)
+}
+
+func allowedTemplateField(name string) bool {
+	for _, field := range instructionsTemplateFields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+var templateData = InstructionsTemplateData{
+	Name:      "researcher",
+	Namespace: "team-a",
+	Peers:     []string{"writer", "reviewer"},
+	Model:     "openai/gpt-4o",
+}
+
+func TestRenderInstructionsTemplate(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		expect string
+	}{
+		{name: "plain text is unchanged", text: "You are helpful.", expect: "You are helpful."},
+		{name: "name and namespace", text: "You are {{.Name}} in {{.Namespace}}.", expect: "You are researcher in team-a."},
+		{name: "model", text: "Running on {{.Model}}", expect: "Running on openai/gpt-4o"},
+		{
+			name:   "range over peers",
+			text:   "Delegate to:{{range .Peers}} {{.}}{{end}}",
+			expect: "Delegate to: writer reviewer",
+		},
+		{
+			name:   "root variable inside range",
+			text:   "{{range .Peers}}{{.}}@{{$.Namespace}} {{end}}",
+			expect: "writer@team-a reviewer@team-a ",
+		},
+		{name: "builtin functions", text: `{{if .Peers}}{{len .Peers}} peers{{end}}`, expect: "2 peers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderInstructionsTemplate("config.instructions", tt.text, templateData)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expect {
+				t.Errorf("expected %q, got %q", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestRenderInstructionsTemplateRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		expect string
+	}{
+		{name: "unknown field", text: "{{.Spec.Config}}", expect: "references .Spec"},
+		{name: "unknown field in if", text: "{{if .Secret}}x{{end}}", expect: "references .Secret"},
+		{name: "unknown root variable in range", text: "{{range .Peers}}{{$.Token}}{{end}}", expect: "references .Token"},
+		{name: "unknown field in else", text: "{{with .Model}}{{.}}{{else}}{{.Labels}}{{end}}", expect: "references .Labels"},
+		{name: "parse error", text: "{{.Name", expect: "is not a valid template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RenderInstructionsTemplate("config.instructions", tt.text, templateData)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), "config.instructions") || !strings.Contains(err.Error(), tt.expect) {
+				t.Errorf("expected error containing %q, got %v", tt.expect, err)
+			}
+		})
+	}
+}
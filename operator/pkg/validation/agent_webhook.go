@@ -0,0 +1,113 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/validate-kaos-tools-v1alpha1-agent,mutating=false,failurePolicy=fail,sideEffects=None,groups=kaos.tools,resources=agents,verbs=create;update,versions=v1alpha1,name=vagent.kaos.tools,admissionReviewVersions=v1
+
+// AgentValidator rejects Agents that list themselves in agentNetwork.access, and warns
+// when an Agent and one of its peers list each other. Mutual peers are allowed since
+// they are sometimes intended, but delegation between them can loop. The reconciler
+// runs the self-reference check as a fallback when the webhook is not installed.
+type AgentValidator struct {
+	// Client looks up peer Agents for the mutual-peer warning
+	Client client.Reader
+}
+
+var _ admission.CustomValidator = &AgentValidator{}
+
+// SetupAgentWebhookWithManager registers the Agent validating webhook
+func SetupAgentWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&kaosv1alpha1.Agent{}).
+		WithValidator(&AgentValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// ValidateCreate validates a new Agent
+func (v *AgentValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return v.validate(ctx, obj)
+}
+
+// ValidateUpdate validates an updated Agent. Agents being deleted are admitted so the
+// controller can always remove its finalizer.
+func (v *AgentValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	if agent, ok := newObj.(*kaosv1alpha1.Agent); ok && agent.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	return v.validate(ctx, newObj)
+}
+
+// ValidateDelete allows all deletions
+func (v *AgentValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *AgentValidator) validate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	agent, ok := obj.(*kaosv1alpha1.Agent)
+	if !ok {
+		return nil, fmt.Errorf("expected an Agent but got %T", obj)
+	}
+	if err := ValidateAgentNetworkAccess(agent); err != nil {
+		return nil, err
+	}
+	if err := ValidateAgentOverflow(agent); err != nil {
+		return nil, err
+	}
+	if v.Client == nil || agent.Spec.AgentNetwork == nil {
+		return nil, nil
+	}
+
+	// Warnings are best effort: peers that do not exist yet or cannot be read are skipped
+	var warnings admission.Warnings
+	for _, name := range agent.Spec.AgentNetwork.Access {
+		peer := &kaosv1alpha1.Agent{}
+		if err := v.Client.Get(ctx, types.NamespacedName{Name: name, Namespace: agent.Namespace}, peer); err != nil {
+			continue
+		}
+		if peer.Spec.AgentNetwork != nil && slices.Contains(peer.Spec.AgentNetwork.Access, agent.Name) {
+			warnings = append(warnings, fmt.Sprintf(
+				"agents %q and %q list each other in agentNetwork.access; delegation between them can loop", agent.Name, name))
+		}
+	}
+	return warnings, nil
+}
+
+// ValidateAgentOverflow checks config.overflowPolicy, and that config.queueDepth is only
+// set with the "queue" policy, since rejected requests never wait
+func ValidateAgentOverflow(agent *kaosv1alpha1.Agent) error {
+	config := agent.Spec.Config
+	if config == nil {
+		return nil
+	}
+	switch config.OverflowPolicy {
+	case "", kaosv1alpha1.OverflowPolicyReject, kaosv1alpha1.OverflowPolicyQueue:
+	default:
+		return fmt.Errorf("config.overflowPolicy must be %q or %q, got %q",
+			kaosv1alpha1.OverflowPolicyReject, kaosv1alpha1.OverflowPolicyQueue, config.OverflowPolicy)
+	}
+	if config.QueueDepth != nil && config.OverflowPolicy == kaosv1alpha1.OverflowPolicyReject {
+		return fmt.Errorf("config.queueDepth cannot be set with overflowPolicy %q", kaosv1alpha1.OverflowPolicyReject)
+	}
+	return nil
+}
+
+// ValidateAgentNetworkAccess returns an error when agentNetwork.access contains the
+// agent's own name, which would let the agent delegate to itself
+func ValidateAgentNetworkAccess(agent *kaosv1alpha1.Agent) error {
+	if agent.Spec.AgentNetwork != nil && slices.Contains(agent.Spec.AgentNetwork.Access, agent.Name) {
+		return fmt.Errorf("agentNetwork.access must not contain the agent's own name %q", agent.Name)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package validation
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+func networkedAgent(name string, access ...string) *kaosv1alpha1.Agent {
+	return &kaosv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: kaosv1alpha1.AgentSpec{
+			ModelAPI:     "llm",
+			Model:        "mock-model",
+			AgentNetwork: &kaosv1alpha1.AgentNetworkConfig{Access: access},
+		},
+	}
+}
+
+func agentValidator(t *testing.T, objs ...*kaosv1alpha1.Agent) *AgentValidator {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := kaosv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, obj := range objs {
+		builder = builder.WithObjects(obj)
+	}
+	return &AgentValidator{Client: builder.Build()}
+}
+
+func TestAgentValidatorSelfReference(t *testing.T) {
+	v := agentValidator(t)
+	_, err := v.ValidateCreate(context.Background(), networkedAgent("researcher", "writer", "researcher"))
+	if err == nil || !strings.Contains(err.Error(), `must not contain the agent's own name "researcher"`) {
+		t.Errorf("expected a self-reference error, got %v", err)
+	}
+
+	_, err = v.ValidateUpdate(context.Background(), networkedAgent("researcher"), networkedAgent("researcher", "researcher"))
+	if err == nil {
+		t.Error("expected the update to be rejected")
+	}
+
+	// The controller must still be able to remove its finalizer from a deleting agent
+	deleting := networkedAgent("researcher", "researcher")
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	if _, err := v.ValidateUpdate(context.Background(), deleting, deleting); err != nil {
+		t.Errorf("expected a deleting agent to be admitted, got %v", err)
+	}
+}
+
+func TestAgentValidatorMutualPeers(t *testing.T) {
+	v := agentValidator(t, networkedAgent("writer", "researcher"), networkedAgent("reviewer"))
+
+	warnings, err := v.ValidateCreate(context.Background(), networkedAgent("researcher", "writer", "reviewer", "missing"))
+	if err != nil {
+		t.Fatalf("expected mutual peers to be admitted, got %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], `"researcher" and "writer" list each other`) {
+		t.Errorf("expected one mutual-peer warning, got %v", warnings)
+	}
+
+	warnings, err = v.ValidateCreate(context.Background(), networkedAgent("researcher", "reviewer"))
+	if err != nil || len(warnings) != 0 {
+		t.Errorf("expected no warnings for a one-way peer, got %v, %v", warnings, err)
+	}
+}
+
+func TestValidateAgentOverflow(t *testing.T) {
+	depth := int32(10)
+	tests := []struct {
+		name    string
+		config  *kaosv1alpha1.AgentConfig
+		wantErr string
+	}{
+		{name: "no config"},
+		{name: "defaults", config: &kaosv1alpha1.AgentConfig{}},
+		{name: "reject", config: &kaosv1alpha1.AgentConfig{OverflowPolicy: kaosv1alpha1.OverflowPolicyReject}},
+		{name: "queue with depth", config: &kaosv1alpha1.AgentConfig{OverflowPolicy: kaosv1alpha1.OverflowPolicyQueue, QueueDepth: &depth}},
+		{name: "depth with default policy", config: &kaosv1alpha1.AgentConfig{QueueDepth: &depth}},
+		{name: "invalid policy", config: &kaosv1alpha1.AgentConfig{OverflowPolicy: "drop"}, wantErr: `must be "reject" or "queue", got "drop"`},
+		{name: "depth with reject", config: &kaosv1alpha1.AgentConfig{OverflowPolicy: kaosv1alpha1.OverflowPolicyReject, QueueDepth: &depth}, wantErr: "queueDepth cannot be set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := networkedAgent("agent")
+			agent.Spec.Config = tt.config
+			err := ValidateAgentOverflow(agent)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	agent := networkedAgent("agent")
+	agent.Spec.Config = &kaosv1alpha1.AgentConfig{OverflowPolicy: "drop"}
+	if _, err := agentValidator(t).ValidateCreate(context.Background(), agent); err == nil {
+		t.Error("expected the webhook to reject an invalid overflow policy")
+	}
+}
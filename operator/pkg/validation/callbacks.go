@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// defaultLiteLLMCallbacks are the LiteLLM callbacks accepted in proxyConfig.callbacks
+// unless LITELLM_ALLOWED_CALLBACKS overrides the set
+var defaultLiteLLMCallbacks = []string{
+	"arize",
+	"braintrust",
+	"datadog",
+	"helicone",
+	"lakera_prompt_injection",
+	"langfuse",
+	"langsmith",
+	"lunary",
+	"mlflow",
+	"opik",
+	"presidio",
+	"prometheus",
+}
+
+// AllowedLiteLLMCallbacks returns the callback names accepted in proxyConfig.callbacks,
+// read from the LITELLM_ALLOWED_CALLBACKS env var (comma-separated) or the built-in set
+// when unset
+func AllowedLiteLLMCallbacks() []string {
+	value := os.Getenv("LITELLM_ALLOWED_CALLBACKS")
+	if strings.TrimSpace(value) == "" {
+		return defaultLiteLLMCallbacks
+	}
+
+	var callbacks []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			callbacks = append(callbacks, name)
+		}
+	}
+	return callbacks
+}
+
+// ValidateProxyCallbacks checks proxyConfig.callbacks against the allowed callbacks.
+// Callbacks are rendered into the generated LiteLLM config, so they cannot be combined
+// with configYaml.
+func ValidateProxyCallbacks(proxyConfig *kaosv1alpha1.ProxyConfig) error {
+	if proxyConfig == nil || len(proxyConfig.Callbacks) == 0 {
+		return nil
+	}
+	if proxyConfig.ConfigYaml != nil && proxyConfig.ConfigYaml.FromString != "" {
+		return fmt.Errorf("callbacks cannot be combined with configYaml; set litellm_settings.callbacks in configYaml instead")
+	}
+
+	allowed := AllowedLiteLLMCallbacks()
+	for _, callback := range proxyConfig.Callbacks {
+		if !containsString(allowed, callback) {
+			return fmt.Errorf("callback %q is not allowed; allowed callbacks: %s", callback, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
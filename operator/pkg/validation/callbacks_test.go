@@ -0,0 +1,48 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+func TestValidateProxyCallbacks(t *testing.T) {
+	tests := []struct {
+		name        string
+		allowed     string
+		proxyConfig *kaosv1alpha1.ProxyConfig
+		wantErr     string
+	}{
+		{name: "no callbacks", proxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"*"}}},
+		{name: "default set", proxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"*"}, Callbacks: []string{"langfuse", "lakera_prompt_injection"}}},
+		{name: "unknown callback", proxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"*"}, Callbacks: []string{"custom_logger"}}, wantErr: `callback "custom_logger" is not allowed`},
+		{name: "configured set", allowed: "custom_logger, langfuse", proxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"*"}, Callbacks: []string{"custom_logger"}}},
+		{name: "configured set excludes default", allowed: "custom_logger", proxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"*"}, Callbacks: []string{"langfuse"}}, wantErr: "allowed callbacks: custom_logger"},
+		{
+			name: "with configYaml",
+			proxyConfig: &kaosv1alpha1.ProxyConfig{
+				Models:     []string{"*"},
+				Callbacks:  []string{"langfuse"},
+				ConfigYaml: &kaosv1alpha1.ConfigYamlSource{FromString: "model_list: []"},
+			},
+			wantErr: "cannot be combined with configYaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("LITELLM_ALLOWED_CALLBACKS", tt.allowed)
+			err := ValidateProxyCallbacks(tt.proxyConfig)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// guardrailCategoryPattern matches the category names of guardrail thresholds, which are
+// rendered as YAML keys of category_thresholds
+var guardrailCategoryPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// guardrailThresholdPattern matches plain decimal scores. ParseFloat alone also accepts
+// NaN, Inf, hex floats and exponents, which would be rendered verbatim into the config.
+var guardrailThresholdPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// ValidateProxyGuardrails checks proxyConfig.guardrails: threshold categories must be
+// plain identifiers with a decimal score between 0 and 1. Guardrails are rendered into the
+// generated LiteLLM config, so they cannot be combined with configYaml. Provider and
+// mode are enforced by the CRD schema.
+func ValidateProxyGuardrails(proxyConfig *kaosv1alpha1.ProxyConfig) error {
+	if proxyConfig == nil || len(proxyConfig.Guardrails) == 0 {
+		return nil
+	}
+	if proxyConfig.ConfigYaml != nil && proxyConfig.ConfigYaml.FromString != "" {
+		return fmt.Errorf("guardrails cannot be combined with configYaml; set guardrails in configYaml instead")
+	}
+
+	for _, guardrail := range proxyConfig.Guardrails {
+		categories := make([]string, 0, len(guardrail.Thresholds))
+		for category := range guardrail.Thresholds {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+		for _, category := range categories {
+			if !guardrailCategoryPattern.MatchString(category) {
+				return fmt.Errorf("guardrail %q: invalid threshold category %q", guardrail.Name, category)
+			}
+			value := guardrail.Thresholds[category]
+			score, err := strconv.ParseFloat(value, 64)
+			if !guardrailThresholdPattern.MatchString(value) || err != nil || score > 1 {
+				return fmt.Errorf("guardrail %q: threshold %s=%q must be a decimal number between 0 and 1", guardrail.Name, category, value)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+func TestValidateProxyGuardrails(t *testing.T) {
+	guardrail := func(thresholds map[string]string) kaosv1alpha1.Guardrail {
+		return kaosv1alpha1.Guardrail{Name: "lakera-pre", Provider: "lakera_v2", Mode: "pre_call", Thresholds: thresholds}
+	}
+
+	tests := []struct {
+		name        string
+		proxyConfig *kaosv1alpha1.ProxyConfig
+		wantErr     string
+	}{
+		{name: "no guardrails", proxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"*"}}},
+		{
+			name: "valid thresholds",
+			proxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"*"}, Guardrails: []kaosv1alpha1.Guardrail{
+				guardrail(map[string]string{"prompt_injection": "0.1", "jailbreak": "1"}),
+			}},
+		},
+		{
+			name: "threshold above 1",
+			proxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"*"}, Guardrails: []kaosv1alpha1.Guardrail{
+				guardrail(map[string]string{"jailbreak": "1.5"}),
+			}},
+			wantErr: `threshold jailbreak="1.5" must be a decimal number between 0 and 1`,
+		},
+		{
+			name: "non-numeric threshold",
+			proxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"*"}, Guardrails: []kaosv1alpha1.Guardrail{
+				guardrail(map[string]string{"jailbreak": "high"}),
+			}},
+			wantErr: "must be a decimal number",
+		},
+		{
+			name: "NaN threshold",
+			proxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"*"}, Guardrails: []kaosv1alpha1.Guardrail{
+				guardrail(map[string]string{"jailbreak": "NaN"}),
+			}},
+			wantErr: "must be a decimal number",
+		},
+		{
+			name: "infinite threshold",
+			proxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"*"}, Guardrails: []kaosv1alpha1.Guardrail{
+				guardrail(map[string]string{"jailbreak": "-Inf"}),
+			}},
+			wantErr: "must be a decimal number",
+		},
+		{
+			name: "hex float threshold",
+			proxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"*"}, Guardrails: []kaosv1alpha1.Guardrail{
+				guardrail(map[string]string{"jailbreak": "0x1p-2"}),
+			}},
+			wantErr: "must be a decimal number",
+		},
+		{
+			name: "invalid category",
+			proxyConfig: &kaosv1alpha1.ProxyConfig{Models: []string{"*"}, Guardrails: []kaosv1alpha1.Guardrail{
+				guardrail(map[string]string{"a: b": "0.5"}),
+			}},
+			wantErr: "invalid threshold category",
+		},
+		{
+			name: "with configYaml",
+			proxyConfig: &kaosv1alpha1.ProxyConfig{
+				Models:     []string{"*"},
+				Guardrails: []kaosv1alpha1.Guardrail{guardrail(nil)},
+				ConfigYaml: &kaosv1alpha1.ConfigYamlSource{FromString: "model_list: []"},
+			},
+			wantErr: "cannot be combined with configYaml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateProxyGuardrails(tt.proxyConfig)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/validate-kaos-tools-v1alpha1-modelapi,mutating=false,failurePolicy=fail,sideEffects=None,groups=kaos.tools,resources=modelapis,verbs=create;update,versions=v1alpha1,name=vmodelapi.kaos.tools,admissionReviewVersions=v1
+
+// ModelAPIValidator rejects ModelAPIs whose proxyConfig.configYaml declares models
+// not covered by proxyConfig.models, or whose proxyConfig aliases, callbacks or
+// guardrails are invalid, so the error surfaces at `kubectl apply`
+// instead of as a Failed phase after reconcile. The reconciler runs the same
+// check as a fallback when the webhook is not installed.
+type ModelAPIValidator struct{}
+
+var _ admission.CustomValidator = &ModelAPIValidator{}
+
+// SetupModelAPIWebhookWithManager registers the ModelAPI validating webhook
+func SetupModelAPIWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&kaosv1alpha1.ModelAPI{}).
+		WithValidator(&ModelAPIValidator{}).
+		Complete()
+}
+
+// ValidateCreate validates a new ModelAPI
+func (v *ModelAPIValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(obj)
+}
+
+// ValidateUpdate validates an updated ModelAPI
+func (v *ModelAPIValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(newObj)
+}
+
+// ValidateDelete allows all deletions
+func (v *ModelAPIValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ModelAPIValidator) validate(obj runtime.Object) error {
+	modelapi, ok := obj.(*kaosv1alpha1.ModelAPI)
+	if !ok {
+		return fmt.Errorf("expected a ModelAPI but got %T", obj)
+	}
+	if modelapi.Spec.Mode != kaosv1alpha1.ModelAPIModeProxy {
+		return nil
+	}
+	if err := ValidateConfigYamlModels(modelapi.Spec.ProxyConfig); err != nil {
+		return err
+	}
+	if err := ValidateProxyAliases(modelapi.Spec.ProxyConfig); err != nil {
+		return err
+	}
+	if err := ValidateProxyFallbacks(modelapi.Spec.ProxyConfig); err != nil {
+		return err
+	}
+	if err := ValidateProxyCallbacks(modelapi.Spec.ProxyConfig); err != nil {
+		return err
+	}
+	return ValidateProxyGuardrails(modelapi.Spec.ProxyConfig)
+}
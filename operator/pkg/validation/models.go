@@ -0,0 +1,133 @@
+// Package validation contains spec checks shared by the reconcilers and the
+// validating admission webhooks, so both report the same errors.
+package validation
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+// ModelMatchesPatterns reports whether model matches any pattern in the list.
+// Patterns may be an exact model name, "*" (any model) or a provider wildcard
+// such as "openai/*" (any model with the "openai/" prefix).
+func ModelMatchesPatterns(model string, patterns []string) bool {
+	for _, pattern := range patterns {
+		// Full wildcard
+		if pattern == "*" {
+			return true
+		}
+		// Exact match
+		if pattern == model {
+			return true
+		}
+		// Provider wildcard: "openai/*" matches "openai/gpt-4"
+		if strings.HasSuffix(pattern, "/*") {
+			prefix := strings.TrimSuffix(pattern, "*")
+			if strings.HasPrefix(model, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// liteLLMConfig represents the structure of LiteLLM config for validation
+type liteLLMConfig struct {
+	ModelList []struct {
+		ModelName string `yaml:"model_name"`
+	} `yaml:"model_list"`
+}
+
+// ValidateConfigYamlModels validates that model_names in configYaml match the models list
+func ValidateConfigYamlModels(proxyConfig *kaosv1alpha1.ProxyConfig) error {
+	if proxyConfig == nil || proxyConfig.ConfigYaml == nil || proxyConfig.ConfigYaml.FromString == "" {
+		return nil
+	}
+
+	// Parse the configYaml
+	var config liteLLMConfig
+	if err := yaml.Unmarshal([]byte(proxyConfig.ConfigYaml.FromString), &config); err != nil {
+		return fmt.Errorf("failed to parse configYaml: %w", err)
+	}
+
+	// Check each model_name in configYaml against the models list
+	for _, entry := range config.ModelList {
+		if !ModelMatchesPatterns(entry.ModelName, proxyConfig.Models) {
+			return fmt.Errorf("model_name %q in configYaml not found in models list %v", entry.ModelName, proxyConfig.Models)
+		}
+	}
+
+	return nil
+}
+
+// ValidateProxyFallbacks checks that every model in proxyConfig.fallbacks, and every
+// model it falls back to, is a concrete model served by the proxy (matched by the models
+// list or an alias), and that no model falls back to itself. Fallbacks are rendered into
+// the generated config, so they cannot be combined with configYaml.
+func ValidateProxyFallbacks(proxyConfig *kaosv1alpha1.ProxyConfig) error {
+	if proxyConfig == nil || len(proxyConfig.Fallbacks) == 0 {
+		return nil
+	}
+	if proxyConfig.ConfigYaml != nil && proxyConfig.ConfigYaml.FromString != "" {
+		return fmt.Errorf("fallbacks cannot be combined with configYaml; add router_settings.fallbacks to configYaml instead")
+	}
+
+	served := func(model string) error {
+		if model == "" || strings.Contains(model, "*") {
+			return fmt.Errorf("fallbacks must use concrete models, got %q", model)
+		}
+		if _, ok := proxyConfig.Aliases[model]; !ok && !ModelMatchesPatterns(model, proxyConfig.Models) {
+			return fmt.Errorf("fallback model %q not found in models list %v or aliases", model, proxyConfig.Models)
+		}
+		return nil
+	}
+	for _, model := range slices.Sorted(maps.Keys(proxyConfig.Fallbacks)) {
+		if err := served(model); err != nil {
+			return err
+		}
+		if len(proxyConfig.Fallbacks[model]) == 0 {
+			return fmt.Errorf("fallbacks for %q must list at least one model", model)
+		}
+		for _, fallback := range proxyConfig.Fallbacks[model] {
+			if fallback == model {
+				return fmt.Errorf("model %q cannot fall back to itself", model)
+			}
+			if err := served(fallback); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateProxyAliases checks that every alias in proxyConfig.aliases points to a concrete
+// model matched by the models list and does not shadow a listed model. Aliases are
+// rendered into the generated LiteLLM config, so they cannot be combined with configYaml.
+func ValidateProxyAliases(proxyConfig *kaosv1alpha1.ProxyConfig) error {
+	if proxyConfig == nil || len(proxyConfig.Aliases) == 0 {
+		return nil
+	}
+	if proxyConfig.ConfigYaml != nil && proxyConfig.ConfigYaml.FromString != "" {
+		return fmt.Errorf("aliases cannot be combined with configYaml; add the alias model_list entries to configYaml instead")
+	}
+
+	for _, alias := range slices.Sorted(maps.Keys(proxyConfig.Aliases)) {
+		target := proxyConfig.Aliases[alias]
+		if slices.Contains(proxyConfig.Models, alias) {
+			return fmt.Errorf("alias %q shadows a model in the models list", alias)
+		}
+		if target == "" || strings.Contains(target, "*") {
+			return fmt.Errorf("alias %q must target a concrete model, got %q", alias, target)
+		}
+		if !ModelMatchesPatterns(target, proxyConfig.Models) {
+			return fmt.Errorf("alias %q target %q not found in models list %v", alias, target, proxyConfig.Models)
+		}
+	}
+	return nil
+}
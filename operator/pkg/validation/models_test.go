@@ -0,0 +1,169 @@
+package validation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	kaosv1alpha1 "github.com/axsaucedo/kaos/operator/api/v1alpha1"
+)
+
+func TestModelMatchesPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    string
+		patterns []string
+		expect   bool
+	}{
+		{name: "full wildcard", model: "gemini/gemini-pro", patterns: []string{"*"}, expect: true},
+		{name: "exact match", model: "openai/gpt-4", patterns: []string{"openai/gpt-4"}, expect: true},
+		{name: "provider wildcard", model: "openai/gpt-4o", patterns: []string{"openai/*"}, expect: true},
+		{name: "other provider", model: "gemini/gemini-pro", patterns: []string{"openai/*"}, expect: false},
+		{name: "no match", model: "gpt-4", patterns: []string{"gpt-4o"}, expect: false},
+		{name: "empty patterns", model: "gpt-4", patterns: nil, expect: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ModelMatchesPatterns(tt.model, tt.patterns); got != tt.expect {
+				t.Errorf("expected %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func proxyConfigWithYaml(models []string, configYaml string) *kaosv1alpha1.ProxyConfig {
+	return &kaosv1alpha1.ProxyConfig{
+		Models:     models,
+		ConfigYaml: &kaosv1alpha1.ConfigYamlSource{FromString: configYaml},
+	}
+}
+
+func TestValidateConfigYamlModels(t *testing.T) {
+	configYaml := `
+model_list:
+  - model_name: "openai/gpt-4"
+  - model_name: "gemini/gemini-pro"
+`
+	if err := ValidateConfigYamlModels(proxyConfigWithYaml([]string{"openai/*", "gemini/*"}, configYaml)); err != nil {
+		t.Errorf("expected matching models to pass, got %v", err)
+	}
+
+	err := ValidateConfigYamlModels(proxyConfigWithYaml([]string{"openai/*"}, configYaml))
+	if err == nil || !strings.Contains(err.Error(), "gemini/gemini-pro") {
+		t.Errorf("expected error naming the mismatched model, got %v", err)
+	}
+
+	if err := ValidateConfigYamlModels(proxyConfigWithYaml([]string{"*"}, "model_list: [")); err == nil {
+		t.Error("expected parse error for invalid YAML")
+	}
+
+	if err := ValidateConfigYamlModels(&kaosv1alpha1.ProxyConfig{Models: []string{"gpt-4"}}); err != nil {
+		t.Errorf("expected no error without configYaml, got %v", err)
+	}
+}
+
+func TestModelAPIValidator(t *testing.T) {
+	v := &ModelAPIValidator{}
+	modelapi := &kaosv1alpha1.ModelAPI{
+		Spec: kaosv1alpha1.ModelAPISpec{
+			Mode: kaosv1alpha1.ModelAPIModeProxy,
+			ProxyConfig: proxyConfigWithYaml([]string{"openai/gpt-4"}, `
+model_list:
+  - model_name: "gemini/gemini-pro"
+`),
+		},
+	}
+
+	if _, err := v.ValidateCreate(context.Background(), modelapi); err == nil {
+		t.Error("expected create with mismatched configYaml model to be rejected")
+	}
+	if _, err := v.ValidateUpdate(context.Background(), modelapi, modelapi); err == nil {
+		t.Error("expected update with mismatched configYaml model to be rejected")
+	}
+	if _, err := v.ValidateDelete(context.Background(), modelapi); err != nil {
+		t.Errorf("expected delete to be allowed, got %v", err)
+	}
+
+	modelapi.Spec.ProxyConfig.Models = []string{"*"}
+	if _, err := v.ValidateCreate(context.Background(), modelapi); err != nil {
+		t.Errorf("expected wildcard models to be accepted, got %v", err)
+	}
+}
+
+func TestValidateProxyAliases(t *testing.T) {
+	tests := []struct {
+		name    string
+		aliases map[string]string
+		yaml    string
+		wantErr string
+	}{
+		{name: "no aliases"},
+		{name: "exact target", aliases: map[string]string{"smart": "gpt-4o"}},
+		{name: "wildcard-matched target", aliases: map[string]string{"fast": "openai/gpt-4o-mini"}},
+		{name: "unknown target", aliases: map[string]string{"fast": "claude-3"}, wantErr: `alias "fast" target "claude-3" not found`},
+		{name: "wildcard target", aliases: map[string]string{"fast": "openai/*"}, wantErr: "must target a concrete model"},
+		{name: "shadows a model", aliases: map[string]string{"gpt-4o": "openai/gpt-4o"}, wantErr: "shadows a model"},
+		{name: "with configYaml", aliases: map[string]string{"smart": "gpt-4o"}, yaml: "model_list: []", wantErr: "cannot be combined with configYaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxyConfig := &kaosv1alpha1.ProxyConfig{Models: []string{"gpt-4o", "openai/*"}, Aliases: tt.aliases}
+			if tt.yaml != "" {
+				proxyConfig.ConfigYaml = &kaosv1alpha1.ConfigYamlSource{FromString: tt.yaml}
+			}
+			err := ValidateProxyAliases(proxyConfig)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateProxyFallbacks(t *testing.T) {
+	tests := []struct {
+		name      string
+		fallbacks map[string][]string
+		yaml      string
+		wantErr   string
+	}{
+		{name: "no fallbacks"},
+		{name: "models and aliases", fallbacks: map[string][]string{"gpt-4o": {"openai/gpt-4o-mini", "smart"}, "smart": {"gpt-4o"}}},
+		{name: "unknown model", fallbacks: map[string][]string{"claude-3": {"gpt-4o"}}, wantErr: `fallback model "claude-3" not found`},
+		{name: "unknown fallback", fallbacks: map[string][]string{"gpt-4o": {"claude-3"}}, wantErr: `fallback model "claude-3" not found`},
+		{name: "wildcard fallback", fallbacks: map[string][]string{"gpt-4o": {"openai/*"}}, wantErr: "must use concrete models"},
+		{name: "self fallback", fallbacks: map[string][]string{"gpt-4o": {"gpt-4o"}}, wantErr: "cannot fall back to itself"},
+		{name: "empty chain", fallbacks: map[string][]string{"gpt-4o": {}}, wantErr: "at least one model"},
+		{name: "with configYaml", fallbacks: map[string][]string{"gpt-4o": {"smart"}}, yaml: "model_list: []", wantErr: "cannot be combined with configYaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxyConfig := &kaosv1alpha1.ProxyConfig{
+				Models:    []string{"gpt-4o", "openai/*"},
+				Aliases:   map[string]string{"smart": "gpt-4o"},
+				Fallbacks: tt.fallbacks,
+			}
+			if tt.yaml != "" {
+				proxyConfig.ConfigYaml = &kaosv1alpha1.ConfigYamlSource{FromString: tt.yaml}
+			}
+			err := ValidateProxyFallbacks(proxyConfig)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}